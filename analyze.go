@@ -0,0 +1,412 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/parquet-go/parquet-go/encoding"
+)
+
+const (
+	// DefaultAnalyzeHistogramBuckets is the default number of buckets of the
+	// histograms computed by AnalyzeColumn.
+	DefaultAnalyzeHistogramBuckets = 10
+	// DefaultAnalyzeTopValues is the default number of most frequent values
+	// reported by AnalyzeColumn.
+	DefaultAnalyzeTopValues = 10
+	// DefaultAnalyzeMaxDistinctValues is the default limit on the number of
+	// distinct values tracked by AnalyzeColumn.
+	DefaultAnalyzeMaxDistinctValues = 65536
+	// DefaultAnalyzeMaxSamples is the default number of values sampled by
+	// AnalyzeColumn to compute histograms.
+	DefaultAnalyzeMaxSamples = 65536
+)
+
+// AnalyzeConfig carries the configuration of AnalyzeColumn.
+type AnalyzeConfig struct {
+	HistogramBuckets  int
+	TopValues         int
+	MaxDistinctValues int
+	MaxSamples        int
+}
+
+// ConfigureAnalyze applies configuration options from c to config.
+func (c *AnalyzeConfig) ConfigureAnalyze(config *AnalyzeConfig) { *config = *c }
+
+// AnalyzeOption is an interface implemented by types that carry configuration
+// options for AnalyzeColumn.
+type AnalyzeOption interface {
+	ConfigureAnalyze(*AnalyzeConfig)
+}
+
+type analyzeOption func(*AnalyzeConfig)
+
+func (opt analyzeOption) ConfigureAnalyze(config *AnalyzeConfig) { opt(config) }
+
+// AnalyzeHistogramBuckets configures the number of buckets of the histograms
+// computed for numeric columns. Zero disables histograms.
+//
+// Defaults to DefaultAnalyzeHistogramBuckets.
+func AnalyzeHistogramBuckets(numBuckets int) AnalyzeOption {
+	return analyzeOption(func(config *AnalyzeConfig) { config.HistogramBuckets = numBuckets })
+}
+
+// AnalyzeTopValues configures the number of most frequent values reported by
+// AnalyzeColumn. Zero disables the tracking of value frequencies.
+//
+// Defaults to DefaultAnalyzeTopValues.
+func AnalyzeTopValues(k int) AnalyzeOption {
+	return analyzeOption(func(config *AnalyzeConfig) { config.TopValues = k })
+}
+
+// AnalyzeMaxDistinctValues configures the number of distinct values tracked to
+// compute the frequencies of values. When a column chunk contains more distinct
+// values, the values seen after the limit was reached are not counted and the
+// analysis is marked as approximate.
+//
+// Defaults to DefaultAnalyzeMaxDistinctValues.
+func AnalyzeMaxDistinctValues(limit int) AnalyzeOption {
+	return analyzeOption(func(config *AnalyzeConfig) { config.MaxDistinctValues = limit })
+}
+
+// AnalyzeMaxSamples configures the number of values retained to compute the
+// histograms of numeric columns. When a column chunk contains more values, the
+// histogram is computed from a uniform random sample of the values, its counts
+// are estimates and the analysis is marked as approximate. The bounds of the
+// histogram are always the exact minimum and maximum values.
+//
+// Defaults to DefaultAnalyzeMaxSamples.
+func AnalyzeMaxSamples(limit int) AnalyzeOption {
+	return analyzeOption(func(config *AnalyzeConfig) { config.MaxSamples = limit })
+}
+
+// ColumnAnalysis is the result of analyzing the values of a column chunk with
+// AnalyzeColumn.
+type ColumnAnalysis struct {
+	// Number of values in the column chunk, including nulls.
+	NumValues int64
+	// Number of null values in the column chunk.
+	NumNulls int64
+	// The minimum and maximum values of the column chunk, which are null
+	// values if the column chunk contained only nulls.
+	MinValue Value
+	MaxValue Value
+	// Average size of non-null values, in bytes.
+	AverageLength float64
+	// Number of distinct non-null values.
+	NumDistinct int64
+	// The distribution of values of numeric columns, in buckets of equal
+	// width between the minimum and maximum values. The histogram is nil for
+	// columns of other types.
+	Histogram []HistogramBucket
+	// The most frequent values of the column chunk, by decreasing count.
+	TopValues []ValueCount
+	// True if the number of distinct values exceeded the configured limit,
+	// in which case NumDistinct is a lower bound, and the counts of
+	// TopValues may be lower than the actual counts, or if the histogram
+	// was computed from a sample of the values.
+	Approximate bool
+}
+
+// HistogramBucket represents a bucket of the histogram of a numeric column.
+// The bucket counts the values v such that Lower <= v < Upper, except for the
+// last bucket of a histogram which also includes its upper bound.
+type HistogramBucket struct {
+	Lower float64
+	Upper float64
+	Count int64
+}
+
+// ValueCount associates a value with its number of occurrences.
+type ValueCount struct {
+	Value Value
+	Count int64
+}
+
+// AnalyzeColumn reads the pages of chunk to compute statistics about the
+// distribution of its values, such as histograms, most frequent values and
+// average lengths. This can be used to build data profiling features without
+// relying on external query engines.
+//
+// When pages are dictionary-encoded, the frequencies of values are computed
+// from the dictionary indexes, without materializing the values.
+func AnalyzeColumn(chunk ColumnChunk, options ...AnalyzeOption) (*ColumnAnalysis, error) {
+	config := &AnalyzeConfig{
+		HistogramBuckets:  DefaultAnalyzeHistogramBuckets,
+		TopValues:         DefaultAnalyzeTopValues,
+		MaxDistinctValues: DefaultAnalyzeMaxDistinctValues,
+		MaxSamples:        DefaultAnalyzeMaxSamples,
+	}
+	for _, opt := range options {
+		opt.ConfigureAnalyze(config)
+	}
+
+	a := &columnAnalyzer{
+		config:   config,
+		typ:      chunk.Type(),
+		counts:   make(map[string]*ValueCount),
+		numeric:  isNumericKind(chunk.Type().Kind()),
+		unsigned: isUnsignedType(chunk.Type()),
+	}
+
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	for {
+		page, err := pages.ReadPage()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("analyzing column chunk: %w", err)
+		}
+		err = a.analyzePage(page)
+		Release(page)
+		if err != nil {
+			return nil, fmt.Errorf("analyzing column chunk: %w", err)
+		}
+	}
+
+	return a.result(), nil
+}
+
+type columnAnalyzer struct {
+	config    *AnalyzeConfig
+	typ       Type
+	analysis  ColumnAnalysis
+	totalSize int64
+	// Frequencies of values, keyed by their binary representation.
+	counts map[string]*ValueCount
+	// Frequencies of the indexes of the current dictionary, which are folded
+	// into counts when the dictionary changes.
+	dict       Dictionary
+	dictCounts []int64
+	// Reservoir of the values of numeric columns, with the number of times
+	// they were seen, and the bounds of all the values that were observed.
+	numeric  bool
+	unsigned bool
+	numbers  []float64
+	weights  []int64
+	seen     int64
+	lower    float64
+	upper    float64
+	rand     *rand.Rand
+}
+
+func (a *columnAnalyzer) analyzePage(page Page) error {
+	a.analysis.NumValues += page.NumValues()
+	a.analysis.NumNulls += page.NumNulls()
+
+	if min, max, ok := page.Bounds(); ok {
+		if a.analysis.MinValue.IsNull() || a.typ.Compare(min, a.analysis.MinValue) < 0 {
+			a.analysis.MinValue = min.Clone()
+		}
+		if a.analysis.MaxValue.IsNull() || a.typ.Compare(max, a.analysis.MaxValue) > 0 {
+			a.analysis.MaxValue = max.Clone()
+		}
+	}
+
+	if dict := page.Dictionary(); dict != nil {
+		if data := page.Data(); data.Kind() == encoding.Int32 {
+			if dict != a.dict {
+				a.flushDictionary()
+				a.dict, a.dictCounts = dict, make([]int64, dict.Len())
+			}
+			for _, index := range data.Int32() {
+				a.dictCounts[index]++
+			}
+			return nil
+		}
+	}
+
+	values := make([]Value, defaultValueBufferSize)
+	reader := page.Values()
+	for {
+		n, err := reader.ReadValues(values)
+		for _, v := range values[:n] {
+			if !v.IsNull() {
+				a.observe(v, 1)
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (a *columnAnalyzer) flushDictionary() {
+	for i, count := range a.dictCounts {
+		if count > 0 {
+			a.observe(a.dict.Index(int32(i)), count)
+		}
+	}
+	a.dict, a.dictCounts = nil, nil
+}
+
+func (a *columnAnalyzer) observe(v Value, count int64) {
+	a.totalSize += count * sizeOfValue(v)
+
+	if a.numeric && a.config.HistogramBuckets > 0 {
+		if f := numericValueOf(v, a.unsigned); !math.IsNaN(f) {
+			a.sample(f, count)
+		}
+	}
+
+	key := string(v.Bytes())
+	if c, ok := a.counts[key]; ok {
+		c.Count += count
+	} else if len(a.counts) < a.config.MaxDistinctValues {
+		a.counts[key] = &ValueCount{Value: v.Clone(), Count: count}
+	} else {
+		a.analysis.Approximate = true
+	}
+}
+
+func (a *columnAnalyzer) sample(f float64, count int64) {
+	if a.seen == 0 {
+		a.lower, a.upper = f, f
+	} else {
+		a.lower, a.upper = math.Min(a.lower, f), math.Max(a.upper, f)
+	}
+	index := a.seen
+	a.seen++
+
+	if len(a.numbers) < a.config.MaxSamples {
+		a.numbers = append(a.numbers, f)
+		a.weights = append(a.weights, count)
+		return
+	}
+	if a.config.MaxSamples <= 0 {
+		return
+	}
+	if a.rand == nil {
+		a.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if i := a.rand.Int63n(index + 1); i < int64(len(a.numbers)) {
+		a.numbers[i], a.weights[i] = f, count
+	}
+}
+
+func (a *columnAnalyzer) result() *ColumnAnalysis {
+	a.flushDictionary()
+	analysis := a.analysis
+
+	if numValues := analysis.NumValues - analysis.NumNulls; numValues > 0 {
+		analysis.AverageLength = float64(a.totalSize) / float64(numValues)
+	}
+
+	analysis.NumDistinct = int64(len(a.counts))
+
+	if a.config.TopValues > 0 {
+		top := make([]ValueCount, 0, len(a.counts))
+		for _, c := range a.counts {
+			top = append(top, *c)
+		}
+		sort.Slice(top, func(i, j int) bool {
+			if top[i].Count != top[j].Count {
+				return top[i].Count > top[j].Count
+			}
+			return a.typ.Compare(top[i].Value, top[j].Value) < 0
+		})
+		if len(top) > a.config.TopValues {
+			top = top[:a.config.TopValues]
+		}
+		analysis.TopValues = top
+	}
+
+	if a.numeric && a.config.HistogramBuckets > 0 && len(a.numbers) > 0 {
+		// When the values were sampled, each sampled value stands for
+		// seen/len(numbers) of the values that were observed.
+		scale := float64(a.seen) / float64(len(a.numbers))
+		if a.seen > int64(len(a.numbers)) {
+			analysis.Approximate = true
+		}
+		analysis.Histogram = makeHistogram(a.lower, a.upper, a.numbers, a.weights, scale, a.config.HistogramBuckets)
+	}
+	return &analysis
+}
+
+func makeHistogram(lower, upper float64, numbers []float64, weights []int64, scale float64, numBuckets int) []HistogramBucket {
+	if lower == upper {
+		numBuckets = 1
+	}
+
+	width := (upper - lower) / float64(numBuckets)
+	buckets := make([]HistogramBucket, numBuckets)
+	for i := range buckets {
+		buckets[i].Lower = lower + float64(i)*width
+		buckets[i].Upper = lower + float64(i+1)*width
+	}
+	buckets[numBuckets-1].Upper = upper
+
+	counts := make([]float64, numBuckets)
+	for i, f := range numbers {
+		b := numBuckets - 1
+		if width > 0 {
+			b = min(int((f-lower)/width), numBuckets-1)
+		}
+		counts[b] += float64(weights[i])
+	}
+	for i := range buckets {
+		buckets[i].Count = int64(math.Round(counts[i] * scale))
+	}
+	return buckets
+}
+
+func isNumericKind(kind Kind) bool {
+	switch kind {
+	case Int32, Int64, Float, Double:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUnsignedType(t Type) bool {
+	lt := t.LogicalType()
+	return lt != nil && lt.Integer != nil && !lt.Integer.IsSigned
+}
+
+func numericValueOf(v Value, unsigned bool) float64 {
+	switch v.Kind() {
+	case Int32:
+		if unsigned {
+			return float64(v.uint32())
+		}
+		return float64(v.int32())
+	case Int64:
+		if unsigned {
+			return float64(v.uint64())
+		}
+		return float64(v.int64())
+	case Float:
+		return float64(v.float())
+	case Double:
+		return v.double()
+	default:
+		return math.NaN()
+	}
+}
+
+func sizeOfValue(v Value) int64 {
+	switch v.Kind() {
+	case Boolean:
+		return 1
+	case Int32, Float:
+		return 4
+	case Int64, Double:
+		return 8
+	case Int96:
+		return 12
+	default:
+		return int64(len(v.byteArray()))
+	}
+}
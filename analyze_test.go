@@ -0,0 +1,172 @@
+package parquet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestAnalyzeColumn(t *testing.T) {
+	type Row struct {
+		Score   int64   `parquet:"score"`
+		Color   string  `parquet:"color,dict"`
+		Comment *string `parquet:"comment,optional"`
+	}
+
+	colors := []string{"red", "green", "red", "blue", "red", "green"}
+	comment := "hello"
+	rows := make([]Row, 60)
+	for i := range rows {
+		rows[i] = Row{Score: int64(i), Color: colors[i%len(colors)]}
+		if i%3 == 0 {
+			rows[i].Comment = &comment
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(64)); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := file.RowGroups()[0].ColumnChunks()
+
+	t.Run("numeric", func(t *testing.T) {
+		analysis, err := parquet.AnalyzeColumn(chunks[0], parquet.AnalyzeHistogramBuckets(4), parquet.AnalyzeTopValues(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if analysis.NumValues != 60 || analysis.NumNulls != 0 || analysis.NumDistinct != 60 {
+			t.Errorf("wrong counts: %+v", analysis)
+		}
+		if analysis.MinValue.Int64() != 0 || analysis.MaxValue.Int64() != 59 {
+			t.Errorf("wrong bounds: min=%v max=%v", analysis.MinValue, analysis.MaxValue)
+		}
+		if analysis.AverageLength != 8 {
+			t.Errorf("wrong average length: %v", analysis.AverageLength)
+		}
+		if len(analysis.Histogram) != 4 {
+			t.Fatalf("wrong number of histogram buckets: %d", len(analysis.Histogram))
+		}
+		total := int64(0)
+		for _, bucket := range analysis.Histogram {
+			if bucket.Count != 15 {
+				t.Errorf("wrong bucket count: %+v", bucket)
+			}
+			total += bucket.Count
+		}
+		if total != 60 {
+			t.Errorf("wrong total histogram count: %d", total)
+		}
+		if len(analysis.TopValues) != 2 {
+			t.Errorf("wrong number of top values: %d", len(analysis.TopValues))
+		}
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+		analysis, err := parquet.AnalyzeColumn(chunks[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if analysis.NumDistinct != 3 || analysis.Histogram != nil {
+			t.Errorf("wrong analysis: %+v", analysis)
+		}
+		want := []struct {
+			value string
+			count int64
+		}{{"red", 30}, {"green", 20}, {"blue", 10}}
+		if len(analysis.TopValues) != len(want) {
+			t.Fatalf("wrong number of top values: %d", len(analysis.TopValues))
+		}
+		for i, w := range want {
+			if v := analysis.TopValues[i]; v.Value.String() != w.value || v.Count != w.count {
+				t.Errorf("wrong top value %d: want=%s:%d got=%s:%d", i, w.value, w.count, v.Value, v.Count)
+			}
+		}
+		if analysis.AverageLength != 230.0/60 {
+			t.Errorf("wrong average length: %v", analysis.AverageLength)
+		}
+	})
+
+	t.Run("nulls", func(t *testing.T) {
+		analysis, err := parquet.AnalyzeColumn(chunks[2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if analysis.NumValues != 60 || analysis.NumNulls != 40 || analysis.NumDistinct != 1 {
+			t.Errorf("wrong counts: %+v", analysis)
+		}
+		if len(analysis.TopValues) != 1 || analysis.TopValues[0].Count != 20 {
+			t.Errorf("wrong top values: %+v", analysis.TopValues)
+		}
+	})
+
+	t.Run("approximate", func(t *testing.T) {
+		analysis, err := parquet.AnalyzeColumn(chunks[0], parquet.AnalyzeMaxDistinctValues(10))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !analysis.Approximate || analysis.NumDistinct != 10 {
+			t.Errorf("wrong approximation: %+v", analysis)
+		}
+	})
+
+	t.Run("sampled", func(t *testing.T) {
+		analysis, err := parquet.AnalyzeColumn(chunks[0], parquet.AnalyzeHistogramBuckets(4), parquet.AnalyzeMaxSamples(8))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !analysis.Approximate {
+			t.Error("histogram computed from a sample is not marked as approximate")
+		}
+		if len(analysis.Histogram) != 4 {
+			t.Fatalf("wrong number of histogram buckets: %d", len(analysis.Histogram))
+		}
+		if lower, upper := analysis.Histogram[0].Lower, analysis.Histogram[3].Upper; lower != 0 || upper != 59 {
+			t.Errorf("wrong histogram bounds: lower=%v upper=%v", lower, upper)
+		}
+		total := int64(0)
+		for _, bucket := range analysis.Histogram {
+			total += bucket.Count
+		}
+		if total < 58 || total > 62 {
+			t.Errorf("wrong total histogram count: %d", total)
+		}
+	})
+}
+
+func TestAnalyzeColumnUnsigned(t *testing.T) {
+	type Row struct {
+		Value uint32 `parquet:"value"`
+	}
+	rows := []Row{{1}, {2}, {1 << 31}, {1<<32 - 1}}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analysis, err := parquet.AnalyzeColumn(file.RowGroups()[0].ColumnChunks()[0], parquet.AnalyzeHistogramBuckets(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.MinValue.Uint32() != 1 || analysis.MaxValue.Uint32() != 1<<32-1 {
+		t.Errorf("wrong bounds: min=%v max=%v", analysis.MinValue, analysis.MaxValue)
+	}
+	if len(analysis.Histogram) != 2 {
+		t.Fatalf("wrong number of histogram buckets: %d", len(analysis.Histogram))
+	}
+	if lower, upper := analysis.Histogram[0].Lower, analysis.Histogram[1].Upper; lower != 1 || upper != 1<<32-1 {
+		t.Errorf("wrong histogram bounds: lower=%v upper=%v", lower, upper)
+	}
+	if analysis.Histogram[0].Count != 2 || analysis.Histogram[1].Count != 2 {
+		t.Errorf("wrong histogram counts: %+v", analysis.Histogram)
+	}
+}
@@ -1,6 +1,8 @@
 package parquet
 
 import (
+	"bytes"
+	"fmt"
 	"io"
 
 	"github.com/parquet-go/parquet-go/bloom"
@@ -9,6 +11,7 @@ import (
 	"github.com/parquet-go/parquet-go/encoding"
 	"github.com/parquet-go/parquet-go/format"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
+	"github.com/segmentio/encoding/thrift"
 )
 
 // BloomFilter is an interface allowing applications to test whether a key
@@ -29,18 +32,62 @@ type BloomFilter interface {
 	// filter was in memory at the time Check was called can safely ignore the
 	// error, which would always be nil in this case.
 	Check(value Value) (bool, error)
+
+	// Tests whether each of the given values is present in the filter,
+	// setting the entry of found at the same index to the result. The found
+	// slice must be at least as long as values.
+	//
+	// Checking batches of values amortizes the cost of loading filters from
+	// storage mediums, which happens at most once per call.
+	CheckMany(values []Value, found []bool) error
 }
 
 type bloomFilter struct {
 	io.SectionReader
-	hash  bloom.Hash
-	check func(io.ReaderAt, int64, uint64) (bool, error)
+	hash   bloom.Hash
+	check  func(io.ReaderAt, int64, uint64) (bool, error)
+	header format.BloomFilterHeader
+	// Bits of the filter, when they are held in memory.
+	data []byte
 }
 
 func (f *bloomFilter) Check(v Value) (bool, error) {
 	return f.check(&f.SectionReader, f.Size(), v.hash(f.hash))
 }
 
+func (f *bloomFilter) CheckMany(values []Value, found []bool) error {
+	data, err := f.bytes()
+	if err != nil {
+		return err
+	}
+	r := bytes.NewReader(data)
+	hashes := make([]uint64, 0, min(len(values), filterEncodeBufferSize))
+	for len(values) > 0 {
+		n := min(len(values), cap(hashes))
+		hashes = HashMany(hashes[:0], f.hash, values[:n])
+		for i, h := range hashes {
+			ok, err := f.check(r, int64(len(data)), h)
+			if err != nil {
+				return err
+			}
+			found[i] = ok
+		}
+		values, found = values[n:], found[n:]
+	}
+	return nil
+}
+
+func (f *bloomFilter) bytes() ([]byte, error) {
+	if f.data != nil {
+		return f.data, nil
+	}
+	data := make([]byte, f.Size())
+	if n, err := f.ReadAt(data, 0); n < len(data) {
+		return nil, fmt.Errorf("reading bloom filter: %w", err)
+	}
+	return data, nil
+}
+
 func (v Value) hash(h bloom.Hash) uint64 {
 	switch v.Kind() {
 	case Boolean:
@@ -54,6 +101,65 @@ func (v Value) hash(h bloom.Hash) uint64 {
 	}
 }
 
+// HashMany appends the hashes of values computed with h to dst, and returns the
+// result. The hashes are the ones used to insert and check values in bloom
+// filters.
+//
+// Runs of booleans, 32 bits, and 64 bits values are hashed in bulk with the
+// MultiSum64 methods of h, which use the optimized assembly paths of the XXH64
+// implementation when they are available; hashing batches of values with
+// HashMany is therefore faster than hashing the values one by one.
+func HashMany(dst []uint64, h bloom.Hash, values []Value) []uint64 {
+	var buffer [filterEncodeBufferSize]uint64
+
+	for i := 0; i < len(values); {
+		if values[i].IsNull() {
+			dst = append(dst, values[i].hash(h))
+			i++
+			continue
+		}
+
+		kind := values[i].kind
+		j := i + 1
+		for j < len(values) && j-i < len(buffer) && values[j].kind == kind {
+			j++
+		}
+		run := values[i:j]
+		i = j
+
+		offset := len(dst)
+		dst = append(dst, buffer[:len(run)]...)
+		hashes := dst[offset:]
+
+		switch ^Kind(kind) {
+		case Boolean:
+			b := unsafecast.Slice[uint8](buffer[:])[:len(run)]
+			for k := range run {
+				b[k] = run[k].byte()
+			}
+			h.MultiSum64Uint8(hashes, b)
+		case Int32, Float:
+			b := unsafecast.Slice[uint32](buffer[:])[:len(run)]
+			for k := range run {
+				b[k] = run[k].uint32()
+			}
+			h.MultiSum64Uint32(hashes, b)
+		case Int64, Double:
+			b := buffer[:len(run)]
+			for k := range run {
+				b[k] = run[k].uint64()
+			}
+			h.MultiSum64Uint64(hashes, b)
+		default:
+			for k := range run {
+				hashes[k] = run[k].hash(h)
+			}
+		}
+	}
+
+	return dst
+}
+
 func newBloomFilter(file io.ReaderAt, offset int64, header *format.BloomFilterHeader) *bloomFilter {
 	if header.Algorithm.Block != nil {
 		if header.Hash.XxHash != nil {
@@ -62,9 +168,157 @@ func newBloomFilter(file io.ReaderAt, offset int64, header *format.BloomFilterHe
 					SectionReader: *io.NewSectionReader(file, offset, int64(header.NumBytes)),
 					hash:          bloom.XXH64{},
 					check:         bloom.CheckSplitBlock,
+					header:        *header,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ExportBloomFilter returns a standalone representation of filter, which can
+// be loaded with ImportBloomFilter.
+//
+// This allows programs to ship the bloom filters of columns to other programs
+// without shipping the data of the columns, for example to filter the keys of
+// distributed joins on the workers holding the other side of the join.
+//
+// Filters are represented like in parquet files, with a header followed by the
+// bits of the filter. The bloom filters of columns spanning multiple row
+// groups are represented as the sequence of the filters of each row group.
+//
+// The function returns an error if the filter was not obtained from a column
+// chunk or from ImportBloomFilter.
+func ExportBloomFilter(filter BloomFilter) ([]byte, error) {
+	return appendBloomFilter(nil, filter)
+}
+
+func appendBloomFilter(b []byte, filter BloomFilter) ([]byte, error) {
+	switch f := filter.(type) {
+	case *bloomFilter:
+		header, err := thrift.Marshal(new(thrift.CompactProtocol), &f.header)
+		if err != nil {
+			return b, fmt.Errorf("encoding bloom filter header: %w", err)
+		}
+		data, err := f.bytes()
+		if err != nil {
+			return b, err
+		}
+		b = append(b, header...)
+		b = append(b, data...)
+	case multiBloomFilter:
+		for _, c := range f.chunks {
+			if filter := c.BloomFilter(); filter != nil {
+				var err error
+				if b, err = appendBloomFilter(b, filter); err != nil {
+					return b, err
 				}
 			}
 		}
+	case bloomFilterSet:
+		for _, filter := range f {
+			var err error
+			if b, err = appendBloomFilter(b, filter); err != nil {
+				return b, err
+			}
+		}
+	default:
+		return b, fmt.Errorf("cannot export bloom filter of type %T", filter)
+	}
+	return b, nil
+}
+
+// ImportBloomFilter loads a bloom filter from its representation returned by
+// ExportBloomFilter. The filter retains data, which must not be modified after
+// the function returned.
+//
+// When data holds the filters of multiple row groups, the returned filter
+// reports values as present if they are present in any of the filters.
+func ImportBloomFilter(data []byte) (BloomFilter, error) {
+	var filters bloomFilterSet
+	r := bytes.NewReader(data)
+	decoder := thrift.NewDecoder(new(thrift.CompactProtocol).NewReader(r))
+
+	for r.Len() > 0 {
+		header := format.BloomFilterHeader{}
+		if err := decoder.Decode(&header); err != nil {
+			return nil, fmt.Errorf("decoding bloom filter header: %w", err)
+		}
+		offset := len(data) - r.Len()
+		if header.NumBytes < 0 || int(header.NumBytes) > r.Len() {
+			return nil, fmt.Errorf("bloom filter size out of bounds: %d>%d", header.NumBytes, r.Len())
+		}
+		filter := newBloomFilter(r, int64(offset), &header)
+		if filter == nil {
+			return nil, fmt.Errorf("unsupported bloom filter: %+v", header)
+		}
+		filter.data = data[offset : offset+int(header.NumBytes)]
+		filters = append(filters, filter)
+		r.Seek(int64(header.NumBytes), io.SeekCurrent)
+	}
+
+	switch len(filters) {
+	case 0:
+		return nil, fmt.Errorf("importing bloom filter: %w", io.ErrUnexpectedEOF)
+	case 1:
+		return filters[0], nil
+	default:
+		return filters, nil
+	}
+}
+
+// bloomFilterSet is the union of multiple bloom filters, with the bits of the
+// filters laid out one after the other.
+type bloomFilterSet []BloomFilter
+
+func (s bloomFilterSet) ReadAt(b []byte, off int64) (int, error) {
+	n := 0
+	for _, f := range s {
+		size := f.Size()
+		if off >= size {
+			off -= size
+			continue
+		}
+		rn, err := f.ReadAt(b[n:min(len(b), n+int(size-off))], off)
+		n += rn
+		if err != nil && err != io.EOF {
+			return n, err
+		}
+		if n == len(b) {
+			return n, nil
+		}
+		off = 0
+	}
+	return n, io.EOF
+}
+
+func (s bloomFilterSet) Size() (size int64) {
+	for _, f := range s {
+		size += f.Size()
+	}
+	return size
+}
+
+func (s bloomFilterSet) Check(v Value) (bool, error) {
+	for _, f := range s {
+		if ok, err := f.Check(v); ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func (s bloomFilterSet) CheckMany(values []Value, found []bool) error {
+	found = found[:len(values)]
+	clear(found)
+	buffer := make([]bool, len(values))
+	for _, f := range s {
+		if err := f.CheckMany(values, buffer); err != nil {
+			return err
+		}
+		for i, ok := range buffer {
+			found[i] = found[i] || ok
+		}
 	}
 	return nil
 }
@@ -272,3 +526,56 @@ func splitBlockEncodeUint128(filter bloom.SplitBlockFilter, values [][16]byte) {
 		i += n
 	}
 }
+
+// appendSplitBlockHashes appends to dst the hashes that the split block filter
+// encoding inserts in filters for the given values. The hashes can be inserted
+// in a filter later on, once the size of the filter is known.
+func appendSplitBlockHashes(dst []uint64, values encoding.Values) []uint64 {
+	switch values.Kind() {
+	case encoding.Boolean:
+		return appendMultiSum64(dst, values.Boolean(), xxhash.MultiSum64Uint8)
+	case encoding.Int32:
+		return appendMultiSum64(dst, values.Uint32(), xxhash.MultiSum64Uint32)
+	case encoding.Int64:
+		return appendMultiSum64(dst, values.Uint64(), xxhash.MultiSum64Uint64)
+	case encoding.Float:
+		return appendMultiSum64(dst, unsafecast.Float32ToUint32(values.Float()), xxhash.MultiSum64Uint32)
+	case encoding.Double:
+		return appendMultiSum64(dst, unsafecast.Float64ToUint64(values.Double()), xxhash.MultiSum64Uint64)
+	case encoding.Int96:
+		return appendSum64FixedLenByteArray(dst, deprecated.Int96ToBytes(values.Int96()), 12)
+	case encoding.ByteArray:
+		data, offsets := values.ByteArray()
+		if len(offsets) == 0 {
+			return dst
+		}
+		baseOffset := offsets[0]
+		for _, endOffset := range offsets[1:] {
+			dst = append(dst, xxhash.Sum64(data[baseOffset:endOffset:endOffset]))
+			baseOffset = endOffset
+		}
+		return dst
+	case encoding.FixedLenByteArray:
+		data, size := values.FixedLenByteArray()
+		if size == 16 {
+			return appendMultiSum64(dst, unsafecast.BytesToUint128(data), xxhash.MultiSum64Uint128)
+		}
+		return appendSum64FixedLenByteArray(dst, data, size)
+	default:
+		return dst
+	}
+}
+
+func appendMultiSum64[T any](dst []uint64, values []T, multiSum64 func([]uint64, []T) int) []uint64 {
+	offset := len(dst)
+	dst = append(dst, make([]uint64, len(values))...)
+	multiSum64(dst[offset:], values)
+	return dst
+}
+
+func appendSum64FixedLenByteArray(dst []uint64, data []byte, size int) []uint64 {
+	for i, j := 0, size; j <= len(data); i, j = i+size, j+size {
+		dst = append(dst, xxhash.Sum64(data[i:j]))
+	}
+	return dst
+}
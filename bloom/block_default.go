@@ -11,6 +11,8 @@ package bloom
 // implementations of block operations behave the same as the functions in this
 // file.
 
+import "github.com/parquet-go/parquet-go/internal/byteorder"
+
 var salt = [8]uint32{
 	0: salt0,
 	1: salt1,
@@ -22,12 +24,14 @@ var salt = [8]uint32{
 	7: salt7,
 }
 
+// Words are stored in little-endian byte order, like in the serialized
+// filters, so they are converted before testing or setting bits.
 func (w *Word) set(i uint) {
-	*w |= Word(1 << i)
+	*w = Word(byteorder.LittleEndian32(byteorder.LittleEndian32(uint32(*w)) | (1 << i)))
 }
 
 func (w Word) has(i uint) bool {
-	return ((w >> Word(i)) & 1) != 0
+	return ((byteorder.LittleEndian32(uint32(w)) >> i) & 1) != 0
 }
 
 func mask(x uint32) Block {
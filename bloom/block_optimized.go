@@ -2,6 +2,8 @@
 
 package bloom
 
+import "github.com/parquet-go/parquet-go/internal/byteorder"
+
 // The functions in this file are optimized versions of the algorithms described
 // in https://github.com/apache/parquet-format/blob/master/BloomFilter.md
 //
@@ -25,25 +27,31 @@ package bloom
 // block.
 
 func (b *Block) Insert(x uint32) {
-	b[0] |= 1 << ((x * salt0) >> 27)
-	b[1] |= 1 << ((x * salt1) >> 27)
-	b[2] |= 1 << ((x * salt2) >> 27)
-	b[3] |= 1 << ((x * salt3) >> 27)
-	b[4] |= 1 << ((x * salt4) >> 27)
-	b[5] |= 1 << ((x * salt5) >> 27)
-	b[6] |= 1 << ((x * salt6) >> 27)
-	b[7] |= 1 << ((x * salt7) >> 27)
+	b[0] |= bit(x * salt0)
+	b[1] |= bit(x * salt1)
+	b[2] |= bit(x * salt2)
+	b[3] |= bit(x * salt3)
+	b[4] |= bit(x * salt4)
+	b[5] |= bit(x * salt5)
+	b[6] |= bit(x * salt6)
+	b[7] |= bit(x * salt7)
 }
 
 func (b *Block) Check(x uint32) bool {
-	return ((b[0] & (1 << ((x * salt0) >> 27))) != 0) &&
-		((b[1] & (1 << ((x * salt1) >> 27))) != 0) &&
-		((b[2] & (1 << ((x * salt2) >> 27))) != 0) &&
-		((b[3] & (1 << ((x * salt3) >> 27))) != 0) &&
-		((b[4] & (1 << ((x * salt4) >> 27))) != 0) &&
-		((b[5] & (1 << ((x * salt5) >> 27))) != 0) &&
-		((b[6] & (1 << ((x * salt6) >> 27))) != 0) &&
-		((b[7] & (1 << ((x * salt7) >> 27))) != 0)
+	return ((b[0] & bit(x*salt0)) != 0) &&
+		((b[1] & bit(x*salt1)) != 0) &&
+		((b[2] & bit(x*salt2)) != 0) &&
+		((b[3] & bit(x*salt3)) != 0) &&
+		((b[4] & bit(x*salt4)) != 0) &&
+		((b[5] & bit(x*salt5)) != 0) &&
+		((b[6] & bit(x*salt6)) != 0) &&
+		((b[7] & bit(x*salt7)) != 0)
+}
+
+// bit returns the word with the bit selected by the salted value x set. Words
+// are stored in little-endian byte order, like in the serialized filters.
+func bit(x uint32) Word {
+	return Word(byteorder.LittleEndian32(1 << (x >> 27)))
 }
 
 func (f SplitBlockFilter) insertBulk(x []uint64) {
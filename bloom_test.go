@@ -1,6 +1,8 @@
 package parquet
 
 import (
+	"bytes"
+	"fmt"
 	"math/rand"
 	"testing"
 
@@ -171,3 +173,184 @@ func BenchmarkSplitBlockFilter(b *testing.B) {
 
 	b.SetBytes(8 * N)
 }
+
+func TestExportBloomFilter(t *testing.T) {
+	type Row struct {
+		Key string `parquet:"key"`
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := NewGenericWriter[Row](buffer, BloomFilters(SplitBlockFilter(10, "key")))
+	for i := 0; i < 3; i++ {
+		rows := make([]Row, 100)
+		for j := range rows {
+			rows[j].Key = fmt.Sprintf("key-%d-%d", i, j)
+		}
+		if _, err := writer.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := []Value{
+		ValueOf("key-0-0"),
+		ValueOf("key-1-50"),
+		ValueOf("missing"),
+		ValueOf("key-2-99"),
+		ValueOf("key-3-0"),
+	}
+
+	check := func(t *testing.T, filter BloomFilter, want []bool) {
+		t.Helper()
+		found := make([]bool, len(keys))
+		if err := filter.CheckMany(keys, found); err != nil {
+			t.Fatal(err)
+		}
+		for i, key := range keys {
+			ok, err := filter.Check(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != found[i] {
+				t.Errorf("Check and CheckMany disagree on %q: %t!=%t", key, ok, found[i])
+			}
+			// Bloom filters may have false positives but no false negatives.
+			if want[i] && !found[i] {
+				t.Errorf("key %q not found in the bloom filter", key)
+			}
+		}
+	}
+
+	t.Run("row group", func(t *testing.T) {
+		filter := file.RowGroups()[1].ColumnChunks()[0].BloomFilter()
+		data, err := ExportBloomFilter(filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		imported, err := ImportBloomFilter(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if imported.Size() != filter.Size() {
+			t.Errorf("wrong size of imported bloom filter: %d!=%d", imported.Size(), filter.Size())
+		}
+		check(t, filter, []bool{false, true, false, false, false})
+		check(t, imported, []bool{false, true, false, false, false})
+	})
+
+	t.Run("multiple row groups", func(t *testing.T) {
+		filter := MultiRowGroup(file.RowGroups()...).ColumnChunks()[0].BloomFilter()
+		data, err := ExportBloomFilter(filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		imported, err := ImportBloomFilter(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if imported.Size() != filter.Size() {
+			t.Errorf("wrong size of imported bloom filter: %d!=%d", imported.Size(), filter.Size())
+		}
+		bits := make([]byte, imported.Size())
+		if _, err := imported.ReadAt(bits, 0); err != nil {
+			t.Fatal(err)
+		}
+		want := make([]byte, filter.Size())
+		if _, err := filter.ReadAt(want, 0); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(bits, want) {
+			t.Error("bits of imported bloom filter do not match the original filter")
+		}
+		check(t, imported, []bool{true, true, false, true, false})
+
+		reexported, err := ExportBloomFilter(imported)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(reexported, data) {
+			t.Error("exporting an imported bloom filter produced different data")
+		}
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		data, err := ExportBloomFilter(file.RowGroups()[0].ColumnChunks()[0].BloomFilter())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ImportBloomFilter(data[:len(data)-1]); err == nil {
+			t.Error("expected an error importing truncated bloom filter")
+		}
+		if _, err := ImportBloomFilter(nil); err == nil {
+			t.Error("expected an error importing empty data")
+		}
+	})
+}
+
+func TestHashMany(t *testing.T) {
+	values := []Value{
+		ValueOf(true),
+		ValueOf(false),
+		ValueOf(int32(1)),
+		ValueOf(int32(2)),
+		ValueOf(float32(3)),
+		Value{},
+		ValueOf(int64(4)),
+		ValueOf(5.0),
+		ValueOf("hello"),
+		ValueOf([16]byte{1, 2, 3}),
+		ValueOf(deprecated.Int96{6, 7, 8}),
+	}
+	for i := 0; i < 2*filterEncodeBufferSize; i++ {
+		values = append(values, ValueOf(int64(i)))
+	}
+
+	h := bloom.XXH64{}
+	hashes := HashMany([]uint64{42}, h, values)
+	if len(hashes) != len(values)+1 || hashes[0] != 42 {
+		t.Fatalf("hashes were not appended to the destination: len=%d", len(hashes))
+	}
+	for i, v := range values {
+		if want := v.hash(h); hashes[i+1] != want {
+			t.Errorf("wrong hash of value %v at index %d: want=%016x got=%016x", v, i, want, hashes[i+1])
+		}
+	}
+}
+
+func BenchmarkHashMany(b *testing.B) {
+	const N = 1000
+	values := make([]Value, N)
+	r := rand.NewSource(10)
+	for i := range values {
+		values[i] = ValueOf(r.Int63())
+	}
+	hashes := make([]uint64, 0, N)
+	h := bloom.XXH64{}
+
+	b.Run("one-by-one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			hashes = hashes[:0]
+			for _, v := range values {
+				hashes = append(hashes, v.hash(h))
+			}
+		}
+		b.SetBytes(8 * N)
+	})
+
+	b.Run("bulk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			hashes = HashMany(hashes[:0], h, values)
+		}
+		b.SetBytes(8 * N)
+	})
+}
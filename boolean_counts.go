@@ -0,0 +1,248 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go/encoding/plain"
+	"github.com/parquet-go/parquet-go/encoding/rle"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// BooleanCounts holds the number of true, false, and null values of a page or
+// column chunk of a BOOLEAN column, see CountBooleans.
+type BooleanCounts struct {
+	True  int64
+	False int64
+	Null  int64
+}
+
+// NumValues returns the total number of values, including null values.
+func (c BooleanCounts) NumValues() int64 { return c.True + c.False + c.Null }
+
+// TrueRatio returns the ratio of true values among the non-null values, or zero
+// if there are no non-null values.
+func (c BooleanCounts) TrueRatio() float64 {
+	if n := c.True + c.False; n > 0 {
+		return float64(c.True) / float64(n)
+	}
+	return 0
+}
+
+func (c *BooleanCounts) add(other BooleanCounts) {
+	c.True += other.True
+	c.False += other.False
+	c.Null += other.Null
+}
+
+// CountBooleans counts the true, false, and null values of a column chunk of a
+// BOOLEAN column, calling fn with the counts of each data page and returning
+// the counts of the whole chunk. fn may be nil when only the counts of the
+// chunk are needed.
+//
+// When the chunk is read from a file and the values of its pages use the PLAIN
+// or RLE encoding, the true values are counted with population counts on the
+// encoded data, without decoding the booleans one by one. Pages using other
+// encodings are decoded.
+//
+// If fn returns an error, CountBooleans stops and returns the error.
+func CountBooleans(chunk ColumnChunk, fn func(BooleanCounts) error) (BooleanCounts, error) {
+	if kind := chunk.Type().Kind(); kind != Boolean {
+		return BooleanCounts{}, fmt.Errorf("cannot count booleans of column of type %s", kind)
+	}
+	if fn == nil {
+		fn = func(BooleanCounts) error { return nil }
+	}
+
+	if c, ok := chunk.(*FileColumnChunk); ok {
+		pages := new(filePages)
+		pages.init(c)
+		defer pages.Close()
+		return pages.countBooleans(fn)
+	}
+
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	var total BooleanCounts
+	for {
+		page, err := pages.ReadPage()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return total, nil
+			}
+			return total, err
+		}
+		counts, err := countBooleansOfPage(page)
+		Release(page)
+		if err != nil {
+			return total, err
+		}
+		total.add(counts)
+		if err := fn(counts); err != nil {
+			return total, err
+		}
+	}
+}
+
+func countBooleansOfPage(page Page) (BooleanCounts, error) {
+	counts := BooleanCounts{}
+	values := make([]Value, 256)
+	reader := page.Values()
+	for {
+		n, err := reader.ReadValues(values)
+		for _, v := range values[:n] {
+			switch {
+			case v.IsNull():
+				counts.Null++
+			case v.boolean():
+				counts.True++
+			default:
+				counts.False++
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return counts, nil
+			}
+			return counts, err
+		}
+	}
+}
+
+func (f *filePages) countBooleans(fn func(BooleanCounts) error) (BooleanCounts, error) {
+	var total BooleanCounts
+	for {
+		header := new(format.PageHeader)
+		if err := f.decoder.Decode(header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return total, nil
+			}
+			return total, err
+		}
+		data, err := f.readPage(header, f.rbuf)
+		if err != nil {
+			return total, err
+		}
+
+		var counts BooleanCounts
+		var isDataPage bool
+		switch header.Type {
+		case format.DataPage:
+			counts, err = f.countBooleansOfDataPageV1(header, data)
+			isDataPage = true
+		case format.DataPageV2:
+			counts, err = f.countBooleansOfDataPageV2(header, data)
+			isDataPage = true
+		case format.DictionaryPage:
+			// Booleans are not expected to be dictionary encoded, but the
+			// dictionary is retained in case the data pages refer to it.
+			err = f.readDictionaryPage(header, data)
+		}
+		data.unref()
+
+		if err != nil {
+			return total, fmt.Errorf("counting booleans of page %d of column %q: %w", f.index, f.columnPath(), err)
+		}
+		if !isDataPage {
+			continue
+		}
+
+		f.index++
+		total.add(counts)
+		if err := fn(counts); err != nil {
+			return total, err
+		}
+	}
+}
+
+func (f *filePages) countBooleansOfDataPageV1(header *format.PageHeader, page *buffer) (BooleanCounts, error) {
+	if header.DataPageHeader == nil {
+		return BooleanCounts{}, ErrMissingPageHeader
+	}
+	h := DataPageHeaderV1{header.DataPageHeader}
+	if !canCountBooleans(h.Encoding()) {
+		return f.countBooleansOfDecodedPage(f.readDataPageV1(header, page))
+	}
+
+	c := f.chunk.column
+	data := page.data
+	if isCompressed(c.compression) {
+		decompressed, err := c.decompress(data, header.UncompressedPageSize)
+		if err != nil {
+			return BooleanCounts{}, fmt.Errorf("decompressing data page v1: %w", err)
+		}
+		defer decompressed.unref()
+		data = decompressed.data
+	}
+
+	numValues, repetitionLevels, definitionLevels, data, err := c.decodeLevelsOfDataPageV1(h, data)
+	if err != nil {
+		return BooleanCounts{}, err
+	}
+	unrefLevels(repetitionLevels, definitionLevels)
+	return countBooleans(h.Encoding(), data, numValues, h.NumValues())
+}
+
+func (f *filePages) countBooleansOfDataPageV2(header *format.PageHeader, page *buffer) (BooleanCounts, error) {
+	if header.DataPageHeaderV2 == nil {
+		return BooleanCounts{}, ErrMissingPageHeader
+	}
+	h := DataPageHeaderV2{header.DataPageHeaderV2}
+	if !canCountBooleans(h.Encoding()) {
+		return f.countBooleansOfDecodedPage(f.readDataPageV2(header, page))
+	}
+
+	c := f.chunk.column
+	numValues, repetitionLevels, definitionLevels, data, err := c.decodeLevelsOfDataPageV2(h, page.data)
+	if err != nil {
+		return BooleanCounts{}, err
+	}
+	unrefLevels(repetitionLevels, definitionLevels)
+
+	if isCompressed(c.compression) && h.IsCompressed() {
+		decompressed, err := c.decompress(data, header.UncompressedPageSize)
+		if err != nil {
+			return BooleanCounts{}, fmt.Errorf("decompressing data page v2: %w", err)
+		}
+		defer decompressed.unref()
+		data = decompressed.data
+	}
+	return countBooleans(h.Encoding(), data, numValues, h.NumValues())
+}
+
+func (f *filePages) countBooleansOfDecodedPage(page Page, _ int, err error) (BooleanCounts, error) {
+	if err != nil {
+		return BooleanCounts{}, err
+	}
+	defer Release(page)
+	return countBooleansOfPage(page)
+}
+
+func canCountBooleans(encoding format.Encoding) bool {
+	return encoding == format.Plain || encoding == format.RLE
+}
+
+// countBooleans counts the true values among the numNonNull values encoded in
+// data, the other values of the page are either false or null.
+func countBooleans(encoding format.Encoding, data []byte, numNonNull int, numValues int64) (BooleanCounts, error) {
+	var numTrue int
+	var err error
+	switch encoding {
+	case format.Plain:
+		numTrue, err = plain.CountTrue(data, numNonNull)
+	case format.RLE:
+		numTrue, err = rle.CountTrue(data, numNonNull)
+	default:
+		err = fmt.Errorf("cannot count booleans encoded with %s", encoding)
+	}
+	if err != nil {
+		return BooleanCounts{}, err
+	}
+	return BooleanCounts{
+		True:  int64(numTrue),
+		False: int64(numNonNull - numTrue),
+		Null:  numValues - int64(numNonNull),
+	}, nil
+}
@@ -0,0 +1,122 @@
+package parquet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestCountBooleans(t *testing.T) {
+	type Row struct {
+		Plain *bool `parquet:"plain,optional"`
+		RLE   bool  `parquet:"rle"`
+	}
+
+	schema := parquet.NewSchema("Row", parquet.Group{
+		"plain": parquet.Optional(parquet.Leaf(parquet.BooleanType)),
+		"rle":   parquet.Encoded(parquet.Leaf(parquet.BooleanType), &parquet.RLE),
+	})
+
+	rows := make([]Row, 10000)
+	want := [2]parquet.BooleanCounts{}
+	for i := range rows {
+		switch {
+		case i%7 == 0:
+			want[0].Null++
+		case i%3 == 0:
+			rows[i].Plain = new(bool)
+			*rows[i].Plain = true
+			want[0].True++
+		default:
+			rows[i].Plain = new(bool)
+			want[0].False++
+		}
+		// Long runs of the same value are encoded as runs by the RLE encoding.
+		if rows[i].RLE = (i/100)%3 == 0 || i%5 == 0; rows[i].RLE {
+			want[1].True++
+		} else {
+			want[1].False++
+		}
+	}
+
+	countBooleans := func(t *testing.T, chunk parquet.ColumnChunk, want parquet.BooleanCounts) {
+		t.Helper()
+		numPages := 0
+		sum := parquet.BooleanCounts{}
+		total, err := parquet.CountBooleans(chunk, func(counts parquet.BooleanCounts) error {
+			numPages++
+			sum.True += counts.True
+			sum.False += counts.False
+			sum.Null += counts.Null
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != want {
+			t.Errorf("wrong counts of column chunk:\nwant: %+v\ngot:  %+v", want, total)
+		}
+		if sum != total {
+			t.Errorf("counts of pages do not add up to the counts of the column chunk:\nwant: %+v\ngot:  %+v", total, sum)
+		}
+		if numPages == 0 {
+			t.Error("no pages were counted")
+		}
+	}
+
+	for _, test := range []struct {
+		scenario string
+		options  []parquet.WriterOption
+	}{
+		{"data page v1", []parquet.WriterOption{parquet.DataPageVersion(1)}},
+		{"data page v2", []parquet.WriterOption{parquet.DataPageVersion(2)}},
+		{"compressed data page v1", []parquet.WriterOption{parquet.DataPageVersion(1), parquet.Compression(&parquet.Snappy)}},
+		{"compressed data page v2", []parquet.WriterOption{parquet.DataPageVersion(2), parquet.Compression(&parquet.Zstd)}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			options := append(test.options, schema, parquet.PageBufferSize(256))
+			if err := parquet.Write(buf, rows, options...); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, chunk := range f.RowGroups()[0].ColumnChunks() {
+				countBooleans(t, chunk, want[i])
+			}
+		})
+	}
+
+	t.Run("buffer", func(t *testing.T) {
+		buffer := parquet.NewGenericBuffer[Row]()
+		if _, err := buffer.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		for i, chunk := range buffer.ColumnChunks() {
+			countBooleans(t, chunk, want[i])
+		}
+	})
+
+	t.Run("not boolean", func(t *testing.T) {
+		buffer := parquet.NewGenericBuffer[struct{ ID int64 }]()
+		if _, err := parquet.CountBooleans(buffer.ColumnChunks()[0], nil); err == nil {
+			t.Error("expected an error counting booleans of an INT64 column")
+		}
+	})
+}
+
+func TestBooleanCountsTrueRatio(t *testing.T) {
+	counts := parquet.BooleanCounts{True: 1, False: 3, Null: 4}
+	if ratio := counts.TrueRatio(); ratio != 0.25 {
+		t.Errorf("wrong ratio of true values: want=0.25 got=%g", ratio)
+	}
+	if n := counts.NumValues(); n != 8 {
+		t.Errorf("wrong number of values: want=8 got=%d", n)
+	}
+	if ratio := (parquet.BooleanCounts{Null: 1}).TrueRatio(); ratio != 0 {
+		t.Errorf("wrong ratio of true values without values: want=0 got=%g", ratio)
+	}
+}
@@ -1,6 +1,7 @@
 package parquet
 
 import (
+	"io"
 	"log"
 	"reflect"
 	"runtime"
@@ -95,6 +96,11 @@ func (buf *GenericBuffer[T]) Size() int64 {
 	return buf.base.Size()
 }
 
+// SizeBytes is an alias for Size, see Buffer.SizeBytes.
+func (buf *GenericBuffer[T]) SizeBytes() int64 {
+	return buf.base.SizeBytes()
+}
+
 func (buf *GenericBuffer[T]) NumRows() int64 {
 	return buf.base.NumRows()
 }
@@ -123,6 +129,11 @@ func (buf *GenericBuffer[T]) Swap(i, j int) {
 	buf.base.Swap(i, j)
 }
 
+// Sort sorts the rows of the buffer, see (*Buffer).Sort for details.
+func (buf *GenericBuffer[T]) Sort() error {
+	return buf.base.Sort()
+}
+
 func (buf *GenericBuffer[T]) Reset() {
 	buf.base.Reset()
 }
@@ -292,6 +303,11 @@ func (buf *Buffer) Size() int64 {
 	return size
 }
 
+// SizeBytes is an alias for Size, provided for symmetry with the
+// BufferedBytes method of writers so applications can apply the same flush
+// policies to buffers and writers.
+func (buf *Buffer) SizeBytes() int64 { return buf.Size() }
+
 // NumRows returns the number of rows written to the buffer.
 func (buf *Buffer) NumRows() int64 { return int64(buf.Len()) }
 
@@ -352,6 +368,78 @@ func (buf *Buffer) Swap(i, j int) {
 	}
 }
 
+// Sort sorts the rows of the buffer according to its sorting columns. Rows with
+// equal values in the sorting columns retain the order in which they were
+// written.
+//
+// When the buffer is configured with DropDuplicatedRows, rows which have the
+// same values in all the sorting columns are then removed from the buffer,
+// retaining the first row written for each key, or the last one when the
+// buffer is also configured with KeepLastDuplicatedRow.
+func (buf *Buffer) Sort() error {
+	sort.Stable(buf)
+	if buf.config.Sorting.DropDuplicatedRows && len(buf.sorted) > 0 {
+		return buf.dropDuplicatedRows()
+	}
+	return nil
+}
+
+func (buf *Buffer) dropDuplicatedRows() error {
+	numRows := buf.Len()
+	keepLast := buf.config.Sorting.KeepLastDuplicatedRow
+	keep := make([]bool, numRows)
+	numKeep := 0
+
+	for i := 0; i < numRows; i++ {
+		// Since the buffer is sorted, two consecutive rows are duplicates if
+		// the first one does not compare less than the second.
+		if keepLast {
+			keep[i] = i == numRows-1 || buf.Less(i, i+1)
+		} else {
+			keep[i] = i == 0 || buf.Less(i-1, i)
+		}
+		if keep[i] {
+			numKeep++
+		}
+	}
+	if numKeep == numRows {
+		return nil
+	}
+
+	// Columns buffers do not support removing values, so the retained rows are
+	// copied out of the buffer, then written back after resetting it.
+	rows := make([]Row, numRows)
+	reader := buf.Rows()
+	defer reader.Close()
+
+	for n := 0; n < numRows; {
+		r, err := reader.ReadRows(rows[n:])
+		for _, row := range rows[n : n+r] {
+			if keep[n] {
+				rows[n] = row.Clone()
+			}
+			n++
+		}
+		if err != nil {
+			if err == io.EOF && n == numRows {
+				break
+			}
+			return err
+		}
+	}
+
+	kept := rows[:0]
+	for i, row := range rows {
+		if keep[i] {
+			kept = append(kept, row)
+		}
+	}
+
+	buf.Reset()
+	_, err := buf.WriteRows(kept)
+	return err
+}
+
 // Reset clears the content of the buffer, allowing it to be reused.
 func (buf *Buffer) Reset() {
 	for _, col := range buf.columns {
@@ -465,6 +553,9 @@ type buffer struct {
 	refc  uintptr
 	pool  *bufferPool
 	stack []byte
+	// Pool of the buffers allocated from a DecompressionBufferPool, the data is
+	// released to this pool instead of pool.
+	decompressionPool DecompressionBufferPool
 }
 
 func (b *buffer) refCount() int {
@@ -479,6 +570,9 @@ func (b *buffer) unref() {
 	if atomic.AddUintptr(&b.refc, ^uintptr(0)) == 0 {
 		if b.pool != nil {
 			b.pool.put(b)
+		} else if b.decompressionPool != nil {
+			b.decompressionPool.PutDecompressionBuffer(b.data)
+			b.data = nil
 		}
 	}
 }
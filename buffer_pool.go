@@ -340,7 +340,7 @@ func (buf *errorBuffer) WriteTo(io.Writer) (int64, error)  { return 0, buf.err }
 func (buf *errorBuffer) Seek(int64, int) (int64, error)    { return 0, buf.err }
 
 var (
-	defaultColumnBufferPool  = *newChunkMemoryBufferPool(256 * 1024)
+	defaultColumnBufferPool  = *newChunkMemoryBufferPool(defaultColumnBufferChunkSize)
 	defaultSortingBufferPool memoryBufferPool
 
 	_ io.ReaderFrom      = (*errorBuffer)(nil)
@@ -31,6 +31,7 @@ func TestGenericBuffer(t *testing.T) {
 	testGenericBuffer[uuidColumn](t)
 	testGenericBuffer[timeColumn](t)
 	testGenericBuffer[timeInMillisColumn](t)
+	testGenericBuffer[timeOfDayColumn](t)
 	testGenericBuffer[mapColumn](t)
 	testGenericBuffer[decimalColumn](t)
 	testGenericBuffer[addressBook](t)
@@ -1008,3 +1009,63 @@ func BenchmarkBufferWriteRows100x(b *testing.B) {
 		return n
 	})
 }
+
+func TestBufferSortDropDuplicatedRows(t *testing.T) {
+	type Row struct {
+		Key   int64  `parquet:"key"`
+		Value string `parquet:"value"`
+	}
+
+	input := []Row{
+		{Key: 2, Value: "b1"},
+		{Key: 1, Value: "a1"},
+		{Key: 2, Value: "b2"},
+		{Key: 3, Value: "c1"},
+		{Key: 1, Value: "a2"},
+		{Key: 2, Value: "b3"},
+	}
+
+	tests := []struct {
+		scenario string
+		keepLast bool
+		want     []Row
+	}{
+		{
+			scenario: "keep first",
+			want:     []Row{{1, "a1"}, {2, "b1"}, {3, "c1"}},
+		},
+		{
+			scenario: "keep last",
+			keepLast: true,
+			want:     []Row{{1, "a2"}, {2, "b3"}, {3, "c1"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := parquet.NewGenericBuffer[Row](
+				parquet.SortingRowGroupConfig(
+					parquet.SortingColumns(parquet.Ascending("key")),
+					parquet.DropDuplicatedRows(true),
+					parquet.KeepLastDuplicatedRow(test.keepLast),
+				),
+			)
+			if _, err := buf.Write(input); err != nil {
+				t.Fatal(err)
+			}
+			if err := buf.Sort(); err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]Row, buf.NumRows())
+			reader := parquet.NewGenericRowGroupReader[Row](buf)
+			n, err := reader.Read(got)
+			if err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got[:n], test.want) {
+				t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", test.want, got[:n])
+			}
+		})
+	}
+}
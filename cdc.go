@@ -0,0 +1,294 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	// CDCOperationColumn is the conventional name of the column holding the
+	// type of operation that produced a row in a change-data-capture stream.
+	//
+	// The column is expected to be of type STRING, holding one of CDCInsert,
+	// CDCUpdate, or CDCDelete.
+	CDCOperationColumn = "_op"
+
+	// CDCSequenceColumn is the conventional name of the column holding the
+	// sequence number of changes in a change-data-capture stream. Versions of
+	// a row with higher sequence numbers supersede the ones with lower values.
+	CDCSequenceColumn = "_seq"
+)
+
+const (
+	// CDCInsert is the operation of rows inserted in a change-data-capture
+	// stream.
+	CDCInsert = "insert"
+
+	// CDCUpdate is the operation of rows which replace a previous version in a
+	// change-data-capture stream.
+	CDCUpdate = "update"
+
+	// CDCDelete is the operation of rows removed from a change-data-capture
+	// stream. MergeOperationColumn drops the rows whose latest version has
+	// this operation.
+	CDCDelete = "delete"
+)
+
+// MergeChangeDataCapture is a row group option which configures MergeRowGroups
+// to resolve versions of rows from change-data-capture streams, using the
+// conventional CDCSequenceColumn and CDCOperationColumn columns.
+//
+// It is equivalent to passing both these options:
+//
+//	parquet.MergeSequenceColumn(parquet.CDCSequenceColumn)
+//	parquet.MergeOperationColumn(parquet.CDCOperationColumn)
+func MergeChangeDataCapture() RowGroupOption {
+	return rowGroupOption(func(config *RowGroupConfig) {
+		config.SequenceColumn = []string{CDCSequenceColumn}
+		config.OperationColumn = []string{CDCOperationColumn}
+	})
+}
+
+// versionedRowGroup is the row group returned by MergeRowGroups when versions
+// of rows are resolved with a sequence column. The number of rows, the rows,
+// and the column chunks reflect the rows produced after resolving versions,
+// which are materialized in memory the first time any of them is used.
+type versionedRowGroup struct {
+	RowGroup
+	compare   func(Row, Row) int
+	sequence  versionColumn
+	operation versionColumn
+	columns   []ColumnChunk
+
+	once   sync.Once
+	buffer *Buffer
+	bufErr error
+}
+
+type versionColumn struct {
+	columnIndex int
+	compare     func(Value, Value) int
+}
+
+func (c *versionColumn) valueOf(row Row) Value {
+	for _, v := range row {
+		if v.Column() == c.columnIndex {
+			return v
+		}
+	}
+	return Value{}
+}
+
+func newVersionedRowGroup(rowGroup RowGroup, sorting []SortingColumn, config *RowGroupConfig) (*versionedRowGroup, error) {
+	schema := rowGroup.Schema()
+	if len(sorting) == 0 {
+		return nil, fmt.Errorf("cannot merge row groups: sequence column %q requires sorting columns", columnPath(config.SequenceColumn))
+	}
+
+	sequence, ok := schema.Lookup(config.SequenceColumn...)
+	if !ok {
+		return nil, fmt.Errorf("cannot merge row groups: sequence column %q does not exist", columnPath(config.SequenceColumn))
+	}
+	if sequence.MaxRepetitionLevel > 0 {
+		return nil, fmt.Errorf("cannot merge row groups: sequence column %q is repeated", columnPath(config.SequenceColumn))
+	}
+
+	v := &versionedRowGroup{
+		RowGroup: rowGroup,
+		compare:  compareRowsFuncOf(schema, sorting),
+		sequence: versionColumn{
+			columnIndex: sequence.ColumnIndex,
+			compare:     CompareNullsFirst(sequence.Node.Type().Compare),
+		},
+		operation: versionColumn{
+			columnIndex: -1,
+		},
+	}
+
+	if len(config.OperationColumn) > 0 {
+		operation, ok := schema.Lookup(config.OperationColumn...)
+		if !ok {
+			return nil, fmt.Errorf("cannot merge row groups: operation column %q does not exist", columnPath(config.OperationColumn))
+		}
+		if operation.Node.Type().Kind() != ByteArray || operation.MaxRepetitionLevel > 0 {
+			return nil, fmt.Errorf("cannot merge row groups: operation column %q must be a non-repeated string", columnPath(config.OperationColumn))
+		}
+		v.operation.columnIndex = operation.ColumnIndex
+	}
+
+	v.columns = make([]ColumnChunk, len(rowGroup.ColumnChunks()))
+	for i, c := range rowGroup.ColumnChunks() {
+		columnIndex := i
+		v.columns[i] = &lazyColumnChunk{
+			typ:    c.Type(),
+			column: columnIndex,
+			load: func() (ColumnChunk, error) {
+				buffer, err := v.materialize()
+				if err != nil {
+					return nil, err
+				}
+				return buffer.ColumnChunks()[columnIndex], nil
+			},
+		}
+	}
+	return v, nil
+}
+
+// NumRows returns the number of rows remaining after resolving versions, which
+// requires reading all the rows the first time it is called. Zero is returned
+// if the versions could not be resolved, the error is then reported when
+// reading the rows or the pages of the column chunks.
+func (v *versionedRowGroup) NumRows() int64 {
+	buffer, err := v.materialize()
+	if err != nil {
+		return 0
+	}
+	return buffer.NumRows()
+}
+
+func (v *versionedRowGroup) ColumnChunks() []ColumnChunk { return v.columns }
+
+// materialize returns a buffer holding the rows remaining after resolving
+// versions, which is used to serve the rows and the column chunks. Versions
+// are resolved only once, and the error is retained for all later calls.
+func (v *versionedRowGroup) materialize() (*Buffer, error) {
+	v.once.Do(func() {
+		rows := v.resolveVersions()
+		defer rows.Close()
+		v.buffer = NewBuffer(v.Schema())
+		if _, err := CopyRows(v.buffer, rows); err != nil {
+			v.bufErr = fmt.Errorf("resolving versions of merged rows: %w", err)
+		}
+	})
+	return v.buffer, v.bufErr
+}
+
+func (v *versionedRowGroup) Rows() Rows {
+	buffer, err := v.materialize()
+	if err != nil {
+		return errorRows{schema: v.Schema(), err: err}
+	}
+	return buffer.Rows()
+}
+
+// resolveVersions returns a reader producing the latest version of the rows of
+// the underlying row group.
+func (v *versionedRowGroup) resolveVersions() Rows {
+	rows := v.RowGroup.Rows()
+	r := &versionedRows{rows: rows, group: v}
+	r.peek.init(rows)
+	return r
+}
+
+// isDelete returns true if the row was produced by a delete operation.
+func (v *versionedRowGroup) isDelete(row Row) bool {
+	if v.operation.columnIndex < 0 {
+		return false
+	}
+	op := v.operation.valueOf(row)
+	return !op.IsNull() && string(op.byteArray()) == CDCDelete
+}
+
+// versionedRows is the implementation of Rows for row groups merged with a
+// sequence column. Consecutive rows with equal sorting keys are versions of
+// the same row; only the version with the highest sequence number is produced,
+// and it is dropped if it represents a delete operation.
+type versionedRows struct {
+	rows      Rows
+	group     *versionedRowGroup
+	peek      peekRowReader
+	latest    Row
+	owned     Row
+	alloc     rowAllocator
+	pending   bool
+	rowIndex  int64
+	seekToRow int64
+}
+
+func (r *versionedRows) ReadRows(rows []Row) (int, error) {
+	for r.rowIndex < r.seekToRow {
+		n := int(r.seekToRow - r.rowIndex)
+		if n > len(rows) {
+			n = len(rows)
+		}
+		if _, err := r.readRows(rows[:n]); err != nil {
+			return 0, err
+		}
+	}
+	return r.readRows(rows)
+}
+
+func (r *versionedRows) readRows(rows []Row) (n int, err error) {
+	defer func() { r.rowIndex += int64(n) }()
+
+	for n < len(rows) {
+		if r.peek.needsFill() {
+			// Same as the upsert row reader, produced rows may be invalidated
+			// when reading more rows and must be returned to the caller first.
+			if n > 0 {
+				return n, nil
+			}
+			if r.pending {
+				r.alloc.reset()
+				r.owned = append(r.owned[:0], r.latest...)
+				r.alloc.capture(r.owned)
+				r.latest = r.owned
+			}
+			if err := r.peek.fill(); err != nil {
+				return n, err
+			}
+			continue
+		}
+
+		row := r.peek.head()
+
+		if r.pending {
+			if row != nil && r.group.compare(row, r.latest) == 0 {
+				seq := r.group.sequence
+				if seq.compare(seq.valueOf(row), seq.valueOf(r.latest)) >= 0 {
+					r.latest = row
+				}
+				r.peek.next()
+				continue
+			}
+			if !r.group.isDelete(r.latest) {
+				rows[n] = append(rows[n][:0], r.latest...)
+				n++
+			}
+			r.latest = nil
+			r.pending = false
+			continue
+		}
+
+		if row == nil {
+			return n, io.EOF
+		}
+
+		r.latest = row
+		r.pending = true
+		r.peek.next()
+	}
+
+	return n, nil
+}
+
+func (r *versionedRows) SeekToRow(rowIndex int64) error {
+	if rowIndex >= r.rowIndex {
+		r.seekToRow = rowIndex
+		return nil
+	}
+	return fmt.Errorf("SeekToRow: versioned row reader cannot seek backward from row %d to %d", r.rowIndex, rowIndex)
+}
+
+func (r *versionedRows) Close() error {
+	r.latest = nil
+	r.pending = false
+	return r.rows.Close()
+}
+
+func (r *versionedRows) Schema() *Schema {
+	return r.rows.Schema()
+}
+
+var _ Rows = (*versionedRows)(nil)
@@ -0,0 +1,175 @@
+package parquet_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestMergeChangeDataCapture(t *testing.T) {
+	type Row struct {
+		Key   int64  `parquet:"key"`
+		Value string `parquet:"value"`
+		Op    string `parquet:"_op"`
+		Seq   int64  `parquet:"_seq"`
+	}
+
+	sorting := parquet.SortingRowGroupConfig(
+		parquet.SortingColumns(parquet.Ascending("key")),
+	)
+
+	buffer1 := parquet.NewGenericBuffer[Row](sorting)
+	buffer1.Write([]Row{
+		{Key: 1, Value: "a", Op: parquet.CDCInsert, Seq: 1},
+		{Key: 2, Value: "b", Op: parquet.CDCInsert, Seq: 2},
+		{Key: 3, Value: "c", Op: parquet.CDCInsert, Seq: 3},
+		{Key: 4, Value: "d", Op: parquet.CDCUpdate, Seq: 9},
+	})
+
+	buffer2 := parquet.NewGenericBuffer[Row](sorting)
+	buffer2.Write([]Row{
+		{Key: 1, Value: "A", Op: parquet.CDCUpdate, Seq: 4},
+		{Key: 2, Value: "", Op: parquet.CDCDelete, Seq: 5},
+		{Key: 4, Value: "D", Op: parquet.CDCUpdate, Seq: 6},
+		{Key: 5, Value: "e", Op: parquet.CDCInsert, Seq: 7},
+	})
+
+	merged, err := parquet.MergeRowGroups(
+		[]parquet.RowGroup{buffer1, buffer2},
+		sorting,
+		parquet.MergeChangeDataCapture(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Row{
+		{Key: 1, Value: "A", Op: parquet.CDCUpdate, Seq: 4},
+		{Key: 3, Value: "c", Op: parquet.CDCInsert, Seq: 3},
+		{Key: 4, Value: "d", Op: parquet.CDCUpdate, Seq: 9},
+		{Key: 5, Value: "e", Op: parquet.CDCInsert, Seq: 7},
+	}
+
+	reader := parquet.NewGenericRowGroupReader[Row](merged)
+	defer reader.Close()
+
+	got := make([]Row, 10)
+	n, _ := reader.Read(got)
+	assertRowsEqual(t, want, got[:n])
+
+	if numRows := merged.NumRows(); numRows != int64(len(want)) {
+		t.Errorf("wrong number of rows: want=%d got=%d", len(want), numRows)
+	}
+	for i, chunk := range merged.ColumnChunks() {
+		if numValues := chunk.NumValues(); numValues != int64(len(want)) {
+			t.Errorf("column %d: wrong number of values: want=%d got=%d", i, len(want), numValues)
+		}
+	}
+
+	// The column chunks hold the rows remaining after resolving versions.
+	column := merged.ColumnChunks()[0]
+	pages := column.Pages()
+	defer pages.Close()
+	var keys []int64
+	for {
+		page, err := pages.ReadPage()
+		if err != nil {
+			break
+		}
+		values := make([]parquet.Value, page.NumValues())
+		page.Values().ReadValues(values)
+		for _, v := range values {
+			keys = append(keys, v.Int64())
+		}
+	}
+	if !slices.Equal(keys, []int64{1, 3, 4, 5}) {
+		t.Errorf("wrong values in column chunk: %v", keys)
+	}
+}
+
+func TestMergeSequenceColumnRequiresSorting(t *testing.T) {
+	type Row struct {
+		Key int64 `parquet:"key"`
+		Seq int64 `parquet:"_seq"`
+	}
+
+	buffer := parquet.NewGenericBuffer[Row]()
+
+	merged, err := parquet.MergeRowGroups(
+		[]parquet.RowGroup{buffer, buffer},
+		parquet.MergeSequenceColumn(parquet.CDCSequenceColumn),
+	)
+	if err == nil {
+		t.Fatal("expected an error when merging without sorting columns")
+	}
+	if merged != nil {
+		t.Errorf("expected a nil row group on error, got %T", merged)
+	}
+}
+
+func TestMergeSequenceColumnError(t *testing.T) {
+	type Row struct {
+		Key int64 `parquet:"key"`
+		Seq int64 `parquet:"_seq"`
+	}
+
+	sorting := parquet.SortingRowGroupConfig(
+		parquet.SortingColumns(parquet.Ascending("key")),
+	)
+	buffer := parquet.NewGenericBuffer[Row](sorting)
+	if _, err := buffer.Write([]Row{{Key: 1, Seq: 1}, {Key: 1, Seq: 2}}); err != nil {
+		t.Fatal(err)
+	}
+
+	errRead := errors.New("read error")
+	failing := &failingRowGroup{RowGroup: buffer, err: errRead}
+	merged, err := parquet.MergeRowGroups(
+		[]parquet.RowGroup{failing},
+		sorting,
+		parquet.MergeSequenceColumn(parquet.CDCSequenceColumn),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if numRows := merged.NumRows(); numRows != 0 {
+		t.Errorf("wrong number of rows: want=0 got=%d", numRows)
+	}
+	rows := merged.Rows()
+	defer rows.Close()
+	if _, err := rows.ReadRows(make([]parquet.Row, 1)); !errors.Is(err, errRead) {
+		t.Errorf("expected the read error, got %v", err)
+	}
+	pages := merged.ColumnChunks()[0].Pages()
+	defer pages.Close()
+	page, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := page.Values().ReadValues(make([]parquet.Value, 1)); !errors.Is(err, errRead) {
+		t.Errorf("expected the read error, got %v", err)
+	}
+	if failing.reads != 1 {
+		t.Errorf("versions were resolved %d times", failing.reads)
+	}
+}
+
+type failingRowGroup struct {
+	parquet.RowGroup
+	err   error
+	reads int
+}
+
+func (g *failingRowGroup) Rows() parquet.Rows {
+	g.reads++
+	return failingRows{g.RowGroup.Rows(), g.err}
+}
+
+type failingRows struct {
+	parquet.Rows
+	err error
+}
+
+func (r failingRows) ReadRows([]parquet.Row) (int, error) { return 0, r.err }
@@ -0,0 +1,88 @@
+package parquet
+
+import "math/bits"
+
+// contentDefinedChunker finds content-defined cut points in a stream of values,
+// using a gear hash rolling over the bytes of the values and of their levels.
+//
+// Because cut points only depend on the last bytes of the stream, inserting or
+// removing values only moves the cut points in the vicinity of the change; the
+// pages or row groups cut at the other points are identical across versions of
+// a data set, which allows content-addressed storage systems to dedupe them.
+//
+// Cut points are only considered after minSize bytes since the previous cut,
+// and cuts are forced after maxSize bytes, so the size of chunks is bounded.
+type contentDefinedChunker struct {
+	hash    uint64
+	mask    uint64
+	size    int64
+	minSize int64
+	maxSize int64
+	found   bool
+}
+
+// newContentDefinedChunker constructs a chunker which produces chunks of the
+// given average size in bytes.
+func newContentDefinedChunker(averageSize int64) *contentDefinedChunker {
+	// The probability of a cut point is 1/2^n for each byte past the minimum
+	// size, the average size of chunks is then minSize + 2^n.
+	minSize := averageSize / 4
+	n := bits.Len64(uint64(max(averageSize-minSize, 1))) - 1
+	return &contentDefinedChunker{
+		// The most significant bits of gear hashes depend on the last 64
+		// bytes while the least significant bits only depend on the last few.
+		mask:    ^(^uint64(0) >> n),
+		minSize: minSize,
+		maxSize: 4 * averageSize,
+	}
+}
+
+func (c *contentDefinedChunker) write(b []byte) {
+	for _, x := range b {
+		c.hash = (c.hash << 1) + gearTable[x]
+		c.size++
+		if c.size >= c.minSize && c.hash&c.mask == 0 {
+			c.found = true
+		}
+	}
+}
+
+func (c *contentDefinedChunker) writeValue(v Value) {
+	levels := [2]byte{v.repetitionLevel, v.definitionLevel}
+	c.write(levels[:])
+	switch v.Kind() {
+	case ByteArray, FixedLenByteArray, Int96:
+		c.write(v.byteArray())
+	default:
+		var b [8]byte
+		c.write(v.AppendBytes(b[:0]))
+	}
+}
+
+// cut returns true if a cut point was found since the last cut, or the chunk
+// reached the maximum size.
+func (c *contentDefinedChunker) cut() bool {
+	return c.found || c.size >= c.maxSize
+}
+
+func (c *contentDefinedChunker) reset() {
+	c.hash = 0
+	c.size = 0
+	c.found = false
+}
+
+// gearTable holds the random values mapped to each byte by gear hashes. The
+// values are generated with a fixed seed so cut points are stable across
+// programs.
+var gearTable = func() (table [256]uint64) {
+	// splitmix64, see https://prng.di.unimi.it/splitmix64.c
+	seed := uint64(0x5041523143444321)
+	for i := range table {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
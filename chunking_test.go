@@ -0,0 +1,144 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestContentDefinedChunking(t *testing.T) {
+	type Row struct {
+		ID      int64  `parquet:"id"`
+		Payload string `parquet:"payload"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	original := make([]Row, 20000)
+	for i := range original {
+		original[i] = Row{ID: int64(i), Payload: fmt.Sprintf("%x", prng.Int63())}
+	}
+	// The modified data set has a few rows inserted in the first half, which
+	// shifts the position of all the rows that follow.
+	modified := append([]Row{}, original[:5000]...)
+	modified = append(modified, Row{ID: -1, Payload: "inserted"}, Row{ID: -2, Payload: "rows"})
+	modified = append(modified, original[5000:]...)
+
+	write := func(rows []Row, options ...parquet.WriterOption) *parquet.File {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows, options...); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	// sharedPages returns the fraction of pages of b which also exist in a,
+	// identified by a hash of their values.
+	sharedPages := func(a, b *parquet.File) float64 {
+		t.Helper()
+		pageHashes := func(f *parquet.File) []uint64 {
+			var hashes []uint64
+			for _, rowGroup := range f.RowGroups() {
+				for _, chunk := range rowGroup.ColumnChunks() {
+					pages := chunk.Pages()
+					for {
+						p, err := pages.ReadPage()
+						if err == io.EOF {
+							break
+						}
+						if err != nil {
+							t.Fatal(err)
+						}
+						values := make([]parquet.Value, p.NumValues())
+						if _, err := p.Values().ReadValues(values); err != nil && err != io.EOF {
+							t.Fatal(err)
+						}
+						h := fnv.New64a()
+						for _, v := range values {
+							fmt.Fprintf(h, "%d/%d/%s;", v.RepetitionLevel(), v.DefinitionLevel(), v)
+						}
+						hashes = append(hashes, h.Sum64())
+						parquet.Release(p)
+					}
+					pages.Close()
+				}
+			}
+			return hashes
+		}
+		seen := make(map[uint64]bool)
+		for _, h := range pageHashes(a) {
+			seen[h] = true
+		}
+		hashes := pageHashes(b)
+		shared := 0
+		for _, h := range hashes {
+			if seen[h] {
+				shared++
+			}
+		}
+		return float64(shared) / float64(len(hashes))
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		options := []parquet.WriterOption{parquet.PageBufferSize(4096), parquet.ContentDefinedChunking(4096, 0)}
+		a, b := write(original, options...), write(modified, options...)
+		if shared := sharedPages(a, b); shared < 0.9 {
+			t.Errorf("only %.0f%% of the pages are shared with content-defined chunking", 100*shared)
+		}
+		if shared := sharedPages(write(original, parquet.PageBufferSize(4096)), write(modified, parquet.PageBufferSize(4096))); shared > 0.75 {
+			t.Errorf("%.0f%% of the pages are shared without content-defined chunking, the test does not exercise the shifting of page boundaries", 100*shared)
+		}
+	})
+
+	t.Run("row groups", func(t *testing.T) {
+		options := []parquet.WriterOption{parquet.ContentDefinedChunking(4096, 32*1024)}
+		a, b := write(original, options...), write(modified, options...)
+		if n := len(a.RowGroups()); n < 4 {
+			t.Fatalf("not enough row groups to test content-defined chunking: %d", n)
+		}
+
+		firstRows := func(f *parquet.File) map[[2]int64]bool {
+			rows := make(map[[2]int64]bool)
+			for _, rowGroup := range f.RowGroups() {
+				buf := make([]parquet.Row, 1)
+				r := rowGroup.Rows()
+				if _, err := r.ReadRows(buf); err != nil && err != io.EOF {
+					t.Fatal(err)
+				}
+				r.Close()
+				rows[[2]int64{buf[0][0].Int64(), rowGroup.NumRows()}] = true
+			}
+			return rows
+		}
+		rowGroupsOfA, rowGroupsOfB := firstRows(a), firstRows(b)
+		shared := 0
+		for key := range rowGroupsOfB {
+			if rowGroupsOfA[key] {
+				shared++
+			}
+		}
+		if shared < len(rowGroupsOfB)-2 {
+			t.Errorf("only %d/%d row groups are shared with content-defined chunking", shared, len(rowGroupsOfB))
+		}
+
+		rows := make([]Row, b.NumRows())
+		r := parquet.NewGenericReader[Row](b)
+		defer r.Close()
+		if n, err := r.Read(rows); n != len(rows) || (err != nil && err != io.EOF) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+		if !reflect.DeepEqual(rows, modified) {
+			t.Error("rows read from the file do not match the rows written")
+		}
+	})
+}
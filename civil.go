@@ -0,0 +1,109 @@
+package parquet
+
+import (
+	"fmt"
+	"time"
+)
+
+// CivilDate represents a calendar date, independently of any time zone.
+//
+// The value is the number of days since the Unix epoch, which is the
+// representation of the DATE logical type. Struct fields of type CivilDate are
+// mapped to DATE columns, and DATE values can be read into CivilDate fields
+// without going through time.Time, which would require picking a time zone to
+// interpret the dates in.
+type CivilDate int32
+
+// CivilDateOf returns the calendar date of t, in the location of t.
+func CivilDateOf(t time.Time) CivilDate {
+	year, month, day := t.Date()
+	return MakeCivilDate(year, month, day)
+}
+
+// MakeCivilDate constructs a CivilDate from a year, month, and day. The values
+// are normalized like they would be by time.Date.
+func MakeCivilDate(year int, month time.Month, day int) CivilDate {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return CivilDate(t.Unix() / (24 * 60 * 60))
+}
+
+// Date returns the year, month, and day of d.
+func (d CivilDate) Date() (year int, month time.Month, day int) {
+	return d.Time(time.UTC).Date()
+}
+
+// Time returns the time at midnight of d in the given location.
+func (d CivilDate) Time(loc *time.Location) time.Time {
+	year, month, day := unixEpoch.AddDate(0, 0, int(d)).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// String returns the representation of d in the YYYY-MM-DD format.
+func (d CivilDate) String() string {
+	return d.Time(time.UTC).Format("2006-01-02")
+}
+
+// ParseCivilDate parses a date in the YYYY-MM-DD format.
+func ParseCivilDate(s string) (CivilDate, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return 0, fmt.Errorf("parsing civil date: %w", err)
+	}
+	return CivilDateOf(t), nil
+}
+
+// TimeOfDay represents a time of the day, independently of any date or time
+// zone.
+//
+// The value is the number of nanoseconds since midnight. Struct fields of type
+// TimeOfDay are mapped to TIME columns with nanosecond precision by default,
+// the "time" struct tag can be used to select a different precision.
+type TimeOfDay int64
+
+// TimeOfDayOf returns the time of day of t, in the location of t.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	hour, min, sec := t.Clock()
+	return MakeTimeOfDay(hour, min, sec, t.Nanosecond())
+}
+
+// MakeTimeOfDay constructs a TimeOfDay from an hour, minute, second, and
+// nanosecond.
+func MakeTimeOfDay(hour, min, sec, nsec int) TimeOfDay {
+	return TimeOfDay(time.Duration(hour)*time.Hour +
+		time.Duration(min)*time.Minute +
+		time.Duration(sec)*time.Second +
+		time.Duration(nsec))
+}
+
+// Clock returns the hour, minute, and second of t.
+func (t TimeOfDay) Clock() (hour, min, sec int) {
+	d := time.Duration(t)
+	return int(d / time.Hour), int(d % time.Hour / time.Minute), int(d % time.Minute / time.Second)
+}
+
+// Nanosecond returns the nanosecond offset within the second of t.
+func (t TimeOfDay) Nanosecond() int { return int(time.Duration(t) % time.Second) }
+
+// Duration returns the time elapsed since midnight.
+func (t TimeOfDay) Duration() time.Duration { return time.Duration(t) }
+
+// On returns the time at t on the date d, in the given location.
+func (t TimeOfDay) On(d CivilDate, loc *time.Location) time.Time {
+	year, month, day := d.Date()
+	hour, min, sec := t.Clock()
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), loc)
+}
+
+// String returns the representation of t in the HH:MM:SS[.fraction] format.
+func (t TimeOfDay) String() string {
+	return time.Unix(0, int64(t)).UTC().Format("15:04:05.999999999")
+}
+
+// ParseTimeOfDay parses a time of day in the HH:MM:SS[.fraction] format.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := time.Parse("15:04:05.999999999", s)
+	if err != nil {
+		return 0, fmt.Errorf("parsing time of day: %w", err)
+	}
+	return TimeOfDayOf(t), nil
+}
@@ -0,0 +1,125 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestCivilDate(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	// 23:30 in New York is already the next day in UTC.
+	d := parquet.CivilDateOf(time.Date(2023, time.March, 14, 23, 30, 0, 0, est))
+	if s := d.String(); s != "2023-03-14" {
+		t.Errorf("wrong date: %s", s)
+	}
+	if year, month, day := d.Date(); year != 2023 || month != time.March || day != 14 {
+		t.Errorf("wrong date components: %d-%d-%d", year, month, day)
+	}
+	if d != parquet.MakeCivilDate(2023, time.March, 14) {
+		t.Errorf("dates mismatch: %s != %s", d, parquet.MakeCivilDate(2023, time.March, 14))
+	}
+	if want := time.Date(2023, time.March, 14, 0, 0, 0, 0, est); !d.Time(est).Equal(want) {
+		t.Errorf("wrong time: %s", d.Time(est))
+	}
+
+	for _, s := range []string{"1970-01-01", "1969-12-31", "1600-02-29", "2400-12-31"} {
+		d, err := parquet.ParseCivilDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != s {
+			t.Errorf("date does not round trip: want=%s got=%s", s, d)
+		}
+	}
+	if d, _ := parquet.ParseCivilDate("1969-12-31"); d != -1 {
+		t.Errorf("wrong number of days since the epoch: %d", d)
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	tod := parquet.TimeOfDayOf(time.Date(2023, time.March, 14, 23, 30, 15, 123456789, est))
+	if s := tod.String(); s != "23:30:15.123456789" {
+		t.Errorf("wrong time of day: %s", s)
+	}
+	if hour, min, sec := tod.Clock(); hour != 23 || min != 30 || sec != 15 || tod.Nanosecond() != 123456789 {
+		t.Errorf("wrong time of day components: %d:%d:%d.%d", hour, min, sec, tod.Nanosecond())
+	}
+	if got := tod.On(parquet.MakeCivilDate(2023, time.March, 14), est); !got.Equal(time.Date(2023, time.March, 14, 23, 30, 15, 123456789, est)) {
+		t.Errorf("wrong time: %s", got)
+	}
+	if parsed, err := parquet.ParseTimeOfDay("23:30:15.123456789"); err != nil || parsed != tod {
+		t.Errorf("wrong parsed time of day: %s (%v)", parsed, err)
+	}
+}
+
+func TestCivilTypesRoundTrip(t *testing.T) {
+	type Row struct {
+		Date        parquet.CivilDate
+		Time        parquet.TimeOfDay
+		TimeMicros  parquet.TimeOfDay  `parquet:",time(microsecond)"`
+		TimeMillis  parquet.TimeOfDay  `parquet:",time(millisecond)"`
+		OptDate     *parquet.CivilDate `parquet:",optional"`
+		RepeatTimes []parquet.TimeOfDay
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	want := `message Row {
+	required int32 Date (DATE);
+	required int64 Time (TIME(isAdjustedToUTC=true,unit=NANOS));
+	required int64 TimeMicros (TIME(isAdjustedToUTC=true,unit=MICROS));
+	required int32 TimeMillis (TIME(isAdjustedToUTC=true,unit=MILLIS));
+	optional int32 OptDate (DATE);
+	repeated int64 RepeatTimes (TIME(isAdjustedToUTC=true,unit=NANOS));
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	date := parquet.MakeCivilDate(1969, time.July, 20)
+	tod := parquet.MakeTimeOfDay(20, 17, 40, 123456789)
+	rows := []Row{
+		{
+			Date:        date,
+			Time:        tod,
+			TimeMicros:  tod,
+			TimeMillis:  tod,
+			OptDate:     &date,
+			RepeatTimes: []parquet.TimeOfDay{tod, 0},
+		},
+		{RepeatTimes: []parquet.TimeOfDay{}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	// Rows written with the non-generic writer go through Row deconstruction.
+	buf2 := new(bytes.Buffer)
+	w := parquet.NewWriter(buf2, schema)
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows[0].TimeMicros = parquet.MakeTimeOfDay(20, 17, 40, 123456000)
+	rows[0].TimeMillis = parquet.MakeTimeOfDay(20, 17, 40, 123000000)
+
+	for _, b := range []*bytes.Buffer{buf, buf2} {
+		got, err := parquet.Read[Row](bytes.NewReader(b.Bytes()), int64(b.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// Clustering is the interface implemented by clustering expressions, which
+// determine the order of rows within the row groups produced by writers.
+//
+// Clustering rows on the values of multiple columns (for example with a
+// z-order curve over two columns) tightens the min/max statistics of pages and
+// row groups for all the columns at once, improving the pruning of queries
+// with predicates on any of those columns. Sorting rows only does this for the
+// first sorting column.
+//
+// Writers configured with a clustering expression using the ClusterRows option
+// buffer the rows of each row group in memory, and write them in the order of
+// their clustering keys when the row group is flushed.
+type Clustering interface {
+	// Returns the dot-separated paths to the leaf columns that clustering
+	// keys are computed from.
+	Columns() []string
+
+	// Appends the clustering key of a row to dst and returns the result.
+	//
+	// The values slice holds the first value of the row in each of the
+	// columns returned by Columns, in the same order; the value is null if
+	// the row has no values in the column. Rows are ordered by comparing
+	// their keys with bytes.Compare.
+	AppendClusteringKey(dst []byte, values []Value) []byte
+}
+
+// ClusterBy constructs a clustering expression which uses the key function to
+// compute the clustering keys of rows from their values in the given columns.
+//
+// The column paths are dot-separated, for example "location.latitude".
+func ClusterBy(key func(dst []byte, values []Value) []byte, columns ...string) Clustering {
+	return &clusteringFunc{columns: columns, key: key}
+}
+
+type clusteringFunc struct {
+	columns []string
+	key     func([]byte, []Value) []byte
+}
+
+func (c *clusteringFunc) Columns() []string { return c.columns }
+
+func (c *clusteringFunc) AppendClusteringKey(dst []byte, values []Value) []byte {
+	return c.key(dst, values)
+}
+
+// rowClusterer buffers the rows of a row group so they can be written in the
+// order of their clustering keys.
+type rowClusterer struct {
+	clustering Clustering
+	columns    []int
+	values     []Value
+	rows       []Row
+	keys       [][]byte
+	size       int64
+}
+
+func newRowClusterer(clustering Clustering, schema *Schema) *rowClusterer {
+	paths := clustering.Columns()
+	columns := make([]int, len(paths))
+
+	for i, path := range paths {
+		leaf, ok := schema.Lookup(strings.Split(path, ".")...)
+		if !ok || !leaf.Node.Leaf() {
+			panic(fmt.Errorf("clustering column %q does not exist in parquet schema %s", path, schema.Name()))
+		}
+		columns[i] = leaf.ColumnIndex
+	}
+
+	return &rowClusterer{
+		clustering: clustering,
+		columns:    columns,
+		values:     make([]Value, len(columns)),
+	}
+}
+
+func (c *rowClusterer) writeRows(rows []Row) {
+	for _, row := range rows {
+		for i, columnIndex := range c.columns {
+			c.values[i] = Value{}
+			for _, v := range row {
+				if v.Column() == columnIndex {
+					c.values[i] = v
+					break
+				}
+			}
+		}
+
+		row = row.Clone()
+		key := c.clustering.AppendClusteringKey(nil, c.values)
+		c.rows = append(c.rows, row)
+		c.keys = append(c.keys, key)
+		c.size += int64(len(row))*int64(unsafe.Sizeof(Value{})) + int64(len(key))
+		for _, v := range row {
+			if k := v.Kind(); k == ByteArray || k == FixedLenByteArray {
+				c.size += int64(len(v.byteArray()))
+			}
+		}
+	}
+	clearValues(c.values)
+}
+
+// sortedRows returns the buffered rows in the order of their clustering keys.
+// Rows with equal keys retain the order in which they were written.
+func (c *rowClusterer) sortedRows() []Row {
+	sort.Stable(c)
+	return c.rows
+}
+
+func (c *rowClusterer) reset() {
+	clearRows(c.rows)
+	for i := range c.keys {
+		c.keys[i] = nil
+	}
+	c.rows = c.rows[:0]
+	c.keys = c.keys[:0]
+	c.size = 0
+}
+
+func (c *rowClusterer) Len() int { return len(c.rows) }
+
+func (c *rowClusterer) Less(i, j int) bool { return bytes.Compare(c.keys[i], c.keys[j]) < 0 }
+
+func (c *rowClusterer) Swap(i, j int) {
+	c.rows[i], c.rows[j] = c.rows[j], c.rows[i]
+	c.keys[i], c.keys[j] = c.keys[j], c.keys[i]
+}
+
+// ZOrder constructs a clustering expression which orders rows along a z-order
+// curve (also known as Morton order) over the values of the given columns.
+//
+// The sorting columns define the dimensions of the curve: columns sorted in
+// descending order have their values reversed, and null values are placed at
+// the beginning of columns sorted with NullsFirst, at the end otherwise.
+func ZOrder(columns ...SortingColumn) Clustering {
+	return newCurveClustering(columns, interleaveBits)
+}
+
+// HilbertOrder constructs a clustering expression which orders rows along a
+// Hilbert curve over the values of the given columns.
+//
+// Hilbert curves are more expensive to compute than z-order curves, but
+// preserve locality better: rows which are close on the curve are always close
+// in all the dimensions, which results in tighter statistics for the pages and
+// row groups.
+//
+// The sorting columns define the dimensions of the curve like they do for
+// ZOrder.
+func HilbertOrder(columns ...SortingColumn) Clustering {
+	return newCurveClustering(columns, hilbertIndex)
+}
+
+// ZOrderKey returns the position of the values on a z-order curve, as a byte
+// key which orders the same as the position when compared with bytes.Compare.
+//
+// Each value is mapped to a 64 bits coordinate preserving the order of values
+// of the same kind; integers are treated as signed, byte arrays are ordered
+// by their first 8 bytes, and null values map to the lowest coordinate. The
+// bits of the coordinates are then interleaved, the most significant bits
+// first, which produces keys of 8 bytes per value.
+func ZOrderKey(values ...Value) []byte {
+	return appendCurveKey(nil, values, interleaveBits)
+}
+
+// HilbertKey is like ZOrderKey but returns the position of the values on a
+// Hilbert curve.
+func HilbertKey(values ...Value) []byte {
+	return appendCurveKey(nil, values, hilbertIndex)
+}
+
+type curveClustering struct {
+	columns    []string
+	descending []bool
+	nullsFirst []bool
+	curve      func(dst []byte, coords []uint64) []byte
+}
+
+func newCurveClustering(columns []SortingColumn, curve func([]byte, []uint64) []byte) *curveClustering {
+	c := &curveClustering{
+		columns:    make([]string, len(columns)),
+		descending: make([]bool, len(columns)),
+		nullsFirst: make([]bool, len(columns)),
+		curve:      curve,
+	}
+	for i, column := range columns {
+		c.columns[i] = columnPath(column.Path()).String()
+		c.descending[i] = column.Descending()
+		c.nullsFirst[i] = column.NullsFirst()
+	}
+	return c
+}
+
+func (c *curveClustering) Columns() []string { return c.columns }
+
+func (c *curveClustering) AppendClusteringKey(dst []byte, values []Value) []byte {
+	// The coordinates are not retained on the clustering, which may be shared
+	// by writers appending keys concurrently.
+	coords := make([]uint64, len(values))
+	for i, v := range values {
+		coord := curveCoordinateOf(v)
+		if v.IsNull() && !c.nullsFirst[i] {
+			coord = math.MaxUint64
+		}
+		if c.descending[i] {
+			coord = ^coord
+		}
+		coords[i] = coord
+	}
+	return c.curve(dst, coords)
+}
+
+func appendCurveKey(dst []byte, values []Value, curve func([]byte, []uint64) []byte) []byte {
+	coords := make([]uint64, len(values))
+	for i, v := range values {
+		coords[i] = curveCoordinateOf(v)
+	}
+	return curve(dst, coords)
+}
+
+// curveCoordinateOf maps v to an unsigned integer, preserving the order of
+// values of the same kind. The most significant bits of the coordinates are
+// the most significant bits of the values, which matters for the positions on
+// space filling curves since their bits are interleaved.
+func curveCoordinateOf(v Value) uint64 {
+	if v.IsNull() {
+		return 0
+	}
+	switch v.Kind() {
+	case Boolean:
+		if v.boolean() {
+			return 1 << 63
+		}
+		return 0
+	case Int32:
+		return uint64(uint32(v.int32())^(1<<31)) << 32
+	case Int64:
+		return uint64(v.int64()) ^ (1 << 63)
+	case Int96:
+		i96 := v.Int96()
+		return uint64(i96[2]^(1<<31))<<32 | uint64(i96[1])
+	case Float:
+		bits := math.Float32bits(v.float())
+		if bits&(1<<31) != 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 31
+		}
+		return uint64(bits) << 32
+	case Double:
+		bits := math.Float64bits(v.double())
+		if bits&(1<<63) != 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+		return bits
+	default:
+		var b [8]byte
+		copy(b[:], v.byteArray())
+		return binary.BigEndian.Uint64(b[:])
+	}
+}
+
+// interleaveBits appends the bits of the coordinates to dst, interleaved from
+// the most significant to the least significant bits, which is the position of
+// the coordinates on a z-order curve.
+func interleaveBits(dst []byte, coords []uint64) []byte {
+	n := len(coords)
+	offset := len(dst)
+	dst = append(dst, make([]byte, 8*n)...)
+	key := dst[offset:]
+
+	for bit := 0; bit < 64; bit++ {
+		for i, coord := range coords {
+			if coord&(1<<(63-bit)) != 0 {
+				k := bit*n + i
+				key[k/8] |= 1 << (7 - k%8)
+			}
+		}
+	}
+	return dst
+}
+
+// hilbertIndex appends the position of the coordinates on a Hilbert curve to
+// dst. The coordinates are transformed in place to the transposed Hilbert
+// index using the algorithm described by John Skilling in "Programming the
+// Hilbert curve" (2004), which is then interleaved like a z-order position.
+func hilbertIndex(dst []byte, coords []uint64) []byte {
+	n := len(coords)
+	if n == 0 {
+		return dst
+	}
+	const m = uint64(1) << 63
+
+	for q := m; q > 1; q >>= 1 {
+		p := q - 1
+		for i := 0; i < n; i++ {
+			if coords[i]&q != 0 {
+				coords[0] ^= p
+			} else {
+				t := (coords[0] ^ coords[i]) & p
+				coords[0] ^= t
+				coords[i] ^= t
+			}
+		}
+	}
+
+	for i := 1; i < n; i++ {
+		coords[i] ^= coords[i-1]
+	}
+	t := uint64(0)
+	for q := m; q > 1; q >>= 1 {
+		if coords[n-1]&q != 0 {
+			t ^= q - 1
+		}
+	}
+	for i := range coords {
+		coords[i] ^= t
+	}
+
+	return interleaveBits(dst, coords)
+}
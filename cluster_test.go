@@ -0,0 +1,275 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestClusterRows(t *testing.T) {
+	type Point struct {
+		X    int32  `parquet:"x"`
+		Y    int32  `parquet:"y"`
+		Name string `parquet:"name"`
+	}
+
+	// Interleave the bits of the two coordinates, which orders points along
+	// a z-order curve.
+	zorder := parquet.ClusterBy(func(dst []byte, values []parquet.Value) []byte {
+		x, y := uint32(values[0].Int32()), uint32(values[1].Int32())
+		z := uint64(0)
+		for i := 0; i < 32; i++ {
+			z |= uint64(x>>i&1)<<(2*i+1) | uint64(y>>i&1)<<(2*i)
+		}
+		return binary.BigEndian.AppendUint64(dst, z)
+	}, "x", "y")
+
+	rows := []Point{
+		{X: 3, Y: 3, Name: "d"},
+		{X: 0, Y: 1, Name: "a"},
+		{X: 1, Y: 0, Name: "b"},
+		{X: 2, Y: 2, Name: "c"},
+		{X: 0, Y: 0, Name: "first"},
+		{X: 0, Y: 0, Name: "second"},
+	}
+	// Rows are reordered within each row group of 4 rows, rows with equal
+	// keys retain their order.
+	want := []Point{
+		{X: 0, Y: 1, Name: "a"},
+		{X: 1, Y: 0, Name: "b"},
+		{X: 2, Y: 2, Name: "c"},
+		{X: 3, Y: 3, Name: "d"},
+		{X: 0, Y: 0, Name: "first"},
+		{X: 0, Y: 0, Name: "second"},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Point](buf,
+		parquet.ClusterRows(zorder),
+		parquet.MaxRowsPerRowGroup(4),
+	)
+	if _, err := w.Write(rows[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if n := w.BufferedBytes(); n == 0 {
+		t.Error("buffered rows are not accounted for by BufferedBytes")
+	}
+	if _, err := w.Write(rows[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != 2 {
+		t.Fatalf("wrong number of row groups: %d", n)
+	}
+
+	got, err := parquet.Read[Point](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong row order:\nwant: %+v\ngot:  %+v", want, got)
+	}
+
+	t.Run("unknown column", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic for a clustering column missing from the schema")
+			}
+		}()
+		parquet.NewGenericWriter[Point](new(bytes.Buffer), parquet.ClusterRows(parquet.ClusterBy(nil, "z")))
+	})
+}
+
+func TestZOrderKey(t *testing.T) {
+	// On a 2x2 grid, the z-order curve visits (0,0), (0,1), (1,0), (1,1) when
+	// the first dimension holds the most significant bit of each pair.
+	points := [][2]int64{{1, 1}, {1, 0}, {0, 1}, {0, 0}}
+	keys := make([][]byte, len(points))
+	for i, p := range points {
+		keys[i] = parquet.ZOrderKey(parquet.Int64Value(p[0]), parquet.Int64Value(p[1]))
+	}
+	for i := 1; i < len(keys); i++ {
+		if bytes.Compare(keys[i-1], keys[i]) <= 0 {
+			t.Errorf("key of %v is not greater than the key of %v", points[i-1], points[i])
+		}
+	}
+
+	ordered := []parquet.Value{
+		parquet.Int64Value(-2),
+		parquet.Int64Value(-1),
+		parquet.Int64Value(0),
+		parquet.Int64Value(1),
+	}
+	for i := 1; i < len(ordered); i++ {
+		a := parquet.ZOrderKey(ordered[i-1], ordered[i-1])
+		b := parquet.ZOrderKey(ordered[i], ordered[i])
+		if bytes.Compare(a, b) >= 0 {
+			t.Errorf("key of %v is not less than the key of %v", ordered[i-1], ordered[i])
+		}
+	}
+
+	floats := []float64{-10.5, -0.25, 0, 0.25, 10.5}
+	for i := 1; i < len(floats); i++ {
+		a := parquet.ZOrderKey(parquet.DoubleValue(floats[i-1]))
+		b := parquet.ZOrderKey(parquet.DoubleValue(floats[i]))
+		if bytes.Compare(a, b) >= 0 {
+			t.Errorf("key of %v is not less than the key of %v", floats[i-1], floats[i])
+		}
+	}
+}
+
+func TestHilbertKey(t *testing.T) {
+	// Consecutive cells along a Hilbert curve are always adjacent, which is
+	// checked on an 8x8 grid placed on the most significant bits of the
+	// coordinates.
+	type cell struct {
+		x, y int
+		key  []byte
+	}
+	var cells []cell
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			key := parquet.HilbertKey(
+				parquet.ByteArrayValue([]byte{byte(x << 5)}),
+				parquet.ByteArrayValue([]byte{byte(y << 5)}),
+			)
+			cells = append(cells, cell{x, y, key})
+		}
+	}
+	slices.SortFunc(cells, func(a, b cell) int { return bytes.Compare(a.key, b.key) })
+
+	for i := 1; i < len(cells); i++ {
+		a, b := cells[i-1], cells[i]
+		if d := abs(a.x-b.x) + abs(a.y-b.y); d != 1 {
+			t.Errorf("cells (%d,%d) and (%d,%d) are consecutive on the curve but not adjacent", a.x, a.y, b.x, b.y)
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestClusterRowsHilbertOrder(t *testing.T) {
+	type Row struct {
+		X int64  `parquet:"x"`
+		Y *int64 `parquet:"y,optional"`
+	}
+	y := func(v int64) *int64 { return &v }
+
+	rows := []Row{{X: 1, Y: y(1)}, {X: 0, Y: nil}, {X: 0, Y: y(0)}, {X: 1, Y: y(0)}}
+
+	for _, test := range []struct {
+		scenario   string
+		clustering parquet.Clustering
+		want       []Row
+	}{
+		{
+			scenario:   "nulls last",
+			clustering: parquet.ZOrder(parquet.Ascending("x"), parquet.Ascending("y")),
+			want:       []Row{{X: 0, Y: y(0)}, {X: 1, Y: y(0)}, {X: 1, Y: y(1)}, {X: 0, Y: nil}},
+		},
+		{
+			scenario:   "nulls first",
+			clustering: parquet.ZOrder(parquet.Ascending("x"), parquet.NullsFirst(parquet.Ascending("y"))),
+			want:       []Row{{X: 0, Y: nil}, {X: 0, Y: y(0)}, {X: 1, Y: y(0)}, {X: 1, Y: y(1)}},
+		},
+		{
+			scenario:   "descending",
+			clustering: parquet.ZOrder(parquet.Descending("x"), parquet.Descending("y")),
+			want:       []Row{{X: 0, Y: nil}, {X: 1, Y: y(1)}, {X: 1, Y: y(0)}, {X: 0, Y: y(0)}},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := parquet.Write(buf, rows, parquet.ClusterRows(test.clustering)); err != nil {
+				t.Fatal(err)
+			}
+			got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("wrong row order:\nwant: %s\ngot:  %s", formatRows(test.want), formatRows(got))
+			}
+		})
+	}
+
+	t.Run("hilbert", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		clustering := parquet.HilbertOrder(parquet.Ascending("x"), parquet.Ascending("y"))
+		if err := parquet.Write(buf, rows, parquet.ClusterRows(clustering)); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(rows) || got[len(got)-1].Y != nil {
+			t.Errorf("the row with a null value was not placed last: %s", formatRows(got))
+		}
+	})
+}
+
+func formatRows[Row any](rows []Row) string {
+	s := new(strings.Builder)
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		s.WriteString("{")
+		for i := 0; i < v.NumField(); i++ {
+			if i > 0 {
+				s.WriteString(" ")
+			}
+			f := v.Field(i)
+			if f.Kind() == reflect.Pointer {
+				if f.IsNil() {
+					s.WriteString("nil")
+					continue
+				}
+				f = f.Elem()
+			}
+			fmt.Fprint(s, f.Interface())
+		}
+		s.WriteString("}")
+	}
+	return s.String()
+}
+
+func TestCurveClusteringConcurrentKeys(t *testing.T) {
+	// Writers which share a clustering append keys concurrently.
+	clustering := parquet.HilbertOrder(parquet.Ascending("x"), parquet.Ascending("y"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int64) {
+			defer wg.Done()
+			values := []parquet.Value{parquet.Int64Value(i), parquet.Int64Value(-i)}
+			want := clustering.AppendClusteringKey(nil, values)
+			for j := 0; j < 1000; j++ {
+				if got := clustering.AppendClusteringKey(nil, values); !bytes.Equal(got, want) {
+					t.Errorf("key of %v changed: want=%x got=%x", values, want, got)
+					return
+				}
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+}
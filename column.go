@@ -1,16 +1,20 @@
 package parquet
 
 import (
+	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
+	"sync"
 
 	"github.com/parquet-go/parquet-go/compress"
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/encoding"
 	"github.com/parquet-go/parquet-go/format"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
+	"github.com/parquet-go/parquet-go/sparse"
 )
 
 // Column represents a column in a parquet file.
@@ -36,6 +40,11 @@ type Column struct {
 	maxRepetitionLevel byte
 	maxDefinitionLevel byte
 	index              int16
+	checkLevels        bool
+	repairLevels       bool
+	lazyValues         bool
+
+	dictionaryCache columnDictionaryCache
 }
 
 // Type returns the type of the column.
@@ -43,6 +52,21 @@ type Column struct {
 // The returned value is unspecified if c is not a leaf column.
 func (c *Column) Type() Type { return c.typ }
 
+// ColumnOrder returns the order used by the writer of the file to compute the
+// min and max statistics of the column, or nil if the file does not declare
+// it (files written by older applications, or if c is not a leaf column).
+//
+// Without a column order, the meaning of the min and max statistics is
+// undefined for types which are not ordered by signed comparisons (see
+// SortOrderOf), since writers may have computed them with signed comparisons.
+func (c *Column) ColumnOrder() *format.ColumnOrder { return c.order }
+
+// hasTypeDefinedOrder returns true if the min and max statistics of the column
+// are computed with the sort order of its type.
+func (c *Column) hasTypeDefinedOrder() bool {
+	return c.order != nil && c.order.TypeOrder != nil
+}
+
 // Optional returns true if the column is optional.
 func (c *Column) Optional() bool { return schemaRepetitionTypeOf(c.schema) == format.Optional }
 
@@ -104,7 +128,7 @@ func (c *Column) Pages() Pages {
 		pages: make([]filePages, len(c.file.rowGroups)),
 	}
 	for i := range r.pages {
-		r.pages[i].init(c.file.rowGroups[i].(*fileRowGroup).columns[c.index].(*fileColumnChunk))
+		r.pages[i].init(c.file.rowGroups[i].(*FileRowGroup).columns[c.index].(*FileColumnChunk))
 	}
 	return r
 }
@@ -223,18 +247,9 @@ func openColumns(file *File) (*Column, error) {
 }
 
 func (c *Column) setLevels(depth, repetition, definition, index int) (int, error) {
-	if depth > MaxColumnDepth {
-		return -1, fmt.Errorf("cannot represent parquet columns with more than %d nested levels: %s", MaxColumnDepth, c.path)
-	}
 	if index > MaxColumnIndex {
 		return -1, fmt.Errorf("cannot represent parquet rows with more than %d columns: %s", MaxColumnIndex, c.path)
 	}
-	if repetition > MaxRepetitionLevel {
-		return -1, fmt.Errorf("cannot represent parquet columns with more than %d repetition levels: %s", MaxRepetitionLevel, c.path)
-	}
-	if definition > MaxDefinitionLevel {
-		return -1, fmt.Errorf("cannot represent parquet columns with more than %d definition levels: %s", MaxDefinitionLevel, c.path)
-	}
 
 	switch schemaRepetitionTypeOf(c.schema) {
 	case format.Optional:
@@ -244,6 +259,12 @@ func (c *Column) setLevels(depth, repetition, definition, index int) (int, error
 		definition++
 	}
 
+	// The levels are checked after being incremented, the ones of the column
+	// must fit in bytes.
+	if err := checkColumnLevels(c.path, depth, repetition, definition); err != nil {
+		return -1, err
+	}
+
 	c.depth = int8(depth)
 	c.maxRepetitionLevel = byte(repetition)
 	c.maxDefinitionLevel = byte(definition)
@@ -273,8 +294,11 @@ type columnLoader struct {
 
 func (cl *columnLoader) open(file *File, path []string) (*Column, error) {
 	c := &Column{
-		file:   file,
-		schema: &file.metadata.Schema[cl.schemaIndex],
+		file:         file,
+		schema:       &file.metadata.Schema[cl.schemaIndex],
+		checkLevels:  file.config.CheckDefinitionLevels || file.config.RepairDefinitionLevels,
+		repairLevels: file.config.RepairDefinitionLevels,
+		lazyValues:   file.config.LazyValueDecoding,
 	}
 	c.path = columnPath(path).append(c.schema.Name)
 
@@ -350,7 +374,7 @@ func (cl *columnLoader) open(file *File, path []string) (*Column, error) {
 	}
 
 	c.typ = &groupType{}
-	if lt := c.schema.LogicalType; lt != nil && lt.Map != nil {
+	if isMapSchemaElement(file.metadata.Schema, cl.schemaIndex-1) {
 		c.typ = &mapType{}
 	}
 	c.columns = make([]*Column, numChildren)
@@ -371,6 +395,57 @@ func (cl *columnLoader) open(file *File, path []string) (*Column, error) {
 	return c, nil
 }
 
+// isMapSchemaElement returns true if the group at index i of the schema is of
+// the MAP logical type. Files written by older parquet writers may only carry
+// the MAP converted type, or incorrectly use the MAP_KEY_VALUE converted type
+// on the outer group instead of the repeated key/value group, which must be
+// considered as well as long as the group has the structure of a map.
+func isMapSchemaElement(schema []format.SchemaElement, i int) bool {
+	s := &schema[i]
+	if lt := s.LogicalType; lt != nil {
+		return lt.Map != nil
+	}
+	if ct := s.ConvertedType; ct != nil {
+		switch *ct {
+		case deprecated.Map, deprecated.MapKeyValue:
+			return hasMapStructure(schema, i)
+		}
+	}
+	return false
+}
+
+// hasMapStructure returns true if the group at index i of the schema contains
+// a single repeated group with a required key and a value field. Older writers
+// did not consistently name the repeated group (e.g. "map" instead of
+// "key_value"), so only the names of the key and value fields are checked.
+func hasMapStructure(schema []format.SchemaElement, i int) bool {
+	if i+3 >= len(schema) || schema[i].NumChildren != 1 || schemaRepetitionTypeOf(&schema[i]) == format.Repeated {
+		return false
+	}
+	keyValue := &schema[i+1]
+	if keyValue.NumChildren != 2 || schemaRepetitionTypeOf(keyValue) != format.Repeated {
+		return false
+	}
+	key := &schema[i+2]
+	j := skipSchemaElement(schema, i+2)
+	if j >= len(schema) {
+		return false
+	}
+	value := &schema[j]
+	return key.Name == "key" && schemaRepetitionTypeOf(key) == format.Required &&
+		value.Name == "value" && schemaRepetitionTypeOf(value) != format.Repeated
+}
+
+// skipSchemaElement returns the index of the schema element following the
+// element at index i and all its children.
+func skipSchemaElement(schema []format.SchemaElement, i int) int {
+	j := i + 1
+	for n := schema[i].NumChildren; n > 0 && j < len(schema); n-- {
+		j = skipSchemaElement(schema, j)
+	}
+	return j
+}
+
 func schemaElementTypeOf(s *format.SchemaElement) Type {
 	if lt := s.LogicalType; lt != nil {
 		// A logical type exists, the Type interface implementations in this
@@ -400,8 +475,10 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 						panic("DECIMAL using FIXED_LEN_BYTE_ARRAY must specify a length")
 					}
 					typ = FixedLenByteArrayType(int(*s.TypeLength))
+				case ByteArray:
+					typ = ByteArrayType
 				default:
-					panic("DECIMAL must be of type INT32, INT64, or FIXED_LEN_BYTE_ARRAY but got " + kind.String())
+					panic("DECIMAL must be of type INT32, INT64, BYTE_ARRAY or FIXED_LEN_BYTE_ARRAY but got " + kind.String())
 				}
 				return &decimalType{
 					decimal: *lt.Decimal,
@@ -545,7 +622,16 @@ func schemaRepetitionTypeOf(s *format.SchemaElement) format.FieldRepetitionType
 }
 
 func (c *Column) decompress(compressedPageData []byte, uncompressedPageSize int32) (page *buffer, err error) {
-	page = buffers.get(int(uncompressedPageSize))
+	if c.file != nil && c.file.config.DecompressionBuffers != nil {
+		pool := c.file.config.DecompressionBuffers
+		page = &buffer{
+			data:              pool.GetDecompressionBuffer(max(int(uncompressedPageSize), 0)),
+			refc:              1,
+			decompressionPool: pool,
+		}
+	} else {
+		page = buffers.get(int(uncompressedPageSize))
+	}
 	page.data, err = c.compression.Decode(page.data, compressedPageData)
 	if err != nil {
 		page.unref()
@@ -557,62 +643,109 @@ func (c *Column) decompress(compressedPageData []byte, uncompressedPageSize int3
 // DecodeDataPageV1 decodes a data page from the header, compressed data, and
 // optional dictionary passed as arguments.
 func (c *Column) DecodeDataPageV1(header DataPageHeaderV1, page []byte, dict Dictionary) (Page, error) {
-	return c.decodeDataPageV1(header, &buffer{data: page}, dict, -1)
+	p, _, err := c.decodeDataPageV1(header, &buffer{data: page}, dict, -1, 0)
+	return p, err
 }
 
-func (c *Column) decodeDataPageV1(header DataPageHeaderV1, page *buffer, dict Dictionary, size int32) (Page, error) {
+func (c *Column) decodeDataPageV1(header DataPageHeaderV1, page *buffer, dict Dictionary, size int32, skip int) (Page, int, error) {
 	var pageData = page.data
 	var err error
 
 	if isCompressed(c.compression) {
+		if stream := c.streamDecoderOf(header, size); stream != nil {
+			return c.decodeDataPageV1Stream(stream, header, pageData, size)
+		}
 		if page, err = c.decompress(pageData, size); err != nil {
-			return nil, fmt.Errorf("decompressing data page v1: %w", err)
+			return nil, 0, fmt.Errorf("decompressing data page v1: %w", err)
 		}
 		defer page.unref()
 		pageData = page.data
 	}
 
-	var numValues = int(header.NumValues())
-	var repetitionLevels *buffer
-	var definitionLevels *buffer
+	numValues, repetitionLevels, definitionLevels, pageData, err := c.decodeLevelsOfDataPageV1(header, pageData)
+	if err != nil {
+		return nil, 0, err
+	}
+	if repetitionLevels != nil {
+		defer repetitionLevels.unref()
+	}
+	if definitionLevels != nil {
+		defer definitionLevels.unref()
+	}
+	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict, skip)
+}
+
+// decodeLevelsOfDataPageV1 decodes the repetition and definition levels at the
+// beginning of the uncompressed data of a page v1. The function returns the
+// number of non-null values in the page and the data following the levels.
+// The level buffers are nil if the column has no levels of the kind.
+func (c *Column) decodeLevelsOfDataPageV1(header DataPageHeaderV1, pageData []byte) (numValues int, repetitionLevels, definitionLevels *buffer, rest []byte, err error) {
+	numValues = int(header.NumValues())
 
 	if c.maxRepetitionLevel > 0 {
 		encoding := lookupLevelEncoding(header.RepetitionLevelEncoding(), c.maxRepetitionLevel)
 		repetitionLevels, pageData, err = decodeLevelsV1(encoding, numValues, pageData)
 		if err != nil {
-			return nil, fmt.Errorf("decoding repetition levels of data page v1: %w", err)
+			unrefLevels(repetitionLevels, nil)
+			return 0, nil, nil, nil, fmt.Errorf("decoding repetition levels of data page v1: %w", err)
 		}
-		defer repetitionLevels.unref()
 	}
 
 	if c.maxDefinitionLevel > 0 {
 		encoding := lookupLevelEncoding(header.DefinitionLevelEncoding(), c.maxDefinitionLevel)
 		definitionLevels, pageData, err = decodeLevelsV1(encoding, numValues, pageData)
 		if err != nil {
-			return nil, fmt.Errorf("decoding definition levels of data page v1: %w", err)
+			unrefLevels(repetitionLevels, definitionLevels)
+			return 0, nil, nil, nil, fmt.Errorf("decoding definition levels of data page v1: %w", err)
 		}
-		defer definitionLevels.unref()
 
 		// Data pages v1 did not embed the number of null values,
 		// so we have to compute it from the definition levels.
 		numValues -= countLevelsNotEqual(definitionLevels.data, c.maxDefinitionLevel)
 	}
 
-	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict)
+	return numValues, repetitionLevels, definitionLevels, pageData, nil
 }
 
 // DecodeDataPageV2 decodes a data page from the header, compressed data, and
 // optional dictionary passed as arguments.
 func (c *Column) DecodeDataPageV2(header DataPageHeaderV2, page []byte, dict Dictionary) (Page, error) {
-	return c.decodeDataPageV2(header, &buffer{data: page}, dict, -1)
+	p, _, err := c.decodeDataPageV2(header, &buffer{data: page}, dict, -1, 0)
+	return p, err
 }
 
-func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Dictionary, size int32) (Page, error) {
-	var numValues = int(header.NumValues())
-	var pageData = page.data
-	var err error
-	var repetitionLevels *buffer
-	var definitionLevels *buffer
+func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Dictionary, size int32, skip int) (Page, int, error) {
+	numValues, repetitionLevels, definitionLevels, pageData, err := c.decodeLevelsOfDataPageV2(header, page.data)
+	if err != nil {
+		return nil, 0, err
+	}
+	if repetitionLevels != nil {
+		defer repetitionLevels.unref()
+	}
+	if definitionLevels != nil {
+		defer definitionLevels.unref()
+	}
+
+	if isCompressed(c.compression) && header.IsCompressed() {
+		if stream := c.streamDecoderOf(header, size); stream != nil {
+			return c.decodeDataPageV2Stream(stream, header, numValues, repetitionLevels, definitionLevels, pageData, size)
+		}
+		if page, err = c.decompress(pageData, size); err != nil {
+			return nil, 0, fmt.Errorf("decompressing data page v2: %w", err)
+		}
+		defer page.unref()
+		pageData = page.data
+	}
+
+	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict, skip)
+}
+
+// decodeLevelsOfDataPageV2 decodes the repetition and definition levels at the
+// beginning of the data of a page v2, which are never compressed. The function
+// returns the number of non-null values in the page and the data following the
+// levels. The level buffers are nil if the column has no levels of the kind.
+func (c *Column) decodeLevelsOfDataPageV2(header DataPageHeaderV2, pageData []byte) (numValues int, repetitionLevels, definitionLevels *buffer, rest []byte, err error) {
+	numValues = int(header.NumValues())
 
 	if length := header.RepetitionLevelsByteLength(); length > 0 {
 		if c.maxRepetitionLevel == 0 {
@@ -627,10 +760,8 @@ func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Di
 			repetitionLevels, pageData, err = decodeLevelsV2(encoding, numValues, pageData, length)
 		}
 		if err != nil {
-			return nil, fmt.Errorf("decoding repetition levels of data page v2: %w", io.ErrUnexpectedEOF)
-		}
-		if repetitionLevels != nil {
-			defer repetitionLevels.unref()
+			unrefLevels(repetitionLevels, nil)
+			return 0, nil, nil, nil, fmt.Errorf("decoding repetition levels of data page v2: %w", io.ErrUnexpectedEOF)
 		}
 	}
 
@@ -642,26 +773,23 @@ func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Di
 			definitionLevels, pageData, err = decodeLevelsV2(encoding, numValues, pageData, length)
 		}
 		if err != nil {
-			return nil, fmt.Errorf("decoding definition levels of data page v2: %w", io.ErrUnexpectedEOF)
-		}
-		if definitionLevels != nil {
-			defer definitionLevels.unref()
+			unrefLevels(repetitionLevels, definitionLevels)
+			return 0, nil, nil, nil, fmt.Errorf("decoding definition levels of data page v2: %w", io.ErrUnexpectedEOF)
 		}
 	}
 
-	if isCompressed(c.compression) && header.IsCompressed() {
-		if page, err = c.decompress(pageData, size); err != nil {
-			return nil, fmt.Errorf("decompressing data page v2: %w", err)
-		}
-		defer page.unref()
-		pageData = page.data
-	}
-
 	numValues -= int(header.NumNulls())
-	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict)
+	return numValues, repetitionLevels, definitionLevels, pageData, nil
 }
 
-func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetitionLevels, definitionLevels, page *buffer, data []byte, dict Dictionary) (Page, error) {
+// decodeDataPage decodes the values of a data page. When skip is positive, the
+// caller does not need the first skip rows of the page; the function removes
+// them before decoding the values when the column is not repeated and the
+// encoding allows it, and returns the number of rows that were removed.
+//
+// Skipping rows requires modifying the page data, the caller must own the page
+// buffer.
+func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetitionLevels, definitionLevels, page *buffer, data []byte, dict Dictionary, skip int) (Page, int, error) {
 	pageEncoding := LookupEncoding(header.Encoding())
 	pageType := c.Type()
 
@@ -674,51 +802,147 @@ func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetition
 		pageType = indexedPageType{newIndexedType(pageType, dict)}
 	}
 
+	var levels []byte
+	if definitionLevels != nil {
+		levels = definitionLevels.data
+	}
+	skipped := 0
+	if skip > 0 && c.maxRepetitionLevel == 0 {
+		skipped, levels, data, numValues = c.skipRows(pageEncoding, pageType, skip, levels, data, numValues)
+	}
+
+	var newPage Page
 	var vbuf, obuf *buffer
-	var pageValues []byte
-	var pageOffsets []uint32
 
-	if pageEncoding.CanDecodeInPlace() {
+	if c.lazyValues && pageType.Kind() == ByteArray && !isDictionaryEncoding(pageEncoding) {
+		// The page retains its encoded data, values are decoded on access.
+		if definitionLevels != nil && c.checkLevels {
+			var err error
+			numValues, err = c.checkDefinitionLevels(levels, numValues, -1)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
 		vbuf = page
-		pageValues = data
+		newPage = newLazyByteArrayPage(pageType, c.Index(), numValues, pageEncoding, data)
 	} else {
-		vbuf = buffers.get(pageType.EstimateDecodeSize(numValues, data, pageEncoding))
-		defer vbuf.unref()
-		pageValues = vbuf.data
-	}
+		var pageValues []byte
+		var pageOffsets []uint32
 
-	// Page offsets not needed when dictionary-encoded
-	if pageType.Kind() == ByteArray && !isDictionaryEncoding(pageEncoding) {
-		obuf = buffers.get(4 * (numValues + 1))
-		defer obuf.unref()
-		pageOffsets = unsafecast.BytesToUint32(obuf.data)
-	}
+		if pageEncoding.CanDecodeInPlace() {
+			vbuf = page
+			pageValues = data
+		} else {
+			vbuf = buffers.get(pageType.EstimateDecodeSize(numValues, data, pageEncoding))
+			defer vbuf.unref()
+			pageValues = vbuf.data
+		}
 
-	values := pageType.NewValues(pageValues, pageOffsets)
-	values, err := pageType.Decode(values, data, pageEncoding)
-	if err != nil {
-		return nil, err
+		// Page offsets not needed when dictionary-encoded
+		if pageType.Kind() == ByteArray && !isDictionaryEncoding(pageEncoding) {
+			obuf = buffers.get(4 * (numValues + 1))
+			defer obuf.unref()
+			pageOffsets = unsafecast.BytesToUint32(obuf.data)
+		}
+
+		values := pageType.NewValues(pageValues, pageOffsets)
+		values, err := pageType.Decode(values, data, pageEncoding)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if definitionLevels != nil && c.checkLevels {
+			numDecoded := countDecodedValues(pageEncoding, values)
+			numValues, err = c.checkDefinitionLevels(levels, numValues, numDecoded)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+
+		newPage = pageType.NewPage(c.Index(), numValues, values)
 	}
 
-	newPage := pageType.NewPage(c.Index(), numValues, values)
+	return c.newDataPage(newPage, repetitionLevels, definitionLevels, levels, vbuf, obuf), skipped, nil
+}
+
+// newDataPage wraps the page of values decoded from a data page with the levels
+// of the column, and the buffers that the page references. The definition
+// levels of optional columns are passed as levels, which may be a suffix of the
+// data of definitionLevels when leading rows were skipped.
+func (c *Column) newDataPage(values Page, repetitionLevels, definitionLevels *buffer, levels []byte, vbuf, obuf *buffer) Page {
 	switch {
 	case c.maxRepetitionLevel > 0:
-		newPage = newRepeatedPage(
-			newPage,
+		values = newRepeatedPage(
+			values,
 			c.maxRepetitionLevel,
 			c.maxDefinitionLevel,
 			repetitionLevels.data,
 			definitionLevels.data,
 		)
 	case c.maxDefinitionLevel > 0:
-		newPage = newOptionalPage(
-			newPage,
+		values = newOptionalPage(
+			values,
 			c.maxDefinitionLevel,
-			definitionLevels.data,
+			levels,
 		)
 	}
+	return newBufferedPage(values, vbuf, obuf, repetitionLevels, definitionLevels)
+}
 
-	return newBufferedPage(newPage, vbuf, obuf, repetitionLevels, definitionLevels), nil
+// checkDefinitionLevels verifies that the definition levels of a data page
+// agree with the number of values that the page header announced and the
+// number of values that were decoded from the page (numDecoded is negative
+// when the latter is unknown).
+//
+// Some legacy writers produce definition levels which are inconsistent with
+// the schema, for example when writing required columns nested in optional
+// groups. When the column was opened with the RepairDefinitionLevels option,
+// the levels which can be repaired unambiguously are rewritten instead of
+// failing.
+//
+// The check is only done when the file was opened with CheckDefinitionLevels or
+// RepairDefinitionLevels.
+func (c *Column) checkDefinitionLevels(levels []byte, numValues, numDecoded int) (int, error) {
+	numDefined := countLevelsEqual(levels, c.maxDefinitionLevel)
+	if numDefined == numValues && (numDecoded < 0 || numDecoded == numValues) {
+		return numValues, nil
+	}
+	if numDecoded >= 0 {
+		numValues = numDecoded
+	}
+	if c.repairLevels && repairDefinitionLevels(levels, c.maxDefinitionLevel, numValues) {
+		return numValues, nil
+	}
+	return numValues, fmt.Errorf("column %q has %d values but its definition levels indicate %d: %w",
+		c.path, numValues, numDefined, ErrInvalidDefinitionLevels)
+}
+
+// countDecodedValues returns the number of values held in values after being
+// decoded with enc, or -1 if the encoding does not allow knowing the exact
+// count (e.g. when bit-packed runs are padded).
+func countDecodedValues(enc encoding.Encoding, values encoding.Values) int {
+	switch enc.Encoding() {
+	case format.Plain, format.DeltaBinaryPacked, format.DeltaLengthByteArray, format.DeltaByteArray, format.ByteStreamSplit:
+	default:
+		return -1
+	}
+	data, offsets := values.Data()
+	switch values.Kind() {
+	case encoding.Int32, encoding.Float:
+		return len(data) / 4
+	case encoding.Int64, encoding.Double:
+		return len(data) / 8
+	case encoding.Int96:
+		return len(data) / 12
+	case encoding.ByteArray:
+		return max(len(offsets)-1, 0)
+	case encoding.FixedLenByteArray:
+		_, size := values.FixedLenByteArray()
+		if size > 0 {
+			return len(data) / size
+		}
+	}
+	return -1
 }
 
 func decodeLevelsV1(enc encoding.Encoding, numValues int, data []byte) (*buffer, []byte, error) {
@@ -756,6 +980,15 @@ func decodeLevels(enc encoding.Encoding, numValues int, data []byte) (levels *bu
 	return levels, err
 }
 
+func unrefLevels(repetitionLevels, definitionLevels *buffer) {
+	if repetitionLevels != nil {
+		repetitionLevels.unref()
+	}
+	if definitionLevels != nil {
+		definitionLevels.unref()
+	}
+}
+
 func skipLevelsV2(data []byte, length int64) ([]byte, error) {
 	if length >= int64(len(data)) {
 		return data, io.ErrUnexpectedEOF
@@ -771,6 +1004,10 @@ func (c *Column) DecodeDictionary(header DictionaryPageHeader, page []byte) (Dic
 
 func (c *Column) decodeDictionary(header DictionaryPageHeader, page *buffer, size int32) (Dictionary, error) {
 	pageData := page.data
+	rawData := pageData
+	if dict := c.dictionaryCache.load(header, rawData); dict != nil {
+		return dict, nil
+	}
 
 	if isCompressed(c.compression) {
 		var err error
@@ -795,9 +1032,61 @@ func (c *Column) decodeDictionary(header DictionaryPageHeader, page *buffer, siz
 	if err != nil {
 		return nil, err
 	}
-	return pageType.NewDictionary(int(c.index), numValues, values), nil
+	dict := &frozenDictionary{pageType.NewDictionary(int(c.index), numValues, values)}
+	c.dictionaryCache.store(header, rawData, dict)
+	return dict, nil
+}
+
+// columnDictionaryCache retains the last dictionary decoded for a column, so it
+// can be reused when the following row groups have identical dictionary pages.
+// This is common in files written by long-running jobs where the values of low
+// cardinality columns remain the same across row groups.
+//
+// The cached dictionaries are frozen, so they can be shared by the pages of all
+// the row groups.
+type columnDictionaryCache struct {
+	mutex      sync.Mutex
+	encoding   format.Encoding
+	numValues  int64
+	data       []byte
+	dictionary Dictionary
+}
+
+func (cache *columnDictionaryCache) load(header DictionaryPageHeader, data []byte) Dictionary {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if cache.dictionary == nil ||
+		cache.encoding != header.Encoding() ||
+		cache.numValues != header.NumValues() ||
+		!bytes.Equal(cache.data, data) {
+		return nil
+	}
+	return cache.dictionary
 }
 
+func (cache *columnDictionaryCache) store(header DictionaryPageHeader, data []byte, dict Dictionary) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.encoding = header.Encoding()
+	cache.numValues = header.NumValues()
+	cache.data = append(cache.data[:0], data...)
+	cache.dictionary = dict
+}
+
+// frozenDictionary wraps the dictionaries decoded from dictionary pages, which
+// are shared by the pages of all the row groups of a column. The methods that
+// would modify the dictionary panic instead of changing the values seen by the
+// other pages.
+type frozenDictionary struct{ Dictionary }
+
+func (d *frozenDictionary) Insert([]int32, []Value) { panic(errFrozenDictionary) }
+
+func (d *frozenDictionary) Reset() { panic(errFrozenDictionary) }
+
+func (d *frozenDictionary) insert([]int32, sparse.Array) { panic(errFrozenDictionary) }
+
+var errFrozenDictionary = errors.New("cannot modify a dictionary decoded from a dictionary page of a parquet file")
+
 var (
 	_ Node = (*Column)(nil)
 )
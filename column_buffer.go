@@ -2008,12 +2008,28 @@ func writeRowsFuncOf(t reflect.Type, schema *Schema, path columnPath) writeRowsF
 		return writeRowsFuncOfRequired(t, schema, path)
 	case reflect.TypeOf(time.Time{}):
 		return writeRowsFuncOfTime(t, schema, path)
+	case reflect.TypeOf(time.Duration(0)), reflect.TypeOf(TimeOfDay(0)):
+		if leaf, exists := schema.Lookup(path...); exists && leaf.Node.Type().LogicalType() != nil && leaf.Node.Type().LogicalType().Time != nil {
+			return writeRowsFuncOfDuration(t, schema, path)
+		}
+	}
+
+	if leaf, exists := schema.Lookup(path...); exists && isNetipColumn(t, leaf.Node.Type()) {
+		return writeRowsFuncOfNetip(t, schema, path)
+	}
+
+	if isTextFallbackType(t) {
+		return writeRowsFuncOfText(t, schema, path)
 	}
 
 	switch t.Kind() {
 	case reflect.Bool,
 		reflect.Int,
 		reflect.Uint,
+		reflect.Int8,
+		reflect.Uint8,
+		reflect.Int16,
+		reflect.Uint16,
 		reflect.Int32,
 		reflect.Uint32,
 		reflect.Int64,
@@ -2051,12 +2067,46 @@ func writeRowsFuncOf(t reflect.Type, schema *Schema, path columnPath) writeRowsF
 func writeRowsFuncOfRequired(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
 	column := schema.mapping.lookup(path)
 	columnIndex := column.columnIndex
+	if columnIndex < 0 {
+		return writeRowsFuncOfError(fmt.Errorf("cannot write Go values of type %s: no column %q in parquet schema", t, path))
+	}
+	if columnType := column.node.Type(); !writesGoValuesDirectly(t, columnType) {
+		if !canConvertGoValue(t, columnType.Kind()) {
+			return writeRowsFuncOfError(fmt.Errorf("cannot write Go values of type %s to column %q of type %s", t, path, columnType))
+		}
+		return writeRowsFuncOfConversion(t, schema, path, columnType.Kind())
+	}
 	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
 		columns[columnIndex].writeValues(rows, levels)
 		return nil
 	}
 }
 
+// writeRowsFuncOfConversion returns a function which converts Go values of
+// type t to the Go type matching the column kind before writing them, for
+// example when float32 values are written to a DOUBLE column.
+func writeRowsFuncOfConversion(t reflect.Type, schema *Schema, path columnPath, kind Kind) writeRowsFunc {
+	elemType := conversionTypeOf(kind)
+	elemSize := uintptr(elemType.Size())
+	writeRows := writeRowsFuncOfRequired(elemType, schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+
+		values := reflect.MakeSlice(reflect.SliceOf(elemType), rows.Len(), rows.Len())
+		for i := 0; i < rows.Len(); i++ {
+			convertGoValue(values.Index(i), reflect.NewAt(t, rows.Index(i)).Elem())
+		}
+		return writeRows(columns, makeArray(values.UnsafePointer(), rows.Len(), elemSize), levels)
+	}
+}
+
+func writeRowsFuncOfError(err error) writeRowsFunc {
+	return func([]ColumnBuffer, sparse.Array, columnLevels) error { return err }
+}
+
 func writeRowsFuncOfOptional(t reflect.Type, schema *Schema, path columnPath, writeRows writeRowsFunc) writeRowsFunc {
 	nullIndex := nullIndexFuncOf(t)
 	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
@@ -2311,15 +2361,25 @@ func writeRowsFuncOfStruct(t reflect.Type, schema *Schema, path columnPath) writ
 }
 
 func writeRowsFuncOfMap(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
-	keyPath := path.append("key_value", "key")
+	keyValuePath := mapKeyValuePath(schema, path)
+	keyPath := keyValuePath.append("key")
 	keyType := t.Key()
 	keySize := uintptr(keyType.Size())
 	writeKeys := writeRowsFuncOf(keyType, schema, keyPath)
 
-	valuePath := path.append("key_value", "value")
+	valuePath := keyValuePath.append("value")
 	valueType := t.Elem()
 	valueSize := uintptr(valueType.Size())
 	writeValues := writeRowsFuncOf(valueType, schema, valuePath)
+	// The values may be optional without having a pointer type, for example
+	// when the optional option is set in the parquet-value tag.
+	if node := nodeByPath(schema, valuePath); node != nil && node.Optional() {
+		switch valueType.Kind() {
+		case reflect.Pointer, reflect.Slice:
+		default:
+			writeValues = writeRowsFuncOfOptional(valueType, schema, valuePath, writeValues)
+		}
+	}
 
 	writeKeyValues := func(columns []ColumnBuffer, keys, values sparse.Array, levels columnLevels) error {
 		if err := writeKeys(columns, keys, levels); err != nil {
@@ -2449,3 +2509,53 @@ func writeRowsFuncOfTime(_ reflect.Type, schema *Schema, path columnPath) writeR
 		return nil
 	}
 }
+
+func writeRowsFuncOfDuration(_ reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	col, _ := schema.Lookup(path...)
+	unit := col.Node.Type().LogicalType().Time.Unit
+
+	if unit.Millis != nil {
+		t := reflect.TypeOf(int32(0))
+		elemSize := uintptr(t.Size())
+		writeRows := writeRowsFuncOf(t, schema, path)
+
+		return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+			if rows.Len() == 0 {
+				return writeRows(columns, rows, levels)
+			}
+
+			durations := rows.Int64Array()
+			for i := 0; i < durations.Len(); i++ {
+				val := int32(time.Duration(durations.Index(i)).Milliseconds())
+				a := makeArray(unsafecast.PointerOfValue(reflect.ValueOf(val)), 1, elemSize)
+				if err := writeRows(columns, a, levels); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	t := reflect.TypeOf(int64(0))
+	elemSize := uintptr(t.Size())
+	writeRows := writeRowsFuncOf(t, schema, path)
+	scale := int64(timeUnitDuration(unit))
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 || scale == 1 {
+			return writeRows(columns, rows, levels)
+		}
+
+		durations := rows.Int64Array()
+		for i := 0; i < durations.Len(); i++ {
+			val := durations.Index(i) / scale
+			a := makeArray(unsafecast.PointerOfValue(reflect.ValueOf(val)), 1, elemSize)
+			if err := writeRows(columns, a, levels); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
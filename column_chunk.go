@@ -3,6 +3,7 @@ package parquet
 import (
 	"errors"
 	"io"
+	"sync"
 )
 
 var (
@@ -48,6 +49,77 @@ type ColumnChunk interface {
 	NumValues() int64
 }
 
+// ColumnChunkWithPageHeaders is an extension of the ColumnChunk interface
+// implemented by column chunks which can iterate over the headers of their
+// pages without decoding them. Only the column chunks of parquet files, which
+// hold serialized pages, implement this interface: programs can test whether
+// a column chunk implements it with a type assertion, for example:
+//
+//	if chunk, ok := columnChunk.(parquet.ColumnChunkWithPageHeaders); ok {
+//		headers := chunk.PageHeaders()
+//		defer headers.Close()
+//		...
+//	}
+type ColumnChunkWithPageHeaders interface {
+	ColumnChunk
+	PageHeaders() *FilePageHeaders
+}
+
+// lazyColumnChunk is a column chunk which is only produced by the load function
+// when its content is first accessed. If loading the column chunk fails, the
+// pages of the column chunk yield the error.
+type lazyColumnChunk struct {
+	typ    Type
+	column int
+	load   func() (ColumnChunk, error)
+	once   sync.Once
+	chunk  ColumnChunk
+	err    error
+}
+
+func (c *lazyColumnChunk) loaded() (ColumnChunk, error) {
+	c.once.Do(func() { c.chunk, c.err = c.load() })
+	return c.chunk, c.err
+}
+
+func (c *lazyColumnChunk) Type() Type { return c.typ }
+
+func (c *lazyColumnChunk) Column() int { return c.column }
+
+func (c *lazyColumnChunk) Pages() Pages {
+	chunk, err := c.loaded()
+	if err != nil {
+		return onePage(newErrorPage(c.typ, c.column, "%w", err))
+	}
+	return chunk.Pages()
+}
+
+func (c *lazyColumnChunk) ColumnIndex() (ColumnIndex, error) {
+	chunk, err := c.loaded()
+	if err != nil {
+		return nil, err
+	}
+	return chunk.ColumnIndex()
+}
+
+func (c *lazyColumnChunk) OffsetIndex() (OffsetIndex, error) {
+	chunk, err := c.loaded()
+	if err != nil {
+		return nil, err
+	}
+	return chunk.OffsetIndex()
+}
+
+func (c *lazyColumnChunk) BloomFilter() BloomFilter { return nil }
+
+func (c *lazyColumnChunk) NumValues() int64 {
+	chunk, err := c.loaded()
+	if err != nil {
+		return 0
+	}
+	return chunk.NumValues()
+}
+
 type pageAndValueWriter interface {
 	PageWriter
 	ValueWriter
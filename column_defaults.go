@@ -0,0 +1,189 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// ColumnDefaultsKey is the key of the footer key/value metadata holding the
+// JSON representation of the default values of columns.
+const ColumnDefaultsKey = "parquet-go.column.defaults"
+
+// ColumnDefaults maps dot-separated paths of columns to the values that readers
+// produce when reading files which predate the columns, instead of zero values.
+//
+// Default values are JSON values: strings, numbers, booleans, or null. Strings
+// are parsed according to the type of the column, for example as RFC 3339
+// timestamps for TIMESTAMP columns, or as decimal numbers for integer columns.
+// The Go types int, int32, int64 and float32 are also accepted.
+//
+// Defaults belong to the target schema of conversions, since the files which
+// predate a column cannot know its default value. ColumnDefaults implements the
+// SchemaOption interface to attach the defaults to a schema, and the
+// ReaderOption interface to pass them when reading files with a schema which
+// has more columns than the files:
+//
+//	reader := parquet.NewGenericReader[RowV2](file, parquet.ColumnDefaults{
+//		"country": "unknown",
+//	})
+//
+// Defaults passed in the options of readers take precedence over the defaults
+// of their schema.
+//
+// ColumnDefaults also implements the WriterOption interface, which records the
+// defaults under ColumnDefaultsKey in the footer of the written files. Writers
+// also record the defaults of their schema. The defaults of files written with
+// the latest version of a schema can be obtained with ColumnDefaultsOf to read
+// older files, and the schema of a file opened with OpenFile carries the
+// defaults recorded in its footer. Numbers are recorded with the exact decimal
+// representation of their value, and read back without loss of precision.
+type ColumnDefaults map[string]any
+
+// ConfigureSchema satisfies the SchemaOption interface.
+func (d ColumnDefaults) ConfigureSchema(config *SchemaConfig) { config.Defaults = d }
+
+// ConfigureReader satisfies the ReaderOption interface.
+func (d ColumnDefaults) ConfigureReader(config *ReaderConfig) { config.Defaults = d }
+
+// ConfigureWriter satisfies the WriterOption interface.
+func (d ColumnDefaults) ConfigureWriter(config *WriterConfig) {
+	KeyValueMetadata(ColumnDefaultsKey, d.encode()).ConfigureWriter(config)
+}
+
+// encode returns the JSON representation of d recorded in the footer of files.
+//
+// Values of type float32 are widened to float64 so their decimal representation
+// is the exact value that they hold, and NaN or infinite values which have no
+// JSON representation are encoded as strings parsed by the conversions of
+// strings to floating point values.
+func (d ColumnDefaults) encode() string {
+	values := make(map[string]any, len(d))
+	for path, value := range d {
+		if x, ok := value.(float32); ok {
+			value = float64(x)
+		}
+		if x, ok := value.(float64); ok && (math.IsNaN(x) || math.IsInf(x, 0)) {
+			value = fmt.Sprint(x)
+		}
+		values[path] = value
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		panic(fmt.Errorf("encoding parquet column defaults: %w", err))
+	}
+	return string(b)
+}
+
+// Convert is like the Convert function, but the columns which exist in the
+// target schema and not in the source schema are set to their default values.
+func (d ColumnDefaults) Convert(to, from Node) (Conversion, error) {
+	return convert(to, from, convertToTypeOf, d)
+}
+
+// convert is like Convert, but the values converted to narrower types are
+// handled according to the narrowing policy.
+func (d ColumnDefaults) convert(to, from Node, narrowing NarrowingPolicy) (Conversion, error) {
+	return convert(to, from, narrowing.convertToTypeOf, d)
+}
+
+// ColumnDefaultsOf returns the column defaults recorded in the metadata of f.
+//
+// Numbers are returned as json.Number values holding their exact decimal
+// representation, which is parsed according to the type of the columns.
+//
+// The function returns nil defaults and no error if f has no defaults.
+func ColumnDefaultsOf(f *File) (ColumnDefaults, error) {
+	value, ok := f.Lookup(ColumnDefaultsKey)
+	if !ok {
+		return nil, nil
+	}
+	return decodeColumnDefaults(value)
+}
+
+func decodeColumnDefaults(value string) (ColumnDefaults, error) {
+	var defaults ColumnDefaults
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&defaults); err != nil {
+		return nil, fmt.Errorf("decoding parquet column defaults: %w", err)
+	}
+	return defaults, nil
+}
+
+// lookup returns the default value of the column at the given path, converted
+// to the type of the column.
+func (d ColumnDefaults) lookup(path columnPath, typ Type) (Value, bool, error) {
+	value, ok := d[path.String()]
+	if !ok {
+		return Value{}, false, nil
+	}
+
+	var v Value
+	var t Type
+	switch x := value.(type) {
+	case nil:
+		return Value{}, true, nil
+	case bool:
+		v, t = BooleanValue(x), BooleanType
+	case string:
+		v, t = ByteArrayValue([]byte(x)), String().Type()
+	case float32:
+		v, t = FloatValue(x), FloatType
+	case float64:
+		switch typ.Kind() {
+		case Int32, Int64, Int96:
+			if x != math.Trunc(x) {
+				return Value{}, true, fmt.Errorf("default value of column %q is not an integer: %v", path, x)
+			}
+			v, t = Int64Value(int64(x)), Int64Type
+		default:
+			v, t = DoubleValue(x), DoubleType
+		}
+	case json.Number:
+		v, t = ByteArrayValue([]byte(x)), String().Type()
+	case int:
+		v, t = Int64Value(int64(x)), Int64Type
+	case int32:
+		v, t = Int64Value(int64(x)), Int64Type
+	case int64:
+		v, t = Int64Value(x), Int64Type
+	default:
+		return Value{}, true, fmt.Errorf("default value of column %q has unsupported type %T", path, value)
+	}
+
+	if typesAreEqual(typ, t) {
+		return v, true, nil
+	}
+	converted, err := typ.ConvertValue(v, t)
+	if err != nil {
+		return Value{}, true, fmt.Errorf("default value of column %q cannot be converted to %s: %w", path, typ, err)
+	}
+	if converted.Kind() == ByteArray || converted.Kind() == FixedLenByteArray {
+		// Conversions may return values referencing temporary buffers.
+		converted = converted.Clone()
+	}
+	return converted, true, nil
+}
+
+// convertToDefault returns a conversion function setting the values of a
+// missing column to value, using the values of a sibling column to determine
+// whether the parent group of the column is null.
+//
+//go:noinline
+func convertToDefault(value Value, parentDefinitionLevel, definitionLevel byte) conversionFunc {
+	return func(column []Value) error {
+		for i, v := range column {
+			switch {
+			case v.definitionLevel < parentDefinitionLevel:
+				column[i] = Value{}.Level(int(v.repetitionLevel), int(v.definitionLevel), 0)
+			case value.IsNull():
+				column[i] = value.Level(int(v.repetitionLevel), int(parentDefinitionLevel), 0)
+			default:
+				column[i] = value.Level(int(v.repetitionLevel), int(definitionLevel), 0)
+			}
+		}
+		return nil
+	}
+}
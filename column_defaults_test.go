@@ -0,0 +1,188 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestColumnDefaults(t *testing.T) {
+	type AddressV1 struct {
+		City string `parquet:"city"`
+	}
+	type RowV1 struct {
+		ID      int64      `parquet:"id"`
+		Address *AddressV1 `parquet:"address,optional"`
+	}
+	type AddressV2 struct {
+		City string `parquet:"city"`
+		Zip  string `parquet:"zip"`
+	}
+	type RowV2 struct {
+		ID      int64      `parquet:"id"`
+		Address *AddressV2 `parquet:"address,optional"`
+		Country string     `parquet:"country"`
+		Score   int32      `parquet:"score"`
+		Note    *string    `parquet:"note,optional"`
+	}
+
+	defaults := parquet.ColumnDefaults{
+		"address.zip": "00000",
+		"country":     "unknown",
+		"score":       float64(10),
+		"note":        nil,
+	}
+
+	rows := []RowV1{
+		{ID: 1, Address: &AddressV1{City: "Tatooine"}},
+		{ID: 2},
+	}
+	want := []RowV2{
+		{ID: 1, Address: &AddressV2{City: "Tatooine", Zip: "00000"}, Country: "unknown", Score: 10},
+		{ID: 2, Country: "unknown", Score: 10},
+	}
+
+	read := func(t *testing.T, data []byte, options ...parquet.ReaderOption) []RowV2 {
+		t.Helper()
+		reader := parquet.NewGenericReader[RowV2](bytes.NewReader(data), options...)
+		defer reader.Close()
+		got := make([]RowV2, len(rows))
+		n, err := reader.Read(got)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		return got[:n]
+	}
+
+	t.Run("reader option", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		if got := read(t, buf.Bytes(), defaults); !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", want, got)
+		}
+		if got := read(t, buf.Bytes()); got[0].Country != "" || got[0].Score != 0 {
+			t.Errorf("zero values expected without defaults, got %+v", got[0])
+		}
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		schema := parquet.SchemaOf(RowV2{}, defaults)
+		if got := read(t, buf.Bytes(), schema); !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", want, got)
+		}
+	})
+
+	t.Run("file metadata", func(t *testing.T) {
+		// The defaults recorded in a file written with the latest schema are
+		// used to read the files which predate the new columns, the footer of
+		// the older files is not consulted.
+		oldFile := new(bytes.Buffer)
+		if err := parquet.Write(oldFile, rows, parquet.ColumnDefaults{"country": "ignored"}); err != nil {
+			t.Fatal(err)
+		}
+		newFile := new(bytes.Buffer)
+		if err := parquet.Write(newFile, want[:1], parquet.SchemaOf(RowV2{}, defaults)); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(newFile.Bytes()), int64(newFile.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		recorded, err := parquet.ColumnDefaultsOf(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantRecorded := parquet.ColumnDefaults{
+			"address.zip": "00000",
+			"country":     "unknown",
+			"score":       json.Number("10"),
+			"note":        nil,
+		}
+		if !reflect.DeepEqual(recorded, wantRecorded) {
+			t.Errorf("wrong defaults recorded: %v", recorded)
+		}
+		if got := read(t, oldFile.Bytes(), parquet.SchemaOf(RowV2{}, recorded)); !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", want, got)
+		}
+
+		// The schema of the new file carries its defaults.
+		reader := parquet.NewReader(bytes.NewReader(oldFile.Bytes()), f.Schema())
+		defer reader.Close()
+		buffer := make([]parquet.Row, 1)
+		if _, err := reader.ReadRows(buffer); err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		country, _ := f.Schema().Lookup("country")
+		if v := buffer[0][country.ColumnIndex]; v.String() != "unknown" {
+			t.Errorf("wrong default value read with the schema of the file: %v", v)
+		}
+
+		if got := read(t, oldFile.Bytes()); got[0].Country != "" {
+			t.Errorf("defaults of the old file were used: %+v", got[0])
+		}
+	})
+
+	t.Run("invalid default", func(t *testing.T) {
+		invalid := parquet.ColumnDefaults{"score": "high"}
+		_, err := invalid.Convert(parquet.SchemaOf(RowV2{}), parquet.SchemaOf(RowV1{}))
+		if err == nil {
+			t.Error("expected an error for a default value which cannot be converted")
+		}
+	})
+
+	t.Run("exact numbers", func(t *testing.T) {
+		type Old struct {
+			ID int64 `parquet:"id"`
+		}
+		type New struct {
+			ID     int64   `parquet:"id"`
+			Big    int64   `parquet:"big"`
+			Ratio  float32 `parquet:"ratio"`
+			Double float64 `parquet:"double"`
+			NaN    float64 `parquet:"nan"`
+		}
+		defaults := parquet.ColumnDefaults{
+			"big":    int64(1<<62 + 1),
+			"ratio":  float32(0.1),
+			"double": float32(0.1),
+			"nan":    math.Inf(-1),
+		}
+		newFile := new(bytes.Buffer)
+		if err := parquet.Write(newFile, []New{{}}, parquet.SchemaOf(New{}, defaults)); err != nil {
+			t.Fatal(err)
+		}
+		oldFile := new(bytes.Buffer)
+		if err := parquet.Write(oldFile, []Old{{ID: 1}}); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(newFile.Bytes()), int64(newFile.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		recorded, err := parquet.ColumnDefaultsOf(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader := parquet.NewGenericReader[New](bytes.NewReader(oldFile.Bytes()), recorded)
+		defer reader.Close()
+		got := make([]New, 1)
+		if _, err := reader.Read(got); err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		want := New{ID: 1, Big: 1<<62 + 1, Ratio: 0.1, Double: float64(float32(0.1)), NaN: math.Inf(-1)}
+		if got[0] != want {
+			t.Errorf("wrong row:\nwant: %+v\ngot:  %+v", want, got[0])
+		}
+	})
+}
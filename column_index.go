@@ -18,7 +18,8 @@ type ColumnIndex interface {
 	NullPage(int) bool
 
 	// PageIndex return min/max bounds for the page at the given index in the
-	// column.
+	// column. The values must be compared with the Compare method of the
+	// column type, which implements its sort order (see SortOrderOf).
 	MinValue(int) Value
 	MaxValue(int) Value
 
@@ -84,7 +85,7 @@ func (f *formatColumnIndex) IsDescending() bool {
 	return f.index.BoundaryOrder == format.Descending
 }
 
-type fileColumnIndex struct{ chunk *fileColumnChunk }
+type fileColumnIndex struct{ chunk *FileColumnChunk }
 
 func (i fileColumnIndex) NumPages() int {
 	return len(i.chunk.columnIndex.NullPages)
@@ -292,6 +293,14 @@ func (i *baseColumnIndexer) columnIndex(minValues, maxValues [][]byte, minOrder,
 	copy(nullPages, i.nullPages)
 	nullCounts := make([]int64, len(i.nullCounts))
 	copy(nullCounts, i.nullCounts)
+	// The parquet format requires the bounds of pages containing only null
+	// values to be empty byte arrays.
+	for j, nullPage := range nullPages {
+		if nullPage {
+			minValues[j] = []byte{}
+			maxValues[j] = []byte{}
+		}
+	}
 	return format.ColumnIndex{
 		NullPages:     nullPages,
 		NullCounts:    nullCounts,
@@ -327,8 +336,8 @@ func (i *booleanColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(unsafecast.BoolToBytes(i.minValues), 1),
 		splitFixedLenByteArrays(unsafecast.BoolToBytes(i.maxValues), 1),
-		orderOfBool(i.minValues),
-		orderOfBool(i.maxValues),
+		orderOfBool(nonNullPages(i.minValues, i.nullPages)),
+		orderOfBool(nonNullPages(i.maxValues, i.nullPages)),
 	)
 }
 
@@ -358,8 +367,8 @@ func (i *int32ColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(unsafecast.Int32ToBytes(i.minValues), 4),
 		splitFixedLenByteArrays(unsafecast.Int32ToBytes(i.maxValues), 4),
-		orderOfInt32(i.minValues),
-		orderOfInt32(i.maxValues),
+		orderOfInt32(nonNullPages(i.minValues, i.nullPages)),
+		orderOfInt32(nonNullPages(i.maxValues, i.nullPages)),
 	)
 }
 
@@ -389,8 +398,8 @@ func (i *int64ColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(unsafecast.Int64ToBytes(i.minValues), 8),
 		splitFixedLenByteArrays(unsafecast.Int64ToBytes(i.maxValues), 8),
-		orderOfInt64(i.minValues),
-		orderOfInt64(i.maxValues),
+		orderOfInt64(nonNullPages(i.minValues, i.nullPages)),
+		orderOfInt64(nonNullPages(i.maxValues, i.nullPages)),
 	)
 }
 
@@ -420,8 +429,8 @@ func (i *int96ColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(deprecated.Int96ToBytes(i.minValues), 12),
 		splitFixedLenByteArrays(deprecated.Int96ToBytes(i.maxValues), 12),
-		deprecated.OrderOfInt96(i.minValues),
-		deprecated.OrderOfInt96(i.maxValues),
+		deprecated.OrderOfInt96(nonNullPages(i.minValues, i.nullPages)),
+		deprecated.OrderOfInt96(nonNullPages(i.maxValues, i.nullPages)),
 	)
 }
 
@@ -451,8 +460,8 @@ func (i *floatColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(unsafecast.Float32ToBytes(i.minValues), 4),
 		splitFixedLenByteArrays(unsafecast.Float32ToBytes(i.maxValues), 4),
-		orderOfFloat32(i.minValues),
-		orderOfFloat32(i.maxValues),
+		orderOfFloat32(nonNullPages(i.minValues, i.nullPages)),
+		orderOfFloat32(nonNullPages(i.maxValues, i.nullPages)),
 	)
 }
 
@@ -482,8 +491,8 @@ func (i *doubleColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(unsafecast.Float64ToBytes(i.minValues), 8),
 		splitFixedLenByteArrays(unsafecast.Float64ToBytes(i.maxValues), 8),
-		orderOfFloat64(i.minValues),
-		orderOfFloat64(i.maxValues),
+		orderOfFloat64(nonNullPages(i.minValues, i.nullPages)),
+		orderOfFloat64(nonNullPages(i.maxValues, i.nullPages)),
 	)
 }
 
@@ -524,8 +533,8 @@ func (i *byteArrayColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		minValues,
 		maxValues,
-		orderOfBytes(minValues),
-		orderOfBytes(maxValues),
+		orderOfBytes(nonNullPages(minValues, i.nullPages)),
+		orderOfBytes(nonNullPages(maxValues, i.nullPages)),
 	)
 }
 
@@ -570,8 +579,8 @@ func (i *fixedLenByteArrayColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		minValues,
 		maxValues,
-		orderOfBytes(minValues),
-		orderOfBytes(maxValues),
+		orderOfBytes(nonNullPages(minValues, i.nullPages)),
+		orderOfBytes(nonNullPages(maxValues, i.nullPages)),
 	)
 }
 
@@ -601,8 +610,8 @@ func (i *uint32ColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(unsafecast.Uint32ToBytes(i.minValues), 4),
 		splitFixedLenByteArrays(unsafecast.Uint32ToBytes(i.maxValues), 4),
-		orderOfUint32(i.minValues),
-		orderOfUint32(i.maxValues),
+		orderOfUint32(nonNullPages(i.minValues, i.nullPages)),
+		orderOfUint32(nonNullPages(i.maxValues, i.nullPages)),
 	)
 }
 
@@ -632,8 +641,8 @@ func (i *uint64ColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(unsafecast.Uint64ToBytes(i.minValues), 8),
 		splitFixedLenByteArrays(unsafecast.Uint64ToBytes(i.maxValues), 8),
-		orderOfUint64(i.minValues),
-		orderOfUint64(i.maxValues),
+		orderOfUint64(nonNullPages(i.minValues, i.nullPages)),
+		orderOfUint64(nonNullPages(i.maxValues, i.nullPages)),
 	)
 }
 
@@ -669,8 +678,8 @@ func (i *be128ColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		minValues,
 		maxValues,
-		orderOfBytes(minValues),
-		orderOfBytes(maxValues),
+		orderOfBytes(nonNullPages(minValues, i.nullPages)),
+		orderOfBytes(nonNullPages(maxValues, i.nullPages)),
 	)
 }
 
@@ -734,6 +743,25 @@ func splitFixedLenByteArrays(data []byte, size int) [][]byte {
 	return values
 }
 
+// nonNullPages returns the subset of values for pages which are not null pages.
+// The bounds of null pages carry no information and must be ignored when
+// determining the boundary order of a column index.
+func nonNullPages[T any](values []T, nullPages []bool) []T {
+	for i, nullPage := range nullPages {
+		if nullPage {
+			nonNull := make([]T, i, len(values)-1)
+			copy(nonNull, values[:i])
+			for j, v := range values[i+1:] {
+				if !nullPages[i+1+j] {
+					nonNull = append(nonNull, v)
+				}
+			}
+			return nonNull
+		}
+	}
+	return values
+}
+
 func boundaryOrderOf(minOrder, maxOrder int) format.BoundaryOrder {
 	if minOrder == maxOrder {
 		switch {
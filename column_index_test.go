@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
 	"github.com/stretchr/testify/require"
 )
 
@@ -94,3 +95,47 @@ func Test_ColumnIndexReuse(t *testing.T) {
 	require.Equal(t, 1, len(before.NullPages))
 	require.False(t, before.NullPages[0])
 }
+
+func TestColumnIndexNullPages(t *testing.T) {
+	testCases := []struct {
+		typ    parquet.Type
+		values []interface{}
+		order  format.BoundaryOrder
+	}{
+		{parquet.Int64Type, []interface{}{int64(1), int64(2), nil, int64(3)}, format.Ascending},
+		{parquet.Int64Type, []interface{}{int64(3), nil, int64(2), int64(1)}, format.Descending},
+		{parquet.Int64Type, []interface{}{int64(3), nil, int64(1), int64(2)}, format.Unordered},
+		{parquet.ByteArrayType, []interface{}{nil, "a", "b", nil, "c"}, format.Ascending},
+	}
+
+	for _, testCase := range testCases {
+		indexer := testCase.typ.NewColumnIndexer(16)
+		for _, v := range testCase.values {
+			if v == nil {
+				indexer.IndexPage(10, 10, parquet.Value{}, parquet.Value{})
+			} else {
+				indexer.IndexPage(10, 1, parquet.ValueOf(v), parquet.ValueOf(v))
+			}
+		}
+
+		index := indexer.ColumnIndex()
+		if index.BoundaryOrder != testCase.order {
+			t.Errorf("%s %v: boundary order mismatch: want=%s got=%s", testCase.typ, testCase.values, testCase.order, index.BoundaryOrder)
+		}
+		for i, v := range testCase.values {
+			wantNullCount := int64(1)
+			if v == nil {
+				wantNullCount = 10
+				if len(index.MinValues[i]) != 0 || len(index.MaxValues[i]) != 0 {
+					t.Errorf("%s %v: null page %d has non-empty bounds", testCase.typ, testCase.values, i)
+				}
+			}
+			if index.NullPages[i] != (v == nil) {
+				t.Errorf("%s %v: null page %d mismatch", testCase.typ, testCase.values, i)
+			}
+			if index.NullCounts[i] != wantNullCount {
+				t.Errorf("%s %v: null count of page %d mismatch: want=%d got=%d", testCase.typ, testCase.values, i, wantNullCount, index.NullCounts[i])
+			}
+		}
+	}
+}
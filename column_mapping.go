@@ -1,5 +1,7 @@
 package parquet
 
+import "github.com/parquet-go/parquet-go/format"
+
 // LeafColumn is a struct type representing leaf columns of a parquet schema.
 type LeafColumn struct {
 	Node               Node
@@ -9,6 +11,36 @@ type LeafColumn struct {
 	MaxDefinitionLevel int
 }
 
+// LeafInfo describes a leaf column of a parquet schema, as yielded by the
+// Leaves method of Schema.
+type LeafInfo struct {
+	LeafColumn
+	// The physical type of the column values.
+	Kind Kind
+	// The logical type of the column, or nil if the column has none.
+	LogicalType *format.LogicalType
+	// True if the leaf node is optional. Values of the column may also be
+	// null if one of its parent groups is optional or repeated, which is the
+	// case when MaxDefinitionLevel is greater than zero.
+	Optional bool
+}
+
+func leafInfoOf(leaf leafColumn) LeafInfo {
+	typ := leaf.node.Type()
+	return LeafInfo{
+		LeafColumn: LeafColumn{
+			Node:               leaf.node,
+			Path:               leaf.path,
+			ColumnIndex:        int(leaf.columnIndex),
+			MaxRepetitionLevel: int(leaf.maxRepetitionLevel),
+			MaxDefinitionLevel: int(leaf.maxDefinitionLevel),
+		},
+		Kind:        typ.Kind(),
+		LogicalType: typ.LogicalType(),
+		Optional:    leaf.node.Optional(),
+	}
+}
+
 func columnMappingOf(schema Node) (mapping columnMappingGroup, columns [][]string) {
 	mapping = make(columnMappingGroup)
 	columns = make([][]string, 0, 16)
@@ -18,6 +50,8 @@ func columnMappingOf(schema Node) (mapping columnMappingGroup, columns [][]strin
 		copy(path, leaf.path)
 		columns = append(columns, path)
 
+		leaf.path = path // use the copy
+
 		group := mapping
 		for len(path) > 1 {
 			columnName := path[0]
@@ -29,7 +63,6 @@ func columnMappingOf(schema Node) (mapping columnMappingGroup, columns [][]strin
 			group, path = g, path[1:]
 		}
 
-		leaf.path = path // use the copy
 		group[path[0]] = &columnMappingLeaf{column: leaf}
 	})
 
@@ -0,0 +1,148 @@
+package parquet
+
+import (
+	"sort"
+	"strings"
+)
+
+// ColumnOrder is an interface representing policies which determine the order
+// of fields in the groups of a parquet schema. Since leaf columns are numbered
+// in the depth-first order of the schema, the policy also determines the
+// layout of columns in parquet files.
+//
+// Schemas built from Go structs retain the declaration order of the struct
+// fields, while Group nodes order their fields alphabetically. Applying the
+// same policy in all code paths guarantees that the files they produce have
+// the same layout.
+type ColumnOrder interface {
+	// Returns the fields of the group at the given path, in the order defined
+	// by the policy. The path is empty for the root of the schema.
+	OrderFields(path []string, fields []Field) []Field
+}
+
+// DeclarationOrder returns a column order which retains the order in which
+// fields are declared, for example the order of fields in Go structs.
+func DeclarationOrder() ColumnOrder { return declarationOrder{} }
+
+// AlphabeticalOrder returns a column order which sorts the fields of groups by
+// name, which is the order used by Group nodes.
+func AlphabeticalOrder() ColumnOrder { return alphabeticalOrder{} }
+
+// ExplicitOrder returns a column order which places the fields at the given
+// paths first, in the order they are listed. Fields which are not listed
+// retain their declaration order after the listed fields.
+//
+// Paths are dot-separated field names, including the names of the repeated
+// groups of LIST and MAP types. Listing the path of a nested field also places
+// its parent groups, for example:
+//
+//	parquet.ExplicitOrder("id", "address.zip", "address.city")
+func ExplicitOrder(paths ...string) ColumnOrder {
+	order := make(explicitOrder, len(paths))
+	for i, path := range paths {
+		order[i] = strings.Split(path, ".")
+	}
+	return order
+}
+
+type declarationOrder struct{}
+
+func (declarationOrder) OrderFields(_ []string, fields []Field) []Field { return fields }
+
+type alphabeticalOrder struct{}
+
+func (alphabeticalOrder) OrderFields(_ []string, fields []Field) []Field {
+	ordered := make([]Field, len(fields))
+	copy(ordered, fields)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Name() < ordered[j].Name()
+	})
+	return ordered
+}
+
+type explicitOrder []columnPath
+
+func (order explicitOrder) OrderFields(path []string, fields []Field) []Field {
+	ordered := make([]Field, 0, len(fields))
+	placed := make([]bool, len(fields))
+
+	for _, columnPath := range order {
+		if len(columnPath) <= len(path) || !columnPath[:len(path)].equal(path) {
+			continue
+		}
+		name := columnPath[len(path)]
+		for i, field := range fields {
+			if !placed[i] && field.Name() == name {
+				ordered, placed[i] = append(ordered, field), true
+				break
+			}
+		}
+	}
+
+	for i, field := range fields {
+		if !placed[i] {
+			ordered = append(ordered, field)
+		}
+	}
+	return ordered
+}
+
+// orderColumnsOf returns a version of node where the fields of all groups are
+// ordered by the given policy. The fields of the repeated groups of LIST and
+// MAP types are never reordered, since their positions are defined by the
+// parquet specification.
+func orderColumnsOf(node Node, order ColumnOrder) Node {
+	if node.Leaf() {
+		return node
+	}
+	return &orderedGroup{
+		Node:   node,
+		fields: orderFieldsOf(node, order, nil),
+	}
+}
+
+func orderFieldsOf(node Node, order ColumnOrder, path columnPath) []Field {
+	fields := node.Fields()
+	if isListGroup(node) || isMap(node) {
+		if len(fields) == 1 && !fields[0].Leaf() {
+			wrapper := fields[0]
+			children := wrapper.Fields()
+			ordered := make([]Field, len(children))
+			for i, child := range children {
+				ordered[i] = orderFieldOf(child, order, path.append(wrapper.Name(), child.Name()))
+			}
+			return []Field{&orderedField{Field: wrapper, fields: ordered}}
+		}
+		return fields
+	}
+
+	fields = order.OrderFields(path, fields)
+	ordered := make([]Field, len(fields))
+	for i, field := range fields {
+		ordered[i] = orderFieldOf(field, order, path.append(field.Name()))
+	}
+	return ordered
+}
+
+func orderFieldOf(field Field, order ColumnOrder, path columnPath) Field {
+	if field.Leaf() {
+		return field
+	}
+	return &orderedField{Field: field, fields: orderFieldsOf(field, order, path)}
+}
+
+type orderedGroup struct {
+	Node
+	fields []Field
+}
+
+func (g *orderedGroup) String() string  { return sprint("", g) }
+func (g *orderedGroup) Fields() []Field { return g.fields }
+
+type orderedField struct {
+	Field
+	fields []Field
+}
+
+func (f *orderedField) String() string  { return sprint(f.Name(), f) }
+func (f *orderedField) Fields() []Field { return f.fields }
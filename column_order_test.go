@@ -0,0 +1,135 @@
+package parquet_test
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type columnOrderAddress struct {
+	Zip  string `parquet:"zip"`
+	City string `parquet:"city"`
+}
+
+type columnOrderRecord struct {
+	Name    string               `parquet:"name"`
+	ID      int64                `parquet:"id"`
+	Address columnOrderAddress   `parquet:"address"`
+	Tags    []columnOrderAddress `parquet:"tags,list"`
+}
+
+func columnOrderPaths(schema *parquet.Schema) string {
+	paths := make([]string, len(schema.Columns()))
+	for i, path := range schema.Columns() {
+		paths[i] = strings.Join(path, ".")
+	}
+	return strings.Join(paths, ",")
+}
+
+func TestSchemaColumnOrder(t *testing.T) {
+	tests := []struct {
+		scenario string
+		order    parquet.ColumnOrder
+		columns  string
+	}{
+		{
+			scenario: "declaration",
+			order:    parquet.DeclarationOrder(),
+			columns:  "name,id,address.zip,address.city,tags.list.element.zip,tags.list.element.city",
+		},
+		{
+			scenario: "alphabetical",
+			order:    parquet.AlphabeticalOrder(),
+			columns:  "address.city,address.zip,id,name,tags.list.element.city,tags.list.element.zip",
+		},
+		{
+			scenario: "explicit",
+			order:    parquet.ExplicitOrder("id", "tags.list.element.city", "address.city"),
+			columns:  "id,tags.list.element.city,tags.list.element.zip,address.city,address.zip,name",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			schema := parquet.SchemaOf(columnOrderRecord{}, parquet.SchemaColumnOrder(test.order))
+			if columns := columnOrderPaths(schema); columns != test.columns {
+				t.Fatalf("wrong columns:\nwant = %s\ngot  = %s", test.columns, columns)
+			}
+
+			want := columnOrderRecord{
+				Name:    "Luke",
+				ID:      1,
+				Address: columnOrderAddress{Zip: "94110", City: "Tatooine"},
+				Tags:    []columnOrderAddress{{Zip: "1", City: "A"}, {Zip: "2", City: "B"}},
+			}
+			row := schema.Deconstruct(nil, &want)
+			got := columnOrderRecord{}
+			if err := schema.Reconstruct(&got, row); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("wrong record:\nwant = %+v\ngot  = %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestSchemaColumnOrderMatchesGroup(t *testing.T) {
+	type record struct {
+		B string `parquet:"b"`
+		A int64  `parquet:"a"`
+		C bool   `parquet:"c"`
+	}
+	schema := parquet.SchemaOf(record{}, parquet.SchemaColumnOrder(parquet.AlphabeticalOrder()))
+	group := parquet.NewSchema("record", parquet.Group{
+		"b": parquet.String(),
+		"a": parquet.Int(64),
+		"c": parquet.Leaf(parquet.BooleanType),
+	})
+	if !parquet.EqualNodes(schema, group) {
+		t.Errorf("schemas are not equal:\n%s\n%s", schema, group)
+	}
+}
+
+func TestMergeColumnOrder(t *testing.T) {
+	type record1 struct {
+		A int64  `parquet:"a"`
+		B string `parquet:"b"`
+	}
+	type record2 struct {
+		B string `parquet:"b"`
+		A int64  `parquet:"a"`
+	}
+
+	buffer1 := parquet.NewGenericBuffer[record1]()
+	buffer1.Write([]record1{{A: 1, B: "one"}})
+	buffer2 := parquet.NewGenericBuffer[record2]()
+	buffer2.Write([]record2{{B: "two", A: 2}})
+	rowGroups := []parquet.RowGroup{buffer1, buffer2}
+
+	if _, err := parquet.MergeRowGroups(rowGroups); err == nil {
+		t.Fatal("expected schema mismatch error when merging without column order")
+	}
+
+	merged, err := parquet.MergeRowGroups(rowGroups, parquet.MergeColumnOrder(parquet.AlphabeticalOrder()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if columns := columnOrderPaths(merged.Schema()); columns != "a,b" {
+		t.Errorf("wrong merged columns: %s", columns)
+	}
+
+	reader := parquet.NewGenericRowGroupReader[record1](merged)
+	records := make([]record1, 3)
+	n, err := reader.Read(records)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	want := []record1{{A: 1, B: "one"}, {A: 2, B: "two"}}
+	if !reflect.DeepEqual(records[:n], want) {
+		t.Errorf("wrong merged records:\nwant = %+v\ngot  = %+v", want, records[:n])
+	}
+}
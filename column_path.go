@@ -74,6 +74,9 @@ func forEachLeafColumn(node Node, path columnPath, columnIndex, maxRepetitionLev
 	}
 
 	if node.Leaf() {
+		if err := checkColumnLevels(path, len(path), maxRepetitionLevel, maxDefinitionLevel); err != nil {
+			panic(err)
+		}
 		do(leafColumn{
 			node:               node,
 			path:               path,
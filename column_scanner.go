@@ -0,0 +1,211 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+)
+
+// ColumnScanner reads the values of a column chunk into contiguous slices of Go
+// types, instead of slices of Value.
+//
+// When the pages of the column chunk support it (e.g. for required columns that
+// are not dictionary encoded), values are copied directly from the page buffers
+// to the slices passed to the read methods. In other cases, values are read
+// through an intermediary buffer and converted to the Go type.
+//
+// Null values are written as the zero-value of the Go type. The read methods
+// must be called with slices of the Go type matching the physical type of the
+// column, or they return an error.
+//
+// ColumnScanner instances are not safe to use concurrently from multiple
+// goroutines.
+type ColumnScanner struct {
+	kind   Kind
+	pages  Pages
+	page   Page
+	values ValueReader
+	buffer []Value
+}
+
+// NewColumnScanner constructs a scanner reading the values of the given column
+// chunk.
+func NewColumnScanner(chunk ColumnChunk) *ColumnScanner {
+	return &ColumnScanner{
+		kind:  chunk.Type().Kind(),
+		pages: chunk.Pages(),
+	}
+}
+
+// Close closes the scanner, releasing the underlying pages.
+func (s *ColumnScanner) Close() error {
+	s.releasePage()
+	return s.pages.Close()
+}
+
+// ReadBooleans reads values of a BOOLEAN column. The method returns io.EOF
+// when all values have been read.
+func (s *ColumnScanner) ReadBooleans(values []bool) (int, error) {
+	return scanColumn(s, Boolean, values, func(r ValueReader, values []bool) (int, bool, error) {
+		if br, ok := r.(BooleanReader); ok {
+			n, err := br.ReadBooleans(values)
+			return n, true, err
+		}
+		return 0, false, nil
+	}, Value.Boolean)
+}
+
+// ReadInt32s reads values of an INT32 column. The method returns io.EOF when
+// all values have been read.
+func (s *ColumnScanner) ReadInt32s(values []int32) (int, error) {
+	return scanColumn(s, Int32, values, func(r ValueReader, values []int32) (int, bool, error) {
+		if ir, ok := r.(Int32Reader); ok {
+			n, err := ir.ReadInt32s(values)
+			return n, true, err
+		}
+		return 0, false, nil
+	}, Value.Int32)
+}
+
+// ReadInt64s reads values of an INT64 column. The method returns io.EOF when
+// all values have been read.
+func (s *ColumnScanner) ReadInt64s(values []int64) (int, error) {
+	return scanColumn(s, Int64, values, func(r ValueReader, values []int64) (int, bool, error) {
+		if ir, ok := r.(Int64Reader); ok {
+			n, err := ir.ReadInt64s(values)
+			return n, true, err
+		}
+		return 0, false, nil
+	}, Value.Int64)
+}
+
+// ReadFloats reads values of a FLOAT column. The method returns io.EOF when
+// all values have been read.
+func (s *ColumnScanner) ReadFloats(values []float32) (int, error) {
+	return scanColumn(s, Float, values, func(r ValueReader, values []float32) (int, bool, error) {
+		if fr, ok := r.(FloatReader); ok {
+			n, err := fr.ReadFloats(values)
+			return n, true, err
+		}
+		return 0, false, nil
+	}, Value.Float)
+}
+
+// ReadDoubles reads values of a DOUBLE column. The method returns io.EOF when
+// all values have been read.
+func (s *ColumnScanner) ReadDoubles(values []float64) (int, error) {
+	return scanColumn(s, Double, values, func(r ValueReader, values []float64) (int, bool, error) {
+		if dr, ok := r.(DoubleReader); ok {
+			n, err := dr.ReadDoubles(values)
+			return n, true, err
+		}
+		return 0, false, nil
+	}, Value.Double)
+}
+
+// ReadByteArrays reads values of a BYTE_ARRAY column, appending their content
+// to data. The offsets slice receives the position of the values in data: the
+// value at index i is data[offsets[i]:offsets[i+1]]. At most len(offsets)-1
+// values are read, and offsets[0] is set to the length of data prior to the
+// call.
+//
+// The method returns the number of values read, the extended data slice, and
+// io.EOF when all values have been read.
+func (s *ColumnScanner) ReadByteArrays(offsets []uint32, data []byte) (int, []byte, error) {
+	if s.kind != ByteArray {
+		return 0, data, s.kindMismatch(ByteArray)
+	}
+	if len(offsets) < 2 {
+		return 0, data, io.ErrShortBuffer
+	}
+	offsets[0] = uint32(len(data))
+	values := offsets[1:]
+	n := 0
+
+	for n < len(values) {
+		if s.values == nil {
+			if err := s.readPage(); err != nil {
+				return n, data, err
+			}
+		}
+
+		buffer := s.buffer
+		if remain := len(values) - n; remain < len(buffer) {
+			buffer = buffer[:remain]
+		}
+		k, err := s.values.ReadValues(buffer)
+		for _, v := range buffer[:k] {
+			data = append(data, v.byteArray()...)
+			values[n] = uint32(len(data))
+			n++
+		}
+		if err != nil {
+			if err != io.EOF {
+				return n, data, err
+			}
+			s.releasePage()
+		}
+	}
+
+	return n, data, nil
+}
+
+func scanColumn[T any](s *ColumnScanner, kind Kind, values []T, read func(ValueReader, []T) (int, bool, error), convert func(Value) T) (int, error) {
+	if s.kind != kind {
+		return 0, s.kindMismatch(kind)
+	}
+	n := 0
+
+	for n < len(values) {
+		if s.values == nil {
+			if err := s.readPage(); err != nil {
+				return n, err
+			}
+		}
+
+		k, ok, err := read(s.values, values[n:])
+		if !ok {
+			buffer := s.buffer
+			if remain := len(values) - n; remain < len(buffer) {
+				buffer = buffer[:remain]
+			}
+			k, err = s.values.ReadValues(buffer)
+			for i, v := range buffer[:k] {
+				values[n+i] = convert(v)
+			}
+		}
+		n += k
+
+		if err != nil {
+			if err != io.EOF {
+				return n, err
+			}
+			s.releasePage()
+		}
+	}
+
+	return n, nil
+}
+
+func (s *ColumnScanner) readPage() error {
+	page, err := s.pages.ReadPage()
+	if err != nil {
+		return err
+	}
+	s.page = page
+	s.values = page.Values()
+	if s.buffer == nil {
+		s.buffer = make([]Value, defaultValueBufferSize)
+	}
+	return nil
+}
+
+func (s *ColumnScanner) releasePage() {
+	if s.page != nil {
+		Release(s.page)
+		s.page, s.values = nil, nil
+	}
+}
+
+func (s *ColumnScanner) kindMismatch(kind Kind) error {
+	return fmt.Errorf("cannot read values of type %s from column of type %s", kind, s.kind)
+}
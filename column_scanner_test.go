@@ -0,0 +1,116 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestColumnScanner(t *testing.T) {
+	type Row struct {
+		ID    int64   `parquet:"id"`
+		Score float64 `parquet:"score,optional"`
+		Name  string  `parquet:"name,dict"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Score: float64(i) / 2, Name: string(rune('a' + i%26))}
+	}
+
+	b := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](b, parquet.PageBufferSize(512))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := f.RowGroups()[0].ColumnChunks()
+
+	t.Run("int64", func(t *testing.T) {
+		scanner := parquet.NewColumnScanner(columns[0])
+		defer scanner.Close()
+
+		ids := make([]int64, 0, len(rows))
+		buf := make([]int64, 77)
+		for {
+			n, err := scanner.ReadInt64s(buf)
+			ids = append(ids, buf[:n]...)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(ids) != len(rows) {
+			t.Fatalf("wrong number of values: want=%d got=%d", len(rows), len(ids))
+		}
+		for i, id := range ids {
+			if id != rows[i].ID {
+				t.Fatalf("wrong value at index %d: want=%d got=%d", i, rows[i].ID, id)
+			}
+		}
+
+		if _, err := scanner.ReadDoubles(make([]float64, 1)); err == nil {
+			t.Error("expected an error when reading values of the wrong type")
+		}
+	})
+
+	t.Run("double", func(t *testing.T) {
+		scanner := parquet.NewColumnScanner(columns[1])
+		defer scanner.Close()
+
+		scores := make([]float64, len(rows)+1)
+		n, err := scanner.ReadDoubles(scores)
+		if err != io.EOF {
+			t.Fatalf("expected io.EOF, got %v", err)
+		}
+		if n != len(rows) {
+			t.Fatalf("wrong number of values: want=%d got=%d", len(rows), n)
+		}
+		for i, score := range scores[:n] {
+			if score != rows[i].Score {
+				t.Fatalf("wrong value at index %d: want=%g got=%g", i, rows[i].Score, score)
+			}
+		}
+	})
+
+	t.Run("byte array", func(t *testing.T) {
+		scanner := parquet.NewColumnScanner(columns[2])
+		defer scanner.Close()
+
+		var names []string
+		var data []byte
+		offsets := make([]uint32, 101)
+		for {
+			n, buf, err := scanner.ReadByteArrays(offsets, data[:0])
+			for i := 0; i < n; i++ {
+				names = append(names, string(buf[offsets[i]:offsets[i+1]]))
+			}
+			data = buf
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(names) != len(rows) {
+			t.Fatalf("wrong number of values: want=%d got=%d", len(rows), len(names))
+		}
+		for i, name := range names {
+			if name != rows[i].Name {
+				t.Fatalf("wrong value at index %d: want=%q got=%q", i, rows[i].Name, name)
+			}
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
@@ -606,3 +607,227 @@ func TestColumnPages_SeekToRow(t *testing.T) {
 		}
 	}
 }
+
+func TestColumnPagesSeekToRowSkipValues(t *testing.T) {
+	type Row struct {
+		ID       int64   `parquet:"id"`
+		Name     string  `parquet:"name"`
+		Status   string  `parquet:"status,dict"`
+		Flag     bool    `parquet:"flag"`
+		Delta    int32   `parquet:"delta,delta"`
+		Fixed    [3]byte `parquet:"fixed"`
+		Optional *int32  `parquet:"optional,optional"`
+		Label    *string `parquet:"label,optional,dict"`
+	}
+
+	prng := rand.New(rand.NewSource(1))
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:     int64(i),
+			Name:   fmt.Sprintf("name-%d", prng.Intn(100)),
+			Status: []string{"ok", "error", "timeout"}[(i/7)%3],
+			Flag:   prng.Intn(2) == 0,
+			Delta:  int32(i * 3),
+			Fixed:  [3]byte{byte(i), byte(i >> 8), 1},
+		}
+		if prng.Intn(3) != 0 {
+			optional := int32(i)
+			rows[i].Optional = &optional
+		}
+		if prng.Intn(4) != 0 {
+			label := fmt.Sprintf("label-%d", i/50)
+			rows[i].Label = &label
+		}
+	}
+
+	for _, version := range []int{1, 2} {
+		for _, skipPageIndex := range []bool{false, true} {
+			t.Run(fmt.Sprintf("v%d/skip-page-index=%t", version, skipPageIndex), func(t *testing.T) {
+				buf := new(bytes.Buffer)
+				w := parquet.NewGenericWriter[Row](buf,
+					parquet.DataPageVersion(version),
+					parquet.PageBufferSize(512),
+				)
+				if _, err := w.Write(rows); err != nil {
+					t.Fatal(err)
+				}
+				if err := w.Close(); err != nil {
+					t.Fatal(err)
+				}
+
+				f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()),
+					parquet.SkipPageIndex(skipPageIndex),
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+				r := parquet.NewGenericReader[Row](f)
+				defer r.Close()
+
+				read := make([]Row, 3)
+				for _, rowIndex := range []int{0, 1, 7, 8, 9, 63, 64, 100, 255, 256, 257, 499, 640, 997} {
+					if err := r.SeekToRow(int64(rowIndex)); err != nil {
+						t.Fatal(err)
+					}
+					n, err := r.Read(read)
+					if err != nil && n == 0 {
+						t.Fatalf("reading row %d: %v", rowIndex, err)
+					}
+					for i, row := range read[:n] {
+						if !reflect.DeepEqual(row, rows[rowIndex+i]) {
+							t.Fatalf("row %d mismatch:\nwant = %+v\ngot  = %+v", rowIndex+i, rows[rowIndex+i], row)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestColumnPagesSeekToRowSkipEncodings(t *testing.T) {
+	type Row struct {
+		Flag   bool     `parquet:"flag"`
+		Ratio  float32  `parquet:"ratio"`
+		Amount *float64 `parquet:"amount"`
+		Count  int64    `parquet:"count"`
+		Name   string   `parquet:"name"`
+	}
+
+	schema := parquet.NewSchema("Row", parquet.Group{
+		"flag":   parquet.Encoded(parquet.Leaf(parquet.BooleanType), &parquet.RLE),
+		"ratio":  parquet.Encoded(parquet.Leaf(parquet.FloatType), &parquet.ByteStreamSplit),
+		"amount": parquet.Optional(parquet.Encoded(parquet.Leaf(parquet.DoubleType), &parquet.ByteStreamSplit)),
+		"count":  parquet.Encoded(parquet.Int(64), &parquet.DeltaBinaryPacked),
+		"name":   parquet.Encoded(parquet.String(), &parquet.DeltaLengthByteArray),
+	})
+
+	prng := rand.New(rand.NewSource(1))
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			// Runs of equal values are encoded as RLE runs, the others are
+			// bit-packed.
+			Flag:  (i/20)%2 == 0 || prng.Intn(2) == 0,
+			Ratio: float32(i) / 4,
+			Count: int64(i * i),
+			Name:  fmt.Sprintf("name-%d", prng.Intn(100)),
+		}
+		if prng.Intn(3) != 0 {
+			amount := float64(i) * 1.5
+			rows[i].Amount = &amount
+		}
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf, schema,
+				parquet.DataPageVersion(version),
+				parquet.PageBufferSize(512),
+			)
+			if _, err := w.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := parquet.NewGenericReader[Row](f)
+			defer r.Close()
+
+			read := make([]Row, 3)
+			for _, rowIndex := range []int{0, 1, 7, 8, 9, 21, 63, 64, 100, 255, 256, 257, 499, 640, 997} {
+				if err := r.SeekToRow(int64(rowIndex)); err != nil {
+					t.Fatal(err)
+				}
+				n, err := r.Read(read)
+				if err != nil && n == 0 {
+					t.Fatalf("reading row %d: %v", rowIndex, err)
+				}
+				for i, row := range read[:n] {
+					if !reflect.DeepEqual(row, rows[rowIndex+i]) {
+						t.Fatalf("row %d mismatch:\nwant = %+v\ngot  = %+v", rowIndex+i, rows[rowIndex+i], row)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestColumnDictionaryCache(t *testing.T) {
+	type Row struct {
+		Status string `parquet:"status,dict,snappy"`
+	}
+
+	var rows []Row
+	for _, statuses := range [][]string{
+		{"ok", "error", "timeout"},
+		{"ok", "error", "timeout"},
+		{"ok", "error", "canceled"},
+	} {
+		for i := 0; i < 30; i++ {
+			rows = append(rows, Row{Status: statuses[i%len(statuses)]})
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.MaxRowsPerRowGroup(30)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dictionaries := make([]parquet.Dictionary, len(f.RowGroups()))
+	for i, rowGroup := range f.RowGroups() {
+		pages := rowGroup.ColumnChunks()[0].Pages()
+		page, err := pages.ReadPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dictionaries[i] = page.Dictionary()
+		parquet.Release(page)
+		pages.Close()
+	}
+
+	if len(dictionaries) != 3 {
+		t.Fatalf("wrong number of row groups: %d", len(dictionaries))
+	}
+	if dictionaries[0] == nil || dictionaries[0] != dictionaries[1] {
+		t.Error("identical dictionary pages of consecutive row groups were not decoded once")
+	}
+	if dictionaries[2] == dictionaries[1] {
+		t.Error("different dictionary pages were decoded to the same dictionary")
+	}
+	if v := dictionaries[2].Index(2); v.String() != "canceled" {
+		t.Errorf("wrong value in the dictionary of the last row group: %v", v)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("no panic inserting values in a shared dictionary")
+			}
+		}()
+		dictionaries[0].Insert(make([]int32, 1), []parquet.Value{parquet.ValueOf("unknown")})
+	}()
+	if n := dictionaries[1].Len(); n != 3 {
+		t.Errorf("shared dictionary was modified: %d values", n)
+	}
+
+	values, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range rows {
+		if values[i] != rows[i] {
+			t.Fatalf("wrong row at index %d: want=%v got=%v", i, rows[i], values[i])
+		}
+	}
+}
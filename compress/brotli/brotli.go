@@ -49,6 +49,10 @@ func (c *Codec) Decode(dst, src []byte) ([]byte, error) {
 	})
 }
 
+func (c *Codec) NewDecodeReader(src io.Reader) (io.ReadCloser, error) {
+	return reader{brotli.NewReader(src)}, nil
+}
+
 type reader struct{ *brotli.Reader }
 
 func (reader) Close() error { return nil }
@@ -36,6 +36,16 @@ type Codec interface {
 	Decode(dst, src []byte) ([]byte, error)
 }
 
+// StreamDecoder is an interface implemented by codecs which can decompress data
+// incrementally, without materializing the whole uncompressed data in memory.
+//
+// Block-based codecs such as SNAPPY or LZ4_RAW do not implement it.
+type StreamDecoder interface {
+	// Returns a reader producing the uncompressed version of the data read
+	// from src. The reader must be closed when the program is done with it.
+	NewDecodeReader(src io.Reader) (io.ReadCloser, error)
+}
+
 type Reader interface {
 	io.ReadCloser
 	Reset(io.Reader) error
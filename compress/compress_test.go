@@ -113,6 +113,33 @@ func TestCompressionCodec(t *testing.T) {
 	}
 }
 
+func TestCompressionCodecStreamDecoder(t *testing.T) {
+	for _, test := range tests {
+		stream, ok := test.codec.(compress.StreamDecoder)
+		if !ok {
+			continue
+		}
+		t.Run(test.scenario, func(t *testing.T) {
+			compressed, err := test.codec.Encode(nil, testdata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := stream.NewDecodeReader(bytes.NewReader(compressed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			output, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(testdata, output) {
+				t.Error("content mismatch after compressing and decompressing with a stream decoder")
+			}
+		})
+	}
+}
+
 func BenchmarkEncode(b *testing.B) {
 	buffer := make([]byte, 0, len(testdata))
 
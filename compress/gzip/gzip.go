@@ -53,6 +53,10 @@ func (c *Codec) Decode(dst, src []byte) ([]byte, error) {
 	})
 }
 
+func (c *Codec) NewDecodeReader(src io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(src)
+}
+
 type reader struct {
 	*gzip.Reader
 	emptyGzip strings.Reader
@@ -2,6 +2,7 @@
 package zstd
 
 import (
+	"io"
 	"sync"
 
 	"github.com/klauspost/compress/zstd"
@@ -87,6 +88,16 @@ func (c *Codec) Decode(dst, src []byte) ([]byte, error) {
 	return d.DecodeAll(src, dst[:0])
 }
 
+func (c *Codec) NewDecodeReader(src io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(src,
+		zstd.WithDecoderConcurrency(c.concurrency()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
+
 func (c *Codec) level() Level {
 	if c.Level != 0 {
 		return c.Level
@@ -2,10 +2,12 @@ package parquet
 
 import (
 	"fmt"
+	"io"
 	"math"
 	"runtime/debug"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/parquet-go/parquet-go/compress"
 )
@@ -18,11 +20,10 @@ const (
 	ReadModeAsync                 // ReadModeAsync reads pages asynchronously in the background.
 )
 
+// The defaults controlling memory usage depend on the build profile, they are
+// declared in config_default.go and config_lowmem.go.
 const (
 	DefaultColumnIndexSizeLimit = 16
-	DefaultColumnBufferCapacity = 16 * 1024
-	DefaultPageBufferSize       = 256 * 1024
-	DefaultWriteBufferSize      = 32 * 1024
 	DefaultDataPageVersion      = 2
 	DefaultDataPageStatistics   = false
 	DefaultSkipPageIndex        = false
@@ -90,11 +91,21 @@ func formatCreatedBy(application, version, build string) string {
 //		ReadMode:         ReadModeAsync,
 //	})
 type FileConfig struct {
-	SkipPageIndex    bool
-	SkipBloomFilters bool
-	ReadBufferSize   int
-	ReadMode         ReadMode
-	Schema           *Schema
+	SkipPageIndex     bool
+	SkipBloomFilters  bool
+	ReadBufferSize    int
+	ReadMode          ReadMode
+	Schema            *Schema
+	MaxRepeatedValues int
+	ResolveFile       func(path string) (io.ReaderAt, error)
+
+	DecompressionBuffers DecompressionBufferPool
+
+	StreamingDecompressionThreshold int
+
+	CheckDefinitionLevels  bool
+	RepairDefinitionLevels bool
+	LazyValueDecoding      bool
 }
 
 // DefaultFileConfig returns a new FileConfig value initialized with the
@@ -130,17 +141,31 @@ func (c *FileConfig) Apply(options ...FileOption) {
 // ConfigureFile applies configuration options from c to config.
 func (c *FileConfig) ConfigureFile(config *FileConfig) {
 	*config = FileConfig{
-		SkipPageIndex:    c.SkipPageIndex,
-		SkipBloomFilters: c.SkipBloomFilters,
-		ReadBufferSize:   coalesceInt(c.ReadBufferSize, config.ReadBufferSize),
-		ReadMode:         ReadMode(coalesceInt(int(c.ReadMode), int(config.ReadMode))),
-		Schema:           coalesceSchema(c.Schema, config.Schema),
+		SkipPageIndex:     c.SkipPageIndex,
+		SkipBloomFilters:  c.SkipBloomFilters,
+		ReadBufferSize:    coalesceInt(c.ReadBufferSize, config.ReadBufferSize),
+		ReadMode:          ReadMode(coalesceInt(int(c.ReadMode), int(config.ReadMode))),
+		Schema:            coalesceSchema(c.Schema, config.Schema),
+		MaxRepeatedValues: coalesceInt(c.MaxRepeatedValues, config.MaxRepeatedValues),
+		ResolveFile:       coalesceFileResolver(c.ResolveFile, config.ResolveFile),
+
+		DecompressionBuffers: coalesceDecompressionBufferPool(c.DecompressionBuffers, config.DecompressionBuffers),
+
+		StreamingDecompressionThreshold: coalesceInt(c.StreamingDecompressionThreshold, config.StreamingDecompressionThreshold),
+
+		CheckDefinitionLevels:  c.CheckDefinitionLevels,
+		RepairDefinitionLevels: c.RepairDefinitionLevels,
+		LazyValueDecoding:      c.LazyValueDecoding,
 	}
 }
 
 // Validate returns a non-nil error if the configuration of c is invalid.
 func (c *FileConfig) Validate() error {
-	return nil
+	const baseName = "parquet.(*FileConfig)."
+	return errorInvalidConfiguration(
+		validateNotNegativeInt(baseName+"MaxRepeatedValues", c.MaxRepeatedValues),
+		validateNotNegativeInt(baseName+"StreamingDecompressionThreshold", c.StreamingDecompressionThreshold),
+	)
 }
 
 // The ReaderConfig type carries configuration options for parquet readers.
@@ -152,7 +177,12 @@ func (c *FileConfig) Validate() error {
 //		// ...
 //	})
 type ReaderConfig struct {
-	Schema *Schema
+	Schema      *Schema
+	UTF8        UTF8Policy
+	LegacyLists bool
+	Defaults    ColumnDefaults
+	Narrowing   NarrowingPolicy
+	ReadHooks   map[string]func(Value) (Value, error)
 }
 
 // DefaultReaderConfig returns a new ReaderConfig value initialized with the
@@ -182,13 +212,22 @@ func (c *ReaderConfig) Apply(options ...ReaderOption) {
 // ConfigureReader applies configuration options from c to config.
 func (c *ReaderConfig) ConfigureReader(config *ReaderConfig) {
 	*config = ReaderConfig{
-		Schema: coalesceSchema(c.Schema, config.Schema),
+		Schema:      coalesceSchema(c.Schema, config.Schema),
+		UTF8:        coalesceUTF8Policy(c.UTF8, config.UTF8),
+		LegacyLists: coalesceBool(c.LegacyLists, config.LegacyLists),
+		Defaults:    coalesceColumnDefaults(c.Defaults, config.Defaults),
+		Narrowing:   coalesceNarrowingPolicy(c.Narrowing, config.Narrowing),
+		ReadHooks:   coalesceHooks(c.ReadHooks, config.ReadHooks),
 	}
 }
 
 // Validate returns a non-nil error if the configuration of c is invalid.
 func (c *ReaderConfig) Validate() error {
-	return nil
+	const baseName = "parquet.(*ReaderConfig)."
+	return errorInvalidConfiguration(
+		validateUTF8Policy(baseName+"UTF8", c.UTF8),
+		validateNarrowingPolicy(baseName+"Narrowing", c.Narrowing),
+	)
 }
 
 // The WriterConfig type carries configuration options for parquet writers.
@@ -200,34 +239,57 @@ func (c *ReaderConfig) Validate() error {
 //		CreatedBy: "my test program",
 //	})
 type WriterConfig struct {
-	CreatedBy            string
-	ColumnPageBuffers    BufferPool
-	ColumnIndexSizeLimit int
-	PageBufferSize       int
-	WriteBufferSize      int
-	DataPageVersion      int
-	DataPageStatistics   bool
-	MaxRowsPerRowGroup   int64
-	KeyValueMetadata     map[string]string
-	Schema               *Schema
-	BloomFilters         []BloomFilterColumn
-	Compression          compress.Codec
-	Sorting              SortingConfig
-	SkipPageBounds       [][]string
+	CreatedBy               string
+	ColumnPageBuffers       BufferPool
+	ColumnIndexSizeLimit    int
+	PageBufferSize          int
+	WriteBufferSize         int
+	DataPageVersion         int
+	DataPageStatistics      bool
+	PageStatisticsSizeLimit int
+	SkipIndexedPageStats    bool
+	MaxRowsPerRowGroup      int64
+	KeyValueMetadata        map[string]string
+	Schema                  *Schema
+	BloomFilters            []BloomFilterColumn
+	Compression             compress.Codec
+	Sorting                 SortingConfig
+	SkipPageBounds          [][]string
+	StrictWrite             bool
+	UTF8                    UTF8Policy
+	Enums                   []EnumColumn
+	FlushInterval           time.Duration
+	Clock                   func() time.Time
+	LegacyLists             bool
+	LegacyConvertedTypes    bool
+	Clustering              Clustering
+	ChunkPageSize           int
+	ChunkRowGroupSize       int64
+	RowGroupAlignment       int64
+	ColumnChunkAlignment    int64
+	ColumnLayout            []string
+	BloomFilterMemoryBudget int64
+	DictionaryMaxBytes      int64
+	DictionaryFallbackLimit int
+	DictionaryFallbackFunc  func(DictionaryFallback)
+	SortedDictionaries      []string
+	WriteHooks              map[string]func(Value) (Value, error)
+	Encryption              *EncryptionConfig
 }
 
 // DefaultWriterConfig returns a new WriterConfig value initialized with the
 // default writer configuration.
 func DefaultWriterConfig() *WriterConfig {
 	return &WriterConfig{
-		CreatedBy:            defaultCreatedBy(),
-		ColumnPageBuffers:    &defaultColumnBufferPool,
-		ColumnIndexSizeLimit: DefaultColumnIndexSizeLimit,
-		PageBufferSize:       DefaultPageBufferSize,
-		WriteBufferSize:      DefaultWriteBufferSize,
-		DataPageVersion:      DefaultDataPageVersion,
-		DataPageStatistics:   DefaultDataPageStatistics,
-		MaxRowsPerRowGroup:   DefaultMaxRowsPerRowGroup,
+		CreatedBy:               defaultCreatedBy(),
+		ColumnPageBuffers:       &defaultColumnBufferPool,
+		ColumnIndexSizeLimit:    DefaultColumnIndexSizeLimit,
+		PageBufferSize:          DefaultPageBufferSize,
+		WriteBufferSize:         DefaultWriteBufferSize,
+		DataPageVersion:         DefaultDataPageVersion,
+		DataPageStatistics:      DefaultDataPageStatistics,
+		MaxRowsPerRowGroup:      DefaultMaxRowsPerRowGroup,
+		BloomFilterMemoryBudget: DefaultBloomFilterMemoryBudget,
 		Sorting: SortingConfig{
 			SortingBuffers: &defaultSortingBufferPool,
 		},
@@ -265,19 +327,41 @@ func (c *WriterConfig) ConfigureWriter(config *WriterConfig) {
 	}
 
 	*config = WriterConfig{
-		CreatedBy:            coalesceString(c.CreatedBy, config.CreatedBy),
-		ColumnPageBuffers:    coalesceBufferPool(c.ColumnPageBuffers, config.ColumnPageBuffers),
-		ColumnIndexSizeLimit: coalesceInt(c.ColumnIndexSizeLimit, config.ColumnIndexSizeLimit),
-		PageBufferSize:       coalesceInt(c.PageBufferSize, config.PageBufferSize),
-		WriteBufferSize:      coalesceInt(c.WriteBufferSize, config.WriteBufferSize),
-		DataPageVersion:      coalesceInt(c.DataPageVersion, config.DataPageVersion),
-		DataPageStatistics:   coalesceBool(c.DataPageStatistics, config.DataPageStatistics),
-		MaxRowsPerRowGroup:   coalesceInt64(c.MaxRowsPerRowGroup, config.MaxRowsPerRowGroup),
-		KeyValueMetadata:     keyValueMetadata,
-		Schema:               coalesceSchema(c.Schema, config.Schema),
-		BloomFilters:         coalesceBloomFilters(c.BloomFilters, config.BloomFilters),
-		Compression:          coalesceCompression(c.Compression, config.Compression),
-		Sorting:              coalesceSortingConfig(c.Sorting, config.Sorting),
+		CreatedBy:               coalesceString(c.CreatedBy, config.CreatedBy),
+		ColumnPageBuffers:       coalesceBufferPool(c.ColumnPageBuffers, config.ColumnPageBuffers),
+		ColumnIndexSizeLimit:    coalesceInt(c.ColumnIndexSizeLimit, config.ColumnIndexSizeLimit),
+		PageBufferSize:          coalesceInt(c.PageBufferSize, config.PageBufferSize),
+		WriteBufferSize:         coalesceInt(c.WriteBufferSize, config.WriteBufferSize),
+		DataPageVersion:         coalesceInt(c.DataPageVersion, config.DataPageVersion),
+		DataPageStatistics:      coalesceBool(c.DataPageStatistics, config.DataPageStatistics),
+		PageStatisticsSizeLimit: coalesceInt(c.PageStatisticsSizeLimit, config.PageStatisticsSizeLimit),
+		SkipIndexedPageStats:    coalesceBool(c.SkipIndexedPageStats, config.SkipIndexedPageStats),
+		MaxRowsPerRowGroup:      coalesceInt64(c.MaxRowsPerRowGroup, config.MaxRowsPerRowGroup),
+		KeyValueMetadata:        keyValueMetadata,
+		Schema:                  coalesceSchema(c.Schema, config.Schema),
+		BloomFilters:            coalesceBloomFilters(c.BloomFilters, config.BloomFilters),
+		Compression:             coalesceCompression(c.Compression, config.Compression),
+		Sorting:                 coalesceSortingConfig(c.Sorting, config.Sorting),
+		StrictWrite:             coalesceBool(c.StrictWrite, config.StrictWrite),
+		UTF8:                    coalesceUTF8Policy(c.UTF8, config.UTF8),
+		Enums:                   coalesceEnumColumns(c.Enums, config.Enums),
+		FlushInterval:           coalesceDuration(c.FlushInterval, config.FlushInterval),
+		Clock:                   coalesceClock(c.Clock, config.Clock),
+		LegacyLists:             coalesceBool(c.LegacyLists, config.LegacyLists),
+		LegacyConvertedTypes:    coalesceBool(c.LegacyConvertedTypes, config.LegacyConvertedTypes),
+		Clustering:              coalesceClustering(c.Clustering, config.Clustering),
+		ChunkPageSize:           coalesceInt(c.ChunkPageSize, config.ChunkPageSize),
+		ChunkRowGroupSize:       coalesceInt64(c.ChunkRowGroupSize, config.ChunkRowGroupSize),
+		RowGroupAlignment:       coalesceInt64(c.RowGroupAlignment, config.RowGroupAlignment),
+		ColumnChunkAlignment:    coalesceInt64(c.ColumnChunkAlignment, config.ColumnChunkAlignment),
+		ColumnLayout:            coalesceStrings(c.ColumnLayout, config.ColumnLayout),
+		BloomFilterMemoryBudget: coalesceInt64(c.BloomFilterMemoryBudget, config.BloomFilterMemoryBudget),
+		DictionaryMaxBytes:      coalesceInt64(c.DictionaryMaxBytes, config.DictionaryMaxBytes),
+		DictionaryFallbackLimit: coalesceInt(c.DictionaryFallbackLimit, config.DictionaryFallbackLimit),
+		DictionaryFallbackFunc:  coalesceDictionaryFallbackFunc(c.DictionaryFallbackFunc, config.DictionaryFallbackFunc),
+		SortedDictionaries:      coalesceStrings(c.SortedDictionaries, config.SortedDictionaries),
+		WriteHooks:              coalesceHooks(c.WriteHooks, config.WriteHooks),
+		Encryption:              coalesceEncryptionConfig(c.Encryption, config.Encryption),
 	}
 }
 
@@ -289,7 +373,18 @@ func (c *WriterConfig) Validate() error {
 		validatePositiveInt(baseName+"ColumnIndexSizeLimit", c.ColumnIndexSizeLimit),
 		validatePositiveInt(baseName+"PageBufferSize", c.PageBufferSize),
 		validateOneOfInt(baseName+"DataPageVersion", c.DataPageVersion, 1, 2),
+		validateNotNegativeInt(baseName+"PageStatisticsSizeLimit", c.PageStatisticsSizeLimit),
+		validateUTF8Policy(baseName+"UTF8", c.UTF8),
+		validateNotNegativeDuration(baseName+"FlushInterval", c.FlushInterval),
+		validateNotNegativeInt(baseName+"ChunkPageSize", c.ChunkPageSize),
+		validateNotNegativeInt64(baseName+"ChunkRowGroupSize", c.ChunkRowGroupSize),
+		validateNotNegativeInt64(baseName+"RowGroupAlignment", c.RowGroupAlignment),
+		validateNotNegativeInt64(baseName+"ColumnChunkAlignment", c.ColumnChunkAlignment),
+		validateNotNegativeInt64(baseName+"BloomFilterMemoryBudget", c.BloomFilterMemoryBudget),
+		validateNotNegativeInt64(baseName+"DictionaryMaxBytes", c.DictionaryMaxBytes),
+		validateNotNegativeInt(baseName+"DictionaryFallbackLimit", c.DictionaryFallbackLimit),
 		c.Sorting.Validate(),
+		c.Encryption.Validate(),
 	)
 }
 
@@ -305,6 +400,10 @@ type RowGroupConfig struct {
 	ColumnBufferCapacity int
 	Schema               *Schema
 	Sorting              SortingConfig
+	SequenceColumn       []string
+	OperationColumn      []string
+	ColumnOrder          ColumnOrder
+	PageFilters          []PageFilter
 }
 
 // DefaultRowGroupConfig returns a new RowGroupConfig value initialized with the
@@ -349,6 +448,10 @@ func (c *RowGroupConfig) ConfigureRowGroup(config *RowGroupConfig) {
 		ColumnBufferCapacity: coalesceInt(c.ColumnBufferCapacity, config.ColumnBufferCapacity),
 		Schema:               coalesceSchema(c.Schema, config.Schema),
 		Sorting:              coalesceSortingConfig(c.Sorting, config.Sorting),
+		SequenceColumn:       coalescePath(c.SequenceColumn, config.SequenceColumn),
+		OperationColumn:      coalescePath(c.OperationColumn, config.OperationColumn),
+		ColumnOrder:          coalesceColumnOrder(c.ColumnOrder, config.ColumnOrder),
+		PageFilters:          coalescePageFilters(c.PageFilters, config.PageFilters),
 	}
 }
 
@@ -364,9 +467,10 @@ func (c *RowGroupConfig) ConfigureRowGroup(config *RowGroupConfig) {
 //		),
 //	})
 type SortingConfig struct {
-	SortingBuffers     BufferPool
-	SortingColumns     []SortingColumn
-	DropDuplicatedRows bool
+	SortingBuffers        BufferPool
+	SortingColumns        []SortingColumn
+	DropDuplicatedRows    bool
+	KeepLastDuplicatedRow bool
 }
 
 // DefaultSortingConfig returns a new SortingConfig value initialized with the
@@ -405,6 +509,58 @@ func (c *SortingConfig) ConfigureSorting(config *SortingConfig) {
 	*config = coalesceSortingConfig(*c, *config)
 }
 
+// The SchemaConfig type carries configuration options for parquet schemas.
+//
+// SchemaConfig implements the SchemaOption interface so it can be used directly
+// as argument to the SchemaOf function when needed, for example:
+//
+//	schema := parquet.SchemaOf(model, &parquet.SchemaConfig{
+//		SequentialFieldIDs: true,
+//	})
+type SchemaConfig struct {
+	SequentialFieldIDs bool
+	ColumnOrder        ColumnOrder
+	Defaults           ColumnDefaults
+}
+
+// DefaultSchemaConfig returns a new SchemaConfig value initialized with the
+// default schema configuration.
+func DefaultSchemaConfig() *SchemaConfig {
+	return &SchemaConfig{}
+}
+
+// NewSchemaConfig constructs a new schema configuration applying the options
+// passed as arguments.
+//
+// The function returns an non-nil error if some of the options carried invalid
+// configuration values.
+func NewSchemaConfig(options ...SchemaOption) (*SchemaConfig, error) {
+	config := DefaultSchemaConfig()
+	config.Apply(options...)
+	return config, config.Validate()
+}
+
+// Apply applies the given list of options to c.
+func (c *SchemaConfig) Apply(options ...SchemaOption) {
+	for _, opt := range options {
+		opt.ConfigureSchema(c)
+	}
+}
+
+// ConfigureSchema applies configuration options from c to config.
+func (c *SchemaConfig) ConfigureSchema(config *SchemaConfig) {
+	*config = SchemaConfig{
+		SequentialFieldIDs: coalesceBool(c.SequentialFieldIDs, config.SequentialFieldIDs),
+		ColumnOrder:        coalesceColumnOrder(c.ColumnOrder, config.ColumnOrder),
+		Defaults:           coalesceColumnDefaults(c.Defaults, config.Defaults),
+	}
+}
+
+// Validate returns a non-nil error if the configuration of c is invalid.
+func (c *SchemaConfig) Validate() error {
+	return nil
+}
+
 // FileOption is an interface implemented by types that carry configuration
 // options for parquet files.
 type FileOption interface {
@@ -435,6 +591,12 @@ type SortingOption interface {
 	ConfigureSorting(*SortingConfig)
 }
 
+// SchemaOption is an interface implemented by types that carry configuration
+// options for parquet schemas.
+type SchemaOption interface {
+	ConfigureSchema(*SchemaConfig)
+}
+
 // SkipPageIndex is a file configuration option which prevents automatically
 // reading the page index when opening a parquet file, when set to true. This is
 // useful as an optimization when programs know that they will not need to
@@ -455,6 +617,24 @@ func SkipBloomFilters(skip bool) FileOption {
 	return fileOption(func(config *FileConfig) { config.SkipBloomFilters = skip })
 }
 
+// FileResolver is a file configuration option which configures the function
+// used to open the files referenced by the file_path field of column chunks.
+//
+// Summary files, for example the _metadata files written by some frameworks
+// next to partitioned datasets, hold the footers of other files and reference
+// the data files holding the pages of their column chunks. When a column chunk
+// references an external file, the resolver is called with the path recorded
+// in the metadata to obtain the reader of its pages, page index and bloom
+// filter. Each path is resolved once when the file is opened; the program
+// remains responsible for closing the readers returned by the resolver after
+// it is done with the file.
+//
+// Without a resolver, the file_path field of column chunks is ignored and
+// their content is read from the file itself.
+func FileResolver(resolve func(path string) (io.ReaderAt, error)) FileOption {
+	return fileOption(func(config *FileConfig) { config.ResolveFile = resolve })
+}
+
 // FileReadMode is a file configuration option which controls the way pages
 // are read. Currently the only two options are ReadModeAsync and ReadModeSync
 // which control whether or not pages are loaded asynchronously. It can be
@@ -477,6 +657,106 @@ func ReadBufferSize(size int) FileOption {
 	return fileOption(func(config *FileConfig) { config.ReadBufferSize = size })
 }
 
+// MaxRepeatedValues is a file configuration option which limits the number of
+// values that a single row may hold in each column when reading rows from the
+// file.
+//
+// Malformed or malicious files may contain rows with repeated columns holding
+// billions of values; reading such rows would allocate memory unboundedly. When
+// the limit is set, reading rows returns an error wrapping
+// ErrTooManyRepeatedValues as soon as a row exceeds the limit in one of its
+// columns, before the row gets reconstructed.
+//
+// Defaults to zero, which means no limit.
+func MaxRepeatedValues(limit int) FileOption {
+	return fileOption(func(config *FileConfig) { config.MaxRepeatedValues = limit })
+}
+
+// CheckDefinitionLevels is a file configuration option which enables the
+// verification of the definition levels of data pages.
+//
+// When enabled, reading pages where the number of definition levels equal to
+// the maximum definition level disagrees with the number of values in the
+// page returns an error wrapping ErrInvalidDefinitionLevels. The check counts
+// the levels of every page that is read, it is disabled by default.
+//
+// Defaults to false.
+func CheckDefinitionLevels(enabled bool) FileOption {
+	return fileOption(func(config *FileConfig) { config.CheckDefinitionLevels = enabled })
+}
+
+// RepairDefinitionLevels is a file configuration option which enables lenient
+// decoding of definition levels.
+//
+// Some legacy writers emit required columns nested in optional groups with
+// definition levels greater than the maximum definition level of the column,
+// counting the required column as optional. When enabled, the definition
+// levels are checked like they are with CheckDefinitionLevels, and levels
+// above the maximum are lowered to the maximum definition level when the page
+// then holds the right number of values. Other inconsistencies cannot be
+// repaired without guessing which values are null, they still return errors
+// wrapping ErrInvalidDefinitionLevels.
+//
+// Defaults to false.
+func RepairDefinitionLevels(enabled bool) FileOption {
+	return fileOption(func(config *FileConfig) { config.RepairDefinitionLevels = enabled })
+}
+
+// DecompressionBuffers is a file configuration option which sets the pool of
+// buffers that compressed pages of the file are decompressed into.
+//
+// Pages read from the file reference the buffer they were decompressed into,
+// the buffer is returned to the pool when the pages are released with Release,
+// which readers of rows do after reading all the values of a page. Buffers of
+// pages that the program does not release are left to the garbage collector.
+//
+// Defaults to nil, which uses a pool shared by all files.
+func DecompressionBuffers(pool DecompressionBufferPool) FileOption {
+	return fileOption(func(config *FileConfig) { config.DecompressionBuffers = pool })
+}
+
+// StreamingDecompression is a file configuration option which enables streaming
+// the decompression of pages larger than the given threshold (in bytes) into
+// the value decoder, instead of decompressing the whole page in memory before
+// decoding its values.
+//
+// Files written with very large pages (e.g. 64 MiB or more) otherwise require
+// holding the compressed page, the uncompressed page, and the decoded values
+// in memory at the same time. When streaming, the values are decoded as they
+// are decompressed, which bounds the peak memory used to read the page to its
+// compressed and decoded representations.
+//
+// Streaming applies to pages of PLAIN byte arrays compressed with a codec
+// implementing compress.StreamDecoder (GZIP, BROTLI and ZSTD); the values of
+// other pages are decoded from the uncompressed page. Streaming does not apply
+// when LazyValueDecoding is enabled, since lazy pages retain their data.
+//
+// Defaults to zero, which disables streaming decompression.
+func StreamingDecompression(threshold int) FileOption {
+	return fileOption(func(config *FileConfig) { config.StreamingDecompressionThreshold = threshold })
+}
+
+// LazyValueDecoding is a file configuration option which defers decoding the
+// values of byte array columns until they are accessed.
+//
+// When enabled, the pages read from byte array columns retain their encoded
+// data, and their values are decoded the first time they are read. The values
+// of PLAIN pages are never copied: the values returned when reading rows from
+// the file reference the encoded page data. Pages of other encodings, such as
+// DELTA_BYTE_ARRAY where values are reconstructed from the prefixes of the
+// previous values, are decoded when their values are first read.
+//
+// This benefits programs which discard most rows before reading the values of
+// most columns, for example when filtering pages on a few columns, then
+// seeking or slicing the pages of the other columns to the rows that passed
+// the filter. Values of dictionary encoded pages are not affected since they
+// already reference the dictionary.
+//
+// Defaults to false.
+func LazyValueDecoding(enabled bool) FileOption {
+	return fileOption(func(config *FileConfig) { config.LazyValueDecoding = enabled })
+}
+
 // FileSchema is used to pass a known schema in while opening a Parquet file.
 // This optimization is only useful if your application is currently opening
 // an extremely large number of parquet files with the same, known schema.
@@ -486,6 +766,21 @@ func FileSchema(schema *Schema) FileOption {
 	return fileOption(func(config *FileConfig) { config.Schema = schema })
 }
 
+// LegacyConvertedTypes configures writers to annotate the schema with the
+// legacy converted types that older parquet readers expect, in addition to the
+// logical types.
+//
+// Converted types are always written for columns of logical types which have
+// an equivalent converted type (e.g. STRING and UTF8, or TIMESTAMP and
+// TIMESTAMP_MILLIS). When enabled, the repeated key/value groups of maps are
+// also annotated with the MAP_KEY_VALUE converted type, which was required by
+// some readers prior to the introduction of logical types.
+//
+// Defaults to false.
+func LegacyConvertedTypes(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.LegacyConvertedTypes = enabled })
+}
+
 // PageBufferSize configures the size of column page buffers on parquet writers.
 //
 // Note that the page buffer size refers to the in-memory buffers where pages
@@ -553,6 +848,11 @@ func ColumnPageBuffers(buffers BufferPool) WriterOption {
 // ColumnIndexSizeLimit creates a configuration option to customize the size
 // limit of page boundaries recorded in column indexes.
 //
+// The limit only applies to the min and max values of BYTE_ARRAY and
+// FIXED_LEN_BYTE_ARRAY columns in the column index, which are truncated to a
+// lower and upper bound of the page values. The statistics of column chunks are
+// not affected and always record the exact min and max values.
+//
 // Defaults to 16.
 func ColumnIndexSizeLimit(sizeLimit int) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.ColumnIndexSizeLimit = sizeLimit })
@@ -576,6 +876,35 @@ func DataPageStatistics(enabled bool) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.DataPageStatistics = enabled })
 }
 
+// PageStatisticsSizeLimit creates a configuration option which omits the
+// min and max values from the statistics of data pages when one of them is
+// longer than sizeLimit bytes. The null count of the pages is still recorded.
+//
+// Page statistics duplicate the bounds of pages stored in the column index, the
+// limit prevents large BYTE_ARRAY values from being stored twice in files that
+// enable DataPageStatistics. The option has no effect when page statistics are
+// disabled, and the statistics of column chunks are not affected.
+//
+// Defaults to zero, which means no limit.
+func PageStatisticsSizeLimit(sizeLimit int) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.PageStatisticsSizeLimit = sizeLimit })
+}
+
+// SkipIndexedPageStats creates a configuration option which omits the min
+// and max values from the statistics of data pages when the column index
+// already records them exactly, which is the case unless the bounds of the
+// column are skipped (see SkipPageBounds) or BYTE_ARRAY and
+// FIXED_LEN_BYTE_ARRAY values are truncated in the column index (see
+// ColumnIndexSizeLimit). Readers which do not load the column index still see
+// the exact bounds of pages whose values were truncated.
+//
+// The option has no effect when page statistics are disabled.
+//
+// Defaults to false.
+func SkipIndexedPageStats(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.SkipIndexedPageStats = enabled })
+}
+
 // KeyValueMetadata creates a configuration option which adds key/value metadata
 // to add to the metadata of parquet files.
 //
@@ -633,6 +962,22 @@ func SkipPageBounds(path ...string) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.SkipPageBounds = append(config.SkipPageBounds, path) })
 }
 
+// StrictWrite creates a configuration option which enables validation of rows
+// before they are buffered by a writer.
+//
+// When enabled, the writer verifies that each row holds values of the right
+// kind for every column of the schema, that the repetition and definition
+// levels of the values are consistent with the column structure, and that the
+// values of STRING columns are valid UTF-8 (unless UTF8Replace is used).
+// Invalid rows cause the write methods to return a *RowError indicating the
+// index of the row and the path of the column at fault. The invalid row is not
+// written to the file.
+//
+// Strict mode adds overhead to writes, and is disabled by default.
+func StrictWrite(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.StrictWrite = enabled })
+}
+
 // ColumnBufferCapacity creates a configuration option which defines the size of
 // row group column buffers.
 //
@@ -641,6 +986,69 @@ func ColumnBufferCapacity(size int) RowGroupOption {
 	return rowGroupOption(func(config *RowGroupConfig) { config.ColumnBufferCapacity = size })
 }
 
+// MergeSequenceColumn is a row group option which configures MergeRowGroups to
+// resolve multiple versions of rows by sequence number.
+//
+// When set, rows with equal values in the sorting columns of the merge are
+// considered to be versions of the same row, and only the version with the
+// highest value in the sequence column is retained. The option requires
+// sorting columns to be configured on the merge.
+//
+// Versions are resolved the first time the rows, the number of rows, or the
+// pages of the column chunks of the merged row group are read, and the rows
+// which remain are buffered in memory. The number of rows reported by the
+// merged row group accounts for the versions that are removed. Errors
+// resolving versions are returned when reading rows or pages, and the number
+// of rows is then zero.
+func MergeSequenceColumn(path ...string) RowGroupOption {
+	path = append([]string{}, path...)
+	return rowGroupOption(func(config *RowGroupConfig) { config.SequenceColumn = path })
+}
+
+// MergeOperationColumn is a row group option which configures MergeRowGroups
+// to drop rows when the version retained by MergeSequenceColumn was produced
+// by a CDCDelete operation, according to the value of the column at path.
+func MergeOperationColumn(path ...string) RowGroupOption {
+	path = append([]string{}, path...)
+	return rowGroupOption(func(config *RowGroupConfig) { config.OperationColumn = path })
+}
+
+// MergeColumnOrder is a row group option which configures MergeRowGroups to
+// order the columns of the merged schema with the given policy.
+//
+// When no schema is configured on the merge, the schema of the first row group
+// is reordered to become the schema of the merged row group, and the schemas
+// of other row groups are only required to be equal after being reordered,
+// which allows merging row groups whose columns were written in different
+// orders.
+func MergeColumnOrder(order ColumnOrder) RowGroupOption {
+	return rowGroupOption(func(config *RowGroupConfig) { config.ColumnOrder = order })
+}
+
+// MergePageFilter is a row group option which configures MergeRowGroups to
+// evaluate predicate on the page index of the column at the given path in each
+// of the row groups being merged, and skip the pages for which it returned
+// false.
+//
+// Pruning happens on the page indexes of the original row groups, before the
+// rows are merged, so reading a merged row group with a selective filter only
+// decodes the pages which may contain matching rows. The predicate has the
+// same semantics as the one passed to FilterPages, and receives the min and
+// max values in the physical type of the column in the original row groups,
+// which may differ from the merged schema when the row groups were converted.
+//
+// The filter applies at the page granularity: rows of the selected pages are
+// all read, and programs must still apply their filter on the rows to exclude
+// the ones which do not match. The option may be passed multiple times, in
+// which case only the rows selected by all filters are read. The column chunks
+// of the merged row group also only hold the values of the selected rows, the
+// rows of the filtered row groups are buffered in memory to produce them the
+// first time they are accessed.
+func MergePageFilter(predicate func(min, max []byte, nullPage bool) bool, path ...string) RowGroupOption {
+	filter := PageFilter{Path: append([]string{}, path...), Predicate: predicate}
+	return rowGroupOption(func(config *RowGroupConfig) { config.PageFilters = append(config.PageFilters, filter) })
+}
+
 // SortingRowGroupConfig is a row group option which applies configuration
 // specific sorting row groups.
 func SortingRowGroupConfig(options ...SortingOption) RowGroupOption {
@@ -682,6 +1090,253 @@ func DropDuplicatedRows(drop bool) SortingOption {
 	return sortingOption(func(config *SortingConfig) { config.DropDuplicatedRows = drop })
 }
 
+// FlushInterval creates a configuration option which bounds the time that rows
+// may remain buffered in a writer before being flushed to a row group.
+//
+// Writers check the interval each time rows are written: if the first row
+// buffered in the current row group was written more than d ago, the row group
+// is flushed. Since the check only happens on writes, applications producing
+// rows at a low rate should also call the FlushIfDue method of writers
+// periodically (e.g. from a time.Ticker in the goroutine owning the writer).
+//
+// Zero disables time-based flushes, which is the default.
+func FlushInterval(d time.Duration) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.FlushInterval = d })
+}
+
+// ContentDefinedChunking creates a configuration option which aligns the
+// boundaries of pages and row groups to content-defined cut points, so that
+// files written from slightly different versions of a data set share most of
+// their pages and row groups, which dedupe well in content-addressed storage.
+//
+// Cut points are found by a rolling hash over the values written to the
+// columns, and only depend on the values in their vicinity: inserting or
+// removing rows only changes the pages and row groups near the modified rows.
+// The pageSize and rowGroupSize arguments are the average number of bytes of
+// values between cut points, the minimum size of chunks is a quarter of the
+// average, and the maximum is four times the average.
+//
+// When pageSize is positive, pages are cut at the content-defined cut points of
+// each column instead of when the page buffers fill up. When rowGroupSize is
+// positive, row groups are cut at the content-defined cut points of rows; the
+// MaxRowsPerRowGroup option still bounds the size of row groups, but limits
+// applied by the application (e.g. calling Flush after writing a fixed number
+// of rows) may misalign row groups. Cut points always fall on row boundaries.
+//
+// Zero disables content-defined chunking, which is the default.
+func ContentDefinedChunking(pageSize int, rowGroupSize int64) WriterOption {
+	return writerOption(func(config *WriterConfig) {
+		config.ChunkPageSize = pageSize
+		config.ChunkRowGroupSize = rowGroupSize
+	})
+}
+
+// AlignRowGroups creates a configuration option which aligns the start of row
+// groups in the output of writers to multiples of the given number of bytes,
+// by writing zero bytes between row groups when needed.
+//
+// Aligning row groups to the block size of the storage system (e.g. 8 MiB)
+// ensures that range reads of row groups do not straddle block boundaries, and
+// that caching layers working with fixed-size blocks hold whole row groups.
+// The padding increases the size of files by up to the alignment for each row
+// group, which is negligible when row groups are much larger than the
+// alignment.
+//
+// Zero disables the alignment, which is the default.
+func AlignRowGroups(alignment int64) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.RowGroupAlignment = alignment })
+}
+
+// AlignColumnChunks creates a configuration option which aligns the start of
+// column chunks in the output of writers to multiples of the given number of
+// bytes, by writing zero bytes between column chunks when needed.
+//
+// The first page of each column chunk (the dictionary page, or the first data
+// page) starts at an aligned offset. Pages within column chunks cannot be
+// aligned, since the parquet format requires them to be contiguous.
+//
+// Zero disables the alignment, which is the default.
+func AlignColumnChunks(alignment int64) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.ColumnChunkAlignment = alignment })
+}
+
+// BloomFilterMemoryBudget creates a configuration option which limits the
+// memory used by each column to build its bloom filter, in bytes.
+//
+// When the number of values of column chunks is not known in advance (e.g.
+// when rows are written with Write instead of WriteRowGroup), the size of bloom
+// filters cannot be determined until the column chunks are flushed. Writers
+// retain the 64 bits hashes of the values of each page until then, and insert
+// them in the filters when the sizes are known. When the hashes of a column
+// chunk exceed the budget, they are discarded, and the filter is built in a
+// second pass by reading back the pages of the column chunk, trading CPU time
+// for a bounded memory usage on very large column chunks.
+//
+// Defaults to DefaultBloomFilterMemoryBudget.
+func BloomFilterMemoryBudget(size int64) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.BloomFilterMemoryBudget = size })
+}
+
+// DictionaryMaxBytes creates a configuration option which limits the size of the
+// dictionaries of column chunks, in bytes.
+//
+// When the dictionary of a column chunk grows beyond the limit, the pages that
+// were already written keep referencing it, and the following pages of the
+// column chunk are written with the PLAIN encoding instead. The dictionary is
+// restored for the next row group. The fallbacks of each column are counted in
+// the reports of writers.
+//
+// Zero disables the limit, which is the default.
+func DictionaryMaxBytes(size int64) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.DictionaryMaxBytes = size })
+}
+
+// DictionaryFallbackLimit creates a configuration option which disables the
+// dictionary encoding of columns after their dictionaries exceeded the limit
+// set by DictionaryMaxBytes in the given number of consecutive row groups.
+//
+// The column chunks of high-cardinality columns rarely benefit from dictionary
+// encoding; disabling the dictionaries avoids encoding the first pages of each
+// row group with a dictionary that ends up being abandoned. The dictionary
+// encoding remains disabled for the following row groups written by the writer,
+// until it is reset.
+//
+// Zero never disables dictionary encoding, which is the default.
+func DictionaryFallbackLimit(numRowGroups int) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.DictionaryFallbackLimit = numRowGroups })
+}
+
+// OnDictionaryFallback creates a configuration option which sets a function
+// called by writers when the dictionary of a column chunk exceeds the limit set
+// by DictionaryMaxBytes and the following pages fall back to the PLAIN encoding.
+//
+// The function is called synchronously by the goroutine writing rows, it must
+// not retain the event past the call nor call methods of the writer.
+func OnDictionaryFallback(fn func(DictionaryFallback)) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.DictionaryFallbackFunc = fn })
+}
+
+// SortedDictionaries creates a configuration option which sorts the entries of
+// the dictionaries of the columns at the given dot-separated paths, and sets
+// the IsSorted field of their dictionary page headers.
+//
+// Readers can binary search sorted dictionaries when evaluating predicates
+// (see FileColumnChunk.MayContain). Since the dictionary of a column chunk is
+// only complete when the column chunk is flushed, the writer retains the
+// dictionary indexes and levels of its pages in memory until then, and encodes
+// them with the indexes of the sorted dictionary.
+//
+// The option is additive, it may be used multiple times to sort the
+// dictionaries of multiple columns. Columns which are not dictionary encoded
+// are ignored.
+func SortedDictionaries(paths ...string) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.SortedDictionaries = append(config.SortedDictionaries, paths...) })
+}
+
+// Encryption creates a configuration option which encrypts the files produced
+// by writers with the AES_GCM_V1 algorithm of parquet modular encryption.
+//
+// The pages, page headers, indexes, bloom filters, and metadata of encrypted
+// columns are encrypted with the key of the column, and the footer with the
+// footer key; see EncryptionConfig for the available settings. Each file gets
+// a random unique identifier which is part of the additional authenticated
+// data of its modules, so modules cannot be swapped between files.
+//
+// Keys are not stored in the files, the key metadata recorded for the footer
+// and columns lets readers retrieve them.
+//
+// Writers panic if the configuration refers to columns which do not exist in
+// their schema.
+func Encryption(encryption *EncryptionConfig) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.Encryption = encryption })
+}
+
+// ColumnLayout creates a configuration option which sets the physical order of
+// column chunks within the row groups produced by writers.
+//
+// The column chunks of the columns at the given dot-separated paths are
+// written first, in the order of the arguments, followed by the other column
+// chunks in the order of the schema. Placing the columns which are frequently
+// read together at the front of row groups makes their column chunks adjacent
+// in the files, so common projections are served by fewer and larger range
+// reads. The order of columns in the schema and metadata is unchanged, readers
+// locate column chunks by their offsets.
+//
+// If a path does not exist in the schema, the error is returned when writers
+// flush row groups, for example by the Flush and Close methods.
+//
+// By default, column chunks are written in the order of the schema.
+func ColumnLayout(paths ...string) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.ColumnLayout = paths })
+}
+
+// ClusterRows creates a configuration option which sets the clustering
+// expression used to order the rows of row groups produced by writers.
+//
+// The writers buffer the rows of each row group in memory until it is flushed,
+// then write the rows in the order of their clustering keys. The memory held
+// by the buffered rows is bounded by the MaxRowsPerRowGroup option and the
+// flush policy of the application, and is accounted for by the BufferedBytes
+// method of writers.
+//
+// Since the rows are reordered, the row groups of writers configured with a
+// clustering expression never declare sorting columns.
+//
+// Writers panic if the clustering expression refers to columns which do not
+// exist in their schema.
+func ClusterRows(clustering Clustering) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.Clustering = clustering })
+}
+
+// WriterClock creates a configuration option which sets the function used by
+// writers to get the current time when applying the FlushInterval option.
+//
+// Defaults to time.Now.
+func WriterClock(now func() time.Time) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.Clock = now })
+}
+
+// KeepLastDuplicatedRow configures which of the duplicated rows is retained
+// when sorting a Buffer, or writing rows with a SortingWriter, configured with
+// DropDuplicatedRows. By default, the first row written is retained; when
+// enabled, the last row written is retained instead, which implements "last
+// write wins" semantics.
+//
+// Defaults to false
+func KeepLastDuplicatedRow(keepLast bool) SortingOption {
+	return sortingOption(func(config *SortingConfig) { config.KeepLastDuplicatedRow = keepLast })
+}
+
+// SequentialFieldIDs is a schema option which assigns sequential field IDs to
+// all the fields of a schema, starting at 1.
+//
+// IDs are assigned in the order expected by Apache Iceberg: all the fields of
+// a group receive consecutive IDs before their children are numbered, and the
+// element of LIST types or the key and value of MAP types are numbered as if
+// they were direct children of the field. Field IDs declared with the id(n)
+// struct tag are replaced.
+//
+// Parquet files written with such schemas can be registered into Iceberg
+// tables without being rewritten. See NameMappingOf for files which do not
+// embed field IDs.
+//
+// Defaults to false.
+func SequentialFieldIDs(enabled bool) SchemaOption {
+	return schemaOption(func(config *SchemaConfig) { config.SequentialFieldIDs = enabled })
+}
+
+// SchemaColumnOrder is a schema option which orders the fields of groups with
+// the given policy when constructing schemas from Go types.
+//
+// By default, the fields retain the declaration order of the Go struct fields.
+func SchemaColumnOrder(order ColumnOrder) SchemaOption {
+	return schemaOption(func(config *SchemaConfig) { config.ColumnOrder = order })
+}
+
+type schemaOption func(*SchemaConfig)
+
+func (opt schemaOption) ConfigureSchema(config *SchemaConfig) { opt(config) }
+
 type fileOption func(*FileConfig)
 
 func (opt fileOption) ConfigureFile(config *FileConfig) { opt(config) }
@@ -702,6 +1357,62 @@ type sortingOption func(*SortingConfig)
 
 func (opt sortingOption) ConfigureSorting(config *SortingConfig) { opt(config) }
 
+func coalesceDuration(d1, d2 time.Duration) time.Duration {
+	if d1 != 0 {
+		return d1
+	}
+	return d2
+}
+
+func coalesceClock(c1, c2 func() time.Time) func() time.Time {
+	if c1 != nil {
+		return c1
+	}
+	return c2
+}
+
+func coalesceDictionaryFallbackFunc(f1, f2 func(DictionaryFallback)) func(DictionaryFallback) {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
+func coalesceFileResolver(r1, r2 func(string) (io.ReaderAt, error)) func(string) (io.ReaderAt, error) {
+	if r1 != nil {
+		return r1
+	}
+	return r2
+}
+
+func coalesceEnumColumns(e1, e2 []EnumColumn) []EnumColumn {
+	if e1 != nil {
+		return e1
+	}
+	return e2
+}
+
+func coalesceUTF8Policy(p1, p2 UTF8Policy) UTF8Policy {
+	if p1 != UTF8PassThrough {
+		return p1
+	}
+	return p2
+}
+
+func coalesceNarrowingPolicy(p1, p2 NarrowingPolicy) NarrowingPolicy {
+	if p1 != NarrowingChecked {
+		return p1
+	}
+	return p2
+}
+
+func coalesceColumnOrder(o1, o2 ColumnOrder) ColumnOrder {
+	if o1 != nil {
+		return o1
+	}
+	return o2
+}
+
 func coalesceBool(i1, i2 bool) bool {
 	return i1 || i2
 }
@@ -741,6 +1452,13 @@ func coalesceBufferPool(p1, p2 BufferPool) BufferPool {
 	return p2
 }
 
+func coalesceDecompressionBufferPool(p1, p2 DecompressionBufferPool) DecompressionBufferPool {
+	if p1 != nil {
+		return p1
+	}
+	return p2
+}
+
 func coalesceSchema(s1, s2 *Schema) *Schema {
 	if s1 != nil {
 		return s1
@@ -755,11 +1473,47 @@ func coalesceSortingColumns(s1, s2 []SortingColumn) []SortingColumn {
 	return s2
 }
 
+func coalesceColumnDefaults(d1, d2 ColumnDefaults) ColumnDefaults {
+	if d1 != nil {
+		return d1
+	}
+	return d2
+}
+
+func coalesceHooks(h1, h2 map[string]func(Value) (Value, error)) map[string]func(Value) (Value, error) {
+	if h1 != nil {
+		return h1
+	}
+	return h2
+}
+
+func coalescePageFilters(f1, f2 []PageFilter) []PageFilter {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
+func coalescePath(p1, p2 []string) []string {
+	if p1 != nil {
+		return p1
+	}
+	return p2
+}
+
+func coalesceStrings(s1, s2 []string) []string {
+	if s1 != nil {
+		return s1
+	}
+	return s2
+}
+
 func coalesceSortingConfig(c1, c2 SortingConfig) SortingConfig {
 	return SortingConfig{
-		SortingBuffers:     coalesceBufferPool(c1.SortingBuffers, c2.SortingBuffers),
-		SortingColumns:     coalesceSortingColumns(c1.SortingColumns, c2.SortingColumns),
-		DropDuplicatedRows: c1.DropDuplicatedRows,
+		SortingBuffers:        coalesceBufferPool(c1.SortingBuffers, c2.SortingBuffers),
+		SortingColumns:        coalesceSortingColumns(c1.SortingColumns, c2.SortingColumns),
+		DropDuplicatedRows:    c1.DropDuplicatedRows,
+		KeepLastDuplicatedRow: c1.KeepLastDuplicatedRow,
 	}
 }
 
@@ -770,6 +1524,20 @@ func coalesceBloomFilters(f1, f2 []BloomFilterColumn) []BloomFilterColumn {
 	return f2
 }
 
+func coalesceClustering(c1, c2 Clustering) Clustering {
+	if c1 != nil {
+		return c1
+	}
+	return c2
+}
+
+func coalesceEncryptionConfig(c1, c2 *EncryptionConfig) *EncryptionConfig {
+	if c1 != nil {
+		return c1
+	}
+	return c2
+}
+
 func coalesceCompression(c1, c2 compress.Codec) compress.Codec {
 	if c1 != nil {
 		return c1
@@ -791,6 +1559,27 @@ func validatePositiveInt64(optionName string, optionValue int64) error {
 	return errorInvalidOptionValue(optionName, optionValue)
 }
 
+func validateNotNegativeInt(optionName string, optionValue int) error {
+	if optionValue >= 0 {
+		return nil
+	}
+	return errorInvalidOptionValue(optionName, optionValue)
+}
+
+func validateNotNegativeInt64(optionName string, optionValue int64) error {
+	if optionValue >= 0 {
+		return nil
+	}
+	return errorInvalidOptionValue(optionName, optionValue)
+}
+
+func validateNotNegativeDuration(optionName string, optionValue time.Duration) error {
+	if optionValue >= 0 {
+		return nil
+	}
+	return errorInvalidOptionValue(optionName, optionValue)
+}
+
 func validateOneOfInt(optionName string, optionValue int, supportedValues ...int) error {
 	for _, value := range supportedValues {
 		if value == optionValue {
@@ -800,6 +1589,14 @@ func validateOneOfInt(optionName string, optionValue int, supportedValues ...int
 	return errorInvalidOptionValue(optionName, optionValue)
 }
 
+func validateUTF8Policy(optionName string, policy UTF8Policy) error {
+	return validateOneOfInt(optionName, int(policy), int(UTF8PassThrough), int(UTF8Validate), int(UTF8Replace))
+}
+
+func validateNarrowingPolicy(optionName string, policy NarrowingPolicy) error {
+	return validateOneOfInt(optionName, int(policy), int(NarrowingChecked), int(NarrowingTruncate), int(NarrowingReject))
+}
+
 func validateNotNil(optionName string, optionValue interface{}) error {
 	if optionValue != nil {
 		return nil
@@ -848,6 +1645,7 @@ func (err *invalidConfiguration) Error() string {
 }
 
 var (
+	_ SchemaOption   = (*SchemaConfig)(nil)
 	_ FileOption     = (*FileConfig)(nil)
 	_ ReaderOption   = (*ReaderConfig)(nil)
 	_ WriterOption   = (*WriterConfig)(nil)
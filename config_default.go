@@ -0,0 +1,12 @@
+//go:build !wasm && !parquet.lowmem
+
+package parquet
+
+const (
+	DefaultColumnBufferCapacity    = 16 * 1024
+	DefaultPageBufferSize          = 256 * 1024
+	DefaultWriteBufferSize         = 32 * 1024
+	DefaultBloomFilterMemoryBudget = 16 * 1024 * 1024
+
+	defaultColumnBufferChunkSize = 256 * 1024
+)
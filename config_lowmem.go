@@ -0,0 +1,29 @@
+//go:build wasm || parquet.lowmem
+
+package parquet
+
+// The low memory profile is selected when compiling to WebAssembly, or with the
+// parquet.lowmem build tag. Programs running in constrained environments like
+// browsers have a small heap which cannot be returned to the host, so the
+// buffers allocated by default are an order of magnitude smaller; it trades
+// throughput for a lower peak memory usage, and the defaults can still be
+// overridden with the reader and writer options.
+//
+// WebAssembly builds always use the portable implementations of the encodings
+// and algorithms, the assembly code paths are only compiled on amd64 (and may
+// also be disabled with the purego build tag).
+//
+// The conversions between slices of values and their byte representation done
+// with the unsafe package are kept in this profile: they do not depend on the
+// target architecture, they avoid copying the page buffers (which would raise
+// the memory usage this profile intends to reduce), and the column buffers and
+// sparse arrays are built around them. The WebAssembly smoke tests exercise
+// these code paths.
+const (
+	DefaultColumnBufferCapacity    = 1024
+	DefaultPageBufferSize          = 32 * 1024
+	DefaultWriteBufferSize         = 4 * 1024
+	DefaultBloomFilterMemoryBudget = 1024 * 1024
+
+	defaultColumnBufferChunkSize = 32 * 1024
+)
@@ -23,6 +23,8 @@ type ConvertError struct {
 	Path []string
 	From Node
 	To   Node
+	// The reason why the conversion is impossible, may be nil.
+	Err error
 }
 
 // Error satisfies the error interface.
@@ -33,15 +35,22 @@ func (e *ConvertError) Error() string {
 	sourceRepetition := fieldRepetitionTypeOf(e.From)
 	targetRepetition := fieldRepetitionTypeOf(e.To)
 
-	return fmt.Sprintf("cannot convert parquet column %q from %s %s to %s %s",
+	msg := fmt.Sprintf("cannot convert parquet column %q from %s %s to %s %s",
 		columnPath(e.Path),
 		sourceRepetition,
 		sourceType,
 		targetRepetition,
 		targetType,
 	)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
 }
 
+// Unwrap returns the reason why the conversion is impossible.
+func (e *ConvertError) Unwrap() error { return e.Err }
+
 // Conversion is an interface implemented by types that provide conversion of
 // parquet rows from one schema to another.
 //
@@ -83,7 +92,29 @@ func convertToSelf(column []Value) error { return nil }
 func convertToType(targetType, sourceType Type) conversionFunc {
 	return func(column []Value) error {
 		for i, v := range column {
-			v, err := sourceType.ConvertValue(v, targetType)
+			v, err := targetType.ConvertValue(v, sourceType)
+			if err != nil {
+				return err
+			}
+			column[i].ptr = v.ptr
+			column[i].u64 = v.u64
+			column[i].kind = v.kind
+		}
+		return nil
+	}
+}
+
+//go:noinline
+func convertToTypeChecked(targetType, sourceType Type) conversionFunc {
+	return func(column []Value) error {
+		for i, v := range column {
+			if v.IsNull() {
+				continue
+			}
+			if !fitsInType(v, targetType, sourceType) {
+				return fmt.Errorf("%w: value %v does not fit in %s", ErrNarrowingConversion, v, targetType)
+			}
+			v, err := targetType.ConvertValue(v, sourceType)
 			if err != nil {
 				return err
 			}
@@ -236,15 +267,225 @@ func (id identity) Schema() *Schema                 { return id.schema }
 // The function supports converting between schemas where the source or target
 // have extra columns; if there are more columns in the source, they will be
 // stripped out of the rows. Extra columns in the target schema will be set to
-// null or zero values.
+// the default values of the target schema (see ColumnDefaults), or to null or
+// zero values.
 //
 // The returned function is intended to be used to append the converted source
 // row to the destination buffer.
 func Convert(to, from Node) (conv Conversion, err error) {
+	return convert(to, from, convertToTypeOf, nil)
+}
+
+func convertToTypeOf(targetType, sourceType Type) (conversionFunc, error) {
+	return convertToType(targetType, sourceType), nil
+}
+
+// NarrowingPolicy defines how readers convert the values of columns to the
+// narrower types of the schema they read rows into, for example when reading
+// an INT64 column into a Go field of type int32, a DOUBLE column into a field
+// of type float32, floating point columns into integer fields, or INT64
+// columns into floating point fields.
+//
+// Conversions to wider types (INT32 to INT64, FLOAT to DOUBLE) are always
+// applied automatically.
+//
+// NarrowingPolicy values implement the ReaderOption interface, they can be
+// passed directly to the reader constructors, for example:
+//
+//	reader := parquet.NewGenericReader[Row](file, parquet.NarrowingTruncate)
+type NarrowingPolicy int
+
+const (
+	// NarrowingChecked causes readers to return errors wrapping
+	// ErrNarrowingConversion when a value does not fit in the narrower type,
+	// when converting a floating point value to an integer would drop its
+	// fractional part, or when an integer has no exact floating point
+	// representation. This is the default policy.
+	NarrowingChecked NarrowingPolicy = iota
+
+	// NarrowingTruncate converts values like Go conversions between numeric
+	// types do, integers are truncated, the fractional part of floating point
+	// values converted to integers is dropped, and floating point values which
+	// do not fit become infinite.
+	NarrowingTruncate
+
+	// NarrowingReject causes readers to reject schemas which require narrowing
+	// conversions, all reads return errors wrapping ErrNarrowingConversion.
+	NarrowingReject
+)
+
+// String returns a human-readable representation of p.
+func (p NarrowingPolicy) String() string {
+	switch p {
+	case NarrowingChecked:
+		return "checked"
+	case NarrowingTruncate:
+		return "truncate"
+	case NarrowingReject:
+		return "reject"
+	default:
+		return "NarrowingPolicy(?)"
+	}
+}
+
+// ConfigureReader satisfies the ReaderOption interface.
+func (p NarrowingPolicy) ConfigureReader(config *ReaderConfig) { config.Narrowing = p }
+
+func (p NarrowingPolicy) convertToTypeOf(targetType, sourceType Type) (conversionFunc, error) {
+	if p == NarrowingTruncate || !isNarrowingConversion(targetType.Kind(), sourceType.Kind()) {
+		return convertToType(targetType, sourceType), nil
+	}
+	if p == NarrowingReject {
+		return nil, fmt.Errorf("%w: %s to %s", ErrNarrowingConversion, sourceType, targetType)
+	}
+	return convertToTypeChecked(targetType, sourceType), nil
+}
+
+func isNarrowingConversion(targetKind, sourceKind Kind) bool {
+	switch sourceKind {
+	case Int32:
+		return targetKind == Float
+	case Int64:
+		return targetKind == Int32 || targetKind == Float || targetKind == Double
+	case Float:
+		return targetKind == Int32 || targetKind == Int64
+	case Double:
+		return targetKind == Int32 || targetKind == Int64 || targetKind == Float
+	default:
+		return false
+	}
+}
+
+// fitsInType returns true if the value of the source type can be converted to
+// the target type without overflowing, without dropping the fractional part of
+// floating point values, and without rounding integers to floating point
+// values. Values converted from DOUBLE to FLOAT may still lose precision.
+func fitsInType(v Value, targetType, sourceType Type) bool {
+	switch sourceType.Kind() {
+	case Float, Double:
+		f := v.double()
+		if v.Kind() == Float {
+			f = float64(v.float())
+		}
+		switch targetType.Kind() {
+		case Int32, Int64:
+			lower, upper := integerBoundsOf(targetType)
+			return f == math.Trunc(f) && f >= lower && f < upper
+		case Float:
+			return math.IsInf(f, 0) || math.IsNaN(f) || math.Abs(f) <= math.MaxFloat32
+		}
+	case Int32, Int64:
+		if lt := sourceType.LogicalType(); lt != nil && lt.Integer != nil && !lt.Integer.IsSigned {
+			u := v.uint64()
+			if v.Kind() == Int32 {
+				u = uint64(v.uint32())
+			}
+			return unsignedFitsInType(u, targetType)
+		}
+		n := v.int64()
+		if v.Kind() == Int32 {
+			n = int64(v.int32())
+		}
+		return signedFitsInType(n, targetType)
+	}
+	return true
+}
+
+func signedFitsInType(n int64, targetType Type) bool {
+	switch targetType.Kind() {
+	case Int32:
+		minValue, maxValue := int64(math.MinInt32), int64(math.MaxInt32)
+		if lt := targetType.LogicalType(); lt != nil && lt.Integer != nil {
+			bitWidth := uint(lt.Integer.BitWidth)
+			if lt.Integer.IsSigned {
+				minValue, maxValue = -1<<(bitWidth-1), 1<<(bitWidth-1)-1
+			} else {
+				minValue, maxValue = 0, 1<<bitWidth-1
+			}
+		}
+		return n >= minValue && n <= maxValue
+	case Float:
+		f := float32(n)
+		return f < 1<<63 && int64(f) == n
+	case Double:
+		f := float64(n)
+		return f < 1<<63 && int64(f) == n
+	default:
+		return true
+	}
+}
+
+func unsignedFitsInType(u uint64, targetType Type) bool {
+	switch targetType.Kind() {
+	case Int32:
+		return u <= math.MaxInt64 && signedFitsInType(int64(u), targetType)
+	case Float:
+		f := float32(u)
+		return f < 1<<64 && uint64(f) == u
+	case Double:
+		f := float64(u)
+		return f < 1<<64 && uint64(f) == u
+	default:
+		return true
+	}
+}
+
+// integerBoundsOf returns the range of values of the integer type t, the upper
+// bound is exclusive. Both bounds are powers of two which floating point values
+// represent exactly.
+func integerBoundsOf(t Type) (lower, upper float64) {
+	bitWidth, signed := 64, true
+	if t.Kind() == Int32 {
+		bitWidth = 32
+	}
+	if lt := t.LogicalType(); lt != nil && lt.Integer != nil {
+		bitWidth, signed = int(lt.Integer.BitWidth), lt.Integer.IsSigned
+	}
+	if signed {
+		return -math.Ldexp(1, bitWidth-1), math.Ldexp(1, bitWidth-1)
+	}
+	return 0, math.Ldexp(1, bitWidth)
+}
+
+// sourcePathOf returns the path of the column of from which matches path. The
+// repeated groups of maps are matched regardless of their names since files
+// written by older parquet writers did not all name them "key_value".
+func sourcePathOf(from Node, path columnPath) columnPath {
+	sourcePath := path
+	node := from
+	for i, name := range path {
+		if node == nil || node.Leaf() {
+			break
+		}
+		field := fieldByName(node, name)
+		if field == nil && isMap(node) {
+			if fields := node.Fields(); len(fields) == 1 && fields[0].Repeated() {
+				field = fields[0]
+				sourcePath = append(sourcePath[:i:i], field.Name())
+				sourcePath = append(sourcePath, path[i+1:]...)
+			}
+		}
+		if field == nil {
+			break
+		}
+		node = field
+	}
+	return sourcePath
+}
+
+// convert constructs the conversion from one schema to another, calling
+// convertType to obtain the functions converting the values of columns which
+// have different types in the two schemas. Columns which only exist in the
+// target schema are set to their value in defaults, which are the defaults of
+// the target schema when nil, or to zero values.
+func convert(to, from Node, convertType func(targetType, sourceType Type) (conversionFunc, error), defaults ColumnDefaults) (Conversion, error) {
 	schema, _ := to.(*Schema)
 	if schema == nil {
 		schema = NewSchema("", to)
 	}
+	if defaults == nil {
+		defaults = schema.defaults
+	}
 
 	if nodesAreEqual(to, from) {
 		return identity{schema}, nil
@@ -255,17 +496,25 @@ func Convert(to, from Node) (conv Conversion, err error) {
 	columns := make([]conversionColumn, len(targetColumns))
 
 	for i, path := range targetColumns {
+		sourcePath := sourcePathOf(from, path)
 		targetColumn := targetMapping.lookup(path)
-		sourceColumn := sourceMapping.lookup(path)
+		sourceColumn := sourceMapping.lookup(sourcePath)
 
 		conversions := []conversionFunc{}
 		if sourceColumn.node != nil {
 			targetType := targetColumn.node.Type()
 			sourceType := sourceColumn.node.Type()
 			if !typesAreEqual(targetType, sourceType) {
-				conversions = append(conversions,
-					convertToType(targetType, sourceType),
-				)
+				convertValues, err := convertType(targetType, sourceType)
+				if err != nil {
+					return nil, &ConvertError{
+						Path: path,
+						From: sourceColumn.node,
+						To:   targetColumn.node,
+						Err:  err,
+					}
+				}
+				conversions = append(conversions, convertValues)
 			}
 
 			repetitionLevels := make([]byte, len(path)+1)
@@ -279,7 +528,7 @@ func Convert(to, from Node) (conv Conversion, err error) {
 
 			for j := 0; j < len(path); j++ {
 				targetNode = fieldByName(targetNode, path[j])
-				sourceNode = fieldByName(sourceNode, path[j])
+				sourceNode = fieldByName(sourceNode, sourcePath[j])
 
 				targetRepetitionLevel, targetDefinitionLevel = applyFieldRepetitionType(
 					fieldRepetitionTypeOf(targetNode),
@@ -309,7 +558,22 @@ func Convert(to, from Node) (conv Conversion, err error) {
 			targetType := targetColumn.node.Type()
 			targetKind := targetType.Kind()
 			sourceColumn = sourceMapping.lookupClosest(path)
-			if sourceColumn.node != nil {
+			defaultValue, hasDefault, err := defaults.lookup(path, targetType)
+			if err != nil {
+				return nil, err
+			}
+			if hasDefault {
+				parentDefinitionLevel := byte(0)
+				if sourceColumn.node != nil {
+					parentDefinitionLevel = sourceColumn.maxDefinitionLevel
+					if !sourceColumn.node.Required() {
+						parentDefinitionLevel--
+					}
+				}
+				conversions = append(conversions,
+					convertToDefault(defaultValue, parentDefinitionLevel, targetColumn.maxDefinitionLevel),
+				)
+			} else if sourceColumn.node != nil {
 				conversions = append(conversions,
 					convertToZero(targetKind),
 				)
@@ -481,6 +745,10 @@ type missingBloomFilter struct{}
 func (missingBloomFilter) ReadAt([]byte, int64) (int, error) { return 0, io.EOF }
 func (missingBloomFilter) Size() int64                       { return 0 }
 func (missingBloomFilter) Check(Value) (bool, error)         { return false, nil }
+func (missingBloomFilter) CheckMany(v []Value, found []bool) error {
+	clear(found[:len(v)])
+	return nil
+}
 
 type missingPage struct{ *missingColumnChunk }
 
@@ -971,6 +1239,16 @@ func convertStringToTimeMicros(v Value, tz *time.Location) (Value, error) {
 	return v.convertToInt64(microseconds), nil
 }
 
+func convertStringToTimeNanos(v Value, tz *time.Location) (Value, error) {
+	t, err := time.ParseInLocation("15:04:05.999999999", v.string(), tz)
+	if err != nil {
+		return v, conversionError(v, "STRING", "TIME", err)
+	}
+	m := nearestMidnightLessThan(t)
+	nanoseconds := t.Sub(m).Nanoseconds()
+	return v.convertToInt64(nanoseconds), nil
+}
+
 func convertDateToTimestamp(v Value, u format.TimeUnit, tz *time.Location) (Value, error) {
 	t := unixEpoch.AddDate(0, 0, int(v.int32()))
 	d := timeUnitDuration(u)
@@ -995,6 +1273,25 @@ func convertTimeMicrosToString(v Value, tz *time.Location) (Value, error) {
 	return v.convertToByteArray(b), nil
 }
 
+func convertTimeNanosToString(v Value, tz *time.Location) (Value, error) {
+	t := time.Unix(0, v.int64()).In(tz)
+	b := t.AppendFormat(make([]byte, 0, 18), "15:04:05.999999999")
+	return v.convertToByteArray(b), nil
+}
+
+func convertTimeToTime(v Value, sourceUnit, targetUnit format.TimeUnit) (Value, error) {
+	var d time.Duration
+	if sourceUnit.Millis != nil {
+		d = time.Duration(v.int32()) * time.Millisecond
+	} else {
+		d = time.Duration(v.int64()) * timeUnitDuration(sourceUnit)
+	}
+	if targetUnit.Millis != nil {
+		return v.convertToInt32(int32(d.Milliseconds())), nil
+	}
+	return v.convertToInt64(int64(d / timeUnitDuration(targetUnit))), nil
+}
+
 func convertTimestampToDate(v Value, u format.TimeUnit, tz *time.Location) (Value, error) {
 	t := timestamp(v, u, tz)
 	d := daysSinceUnixEpoch(t)
@@ -1015,6 +1312,13 @@ func convertTimestampToTimeMicros(v Value, u format.TimeUnit, sourceZone, target
 	return v.convertToInt64(int64(microseconds)), nil
 }
 
+func convertTimestampToTimeNanos(v Value, u format.TimeUnit, sourceZone, targetZone *time.Location) (Value, error) {
+	t := timestamp(v, u, sourceZone)
+	m := nearestMidnightLessThan(t)
+	nanoseconds := t.In(targetZone).Sub(m).Nanoseconds()
+	return v.convertToInt64(nanoseconds), nil
+}
+
 func convertTimestampToTimestamp(v Value, sourceUnit, targetUnit format.TimeUnit) (Value, error) {
 	sourceScale := timeUnitDuration(sourceUnit).Nanoseconds()
 	targetScale := timeUnitDuration(targetUnit).Nanoseconds()
@@ -479,6 +479,50 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+func TestConvertColumnType(t *testing.T) {
+	type Int32Row struct {
+		V int32 `parquet:"v"`
+	}
+	type Int64Row struct {
+		V int64 `parquet:"v"`
+	}
+	type StringRow struct {
+		V string `parquet:"v"`
+	}
+	type DoubleRow struct {
+		V float64 `parquet:"v"`
+	}
+
+	tests := []struct {
+		scenario string
+		from     any
+		to       any
+		want     parquet.Value
+	}{
+		{"int32 to int64", Int32Row{V: 42}, Int64Row{}, parquet.Int64Value(42)},
+		{"int32 to double", Int32Row{V: -1}, DoubleRow{}, parquet.DoubleValue(-1)},
+		{"string to int64", StringRow{V: "123"}, Int64Row{}, parquet.Int64Value(123)},
+		{"int64 to string", Int64Row{V: 123}, StringRow{}, parquet.ByteArrayValue([]byte("123"))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			from := parquet.SchemaOf(test.from)
+			conv, err := parquet.Convert(parquet.SchemaOf(test.to), from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows := []parquet.Row{from.Deconstruct(nil, test.from)}
+			if _, err := conv.Convert(rows); err != nil {
+				t.Fatal(err)
+			}
+			if got := rows[0][0]; !parquet.Equal(got.Level(0, 0, 0), test.want) || got.Kind() != test.want.Kind() {
+				t.Errorf("wrong converted value: want=%v (%s) got=%v (%s)", test.want, test.want.Kind(), got, got.Kind())
+			}
+		})
+	}
+}
+
 func newInt64(i int64) *int64    { return &i }
 func newString(s string) *string { return &s }
 
@@ -1076,6 +1120,46 @@ func TestConvertValue(t *testing.T) {
 			toValue:   parquet.ByteArrayValue([]byte(`12:34:56.789012`)),
 		},
 
+		{
+			scenario:  "string to nanosecond time",
+			fromType:  parquet.String().Type(),
+			fromValue: parquet.ByteArrayValue([]byte(`12:34:56.789012345`)),
+			toType:    parquet.Time(parquet.Nanosecond).Type(),
+			toValue:   parquet.Int64Value(45296789012345),
+		},
+
+		{
+			scenario:  "nanosecond time to string",
+			fromType:  parquet.Time(parquet.Nanosecond).Type(),
+			fromValue: parquet.Int64Value(45296789012345),
+			toType:    parquet.String().Type(),
+			toValue:   parquet.ByteArrayValue([]byte(`12:34:56.789012345`)),
+		},
+
+		{
+			scenario:  "millisecond time to nanosecond time",
+			fromType:  parquet.Time(parquet.Millisecond).Type(),
+			fromValue: parquet.Int32Value(45296789),
+			toType:    parquet.Time(parquet.Nanosecond).Type(),
+			toValue:   parquet.Int64Value(45296789000000),
+		},
+
+		{
+			scenario:  "nanosecond time to microsecond time",
+			fromType:  parquet.Time(parquet.Nanosecond).Type(),
+			fromValue: parquet.Int64Value(45296789012345),
+			toType:    parquet.Time(parquet.Microsecond).Type(),
+			toValue:   parquet.Int64Value(45296789012),
+		},
+
+		{
+			scenario:  "nanosecond time to millisecond time",
+			fromType:  parquet.Time(parquet.Nanosecond).Type(),
+			fromValue: parquet.Int64Value(45296789012345),
+			toType:    parquet.Time(parquet.Millisecond).Type(),
+			toValue:   parquet.Int32Value(45296789),
+		},
+
 		{
 			scenario:  "millisecond timestamp to date",
 			fromType:  parquet.Timestamp(parquet.Millisecond).Type(),
@@ -1124,6 +1208,14 @@ func TestConvertValue(t *testing.T) {
 			toValue:   parquet.Int64Value(85413123456),
 		},
 
+		{
+			scenario:  "microsecond timestamp to nanosecond time",
+			fromType:  parquet.Timestamp(parquet.Microsecond).Type(),
+			fromValue: parquet.Int64Value(1670888613123456),
+			toType:    parquet.Time(parquet.Nanosecond).Type(),
+			toValue:   parquet.Int64Value(85413123456000),
+		},
+
 		{
 			scenario:  "micros to nanos",
 			fromType:  usType,
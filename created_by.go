@@ -0,0 +1,152 @@
+package parquet
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ApplicationVersion represents the application which wrote a parquet file,
+// as recorded in the CreatedBy field of the file metadata.
+//
+// Writers format the field as "<application> version <version> (build <build>)"
+// (see the CreatedBy writer option), the version is parsed as a semantic
+// version and the numeric components which could not be parsed are zero.
+type ApplicationVersion struct {
+	Application string
+	Version     string
+	Build       string
+	Major       int
+	Minor       int
+	Patch       int
+}
+
+// ParseApplicationVersion parses the CreatedBy field of parquet file metadata.
+//
+// The parser is lenient since writers do not always follow the convention; the
+// application is set to the whole string when it has no version, and the
+// version and build are empty when they are missing.
+func ParseApplicationVersion(createdBy string) ApplicationVersion {
+	v := ApplicationVersion{}
+	createdBy = strings.TrimSpace(createdBy)
+
+	application, version, hasVersion := strings.Cut(createdBy, " version ")
+	v.Application = strings.TrimSpace(application)
+	if !hasVersion {
+		return v
+	}
+
+	if i := strings.IndexByte(version, '('); i >= 0 {
+		build := strings.TrimSpace(version[i+1:])
+		build = strings.TrimSuffix(build, ")")
+		build = strings.TrimPrefix(build, "build")
+		v.Build = strings.TrimSpace(build)
+		version = version[:i]
+	}
+	v.Version = strings.TrimSpace(version)
+
+	// Pre-release and build metadata suffixes of semantic versions are not
+	// considered when comparing versions.
+	semver := v.Version
+	if i := strings.IndexAny(semver, "-+ "); i >= 0 {
+		semver = semver[:i]
+	}
+	numbers := [3]*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range strings.SplitN(semver, ".", 3) {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		*numbers[i] = n
+	}
+	return v
+}
+
+// String returns the CreatedBy representation of v.
+func (v ApplicationVersion) String() string {
+	if v.Version == "" && v.Build == "" {
+		return v.Application
+	}
+	return formatCreatedBy(v.Application, v.Version, v.Build)
+}
+
+// Before returns true if v is a version of the application strictly lower than
+// major.minor.patch.
+func (v ApplicationVersion) Before(application string, major, minor, patch int) bool {
+	if v.Application != application {
+		return false
+	}
+	if v.Major != major {
+		return v.Major < major
+	}
+	if v.Minor != minor {
+		return v.Minor < minor
+	}
+	return v.Patch < patch
+}
+
+// HasCorrectStatistics returns true if the min and max statistics of columns
+// of the given type written by v can be trusted.
+//
+// The method consults a table of known writer bugs, for example parquet-mr
+// could corrupt the statistics of binary columns before 1.8.0 (PARQUET-251),
+// and both parquet-mr before 1.10.0 and parquet-cpp before 1.3.0 used signed
+// comparisons for all types, including those ordered by unsigned comparisons
+// like strings and unsigned integers. Statistics of
+// types which have no defined order (e.g. INT96 and INTERVAL) are never
+// considered correct.
+func (v ApplicationVersion) HasCorrectStatistics(t Type) bool {
+	order := SortOrderOf(t)
+	if order == UndefinedSortOrder {
+		return false
+	}
+	for _, quirk := range writerQuirks {
+		if v.Before(quirk.application, quirk.major, quirk.minor, quirk.patch) && quirk.incorrectStatistics(t, order) {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplicationVersion returns the application which wrote f, parsed from the
+// CreatedBy field of its metadata.
+func (f *File) ApplicationVersion() ApplicationVersion {
+	return ParseApplicationVersion(f.metadata.CreatedBy)
+}
+
+// writerQuirk is an entry of the table of known writer bugs that readers work
+// around, the bugs affect versions of the application lower than the version
+// where they were fixed.
+type writerQuirk struct {
+	application         string
+	major, minor, patch int
+	incorrectStatistics func(Type, SortOrder) bool
+}
+
+var writerQuirks = [...]writerQuirk{
+	// PARQUET-251: the min and max statistics of binary columns could be
+	// corrupted by reusing the buffers of values.
+	{
+		application:         "parquet-mr",
+		major:               1,
+		minor:               8,
+		incorrectStatistics: func(t Type, _ SortOrder) bool { return isByteArrayKind(t.Kind()) },
+	},
+	// PARQUET-686: statistics were computed with signed comparisons regardless
+	// of the sort order of the column type.
+	{
+		application:         "parquet-mr",
+		major:               1,
+		minor:               10,
+		incorrectStatistics: func(_ Type, order SortOrder) bool { return order != SignedSortOrder },
+	},
+	{
+		application:         "parquet-cpp",
+		major:               1,
+		minor:               3,
+		incorrectStatistics: func(_ Type, order SortOrder) bool { return order != SignedSortOrder },
+	},
+}
+
+func isByteArrayKind(kind Kind) bool {
+	return kind == ByteArray || kind == FixedLenByteArray
+}
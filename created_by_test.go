@@ -0,0 +1,199 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestParseApplicationVersion(t *testing.T) {
+	tests := []struct {
+		createdBy string
+		version   parquet.ApplicationVersion
+	}{
+		{
+			createdBy: "parquet-mr version 1.8.0 (build 0fda28af84b9746396014ad6a415b90592a98b3b)",
+			version: parquet.ApplicationVersion{
+				Application: "parquet-mr",
+				Version:     "1.8.0",
+				Build:       "0fda28af84b9746396014ad6a415b90592a98b3b",
+				Major:       1,
+				Minor:       8,
+			},
+		},
+		{
+			createdBy: "parquet-cpp-arrow version 14.0.2",
+			version: parquet.ApplicationVersion{
+				Application: "parquet-cpp-arrow",
+				Version:     "14.0.2",
+				Major:       14,
+				Patch:       2,
+			},
+		},
+		{
+			createdBy: "github.com/parquet-go/parquet-go version 0.23.0(build abc)",
+			version: parquet.ApplicationVersion{
+				Application: "github.com/parquet-go/parquet-go",
+				Version:     "0.23.0",
+				Build:       "abc",
+				Minor:       23,
+			},
+		},
+		{
+			createdBy: "parquet-mr version 1.10.0-SNAPSHOT",
+			version: parquet.ApplicationVersion{
+				Application: "parquet-mr",
+				Version:     "1.10.0-SNAPSHOT",
+				Major:       1,
+				Minor:       10,
+			},
+		},
+		{
+			createdBy: "impala",
+			version:   parquet.ApplicationVersion{Application: "impala"},
+		},
+		{
+			createdBy: "",
+			version:   parquet.ApplicationVersion{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.createdBy, func(t *testing.T) {
+			if version := parquet.ParseApplicationVersion(test.createdBy); version != test.version {
+				t.Errorf("wrong application version:\nwant = %+v\ngot  = %+v", test.version, version)
+			}
+		})
+	}
+}
+
+func TestApplicationVersionHasCorrectStatistics(t *testing.T) {
+	tests := []struct {
+		createdBy string
+		node      parquet.Node
+		correct   bool
+	}{
+		{"parquet-mr version 1.7.0", parquet.Int(64), true},
+		{"parquet-mr version 1.7.0", parquet.Leaf(parquet.ByteArrayType), false},
+		{"parquet-mr version 1.9.0", parquet.String(), false},
+		{"parquet-mr version 1.9.0", parquet.Uint(32), false},
+		{"parquet-mr version 1.10.0", parquet.String(), true},
+		{"parquet-cpp version 1.2.0", parquet.Int(32), true},
+		{"parquet-cpp version 1.2.0", parquet.String(), false},
+		{"parquet-cpp version 1.3.0", parquet.String(), true},
+		{"parquet-cpp-arrow version 14.0.2", parquet.Uint(64), true},
+		{"parquet-cpp-arrow version 14.0.2", parquet.Leaf(parquet.Int96Type), false},
+	}
+
+	for _, test := range tests {
+		version := parquet.ParseApplicationVersion(test.createdBy)
+		if correct := version.HasCorrectStatistics(test.node.Type()); correct != test.correct {
+			t.Errorf("%s: %s: statistics correct = %t, want %t", test.createdBy, test.node.Type(), correct, test.correct)
+		}
+	}
+}
+
+func TestFileColumnChunkBounds(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	rows := []Row{{ID: 3, Name: "c"}, {ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+
+	for _, test := range []struct {
+		version      string
+		trustedNames bool
+	}{
+		{"1.7.0", false},
+		{"1.12.3", true},
+	} {
+		t.Run(test.version, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := parquet.Write(buf, rows, parquet.CreatedBy("parquet-mr", test.version, "")); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if app := f.ApplicationVersion().Application; app != "parquet-mr" {
+				t.Fatalf("wrong application: %q", app)
+			}
+			chunks := f.RowGroups()[0].ColumnChunks()
+
+			min, max, ok := chunks[0].(*parquet.FileColumnChunk).Bounds()
+			if !ok || min.Int64() != 1 || max.Int64() != 3 {
+				t.Errorf("wrong bounds of id column: %v, %v, %t", min, max, ok)
+			}
+
+			min, max, ok = chunks[1].(*parquet.FileColumnChunk).Bounds()
+			if ok != test.trustedNames {
+				t.Fatalf("bounds of name column trusted = %t, want %t", ok, test.trustedNames)
+			}
+			if ok && (string(min.ByteArray()) != "a" || string(max.ByteArray()) != "c") {
+				t.Errorf("wrong bounds of name column: %v, %v", min, max)
+			}
+		})
+	}
+}
+
+func TestFilterPagesOfBuggyWriters(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name,dict"`
+	}
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i].Name = fmt.Sprintf("name-%03d", i)
+	}
+
+	for _, test := range []struct {
+		version string
+		pruned  bool
+	}{
+		{"1.7.0", false},
+		{"1.12.3", true},
+	} {
+		t.Run(test.version, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := parquet.Write(buf, rows,
+				parquet.CreatedBy("parquet-mr", test.version, ""),
+				parquet.PageBufferSize(64),
+				parquet.SortedDictionaries("name"),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunk := f.RowGroups()[0].ColumnChunks()[0]
+
+			selection := parquet.FilterPages(chunk, func(min, max []byte, nullPage bool) bool {
+				return string(min) <= "name-010" && string(max) >= "name-010"
+			})
+			if pruned := selection.NumRows() < int64(len(rows)); pruned != test.pruned {
+				t.Errorf("pages pruned = %t, want %t (selection: %v)", pruned, test.pruned, selection)
+			}
+
+			selection, err = parquet.ScanColumnPrefix(chunk, []byte("name-01"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(selection, parquet.RowSelection{{Start: 10, End: 20}}) {
+				t.Errorf("wrong selection of prefix scan: %v", selection)
+			}
+
+			ok, err := chunk.(*parquet.FileColumnChunk).MayContain(parquet.ValueOf("name-042"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Error("column chunk does not contain a value that was written")
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package parquet
+
+import (
+	"slices"
+)
+
+// DecompressionBufferPool is an interface abstracting the pools of buffers that
+// compressed pages are decompressed into when reading parquet files.
+//
+// By default, files share a pool of buffers backed by sync.Pool, which retains
+// buffers of all sizes until the garbage collector releases them. Programs
+// running many concurrent readers may install their own pool with the
+// DecompressionBuffers file option to bound the memory retained between reads,
+// or to share buffers between files of similar page sizes.
+//
+// DecompressionBufferPool implementations must be safe to use concurrently from
+// multiple goroutines.
+type DecompressionBufferPool interface {
+	// GetDecompressionBuffer returns a buffer of the given length. The buffer
+	// may have a larger capacity, which is used if decompressing the page
+	// requires more space than announced in the page header.
+	GetDecompressionBuffer(size int) []byte
+
+	// PutDecompressionBuffer is called to release a buffer to the pool when the
+	// pages decoded from it are not referenced anymore.
+	//
+	// The buffer may not be one that was returned by GetDecompressionBuffer,
+	// when decompressing the page needed to grow it.
+	PutDecompressionBuffer(buf []byte)
+}
+
+// NewDecompressionBufferPool creates a pool of decompression buffers allocated
+// in the given size classes, retaining at most maxRetained buffers of each size
+// class.
+//
+// Buffers are allocated with the capacity of the smallest size class that can
+// hold them. Buffers larger than the largest size class are allocated with the
+// exact size requested, and never retained. When maxRetained is zero or
+// negative, no buffers are retained.
+func NewDecompressionBufferPool(sizeClasses []int, maxRetained int) DecompressionBufferPool {
+	sizes := slices.Clone(sizeClasses)
+	slices.Sort(sizes)
+	sizes = slices.Compact(sizes)
+	for len(sizes) > 0 && sizes[0] <= 0 {
+		sizes = sizes[1:]
+	}
+	pool := &decompressionBufferPool{
+		sizes:   sizes,
+		buffers: make([]chan []byte, len(sizes)),
+	}
+	for i := range pool.buffers {
+		pool.buffers[i] = make(chan []byte, max(maxRetained, 0))
+	}
+	return pool
+}
+
+type decompressionBufferPool struct {
+	sizes   []int
+	buffers []chan []byte
+}
+
+func (p *decompressionBufferPool) GetDecompressionBuffer(size int) []byte {
+	i, ok := slices.BinarySearch(p.sizes, size)
+	if !ok && i == len(p.sizes) {
+		return make([]byte, size)
+	}
+	select {
+	case buf := <-p.buffers[i]:
+		return buf[:size]
+	default:
+		return make([]byte, size, p.sizes[i])
+	}
+}
+
+func (p *decompressionBufferPool) PutDecompressionBuffer(buf []byte) {
+	// The buffer is retained in the largest size class that it can hold, so it
+	// is always large enough for the sizes returned from this class.
+	i, ok := slices.BinarySearch(p.sizes, cap(buf))
+	if !ok {
+		i--
+	}
+	if i < 0 || cap(buf) > p.sizes[len(p.sizes)-1] {
+		return
+	}
+	select {
+	case p.buffers[i] <- buf[:0]:
+	default:
+	}
+}
@@ -0,0 +1,115 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type countingDecompressionBufferPool struct {
+	parquet.DecompressionBufferPool
+	gets atomic.Int64
+	puts atomic.Int64
+}
+
+func (p *countingDecompressionBufferPool) GetDecompressionBuffer(size int) []byte {
+	p.gets.Add(1)
+	return p.DecompressionBufferPool.GetDecompressionBuffer(size)
+}
+
+func (p *countingDecompressionBufferPool) PutDecompressionBuffer(buf []byte) {
+	p.puts.Add(1)
+	p.DecompressionBufferPool.PutDecompressionBuffer(buf)
+}
+
+func TestDecompressionBuffers(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,snappy"`
+		Name string `parquet:"name,zstd"`
+		Code string `parquet:"code,dict,snappy"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i),
+			Name: "name-" + strconv.Itoa(i),
+			Code: "code-" + strconv.Itoa(i%10),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.PageBufferSize(1024))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := &countingDecompressionBufferPool{
+		DecompressionBufferPool: parquet.NewDecompressionBufferPool([]int{1024, 4096, 16384}, 4),
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()),
+		parquet.DecompressionBuffers(pool),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := parquet.NewGenericReader[Row](f)
+	read := make([]Row, len(rows))
+	if n, err := r.Read(read); n != len(rows) {
+		t.Fatalf("reading rows: %d/%d: %v", n, len(rows), err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, rows) {
+		t.Fatal("rows mismatch")
+	}
+
+	gets, puts := pool.gets.Load(), pool.puts.Load()
+	if gets == 0 {
+		t.Fatal("no buffers were acquired from the decompression buffer pool")
+	}
+	if gets != puts {
+		t.Errorf("buffers acquired and released mismatch: gets=%d puts=%d", gets, puts)
+	}
+}
+
+func TestDecompressionBufferPool(t *testing.T) {
+	pool := parquet.NewDecompressionBufferPool([]int{4096, 1024}, 2)
+
+	for _, test := range []struct {
+		size, cap int
+	}{
+		{size: 0, cap: 1024},
+		{size: 1000, cap: 1024},
+		{size: 1024, cap: 1024},
+		{size: 1025, cap: 4096},
+		{size: 5000, cap: 5000},
+	} {
+		if b := pool.GetDecompressionBuffer(test.size); len(b) != test.size || cap(b) != test.cap {
+			t.Errorf("buffer of size %d: want len=%d cap=%d, got len=%d cap=%d", test.size, test.size, test.cap, len(b), cap(b))
+		}
+	}
+
+	buffers := make([][]byte, 3)
+	for i := range buffers {
+		buffers[i] = make([]byte, 2000)
+		pool.PutDecompressionBuffer(buffers[i])
+	}
+	// Only two buffers are retained in the 1024 bytes size class.
+	for i := 0; i < 3; i++ {
+		b := pool.GetDecompressionBuffer(1000)
+		retained := cap(b) == 2000
+		if retained != (i < 2) {
+			t.Errorf("buffer %d: retained=%t", i, retained)
+		}
+	}
+}
@@ -65,6 +65,70 @@ func (d *dedupeRowWriter) WriteRows(rows []Row) (int, error) {
 	return len(rows), nil
 }
 
+// dedupeLastRowReader is like the reader returned by DedupeRowReader, but it
+// retains the last row of each sequence of duplicated rows instead of the
+// first. The last row read is held until a row which differs is read; only
+// when a batch of rows ends on such a row is it copied out of the memory of
+// the underlying reader, which may be reused by the next read.
+type dedupeLastRowReader struct {
+	reader  RowReader
+	compare func(Row, Row) int
+	rows    []Row
+	last    Row
+	pending bool
+	err     error
+}
+
+func (d *dedupeLastRowReader) ReadRows(rows []Row) (int, error) {
+	n := 0
+
+	for n == 0 && d.err == nil && len(rows) > 0 {
+		if cap(d.rows) < len(rows) {
+			d.rows = make([]Row, len(rows))
+		}
+		r, err := d.reader.ReadRows(d.rows[:len(rows)])
+
+		for _, row := range d.rows[:r] {
+			if d.pending && d.compare(row, d.last) != 0 {
+				rows[n] = d.last
+				n++
+			}
+			d.last, d.pending = row, true
+		}
+		if r > 0 {
+			d.last = d.last.Clone()
+		}
+
+		d.err = err
+	}
+
+	if d.err != nil {
+		if d.pending && n < len(rows) {
+			rows[n] = d.last
+			d.last, d.pending = nil, false
+			n++
+		}
+		if !d.pending {
+			return n, d.err
+		}
+	}
+	return n, nil
+}
+
+// deduplicateLast moves the last row of each sequence of consecutive duplicated
+// rows to the front of rows, preserving their order, and returns their count.
+// The duplicates are moved after them, the rows remain owned by the slice.
+func deduplicateLast(rows []Row, compare func(Row, Row) int) int {
+	n := 0
+	for i := range rows {
+		if i == len(rows)-1 || compare(rows[i], rows[i+1]) != 0 {
+			rows[n], rows[i] = rows[i], rows[n]
+			n++
+		}
+	}
+	return n
+}
+
 type dedupe struct {
 	alloc   rowAllocator
 	lastRow Row
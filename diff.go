@@ -0,0 +1,239 @@
+package parquet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RowDiffKind represents the kind of difference reported by RowDiff values.
+type RowDiffKind int
+
+const (
+	// RowAdded indicates a row which only exists in the second file.
+	RowAdded RowDiffKind = iota
+	// RowRemoved indicates a row which only exists in the first file.
+	RowRemoved
+	// RowChanged indicates a row which exists in both files with different
+	// values in some of its columns.
+	RowChanged
+)
+
+func (k RowDiffKind) String() string {
+	switch k {
+	case RowAdded:
+		return "added"
+	case RowRemoved:
+		return "removed"
+	case RowChanged:
+		return "changed"
+	default:
+		return fmt.Sprintf("RowDiffKind(%d)", int(k))
+	}
+}
+
+// RowDiff describes a difference between the rows of two parquet files, as
+// reported by DiffFiles.
+type RowDiff struct {
+	Kind RowDiffKind
+	// Values of the key columns of the row, in the order of the key columns.
+	Key []Value
+	// Versions of the row in the first and second file. Old is nil for added
+	// rows and New is nil for removed rows.
+	Old Row
+	New Row
+	// Dot-separated paths of the leaf columns which differ between the two
+	// versions of changed rows.
+	Columns []string
+	// Err is set on the last difference reported when comparing the files
+	// failed, in which case the other fields are zero.
+	Err error
+}
+
+// diffFiles compares the rows of a and b, calling yield for each difference
+// until it returns false.
+func diffFiles(a, b *File, keyColumns []string, yield func(RowDiff) bool) error {
+	if len(keyColumns) == 0 {
+		return fmt.Errorf("cannot diff files: no key columns")
+	}
+
+	schema := a.Schema()
+	keys := make([]int, len(keyColumns))
+	sorting := make([]SortingColumn, len(keyColumns))
+	for i, keyColumn := range keyColumns {
+		path := strings.Split(keyColumn, ".")
+		leaf, ok := schema.Lookup(path...)
+		if !ok {
+			return fmt.Errorf("cannot diff files: key column %q does not exist", keyColumn)
+		}
+		if leaf.MaxRepetitionLevel > 0 {
+			return fmt.Errorf("cannot diff files: key column %q is repeated", keyColumn)
+		}
+		keys[i] = leaf.ColumnIndex
+		sorting[i] = Ascending(path...)
+	}
+
+	rowGroupA, err := sortedRowGroupOf(a, sorting)
+	if err != nil {
+		return err
+	}
+	rowGroupB, err := sortedRowGroupOf(b, sorting)
+	if err != nil {
+		return err
+	}
+	if schemaB := rowGroupB.Schema(); !nodesAreEqual(schema, schemaB) {
+		conv, err := Convert(schema, schemaB)
+		if err != nil {
+			return fmt.Errorf("cannot diff files: %w", err)
+		}
+		rowGroupB = ConvertRowGroup(rowGroupB, conv)
+	}
+
+	rowsA := rowGroupA.Rows()
+	defer rowsA.Close()
+	rowsB := rowGroupB.Rows()
+	defer rowsB.Close()
+
+	d := &rowDiffer{
+		compare: compareRowsFuncOf(schema, sorting),
+		columns: schema.Columns(),
+		keys:    keys,
+		valuesA: make([][]Value, len(schema.Columns())),
+		valuesB: make([][]Value, len(schema.Columns())),
+	}
+	d.a.init(rowsA)
+	d.b.init(rowsB)
+	return d.diff(yield)
+}
+
+// sortedRowGroupOf returns a row group exposing the rows of f sorted by the
+// given columns. When all the row groups of the file are already sorted, they
+// are merged on the fly, otherwise the rows are sorted in memory. Both the merge
+// and Buffer.Sort are stable, rows with equal keys are returned in the order
+// they appear in the file.
+func sortedRowGroupOf(f *File, sorting []SortingColumn) (RowGroup, error) {
+	rowGroups := f.RowGroups()
+	config := SortingRowGroupConfig(SortingColumns(sorting...))
+	sorted := true
+	for _, rowGroup := range rowGroups {
+		sorted = sorted && sortingColumnsHavePrefix(rowGroup.SortingColumns(), sorting)
+	}
+	if sorted {
+		if len(rowGroups) == 1 {
+			return rowGroups[0], nil
+		}
+		return MergeRowGroups(rowGroups, config)
+	}
+
+	buffer := NewBuffer(f.Schema(), config)
+	for _, rowGroup := range rowGroups {
+		rows := rowGroup.Rows()
+		_, err := CopyRows(buffer, rows)
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := buffer.Sort(); err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
+
+type rowDiffer struct {
+	compare func(Row, Row) int
+	columns [][]string
+	keys    []int
+	a       peekRowReader
+	b       peekRowReader
+	valuesA [][]Value
+	valuesB [][]Value
+}
+
+func (d *rowDiffer) diff(yield func(RowDiff) bool) error {
+	for {
+		if err := d.a.fill(); err != nil {
+			return err
+		}
+		if err := d.b.fill(); err != nil {
+			return err
+		}
+
+		rowA, rowB := d.a.head(), d.b.head()
+		var diff RowDiff
+		switch {
+		case rowA == nil && rowB == nil:
+			return nil
+		case rowB == nil || (rowA != nil && d.compare(rowA, rowB) < 0):
+			diff = RowDiff{Kind: RowRemoved, Old: rowA.Clone()}
+			d.a.next()
+		case rowA == nil || d.compare(rowA, rowB) > 0:
+			diff = RowDiff{Kind: RowAdded, New: rowB.Clone()}
+			d.b.next()
+		default:
+			columns := d.changedColumns(rowA, rowB)
+			d.a.next()
+			d.b.next()
+			if len(columns) == 0 {
+				continue
+			}
+			diff = RowDiff{Kind: RowChanged, Old: rowA.Clone(), New: rowB.Clone(), Columns: columns}
+		}
+
+		diff.Key = d.keyOf(diff.Old, diff.New)
+		if !yield(diff) {
+			return nil
+		}
+	}
+}
+
+func (d *rowDiffer) keyOf(rowA, rowB Row) []Value {
+	row := rowA
+	if row == nil {
+		row = rowB
+	}
+	key := make([]Value, len(d.keys))
+	for i, columnIndex := range d.keys {
+		for _, v := range row {
+			if v.Column() == columnIndex {
+				key[i] = v
+				break
+			}
+		}
+	}
+	return key
+}
+
+// changedColumns returns the paths of the columns which have different values
+// or levels in rowA and rowB.
+func (d *rowDiffer) changedColumns(rowA, rowB Row) (columns []string) {
+	clear(d.valuesA)
+	clear(d.valuesB)
+	rowA.Range(func(columnIndex int, values []Value) bool {
+		d.valuesA[columnIndex] = values
+		return true
+	})
+	rowB.Range(func(columnIndex int, values []Value) bool {
+		d.valuesB[columnIndex] = values
+		return true
+	})
+
+	for columnIndex, valuesA := range d.valuesA {
+		if !columnValuesAreEqual(valuesA, d.valuesB[columnIndex]) {
+			columns = append(columns, columnPath(d.columns[columnIndex]).String())
+		}
+	}
+	return columns
+}
+
+func columnValuesAreEqual(values1, values2 []Value) bool {
+	if len(values1) != len(values2) {
+		return false
+	}
+	for i, v1 := range values1 {
+		v2 := values2[i]
+		if v1.repetitionLevel != v2.repetitionLevel || v1.definitionLevel != v2.definitionLevel || !Equal(v1, v2) {
+			return false
+		}
+	}
+	return true
+}
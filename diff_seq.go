@@ -0,0 +1,32 @@
+//go:build go1.23
+
+package parquet
+
+import "iter"
+
+// DiffFiles returns a sequence of the differences between the rows of a and b,
+// which is useful to validate that migrations or compactions of data sets
+// preserved their content.
+//
+// Rows are aligned by their values in the key columns, given as dot-separated
+// paths to leaf columns which must not be repeated. When all the row groups of
+// a file are sorted by the key columns, they are merged on the fly; otherwise
+// the rows of the file are loaded and sorted in memory. Rows which only exist
+// in b are reported as added, rows which only exist in a as removed, and rows
+// of both files with different values as changed, along with the paths of the
+// columns which differ. Rows with the same key within a file are paired in
+// their order of appearance.
+//
+// If the schema of b differs from the schema of a, the rows of b are converted
+// to the schema of a before being compared, columns which only exist in b are
+// therefore ignored.
+//
+// If comparing the files fails, the last value of the sequence has its Err
+// field set to the error.
+func DiffFiles(a, b *File, keyColumns []string) iter.Seq[RowDiff] {
+	return func(yield func(RowDiff) bool) {
+		if err := diffFiles(a, b, keyColumns, yield); err != nil {
+			yield(RowDiff{Err: err})
+		}
+	}
+}
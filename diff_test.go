@@ -0,0 +1,175 @@
+//go:build go1.23
+
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestDiffFiles(t *testing.T) {
+	type Row struct {
+		ID    int64    `parquet:"id"`
+		Name  string   `parquet:"name"`
+		Score float64  `parquet:"score"`
+		Tags  []string `parquet:"tags,list"`
+	}
+
+	openFile := func(t *testing.T, buf *bytes.Buffer) *parquet.File {
+		t.Helper()
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	// The first file is sorted by id, its row groups are merged on the fly.
+	bufA := new(bytes.Buffer)
+	writerA := parquet.NewSortingWriter[Row](bufA, 2,
+		parquet.MaxRowsPerRowGroup(2),
+		parquet.SortingWriterConfig(parquet.SortingColumns(parquet.Ascending("id"))),
+	)
+	if _, err := writerA.Write([]Row{
+		{ID: 4, Name: "d", Score: 4},
+		{ID: 1, Name: "a", Score: 1},
+		{ID: 3, Name: "c", Score: 3, Tags: []string{"x"}},
+		{ID: 2, Name: "b", Score: 2},
+		{ID: 5, Name: "e", Score: 5},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writerA.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second file is not sorted, its rows are sorted in memory.
+	bufB := new(bytes.Buffer)
+	writerB := parquet.NewGenericWriter[Row](bufB, parquet.MaxRowsPerRowGroup(2))
+	if _, err := writerB.Write([]Row{
+		{ID: 6, Name: "f", Score: 6},
+		{ID: 5, Name: "e", Score: 5},
+		{ID: 3, Name: "c", Score: 3, Tags: []string{"y"}},
+		{ID: 1, Name: "a", Score: 1},
+		{ID: 4, Name: "D", Score: 4.5},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writerB.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := openFile(t, bufA), openFile(t, bufB)
+	schema := parquet.SchemaOf(new(Row))
+
+	type diff struct {
+		Kind    parquet.RowDiffKind
+		ID      int64
+		Columns []string
+	}
+	var diffs []diff
+	for d := range parquet.DiffFiles(a, b, []string{"id"}) {
+		if d.Err != nil {
+			t.Fatal(d.Err)
+		}
+		diffs = append(diffs, diff{Kind: d.Kind, ID: d.Key[0].Int64(), Columns: d.Columns})
+
+		var old, new Row
+		if d.Old != nil {
+			if err := schema.Reconstruct(&old, d.Old); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if d.New != nil {
+			if err := schema.Reconstruct(&new, d.New); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if d.Kind == parquet.RowChanged && old.ID != new.ID {
+			t.Errorf("changed rows have different keys: %d != %d", old.ID, new.ID)
+		}
+	}
+
+	want := []diff{
+		{Kind: parquet.RowRemoved, ID: 2},
+		{Kind: parquet.RowChanged, ID: 3, Columns: []string{"tags.list.element"}},
+		{Kind: parquet.RowChanged, ID: 4, Columns: []string{"name", "score"}},
+		{Kind: parquet.RowAdded, ID: 6},
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("wrong differences:\nwant = %+v\ngot  = %+v", want, diffs)
+	}
+
+	n := 0
+	for range parquet.DiffFiles(a, b, []string{"id"}) {
+		if n++; n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("wrong number of differences after breaking out of the loop: %d", n)
+	}
+
+	for d := range parquet.DiffFiles(a, a, []string{"id"}) {
+		t.Errorf("unexpected difference when comparing a file with itself: %+v", d)
+	}
+
+	for d := range parquet.DiffFiles(a, b, []string{"missing"}) {
+		if d.Err == nil {
+			t.Errorf("expected an error for a missing key column: %+v", d)
+		}
+	}
+}
+
+func TestDiffFilesDuplicatedKeys(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	// The files are not sorted, rows with the same key must be paired in the
+	// order they appear in each file.
+	writeFile := func(t *testing.T, rows []Row) *parquet.File {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf, parquet.MaxRowsPerRowGroup(2))
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	a := writeFile(t, []Row{{2, "b"}, {1, "a1"}, {1, "a2"}, {1, "a3"}, {0, "z"}})
+	b := writeFile(t, []Row{{1, "a1"}, {0, "z"}, {1, "A2"}, {2, "b"}, {1, "a3"}})
+	schema := parquet.SchemaOf(new(Row))
+
+	var diffs []string
+	for d := range parquet.DiffFiles(a, b, []string{"id"}) {
+		if d.Err != nil {
+			t.Fatal(d.Err)
+		}
+		var old, new Row
+		if err := schema.Reconstruct(&old, d.Old); err != nil {
+			t.Fatal(err)
+		}
+		if err := schema.Reconstruct(&new, d.New); err != nil {
+			t.Fatal(err)
+		}
+		diffs = append(diffs, d.Kind.String()+":"+old.Name+"->"+new.Name)
+	}
+
+	want := []string{"changed:a2->A2"}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("wrong differences:\nwant = %q\ngot  = %q", want, diffs)
+	}
+}
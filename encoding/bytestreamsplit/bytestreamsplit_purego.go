@@ -4,6 +4,10 @@ package bytestreamsplit
 
 import "github.com/parquet-go/parquet-go/internal/unsafecast"
 
+// The functions in this file load and store whole values and split them with
+// shifts, the byte streams always hold the little-endian bytes of values
+// regardless of the byte order of the platform.
+
 func encodeFloat(dst, src []byte) {
 	n := len(src) / 4
 	b0 := dst[0*n : 1*n]
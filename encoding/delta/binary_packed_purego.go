@@ -4,6 +4,8 @@ package delta
 
 import (
 	"encoding/binary"
+
+	"github.com/parquet-go/parquet-go/internal/byteorder"
 )
 
 func encodeMiniBlockInt32(dst []byte, src *[miniBlockSize]int32, bitWidth uint) {
@@ -66,6 +68,9 @@ func decodeBlockInt64(block []int64, minDelta, lastValue int64) int64 {
 	return lastValue
 }
 
+// The words of src are the little-endian bytes of the mini block reinterpreted
+// as 32 bits integers, they are converted to the native byte order before
+// extracting the bits of values.
 func decodeMiniBlockInt32(dst []int32, src []uint32, bitWidth uint) {
 	bitMask := uint32(1<<bitWidth) - 1
 	bitOffset := uint(0)
@@ -73,10 +78,10 @@ func decodeMiniBlockInt32(dst []int32, src []uint32, bitWidth uint) {
 	for n := range dst {
 		i := bitOffset / 32
 		j := bitOffset % 32
-		d := (src[i] & (bitMask << j)) >> j
+		d := (byteorder.LittleEndian32(src[i]) & (bitMask << j)) >> j
 		if j+bitWidth > 32 {
 			k := 32 - j
-			d |= (src[i+1] & (bitMask >> k)) << k
+			d |= (byteorder.LittleEndian32(src[i+1]) & (bitMask >> k)) << k
 		}
 		dst[n] = int32(d)
 		bitOffset += bitWidth
@@ -90,13 +95,13 @@ func decodeMiniBlockInt64(dst []int64, src []uint32, bitWidth uint) {
 	for n := range dst {
 		i := bitOffset / 32
 		j := bitOffset % 32
-		d := (uint64(src[i]) & (bitMask << j)) >> j
+		d := (uint64(byteorder.LittleEndian32(src[i])) & (bitMask << j)) >> j
 		if j+bitWidth > 32 {
 			k := 32 - j
-			d |= (uint64(src[i+1]) & (bitMask >> k)) << k
+			d |= (uint64(byteorder.LittleEndian32(src[i+1])) & (bitMask >> k)) << k
 			if j+bitWidth > 64 {
 				k := 64 - j
-				d |= (uint64(src[i+2]) & (bitMask >> k)) << k
+				d |= (uint64(byteorder.LittleEndian32(src[i+2])) & (bitMask >> k)) << k
 			}
 		}
 		dst[n] = int64(d)
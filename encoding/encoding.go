@@ -1,5 +1,8 @@
 // Package encoding provides the generic APIs implemented by parquet encodings
 // in its sub-packages.
+//
+// The Encode and Decode functions offer a typed API to the encodings, which
+// may be used to reuse them outside of parquet files.
 package encoding
 
 import (
@@ -12,6 +12,8 @@ import (
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/encoding"
 	"github.com/parquet-go/parquet-go/format"
+	"github.com/parquet-go/parquet-go/internal/bytealg"
+	"github.com/parquet-go/parquet-go/internal/byteorder"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
 )
 
@@ -37,23 +39,23 @@ func (e *Encoding) EncodeBoolean(dst []byte, src []byte) ([]byte, error) {
 }
 
 func (e *Encoding) EncodeInt32(dst []byte, src []int32) ([]byte, error) {
-	return append(dst[:0], unsafecast.Int32ToBytes(src)...), nil
+	return byteorder.AppendUint32(dst[:0], unsafecast.Slice[uint32](src)), nil
 }
 
 func (e *Encoding) EncodeInt64(dst []byte, src []int64) ([]byte, error) {
-	return append(dst[:0], unsafecast.Int64ToBytes(src)...), nil
+	return byteorder.AppendUint64(dst[:0], unsafecast.Slice[uint64](src)), nil
 }
 
 func (e *Encoding) EncodeInt96(dst []byte, src []deprecated.Int96) ([]byte, error) {
-	return append(dst[:0], deprecated.Int96ToBytes(src)...), nil
+	return byteorder.AppendUint32(dst[:0], unsafecast.Slice[uint32](src)), nil
 }
 
 func (e *Encoding) EncodeFloat(dst []byte, src []float32) ([]byte, error) {
-	return append(dst[:0], unsafecast.Float32ToBytes(src)...), nil
+	return byteorder.AppendUint32(dst[:0], unsafecast.Slice[uint32](src)), nil
 }
 
 func (e *Encoding) EncodeDouble(dst []byte, src []float64) ([]byte, error) {
-	return append(dst[:0], unsafecast.Float64ToBytes(src)...), nil
+	return byteorder.AppendUint64(dst[:0], unsafecast.Slice[uint64](src)), nil
 }
 
 func (e *Encoding) EncodeByteArray(dst []byte, src []byte, offsets []uint32) ([]byte, error) {
@@ -86,35 +88,35 @@ func (e *Encoding) DecodeInt32(dst []int32, src []byte) ([]int32, error) {
 	if (len(src) % 4) != 0 {
 		return dst, encoding.ErrDecodeInvalidInputSize(e, "INT32", len(src))
 	}
-	return append(dst[:0], unsafecast.BytesToInt32(src)...), nil
+	return unsafecast.Slice[int32](byteorder.DecodeUint32(unsafecast.Slice[uint32](dst[:0]), src)), nil
 }
 
 func (e *Encoding) DecodeInt64(dst []int64, src []byte) ([]int64, error) {
 	if (len(src) % 8) != 0 {
 		return dst, encoding.ErrDecodeInvalidInputSize(e, "INT64", len(src))
 	}
-	return append(dst[:0], unsafecast.BytesToInt64(src)...), nil
+	return unsafecast.Slice[int64](byteorder.DecodeUint64(unsafecast.Slice[uint64](dst[:0]), src)), nil
 }
 
 func (e *Encoding) DecodeInt96(dst []deprecated.Int96, src []byte) ([]deprecated.Int96, error) {
 	if (len(src) % 12) != 0 {
 		return dst, encoding.ErrDecodeInvalidInputSize(e, "INT96", len(src))
 	}
-	return append(dst[:0], deprecated.BytesToInt96(src)...), nil
+	return unsafecast.Slice[deprecated.Int96](byteorder.DecodeUint32(unsafecast.Slice[uint32](dst[:0]), src)), nil
 }
 
 func (e *Encoding) DecodeFloat(dst []float32, src []byte) ([]float32, error) {
 	if (len(src) % 4) != 0 {
 		return dst, encoding.ErrDecodeInvalidInputSize(e, "FLOAT", len(src))
 	}
-	return append(dst[:0], unsafecast.BytesToFloat32(src)...), nil
+	return unsafecast.Slice[float32](byteorder.DecodeUint32(unsafecast.Slice[uint32](dst[:0]), src)), nil
 }
 
 func (e *Encoding) DecodeDouble(dst []float64, src []byte) ([]float64, error) {
 	if (len(src) % 8) != 0 {
 		return dst, encoding.ErrDecodeInvalidInputSize(e, "DOUBLE", len(src))
 	}
-	return append(dst[:0], unsafecast.BytesToFloat64(src)...), nil
+	return unsafecast.Slice[float64](byteorder.DecodeUint64(unsafecast.Slice[uint64](dst[:0]), src)), nil
 }
 
 func (e *Encoding) DecodeByteArray(dst []byte, src []byte, offsets []uint32) ([]byte, []uint32, error) {
@@ -155,6 +157,16 @@ func (e *Encoding) CanDecodeInPlace() bool {
 	return true
 }
 
+// CountTrue returns the number of true values among the first numValues
+// booleans of src, which holds boolean values encoded with the PLAIN encoding.
+// The values are counted with population counts on the bit-packed input.
+func CountTrue(src []byte, numValues int) (int, error) {
+	if numValues < 0 || len(src) < (numValues+7)/8 {
+		return 0, fmt.Errorf("input of length %d is too short to contain %d PLAIN encoded boolean values: %w", len(src), numValues, io.ErrUnexpectedEOF)
+	}
+	return bytealg.CountBits(src, numValues), nil
+}
+
 func Boolean(v bool) []byte { return AppendBoolean(nil, 0, v) }
 
 func Int32(v int32) []byte { return AppendInt32(nil, v) }
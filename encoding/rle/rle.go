@@ -15,6 +15,7 @@ import (
 	"github.com/parquet-go/parquet-go/format"
 	"github.com/parquet-go/parquet-go/internal/bitpack"
 	"github.com/parquet-go/parquet-go/internal/bytealg"
+	"github.com/parquet-go/parquet-go/internal/byteorder"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
 )
 
@@ -95,6 +96,68 @@ func (e *Encoding) wrap(err error) error {
 	return err
 }
 
+// CountTrue returns the number of true values among the first numValues
+// booleans of src, which holds boolean values encoded with the RLE encoding
+// (including the 4 bytes length prefix, see Encoding.DecodeBoolean).
+//
+// The values are counted on the encoded runs: the lengths of run-length
+// encoded blocks are added, and the bits of bit-packed blocks are counted
+// with population counts, which is a lot cheaper than decoding the values.
+func CountTrue(src []byte, numValues int) (int, error) {
+	if len(src) < 4 {
+		if len(src) == 0 && numValues == 0 {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("input shorter than 4 bytes: %w", io.ErrUnexpectedEOF)
+	}
+	n := int(binary.LittleEndian.Uint32(src))
+	src = src[4:]
+	if n > len(src) {
+		return 0, fmt.Errorf("input shorter than length prefix: %d < %d: %w", len(src), n, io.ErrUnexpectedEOF)
+	}
+	src = src[:n]
+	numTrue := 0
+
+	for i := 0; i < len(src) && numValues > 0; {
+		u, n := binary.Uvarint(src[i:])
+		if n == 0 {
+			return numTrue, fmt.Errorf("decoding run-length block header: %w", io.ErrUnexpectedEOF)
+		}
+		if n < 0 {
+			return numTrue, fmt.Errorf("overflow after decoding %d/%d bytes of run-length block header", -n+i, len(src))
+		}
+		i += n
+
+		count, bitpacked := uint(u>>1), (u&1) != 0
+		if count > maxSupportedValueCount {
+			return numTrue, fmt.Errorf("decoded run-length block cannot have more than %d values", maxSupportedValueCount)
+		}
+		if bitpacked {
+			j := i + int(count)
+			if j > len(src) {
+				return numTrue, fmt.Errorf("decoding bit-packed block of %d values: %w", 8*count, io.ErrUnexpectedEOF)
+			}
+			numTrue += bytealg.CountBits(src[i:j], min(numValues, 8*int(count)))
+			numValues -= min(numValues, 8*int(count))
+			i = j
+		} else {
+			if i >= len(src) {
+				return numTrue, fmt.Errorf("decoding run-length block of %d values: %w", count, io.ErrUnexpectedEOF)
+			}
+			if (src[i] & 1) != 0 {
+				numTrue += min(numValues, int(count))
+			}
+			numValues -= min(numValues, int(count))
+			i++
+		}
+	}
+
+	if numValues > 0 {
+		return numTrue, fmt.Errorf("%d missing values: %w", numValues, io.ErrUnexpectedEOF)
+	}
+	return numTrue, nil
+}
+
 func encodeBits(dst, src []byte) ([]byte, error) {
 	if len(src) == 0 || isZero(src) || isOnes(src) {
 		dst = appendUvarint(dst, uint64(8*len(src))<<1)
@@ -267,7 +330,12 @@ func decodeBits(dst, src []byte) ([]byte, error) {
 		} else {
 			word := byte(0)
 			if i < len(src) {
-				word = src[i]
+				// The value of runs is a single bit, the specification has it
+				// set to 1 for true values while this package writes all bits
+				// of the byte, both are expanded to bytes of all ones.
+				if (src[i] & 1) != 0 {
+					word = 0xFF
+				}
 				i++
 			}
 
@@ -385,6 +453,9 @@ func decodeInt32(dst, src []byte, bitWidth uint) ([]byte, error) {
 
 			bits := [4]byte{}
 			copy(bits[:], src[i:j])
+			// The value is little-endian in the input and repeated in dst in
+			// the native byte order of int32 values.
+			binary.NativeEndian.PutUint32(bits[:], binary.LittleEndian.Uint32(bits[:]))
 			dst = appendRepeat(dst, bits[:], count)
 			i = j
 		}
@@ -510,6 +581,9 @@ func encodeBytesBitpackDefault(dst []byte, src []uint64, bitWidth uint) int {
 	n := 0
 
 	for _, word := range src {
+		// The words were loaded from the bytes of the input, the first value
+		// must be in the low bits regardless of the platform byte order.
+		word = byteorder.LittleEndian64(word)
 		word = (word & bitMask) |
 			(((word >> 8) & bitMask) << (1 * bitWidth)) |
 			(((word >> 16) & bitMask) << (2 * bitWidth)) |
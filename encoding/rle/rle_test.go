@@ -59,3 +59,65 @@ func benchmarkEncodeInt32IndexEqual8Contiguous(b *testing.B, f func([][8]int32)
 	}
 	b.SetBytes(32 * int64(len(words)))
 }
+
+func TestCountTrue(t *testing.T) {
+	enc := &Encoding{BitWidth: 1}
+
+	err := quick.Check(func(values []bool) bool {
+		bits := make([]byte, (len(values)+7)/8)
+		want := 0
+		for i, v := range values {
+			if v {
+				bits[i/8] |= 1 << (i % 8)
+				want++
+			}
+		}
+		buf, err := enc.EncodeBoolean(nil, bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := CountTrue(buf, len(values))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("want=%d got=%d", want, got)
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecodeBooleanRunOfOnes(t *testing.T) {
+	// The parquet specification encodes the values of runs on the number of
+	// bytes needed for the bit width, so a run of true values is 1.
+	src := []byte{2, 0, 0, 0, 16 << 1, 1}
+
+	dst, err := (&Encoding{BitWidth: 1}).DecodeBoolean(nil, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dst) != 2 || dst[0] != 0xFF || dst[1] != 0xFF {
+		t.Errorf("wrong decoded values: %08b", dst)
+	}
+}
+
+func TestCountTrueRunOfOnes(t *testing.T) {
+	// The parquet specification encodes the values of runs on the number of
+	// bytes needed for the bit width, so a run of true values is 1.
+	src := []byte{2, 0, 0, 0, 16 << 1, 1}
+
+	n, err := CountTrue(src, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 16 {
+		t.Errorf("wrong count of true values: want=16 got=%d", n)
+	}
+	if _, err := CountTrue(src, 17); err == nil {
+		t.Error("expected an error counting more values than encoded")
+	}
+}
@@ -0,0 +1,183 @@
+package encoding
+
+import (
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/internal/unsafecast"
+)
+
+// FixedSize is the constraint of the types of values that Encode and Decode
+// accept. Unsigned integers are encoded like the signed integers of the same
+// size, except for uint8 values which are encoded as repetition or definition
+// levels. Booleans are bit-packed like the values of BOOLEAN columns.
+type FixedSize interface {
+	bool | uint8 | int32 | int64 | uint32 | uint64 | float32 | float64 | deprecated.Int96
+}
+
+// Encode encodes the values of src with enc, writing the output to dst which
+// may be reallocated if it was too short.
+//
+// Like the methods of the Encoding interface, all the functions of this file
+// overwrite the content of dst, which is only used to avoid allocating a new
+// buffer: the returned slice holds the output from index zero.
+//
+// Encode and Decode are a typed alternative to the methods of the Encoding
+// interface, which may be used to reuse the parquet encodings implemented in
+// the sub-packages (e.g. rle, delta, or bytestreamsplit) outside of parquet
+// files, or to benchmark them in isolation; the encodings use specialized
+// implementations for the platform they are compiled for unless the purego
+// build tag is set, comparing benchmarks with and without the tag measures the
+// gains of the hardware-specific code paths.
+//
+// The function returns an error if enc does not support encoding values of
+// type T, which can be checked beforehand with CanEncode.
+func Encode[T FixedSize](dst []byte, src []T, enc Encoding) ([]byte, error) {
+	switch values := any(src).(type) {
+	case []bool:
+		bits := make([]byte, (len(values)+7)/8)
+		for i, v := range values {
+			if v {
+				bits[i/8] |= 1 << (i % 8)
+			}
+		}
+		return enc.EncodeBoolean(dst, bits)
+	case []uint8:
+		return enc.EncodeLevels(dst, values)
+	case []int32:
+		return enc.EncodeInt32(dst, values)
+	case []uint32:
+		return enc.EncodeInt32(dst, unsafecast.Slice[int32](values))
+	case []int64:
+		return enc.EncodeInt64(dst, values)
+	case []uint64:
+		return enc.EncodeInt64(dst, unsafecast.Slice[int64](values))
+	case []float32:
+		return enc.EncodeFloat(dst, values)
+	case []float64:
+		return enc.EncodeDouble(dst, values)
+	default:
+		return enc.EncodeInt96(dst, any(src).([]deprecated.Int96))
+	}
+}
+
+// Decode decodes the values of src with enc, writing the output to dst which
+// may be reallocated if it was too short.
+//
+// Bit-packed booleans do not record the number of values, the output is padded
+// with false values to a multiple of 8; the number of values must be known
+// from the context to remove the padding.
+//
+// The function returns an error if enc does not support decoding values of
+// type T, which can be checked beforehand with CanEncode.
+func Decode[T FixedSize](dst []T, src []byte, enc Encoding) ([]T, error) {
+	var values any
+	var err error
+	switch buffer := any(dst).(type) {
+	case []bool:
+		var bits []byte
+		bits, err = enc.DecodeBoolean(nil, src)
+		buffer = buffer[:0]
+		for i := 0; i < 8*len(bits); i++ {
+			buffer = append(buffer, bits[i/8]&(1<<(i%8)) != 0)
+		}
+		values = buffer
+	case []uint8:
+		values, err = enc.DecodeLevels(buffer, src)
+	case []int32:
+		values, err = enc.DecodeInt32(buffer, src)
+	case []uint32:
+		var decoded []int32
+		decoded, err = enc.DecodeInt32(unsafecast.Slice[int32](buffer), src)
+		values = unsafecast.Slice[uint32](decoded)
+	case []int64:
+		values, err = enc.DecodeInt64(buffer, src)
+	case []uint64:
+		var decoded []int64
+		decoded, err = enc.DecodeInt64(unsafecast.Slice[int64](buffer), src)
+		values = unsafecast.Slice[uint64](decoded)
+	case []float32:
+		values, err = enc.DecodeFloat(buffer, src)
+	case []float64:
+		values, err = enc.DecodeDouble(buffer, src)
+	default:
+		values, err = enc.DecodeInt96(any(dst).([]deprecated.Int96), src)
+	}
+	return values.([]T), err
+}
+
+// CanEncode returns true if enc supports encoding values of type T.
+func CanEncode[T FixedSize](enc Encoding) bool {
+	switch any([]T(nil)).(type) {
+	case []bool:
+		return CanEncodeBoolean(enc)
+	case []uint8:
+		return CanEncodeLevels(enc)
+	case []int32, []uint32:
+		return CanEncodeInt32(enc)
+	case []int64, []uint64:
+		return CanEncodeInt64(enc)
+	case []float32:
+		return CanEncodeFloat(enc)
+	case []float64:
+		return CanEncodeDouble(enc)
+	default:
+		return CanEncodeInt96(enc)
+	}
+}
+
+// EncodeByteArrays is like Encode but for variable length byte arrays.
+func EncodeByteArrays(dst []byte, src [][]byte, enc Encoding) ([]byte, error) {
+	size := 0
+	for _, value := range src {
+		size += len(value)
+	}
+	data := make([]byte, 0, size)
+	offsets := make([]uint32, 0, len(src)+1)
+	for _, value := range src {
+		offsets = append(offsets, uint32(len(data)))
+		data = append(data, value...)
+	}
+	offsets = append(offsets, uint32(len(data)))
+	return enc.EncodeByteArray(dst, data, offsets)
+}
+
+// DecodeByteArrays is like Decode but for variable length byte arrays. The
+// decoded values share a buffer allocated by the function.
+func DecodeByteArrays(dst [][]byte, src []byte, enc Encoding) ([][]byte, error) {
+	dst = dst[:0]
+	data, offsets, err := enc.DecodeByteArray(make([]byte, 0, enc.EstimateDecodeByteArraySize(src)), src, nil)
+	if len(offsets) > 0 {
+		baseOffset := offsets[0]
+		for _, endOffset := range offsets[1:] {
+			dst = append(dst, data[baseOffset:endOffset:endOffset])
+			baseOffset = endOffset
+		}
+	}
+	return dst, err
+}
+
+// EncodeFixedLenByteArrays is like Encode but for byte arrays of the given
+// size. The function returns an error if the length of a value of src is not
+// size.
+func EncodeFixedLenByteArrays(dst []byte, src [][]byte, size int, enc Encoding) ([]byte, error) {
+	data := make([]byte, 0, size*len(src))
+	for i, value := range src {
+		if len(value) != size {
+			return dst[:0], Errorf(enc, "value at index %d has length %d instead of %d: %w", i, len(value), size, ErrInvalidArgument)
+		}
+		data = append(data, value...)
+	}
+	return enc.EncodeFixedLenByteArray(dst, data, size)
+}
+
+// DecodeFixedLenByteArrays is like Decode but for byte arrays of the given
+// size. The decoded values share a buffer allocated by the function.
+func DecodeFixedLenByteArrays(dst [][]byte, src []byte, size int, enc Encoding) ([][]byte, error) {
+	dst = dst[:0]
+	data, err := enc.DecodeFixedLenByteArray(nil, src, size)
+	if size > 0 {
+		for i := 0; i+size <= len(data); i += size {
+			dst = append(dst, data[i:i+size:i+size])
+		}
+	}
+	return dst, err
+}
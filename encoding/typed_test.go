@@ -0,0 +1,136 @@
+package encoding_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/encoding/bytestreamsplit"
+	"github.com/parquet-go/parquet-go/encoding/delta"
+	"github.com/parquet-go/parquet-go/encoding/plain"
+	"github.com/parquet-go/parquet-go/encoding/rle"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	encodings := []encoding.Encoding{
+		new(plain.Encoding),
+		&rle.Encoding{BitWidth: 32},
+		new(delta.BinaryPackedEncoding),
+		new(bytestreamsplit.Encoding),
+	}
+	for _, e := range encodings {
+		t.Run(e.String(), func(t *testing.T) {
+			t.Run("int32", func(t *testing.T) { testEncodeDecode(t, e, int32Tests[:]) })
+			t.Run("uint32", func(t *testing.T) { testEncodeDecode(t, e, [][]uint32{{}, {0, 1, 1 << 31, 1<<32 - 1}}) })
+			t.Run("int64", func(t *testing.T) { testEncodeDecode(t, e, int64Tests[:]) })
+			t.Run("uint64", func(t *testing.T) { testEncodeDecode(t, e, [][]uint64{{}, {0, 1, 1 << 63, 1<<64 - 1}}) })
+			t.Run("int96", func(t *testing.T) { testEncodeDecode(t, e, int96Tests[:]) })
+			t.Run("float", func(t *testing.T) { testEncodeDecode(t, e, floatTests[:]) })
+			t.Run("double", func(t *testing.T) { testEncodeDecode(t, e, doubleTests[:]) })
+		})
+	}
+}
+
+func testEncodeDecode[T encoding.FixedSize](t *testing.T, e encoding.Encoding, tests [][]T) {
+	if !encoding.CanEncode[T](e) {
+		if _, err := encoding.Encode(nil, tests[0], e); err == nil {
+			t.Error("no error returned when encoding values of unsupported type")
+		}
+		t.Skip("encoding not supported")
+	}
+
+	buffer := []byte{}
+	values := []T{}
+
+	for _, input := range tests {
+		var err error
+		buffer, err = encoding.Encode(buffer, input, e)
+		assertNoError(t, err)
+		values, err = encoding.Decode(values, buffer, e)
+		assertNoError(t, err)
+		if !slices.Equal(input, values) && !(len(input) == 0 && len(values) == 0) {
+			t.Fatalf("values mismatch:\nwant = %v\ngot  = %v", input, values)
+		}
+	}
+}
+
+func TestEncodeDecodeLevels(t *testing.T) {
+	testEncodeDecode(t, &rle.Encoding{BitWidth: 8}, [][]uint8{{}, {0, 1, 2, 2, 2, 1, 0, 255}})
+}
+
+func TestEncodeDecodeBooleans(t *testing.T) {
+	encodings := []encoding.Encoding{
+		new(plain.Encoding),
+		new(rle.Encoding),
+	}
+	for _, e := range encodings {
+		t.Run(e.String(), func(t *testing.T) {
+			values := []bool{true}
+			for _, input := range [][]bool{{}, {true}, {false, true, true}, {true, false, false, true, true, false, true, false, true}} {
+				buffer, err := encoding.Encode(nil, input, e)
+				assertNoError(t, err)
+				values, err = encoding.Decode(values, buffer, e)
+				assertNoError(t, err)
+				// The decoded values are padded to a multiple of 8.
+				if len(values) != (len(input)+7)/8*8 {
+					t.Fatalf("wrong number of values: want %d, got %d", (len(input)+7)/8*8, len(values))
+				}
+				if !slices.Equal(input, values[:len(input)]) {
+					t.Fatalf("values mismatch:\nwant = %v\ngot  = %v", input, values[:len(input)])
+				}
+				if slices.Contains(values[len(input):], true) {
+					t.Fatalf("padding is not false: %v", values[len(input):])
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeByteArrays(t *testing.T) {
+	encodings := []encoding.Encoding{
+		new(plain.Encoding),
+		new(delta.LengthByteArrayEncoding),
+		new(delta.ByteArrayEncoding),
+	}
+	for _, e := range encodings {
+		t.Run(e.String(), func(t *testing.T) {
+			for _, input := range byteArrayTests {
+				buffer, err := encoding.EncodeByteArrays(nil, input, e)
+				assertNoError(t, err)
+				values, err := encoding.DecodeByteArrays([][]byte{[]byte("previous")}, buffer, e)
+				assertNoError(t, err)
+
+				if !slices.EqualFunc(input, values, bytes.Equal) {
+					t.Fatalf("values mismatch:\nwant = %q\ngot  = %q", input, values)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeFixedLenByteArrays(t *testing.T) {
+	encodings := []encoding.Encoding{
+		new(plain.Encoding),
+		new(delta.ByteArrayEncoding),
+	}
+	for _, e := range encodings {
+		t.Run(e.String(), func(t *testing.T) {
+			for _, input := range [][][]byte{{}, {[]byte("abc"), []byte("abd"), []byte("xyz")}} {
+				buffer, err := encoding.EncodeFixedLenByteArrays(nil, input, 3, e)
+				assertNoError(t, err)
+				values, err := encoding.DecodeFixedLenByteArrays([][]byte{[]byte("previous")}, buffer, 3, e)
+				assertNoError(t, err)
+
+				if !slices.EqualFunc(input, values, bytes.Equal) {
+					t.Fatalf("values mismatch:\nwant = %q\ngot  = %q", input, values)
+				}
+			}
+
+			if _, err := encoding.EncodeFixedLenByteArrays(nil, [][]byte{[]byte("abc"), []byte("ab")}, 3, e); !errors.Is(err, encoding.ErrInvalidArgument) {
+				t.Fatalf("wrong error for a value of the wrong length: %v", err)
+			}
+		})
+	}
+}
@@ -44,6 +44,14 @@ func (kind Kind) String() string {
 	}
 }
 
+// Values is a view of a sequence of values of a parquet type.
+//
+// The data of fixed size values is held in the native byte order of the
+// platform so it can be reinterpreted as slices of Go values without copies;
+// the encodings convert it to and from the little-endian byte order of parquet
+// files. The functions constructing Values from slices of bytes (e.g.
+// Int32ValuesFromBytes) expect memory in this native representation, not
+// PLAIN encoded values.
 type Values struct {
 	kind    Kind
 	size    int32
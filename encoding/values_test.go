@@ -1,12 +1,133 @@
 package encoding_test
 
 import (
+	"bytes"
 	"testing"
 	"unsafe"
 
 	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/encoding/bytestreamsplit"
+	"github.com/parquet-go/parquet-go/encoding/delta"
+	"github.com/parquet-go/parquet-go/encoding/plain"
+	"github.com/parquet-go/parquet-go/encoding/rle"
 )
 
 func TestValuesSize(t *testing.T) {
 	t.Log(unsafe.Sizeof(encoding.Values{}))
 }
+
+// TestValuesByteOrder verifies that values held in the native byte order of
+// the platform are encoded to, and decoded from, the little-endian byte order
+// of parquet files. The test is only meaningful on big-endian platforms, where
+// it runs in the emulated s390x job of the CI.
+func TestValuesByteOrder(t *testing.T) {
+	int32Values := encoding.Int32Values([]int32{1, 2, 4, 7, 11, 16, 22, 29})
+	int64Values := encoding.Int64Values([]int64{-3, 5, 2, 9})
+	floatValues := encoding.FloatValues([]float32{1, -2.5})
+	doubleValues := encoding.DoubleValues([]float64{1, -2.5})
+	indexValues := encoding.Int32Values([]int32{1, 2, 3, 4, 5, 6, 7, 8, 300, 300, 300, 300, 300, 300, 300, 300})
+
+	tests := []struct {
+		scenario string
+		encoding encoding.Encoding
+		values   encoding.Values
+		encoded  []byte
+	}{
+		{
+			scenario: "plain int32",
+			encoding: new(plain.Encoding),
+			values:   int32Values,
+			encoded: []byte{
+				0x01, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00,
+				0x0b, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x16, 0x00, 0x00, 0x00, 0x1d, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			scenario: "plain int64",
+			encoding: new(plain.Encoding),
+			values:   int64Values,
+			encoded: []byte{
+				0xfd, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x09, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			scenario: "delta binary packed int32",
+			encoding: new(delta.BinaryPackedEncoding),
+			values:   int32Values,
+			encoded: []byte{
+				0x80, 0x01, 0x04, 0x08, 0x02, 0x39, 0x06, 0x00, 0x00, 0x00, 0xde, 0x07, 0x86, 0xe2, 0x48, 0x02,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00,
+			},
+		},
+		{
+			scenario: "delta binary packed int64",
+			encoding: new(delta.BinaryPackedEncoding),
+			values:   int64Values,
+			encoded: []byte{
+				0x80, 0x01, 0x04, 0x04, 0x05, 0x11, 0x05, 0x00, 0x00, 0x00, 0xd1, 0x40, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			scenario: "byte stream split float",
+			encoding: new(bytestreamsplit.Encoding),
+			values:   floatValues,
+			encoded:  []byte{0x00, 0x00, 0x00, 0x00, 0x80, 0x20, 0x3f, 0xc0},
+		},
+		{
+			scenario: "byte stream split double",
+			encoding: new(bytestreamsplit.Encoding),
+			values:   doubleValues,
+			encoded: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x04, 0x3f, 0xc0,
+			},
+		},
+		{
+			scenario: "rle dictionary indexes",
+			encoding: new(rle.DictionaryEncoding),
+			values:   indexValues,
+			encoded:  []byte{0x09, 0x03, 0x01, 0x04, 0x0c, 0x20, 0x50, 0xc0, 0xc0, 0x01, 0x04, 0x10, 0x2c, 0x01},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			var encoded []byte
+			var decoded encoding.Values
+			var err error
+
+			switch test.values.Kind() {
+			case encoding.Int32:
+				encoded, err = encoding.EncodeInt32(nil, test.values, test.encoding)
+				assertNoError(t, err)
+				decoded, err = encoding.DecodeInt32(encoding.Int32Values(nil), test.encoded, test.encoding)
+				assertNoError(t, err)
+				assertEqualInt32(t, test.values.Int32(), decoded.Int32())
+			case encoding.Int64:
+				encoded, err = encoding.EncodeInt64(nil, test.values, test.encoding)
+				assertNoError(t, err)
+				decoded, err = encoding.DecodeInt64(encoding.Int64Values(nil), test.encoded, test.encoding)
+				assertNoError(t, err)
+				assertEqualInt64(t, test.values.Int64(), decoded.Int64())
+			case encoding.Float:
+				encoded, err = encoding.EncodeFloat(nil, test.values, test.encoding)
+				assertNoError(t, err)
+				decoded, err = encoding.DecodeFloat(encoding.FloatValues(nil), test.encoded, test.encoding)
+				assertNoError(t, err)
+				assertEqualFloat32(t, test.values.Float(), decoded.Float())
+			case encoding.Double:
+				encoded, err = encoding.EncodeDouble(nil, test.values, test.encoding)
+				assertNoError(t, err)
+				decoded, err = encoding.DecodeDouble(encoding.DoubleValues(nil), test.encoded, test.encoding)
+				assertNoError(t, err)
+				assertEqualFloat64(t, test.values.Double(), decoded.Double())
+			}
+
+			if !bytes.Equal(encoded, test.encoded) {
+				t.Errorf("wrong encoded bytes:\nwant = %#v\ngot  = %#v", test.encoded, encoded)
+			}
+		})
+	}
+}
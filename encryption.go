@@ -0,0 +1,355 @@
+package parquet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// EncryptionConfig configures the modular encryption of parquet files written
+// with the AES_GCM_V1 algorithm of the parquet specification, see the
+// Encryption writer option.
+//
+// https://github.com/apache/parquet-format/blob/master/Encryption.md
+type EncryptionConfig struct {
+	// Key encrypting the footer, and the columns which are not configured
+	// with a key of their own. AES keys are 16, 24, or 32 bytes long.
+	FooterKey []byte
+	// Metadata stored in the file to let readers retrieve the footer key, for
+	// example the identifier of the key in a key management service.
+	FooterKeyMetadata []byte
+	// Columns to encrypt. When empty, all the columns are encrypted with the
+	// footer key, otherwise the columns which are not listed are written in
+	// plaintext.
+	Columns []ColumnEncryption
+	// When true, the footer is written in plaintext and signed with the footer
+	// key, which lets readers that do not support encryption read the columns
+	// written in plaintext. Otherwise, the footer is encrypted and the file
+	// uses the "PARE" magic instead of "PAR1".
+	PlaintextFooter bool
+	// Prefix of the additional authenticated data of all modules of the file,
+	// for example the name of the table that the file belongs to, which lets
+	// readers detect files that were moved or replaced. The prefix is stored in
+	// the file unless SupplyAADPrefix is true, in which case readers must know
+	// it to read the file.
+	AADPrefix       []byte
+	SupplyAADPrefix bool
+}
+
+// ColumnEncryption configures the encryption of a column, see EncryptionConfig.
+type ColumnEncryption struct {
+	// Path to the leaf column in the schema.
+	Path []string
+	// Key encrypting the column, or nil to encrypt the column with the footer
+	// key. AES keys are 16, 24, or 32 bytes long.
+	Key []byte
+	// Metadata stored in the file to let readers retrieve the column key.
+	KeyMetadata []byte
+}
+
+// Validate returns a non-nil error if the configuration of c is invalid.
+func (c *EncryptionConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	const baseName = "parquet.(*EncryptionConfig)."
+	errs := []error{
+		validateEncryptionKey(baseName+"FooterKey", c.FooterKey),
+	}
+	if c.SupplyAADPrefix && len(c.AADPrefix) == 0 {
+		errs = append(errs, fmt.Errorf("invalid option value: %sSupplyAADPrefix: the AAD prefix is empty", baseName))
+	}
+	for i, column := range c.Columns {
+		name := fmt.Sprintf("%sColumns[%d]", baseName, i)
+		if len(column.Path) == 0 {
+			errs = append(errs, fmt.Errorf("invalid option value: %s.Path: empty column path", name))
+		}
+		if column.Key != nil {
+			errs = append(errs, validateEncryptionKey(name+".Key", column.Key))
+		}
+		for _, other := range c.Columns[:i] {
+			if columnPath(other.Path).equal(column.Path) {
+				errs = append(errs, fmt.Errorf("invalid option value: %s.Path: column %q is encrypted twice", name, columnPath(column.Path)))
+				break
+			}
+		}
+	}
+	return errorInvalidConfiguration(errs...)
+}
+
+// validateEncryptionKey validates the length of an AES key; the error does not
+// include the key.
+func validateEncryptionKey(optionName string, key []byte) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	}
+	return fmt.Errorf("invalid option value: %s: AES keys must be 16, 24, or 32 bytes long but the key has %d bytes", optionName, len(key))
+}
+
+// Module types of the additional authenticated data of encrypted modules.
+const (
+	footerModule byte = iota
+	columnMetaDataModule
+	dataPageModule
+	dictionaryPageModule
+	dataPageHeaderModule
+	dictionaryPageHeaderModule
+	columnIndexModule
+	offsetIndexModule
+	bloomFilterHeaderModule
+	bloomFilterBitsetModule
+)
+
+const (
+	gcmNonceSize = 12
+	gcmTagSize   = 16
+	// Number of bytes that the encryption adds to modules: the 4 bytes length
+	// prefix, the nonce, and the authentication tag.
+	gcmModuleOverhead = 4 + gcmNonceSize + gcmTagSize
+	// Length of the random identifier of files in the AAD suffix.
+	aadFileUniqueSize = 8
+)
+
+// fileEncryptor holds the state of the encryption of a file written by a
+// writer.
+type fileEncryptor struct {
+	config *EncryptionConfig
+	footer cipher.AEAD
+	// AAD prefix and unique identifier of the file, the AAD of modules append
+	// their type and ordinals to it. The identifier starts at index aadFile.
+	aad     []byte
+	aadFile int
+	// Ordinal of the row group being written.
+	rowGroup int
+}
+
+// columnEncryptor encrypts the modules of a column, columns written in
+// plaintext have no encryptor.
+type columnEncryptor struct {
+	file    *fileEncryptor
+	cipher  cipher.AEAD
+	ordinal int
+	crypto  format.ColumnCryptoMetaData
+}
+
+func newFileEncryptor(config *EncryptionConfig) *fileEncryptor {
+	e := &fileEncryptor{
+		config: config,
+		footer: newGCM(config.FooterKey),
+	}
+	e.aad = append(e.aad, config.AADPrefix...)
+	e.aadFile = len(e.aad)
+	e.aad = append(e.aad, make([]byte, aadFileUniqueSize)...)
+	e.reset()
+	return e
+}
+
+func newGCM(key []byte) cipher.AEAD {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		// The length of keys was checked when validating the configuration.
+		panic(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return gcm
+}
+
+// reset generates a new unique identifier for the next file, the identifiers
+// prevent modules from being swapped between files encrypted with the same
+// keys.
+func (e *fileEncryptor) reset() {
+	e.aad = e.aad[:e.aadFile+aadFileUniqueSize]
+	if _, err := io.ReadFull(rand.Reader, e.aad[e.aadFile:]); err != nil {
+		panic(fmt.Errorf("generating unique identifier of encrypted parquet file: %w", err))
+	}
+	e.rowGroup = 0
+}
+
+// column returns the encryptor of the column at the given path and ordinal, or
+// nil if the column is written in plaintext. The path of the column in the file
+// differs from the path in the schema when writing legacy lists.
+func (e *fileEncryptor) column(path, filePath columnPath, ordinal int) *columnEncryptor {
+	c := &columnEncryptor{file: e, ordinal: ordinal}
+
+	if len(e.config.Columns) == 0 {
+		c.cipher = e.footer
+		c.crypto.EncryptionWithFooterKey = new(format.EncryptionWithFooterKey)
+		return c
+	}
+
+	for _, column := range e.config.Columns {
+		if !path.equal(column.Path) {
+			continue
+		}
+		if column.Key == nil {
+			c.cipher = e.footer
+			c.crypto.EncryptionWithFooterKey = new(format.EncryptionWithFooterKey)
+		} else {
+			c.cipher = newGCM(column.Key)
+			c.crypto.EncryptionWithColumnKey = &format.EncryptionWithColumnKey{
+				PathInSchema: filePath,
+				KeyMetadata:  column.KeyMetadata,
+			}
+		}
+		return c
+	}
+	return nil
+}
+
+func (e *fileEncryptor) algorithm() format.EncryptionAlgorithm {
+	algorithm := &format.AesGcmV1{
+		AadFileUnique:   e.aad[e.aadFile:],
+		SupplyAadPrefix: e.config.SupplyAADPrefix,
+	}
+	if !e.config.SupplyAADPrefix {
+		algorithm.AadPrefix = e.config.AADPrefix
+	}
+	return format.EncryptionAlgorithm{AesGcmV1: algorithm}
+}
+
+// magic returns the magic bytes at the beginning and end of the file.
+func (e *fileEncryptor) magic() string {
+	if e.config.PlaintextFooter {
+		return "PAR1"
+	}
+	return "PARE"
+}
+
+// encryptsMetadata returns true if the metadata of the column chunks of c is
+// encrypted separately from the footer, which is the case when the footer is
+// in plaintext or the column is not encrypted with the footer key.
+func (c *columnEncryptor) encryptsMetadata() bool {
+	return c.file.config.PlaintextFooter || c.crypto.EncryptionWithColumnKey != nil
+}
+
+// encrypt appends to dst the module of the given type holding the encrypted
+// plaintext, for the current row group of the file. The page ordinal is only
+// part of the AAD of data pages and their headers.
+func (c *columnEncryptor) encrypt(dst []byte, module byte, page int, plaintext []byte) ([]byte, error) {
+	return c.encryptAt(dst, module, c.file.rowGroup, page, plaintext)
+}
+
+func (c *columnEncryptor) encryptAt(dst []byte, module byte, rowGroup, page int, plaintext []byte) ([]byte, error) {
+	aad, err := c.aad(module, rowGroup, page)
+	if err != nil {
+		return dst, err
+	}
+	return appendModule(dst, c.cipher, aad, plaintext), nil
+}
+
+// decrypt decrypts a page or page header module of the current row group
+// encrypted by c, the plaintext is written in place.
+func (c *columnEncryptor) decrypt(module byte, page int, data []byte) ([]byte, error) {
+	aad, err := c.aad(module, c.file.rowGroup, page)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcmModuleOverhead || int(binary.LittleEndian.Uint32(data)) != len(data)-4 {
+		return nil, fmt.Errorf("decrypting module of column %d: %w", c.ordinal, io.ErrUnexpectedEOF)
+	}
+	nonce, ciphertext := data[4:4+gcmNonceSize], data[4+gcmNonceSize:]
+	return c.cipher.Open(ciphertext[:0], nonce, ciphertext, aad)
+}
+
+func (c *columnEncryptor) aad(module byte, rowGroup, page int) ([]byte, error) {
+	if rowGroup > math.MaxInt16 || c.ordinal > math.MaxInt16 || page > math.MaxInt16 {
+		return nil, fmt.Errorf("encrypted parquet files cannot have more than %d row groups, columns, or pages per column chunk", math.MaxInt16+1)
+	}
+	aad := make([]byte, 0, len(c.file.aad)+7)
+	aad = append(aad, c.file.aad...)
+	aad = append(aad, module)
+	aad = binary.LittleEndian.AppendUint16(aad, uint16(rowGroup))
+	aad = binary.LittleEndian.AppendUint16(aad, uint16(c.ordinal))
+	if page >= 0 {
+		aad = binary.LittleEndian.AppendUint16(aad, uint16(page))
+	}
+	return aad, nil
+}
+
+// appendModule appends to dst the length prefix, nonce, ciphertext, and tag of
+// the AES-GCM encryption of plaintext.
+func appendModule(dst []byte, gcm cipher.AEAD, aad, plaintext []byte) []byte {
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(gcmNonceSize+len(plaintext)+gcmTagSize))
+	dst = append(dst, make([]byte, gcmNonceSize)...)
+	nonce := dst[len(dst)-gcmNonceSize:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		panic(fmt.Errorf("generating nonce of encrypted parquet module: %w", err))
+	}
+	return gcm.Seal(dst, nonce, plaintext, aad)
+}
+
+// encryptColumnChunk sets the crypto metadata of a column chunk of the row
+// group at the given ordinal, and encrypts its metadata if needed.
+func (c *columnEncryptor) encryptColumnChunk(chunk *format.ColumnChunk, rowGroup int) error {
+	chunk.CryptoMetadata = c.crypto
+	if !c.encryptsMetadata() {
+		return nil
+	}
+	metadata, err := thrift.Marshal(new(thrift.CompactProtocol), &chunk.MetaData)
+	if err != nil {
+		return err
+	}
+	chunk.EncryptedColumnMetadata, err = c.encryptAt(nil, columnMetaDataModule, rowGroup, -1, metadata)
+	if err != nil {
+		return err
+	}
+	if c.file.config.PlaintextFooter {
+		// Readers which cannot decrypt the column metadata still see where
+		// the column chunk is, but not the statistics of its values.
+		chunk.MetaData.Statistics = format.Statistics{}
+		chunk.MetaData.EncodingStats = nil
+	} else {
+		chunk.MetaData = format.ColumnMetaData{}
+	}
+	return nil
+}
+
+// appendFooter appends the footer of the file to dst, given the serialized
+// file metadata. Plaintext footers are followed by the nonce and tag signing
+// them, encrypted footers are preceded by the crypto metadata of the file.
+func (e *fileEncryptor) appendFooter(dst, metadata []byte) ([]byte, error) {
+	aad := append(e.aad[:len(e.aad):len(e.aad)], footerModule)
+
+	if e.config.PlaintextFooter {
+		dst = append(dst, metadata...)
+		module := appendModule(nil, e.footer, aad, metadata)
+		nonce := module[4 : 4+gcmNonceSize]
+		tag := module[len(module)-gcmTagSize:]
+		dst = append(dst, nonce...)
+		return append(dst, tag...), nil
+	}
+
+	crypto, err := thrift.Marshal(new(thrift.CompactProtocol), &format.FileCryptoMetaData{
+		EncryptionAlgorithm: e.algorithm(),
+		KeyMetadata:         e.config.FooterKeyMetadata,
+	})
+	if err != nil {
+		return dst, err
+	}
+	dst = append(dst, crypto...)
+	return appendModule(dst, e.footer, aad, metadata), nil
+}
+
+// validateEncryptedColumns checks that the columns of the encryption config
+// exist in the schema.
+func validateEncryptedColumns(config *EncryptionConfig, schema *Schema) error {
+	for _, column := range config.Columns {
+		leaf, ok := schema.Lookup(column.Path...)
+		if !ok || !leaf.Node.Leaf() {
+			return fmt.Errorf("encrypted column %q does not exist in parquet schema %s", strings.Join(column.Path, "."), schema.Name())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,514 @@
+package parquet_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/segmentio/encoding/thrift"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestEncryption(t *testing.T) {
+	type Row struct {
+		ID     int64   `parquet:"id"`
+		Name   string  `parquet:"name,dict"`
+		Secret *string `parquet:"secret,optional"`
+		Public int32   `parquet:"public"`
+	}
+
+	rows := make([]Row, 3000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:     int64(i),
+			Name:   fmt.Sprintf("name-%d", i%10),
+			Public: int32(i % 7),
+		}
+		if i%3 != 0 {
+			secret := fmt.Sprintf("secret-%d", i)
+			rows[i].Secret = &secret
+		}
+	}
+
+	footerKey := []byte("0123456789abcdef")
+	columnKeys := map[string][]byte{
+		"name":   []byte("name-key-0123456789abcdef"[:24]),
+		"secret": []byte("secret-key-0123456789abcdef-0123"),
+	}
+
+	for _, test := range []struct {
+		scenario   string
+		encryption parquet.EncryptionConfig
+		options    []parquet.WriterOption
+	}{
+		{
+			scenario:   "uniform encryption with footer key",
+			encryption: parquet.EncryptionConfig{FooterKey: footerKey},
+		},
+		{
+			scenario: "column keys with encrypted footer",
+			encryption: parquet.EncryptionConfig{
+				FooterKey:         footerKey,
+				FooterKeyMetadata: []byte("footer"),
+				Columns: []parquet.ColumnEncryption{
+					{Path: []string{"id"}},
+					{Path: []string{"name"}, Key: columnKeys["name"], KeyMetadata: []byte("name")},
+					{Path: []string{"secret"}, Key: columnKeys["secret"], KeyMetadata: []byte("secret")},
+				},
+				AADPrefix: []byte("table"),
+			},
+			options: []parquet.WriterOption{parquet.DataPageVersion(1)},
+		},
+		{
+			scenario: "column keys with plaintext footer",
+			encryption: parquet.EncryptionConfig{
+				FooterKey:         footerKey,
+				FooterKeyMetadata: []byte("footer"),
+				Columns: []parquet.ColumnEncryption{
+					{Path: []string{"id"}},
+					{Path: []string{"secret"}, Key: columnKeys["secret"], KeyMetadata: []byte("secret")},
+				},
+				PlaintextFooter: true,
+				AADPrefix:       []byte("table"),
+				SupplyAADPrefix: true,
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			options := []parquet.WriterOption{
+				parquet.PageBufferSize(1024),
+				parquet.MaxRowsPerRowGroup(1000),
+				parquet.Compression(&parquet.Snappy),
+				parquet.DataPageStatistics(true),
+				parquet.BloomFilters(
+					parquet.SplitBlockFilter(10, "secret"),
+					parquet.SplitBlockFilter(10, "public"),
+				),
+			}
+			options = append(options, test.options...)
+
+			plain := new(bytes.Buffer)
+			if err := parquet.Write(plain, rows, options...); err != nil {
+				t.Fatal(err)
+			}
+			encrypted := new(bytes.Buffer)
+			if err := parquet.Write(encrypted, rows, append(options, parquet.Encryption(&test.encryption))...); err != nil {
+				t.Fatal(err)
+			}
+
+			keys := map[string][]byte{"": footerKey}
+			for _, column := range test.encryption.Columns {
+				if column.Key != nil {
+					keys[strings.Join(column.Path, ".")] = column.Key
+				}
+			}
+			d := &testDecryptor{t: t, data: encrypted.Bytes(), aadPrefix: test.encryption.AADPrefix, keys: keys}
+			d.checkFile(&test.encryption, plain.Bytes())
+		})
+	}
+}
+
+func TestEncryptionConfigValidate(t *testing.T) {
+	key := make([]byte, 16)
+
+	for _, test := range []struct {
+		scenario string
+		config   parquet.EncryptionConfig
+		valid    bool
+	}{
+		{"valid", parquet.EncryptionConfig{FooterKey: key}, true},
+		{"missing footer key", parquet.EncryptionConfig{}, false},
+		{"invalid footer key", parquet.EncryptionConfig{FooterKey: key[:10]}, false},
+		{"invalid column key", parquet.EncryptionConfig{FooterKey: key, Columns: []parquet.ColumnEncryption{{Path: []string{"a"}, Key: key[:1]}}}, false},
+		{"empty column path", parquet.EncryptionConfig{FooterKey: key, Columns: []parquet.ColumnEncryption{{}}}, false},
+		{"duplicate column", parquet.EncryptionConfig{FooterKey: key, Columns: []parquet.ColumnEncryption{{Path: []string{"a"}}, {Path: []string{"a"}}}}, false},
+		{"supplied AAD prefix without prefix", parquet.EncryptionConfig{FooterKey: key, SupplyAADPrefix: true}, false},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := parquet.NewWriterConfig(parquet.Encryption(&test.config))
+			if valid := err == nil; valid != test.valid {
+				t.Errorf("configuration validity mismatch: want=%t got=%t (%v)", test.valid, valid, err)
+			}
+			if err != nil && strings.Contains(err.Error(), string(key)) {
+				t.Errorf("error message contains the key: %v", err)
+			}
+		})
+	}
+
+	t.Run("unknown column", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic when encrypting a column which does not exist")
+			}
+		}()
+		type Row struct{ A int64 }
+		parquet.NewGenericWriter[Row](new(bytes.Buffer), parquet.Encryption(&parquet.EncryptionConfig{
+			FooterKey: key,
+			Columns:   []parquet.ColumnEncryption{{Path: []string{"B"}}},
+		}))
+	})
+}
+
+// testDecryptor decrypts the modules of a file written with encryption, to
+// compare them with the modules of the same file written without encryption.
+type testDecryptor struct {
+	t          *testing.T
+	data       []byte
+	aadPrefix  []byte
+	fileUnique []byte
+	keys       map[string][]byte
+}
+
+func (d *testDecryptor) checkFile(config *parquet.EncryptionConfig, plainData []byte) {
+	t := d.t
+	t.Helper()
+
+	magic := "PARE"
+	if config.PlaintextFooter {
+		magic = "PAR1"
+	}
+	if head, tail := string(d.data[:4]), string(d.data[len(d.data)-4:]); head != magic || tail != magic {
+		t.Fatalf("wrong magic: want=%q got=%q/%q", magic, head, tail)
+	}
+
+	footerLength := int(binary.LittleEndian.Uint32(d.data[len(d.data)-8:]))
+	footer := d.data[len(d.data)-8-footerLength : len(d.data)-8]
+	metadata := new(format.FileMetaData)
+
+	if config.PlaintextFooter {
+		const signatureSize = 12 + 16
+		serialized := footer[:len(footer)-signatureSize]
+		if err := thrift.Unmarshal(new(thrift.CompactProtocol), serialized, metadata); err != nil {
+			t.Fatal(err)
+		}
+		d.checkAlgorithm(config, metadata.EncryptionAlgorithm)
+		if !bytes.Equal(metadata.FooterSigningKeyMetadata, config.FooterKeyMetadata) {
+			t.Errorf("wrong footer signing key metadata: %q", metadata.FooterSigningKeyMetadata)
+		}
+		// The signature is the nonce and tag of the encryption of the footer.
+		signature := footer[len(footer)-signatureSize:]
+		nonce := signature[:12]
+		sealed := newTestGCM(t, d.keys[""]).Seal(nil, nonce, serialized, d.aad(0, -1, -1, -1))
+		if !bytes.Equal(sealed[len(sealed)-16:], signature[12:]) {
+			t.Error("wrong footer signature")
+		}
+	} else {
+		r := bytes.NewReader(footer)
+		crypto := new(format.FileCryptoMetaData)
+		if err := thrift.NewDecoder(new(thrift.CompactProtocol).NewReader(r)).Decode(crypto); err != nil {
+			t.Fatal(err)
+		}
+		d.checkAlgorithm(config, crypto.EncryptionAlgorithm)
+		if !bytes.Equal(crypto.KeyMetadata, config.FooterKeyMetadata) {
+			t.Errorf("wrong footer key metadata: %q", crypto.KeyMetadata)
+		}
+		module := footer[len(footer)-r.Len():]
+		serialized := d.decrypt("", module, d.aad(0, -1, -1, -1))
+		if err := thrift.Unmarshal(new(thrift.CompactProtocol), serialized, metadata); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plain, err := parquet.OpenFile(bytes.NewReader(plainData), int64(len(plainData)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plainMetadata := plain.Metadata()
+	if metadata.NumRows != plainMetadata.NumRows || len(metadata.RowGroups) != len(plainMetadata.RowGroups) {
+		t.Fatalf("wrong number of rows or row groups: %d/%d", metadata.NumRows, len(metadata.RowGroups))
+	}
+	if !reflect.DeepEqual(metadata.Schema, plainMetadata.Schema) {
+		t.Error("schemas of the plaintext and encrypted files differ")
+	}
+
+	for i := range metadata.RowGroups {
+		for j := range metadata.RowGroups[i].Columns {
+			chunk := &metadata.RowGroups[i].Columns[j]
+			plainChunk := &plainMetadata.RowGroups[i].Columns[j]
+			plainColumn := plain.RowGroups()[i].ColumnChunks()[j].(*parquet.FileColumnChunk)
+			d.checkColumnChunk(config, i, j, chunk, plainChunk, plainColumn, plainData)
+		}
+	}
+}
+
+func (d *testDecryptor) checkAlgorithm(config *parquet.EncryptionConfig, algorithm format.EncryptionAlgorithm) {
+	t := d.t
+	t.Helper()
+	if algorithm.AesGcmV1 == nil {
+		t.Fatal("missing AES_GCM_V1 encryption algorithm")
+	}
+	if len(algorithm.AesGcmV1.AadFileUnique) != 8 {
+		t.Errorf("wrong length of file unique identifier: %d", len(algorithm.AesGcmV1.AadFileUnique))
+	}
+	if algorithm.AesGcmV1.SupplyAadPrefix != config.SupplyAADPrefix {
+		t.Errorf("wrong supply AAD prefix flag: %t", algorithm.AesGcmV1.SupplyAadPrefix)
+	}
+	if config.SupplyAADPrefix {
+		if algorithm.AesGcmV1.AadPrefix != nil {
+			t.Error("supplied AAD prefix stored in file")
+		}
+	} else if !bytes.Equal(algorithm.AesGcmV1.AadPrefix, config.AADPrefix) {
+		t.Errorf("wrong AAD prefix: %q", algorithm.AesGcmV1.AadPrefix)
+	}
+	d.fileUnique = algorithm.AesGcmV1.AadFileUnique
+}
+
+func (d *testDecryptor) checkColumnChunk(config *parquet.EncryptionConfig, rowGroup, column int, chunk, plainChunk *format.ColumnChunk, plainColumn *parquet.FileColumnChunk, plainData []byte) {
+	t := d.t
+	t.Helper()
+
+	path := strings.Join(plainChunk.MetaData.PathInSchema, ".")
+	key, encrypted := "", len(config.Columns) == 0
+	for _, c := range config.Columns {
+		if strings.Join(c.Path, ".") == path {
+			encrypted = true
+			if c.Key != nil {
+				key = path
+				crypto := chunk.CryptoMetadata.EncryptionWithColumnKey
+				if crypto == nil || !reflect.DeepEqual(crypto.PathInSchema, c.Path) || !bytes.Equal(crypto.KeyMetadata, c.KeyMetadata) {
+					t.Errorf("column %s: wrong crypto metadata: %+v", path, chunk.CryptoMetadata)
+				}
+			}
+		}
+	}
+	if encrypted && key == "" && chunk.CryptoMetadata.EncryptionWithFooterKey == nil {
+		t.Errorf("column %s: missing crypto metadata of column encrypted with the footer key", path)
+	}
+	if !encrypted && !reflect.DeepEqual(chunk.CryptoMetadata, format.ColumnCryptoMetaData{}) {
+		t.Errorf("column %s: unexpected crypto metadata of plaintext column", path)
+	}
+
+	metadata := &chunk.MetaData
+	if encryptsMetadata := encrypted && (config.PlaintextFooter || key != ""); encryptsMetadata {
+		if config.PlaintextFooter {
+			if metadata.NumValues != plainChunk.MetaData.NumValues || metadata.Statistics.MinValue != nil {
+				t.Errorf("column %s: wrong plaintext metadata of encrypted column: %+v", path, metadata)
+			}
+		} else if !reflect.DeepEqual(*metadata, format.ColumnMetaData{}) {
+			t.Errorf("column %s: plaintext metadata of column encrypted with column key", path)
+		}
+		serialized := d.decrypt(key, chunk.EncryptedColumnMetadata, d.aad(1, rowGroup, column, -1))
+		metadata = new(format.ColumnMetaData)
+		if err := thrift.Unmarshal(new(thrift.CompactProtocol), serialized, metadata); err != nil {
+			t.Fatal(err)
+		}
+	} else if chunk.EncryptedColumnMetadata != nil {
+		t.Errorf("column %s: unexpected encrypted column metadata", path)
+	}
+
+	if !reflect.DeepEqual(metadata.Statistics, plainChunk.MetaData.Statistics) {
+		t.Errorf("column %s: statistics differ from the plaintext file", path)
+	}
+	if metadata.NumValues != plainChunk.MetaData.NumValues || metadata.Codec != plainChunk.MetaData.Codec || !reflect.DeepEqual(metadata.Encoding, plainChunk.MetaData.Encoding) {
+		t.Errorf("column %s: metadata differs from the plaintext file", path)
+	}
+
+	// Pages of the plaintext file, the encrypted file must have the same pages
+	// and page headers, except for the size of the pages.
+	type page struct {
+		offset int64
+		size   int64
+		header *format.PageHeader
+		data   []byte
+	}
+	var plainPages []page
+	headers := plainColumn.PageHeaders()
+	defer headers.Close()
+	for {
+		h, err := headers.ReadPageHeader()
+		if err != nil {
+			break
+		}
+		start := h.Offset + h.HeaderSize
+		plainPages = append(plainPages, page{header: h.Header, data: plainData[start : start+int64(h.Header.CompressedPageSize)]})
+	}
+
+	var pages []page
+	offset := metadata.DataPageOffset
+	if metadata.DictionaryPageOffset != 0 {
+		offset = metadata.DictionaryPageOffset
+	}
+	end := offset + metadata.TotalCompressedSize
+	numDataPages := 0
+	for offset < end {
+		p := page{offset: offset, header: new(format.PageHeader)}
+		headerModule, pageModule := byte(4), byte(2)
+		pageOrdinal := numDataPages
+		if len(pages) == 0 && metadata.DictionaryPageOffset != 0 {
+			headerModule, pageModule, pageOrdinal = 5, 3, -1
+		}
+
+		var serialized []byte
+		if encrypted {
+			module := d.module(offset)
+			serialized = d.decrypt(key, module, d.aad(headerModule, rowGroup, column, pageOrdinal))
+			offset += int64(len(module))
+		} else {
+			r := bytes.NewReader(d.data[offset:end])
+			if err := thrift.NewDecoder(new(thrift.CompactProtocol).NewReader(r)).Decode(p.header); err != nil {
+				t.Fatal(err)
+			}
+			serialized = d.data[offset : end-int64(r.Len())]
+			offset = end - int64(r.Len())
+		}
+		if err := thrift.Unmarshal(new(thrift.CompactProtocol), serialized, p.header); err != nil {
+			t.Fatal(err)
+		}
+
+		data := d.data[offset : offset+int64(p.header.CompressedPageSize)]
+		offset += int64(len(data))
+		if encrypted {
+			data = d.decrypt(key, data, d.aad(pageModule, rowGroup, column, pageOrdinal))
+		}
+		p.data, p.size = data, offset-p.offset
+		if p.header.Type != format.DictionaryPage {
+			numDataPages++
+		}
+		pages = append(pages, p)
+	}
+	if offset != end {
+		t.Fatalf("column %s: pages overflow the column chunk", path)
+	}
+
+	if len(pages) != len(plainPages) {
+		t.Fatalf("column %s: wrong number of pages: want=%d got=%d", path, len(plainPages), len(pages))
+	}
+	for k := range pages {
+		header, plainHeader := *pages[k].header, *plainPages[k].header
+		if encrypted {
+			if header.CompressedPageSize != plainHeader.CompressedPageSize+32 {
+				t.Errorf("column %s: page %d: wrong compressed size of encrypted page: %d", path, k, header.CompressedPageSize)
+			}
+			header.CompressedPageSize = plainHeader.CompressedPageSize
+		}
+		if !reflect.DeepEqual(header, plainHeader) {
+			t.Errorf("column %s: page %d: headers differ:\nwant: %+v\ngot:  %+v", path, k, plainHeader, header)
+		}
+		if !bytes.Equal(pages[k].data, plainPages[k].data) {
+			t.Errorf("column %s: page %d: page data differ", path, k)
+		}
+	}
+
+	decodeIndex := func(module byte, offset int64, length int32, index any) {
+		t.Helper()
+		serialized := d.data[offset : offset+int64(length)]
+		if encrypted {
+			if int64(len(d.module(offset))) != int64(length) {
+				t.Errorf("column %s: wrong length of index module: %d", path, length)
+			}
+			serialized = d.decrypt(key, serialized, d.aad(module, rowGroup, column, -1))
+		}
+		if err := thrift.Unmarshal(new(thrift.CompactProtocol), serialized, index); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	columnIndex, plainColumnIndex := new(format.ColumnIndex), new(format.ColumnIndex)
+	decodeIndex(6, chunk.ColumnIndexOffset, chunk.ColumnIndexLength, columnIndex)
+	if err := thrift.Unmarshal(new(thrift.CompactProtocol), plainData[plainChunk.ColumnIndexOffset:plainChunk.ColumnIndexOffset+int64(plainChunk.ColumnIndexLength)], plainColumnIndex); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(columnIndex, plainColumnIndex) {
+		t.Errorf("column %s: column indexes differ", path)
+	}
+
+	offsetIndex := new(format.OffsetIndex)
+	decodeIndex(7, chunk.OffsetIndexOffset, chunk.OffsetIndexLength, offsetIndex)
+	dataPages := pages
+	if metadata.DictionaryPageOffset != 0 {
+		dataPages = pages[1:]
+	}
+	if len(offsetIndex.PageLocations) != len(dataPages) {
+		t.Fatalf("column %s: wrong number of page locations: %d", path, len(offsetIndex.PageLocations))
+	}
+	for k, location := range offsetIndex.PageLocations {
+		if location.Offset != dataPages[k].offset || int64(location.CompressedPageSize) != dataPages[k].size {
+			t.Errorf("column %s: page %d: wrong page location: %+v", path, k, location)
+		}
+	}
+
+	if plainChunk.MetaData.BloomFilterOffset != 0 {
+		plainFilter := plainColumn.BloomFilter()
+		want := make([]byte, plainFilter.Size())
+		if _, err := plainFilter.ReadAt(want, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		offset := metadata.BloomFilterOffset
+		var got []byte
+		if encrypted {
+			headerModule := d.module(offset)
+			header := new(format.BloomFilterHeader)
+			serialized := d.decrypt(key, headerModule, d.aad(8, rowGroup, column, -1))
+			if err := thrift.Unmarshal(new(thrift.CompactProtocol), serialized, header); err != nil {
+				t.Fatal(err)
+			}
+			got = d.decrypt(key, d.module(offset+int64(len(headerModule))), d.aad(9, rowGroup, column, -1))
+			if len(got) != int(header.NumBytes) {
+				t.Errorf("column %s: wrong size of bloom filter: %d/%d", path, len(got), header.NumBytes)
+			}
+		} else {
+			r := bytes.NewReader(d.data[offset:])
+			header := new(format.BloomFilterHeader)
+			if err := thrift.NewDecoder(new(thrift.CompactProtocol).NewReader(r)).Decode(header); err != nil {
+				t.Fatal(err)
+			}
+			start := int64(len(d.data) - r.Len())
+			got = d.data[start : start+int64(header.NumBytes)]
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("column %s: bloom filters differ", path)
+		}
+	}
+}
+
+// module returns the encrypted module starting at the given offset.
+func (d *testDecryptor) module(offset int64) []byte {
+	length := int64(binary.LittleEndian.Uint32(d.data[offset:]))
+	return d.data[offset : offset+4+length]
+}
+
+func (d *testDecryptor) aad(module byte, rowGroup, column, page int) []byte {
+	aad := append([]byte{}, d.aadPrefix...)
+	aad = append(aad, d.fileUnique...)
+	aad = append(aad, module)
+	for _, ordinal := range []int{rowGroup, column, page} {
+		if ordinal >= 0 {
+			aad = binary.LittleEndian.AppendUint16(aad, uint16(ordinal))
+		}
+	}
+	return aad
+}
+
+func (d *testDecryptor) decrypt(key string, module, aad []byte) []byte {
+	t := d.t
+	t.Helper()
+	if length := int(binary.LittleEndian.Uint32(module)); length != len(module)-4 {
+		t.Fatalf("wrong length of encrypted module: %d/%d", length, len(module)-4)
+	}
+	nonce, ciphertext := module[4:16], module[16:]
+	plaintext, err := newTestGCM(t, d.keys[key]).Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		t.Fatalf("decrypting module: %v", err)
+	}
+	return plaintext
+}
+
+func newTestGCM(t *testing.T, key []byte) cipher.AEAD {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gcm
+}
@@ -0,0 +1,118 @@
+package parquet
+
+import (
+	"fmt"
+	"sort"
+)
+
+// EnumColumn declares the set of values allowed in a column of a parquet
+// file, typically a column of the ENUM logical type.
+//
+// EnumColumn values are configured on writers with the EnumValues option.
+type EnumColumn struct {
+	Path   []string
+	Values []string
+}
+
+// EnumValues creates a configuration option which declares the list of values
+// allowed in the column at the given path.
+//
+// Writers return an error wrapping ErrUnknownEnumValue when they receive a
+// value that is not part of the list. Columns with allowed values are always
+// dictionary-encoded, regardless of the encoding declared in the schema, since
+// the set of distinct values is known to be bounded.
+//
+// This option is additive, it may be used multiple times to declare values of
+// multiple columns.
+func EnumValues(values []string, path ...string) WriterOption {
+	return writerOption(func(config *WriterConfig) {
+		config.Enums = append(config.Enums, EnumColumn{Path: path, Values: values})
+	})
+}
+
+func searchEnumColumn(enums []EnumColumn, path columnPath) map[string]struct{} {
+	for _, enum := range enums {
+		if path.equal(enum.Path) {
+			values := make(map[string]struct{}, len(enum.Values))
+			for _, value := range enum.Values {
+				values[value] = struct{}{}
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+// EnumInteger is the constraint satisfied by the Go types that ENUM values may
+// be mapped to by an EnumMapping.
+type EnumInteger interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// EnumMapping maps the string values of ENUM columns to Go integer constants.
+//
+// The mapping is useful to decode values read from ENUM columns into the
+// constants that a program uses to represent them, for example:
+//
+//	type Color int
+//
+//	const (
+//		Red Color = iota
+//		Green
+//		Blue
+//	)
+//
+//	var colors = parquet.EnumMapping[Color]{
+//		"RED":   Red,
+//		"GREEN": Green,
+//		"BLUE":  Blue,
+//	}
+//
+// The same mapping can be used to declare the allowed values of the column
+// when writing files:
+//
+//	writer := parquet.NewWriter(output, parquet.EnumValues(colors.Names(), "color"))
+type EnumMapping[T EnumInteger] map[string]T
+
+// Names returns the sorted list of string values of the mapping.
+func (m EnumMapping[T]) Names() []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the Go constant that the value maps to. The method returns an
+// error wrapping ErrUnknownEnumValue if the value is not part of the mapping.
+//
+// Null values map to the zero-value of T.
+func (m EnumMapping[T]) Lookup(value Value) (T, error) {
+	if value.IsNull() {
+		return 0, nil
+	}
+	c, ok := m[string(value.byteArray())]
+	if !ok {
+		return 0, fmt.Errorf("%q: %w", value.byteArray(), ErrUnknownEnumValue)
+	}
+	return c, nil
+}
+
+// Decode writes to dst the Go constants that values map to, returning the
+// number of values decoded. Decoding stops at the first value which is not part
+// of the mapping, in which case the method returns an error wrapping
+// ErrUnknownEnumValue.
+//
+// The method panics if dst is shorter than values.
+func (m EnumMapping[T]) Decode(dst []T, values []Value) (int, error) {
+	_ = dst[:len(values)]
+	for i, v := range values {
+		c, err := m.Lookup(v)
+		if err != nil {
+			return i, err
+		}
+		dst[i] = c
+	}
+	return len(values), nil
+}
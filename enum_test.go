@@ -0,0 +1,99 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+type color int
+
+const (
+	red color = iota
+	green
+	blue
+)
+
+var colors = parquet.EnumMapping[color]{
+	"RED":   red,
+	"GREEN": green,
+	"BLUE":  blue,
+}
+
+type enumRow struct {
+	Color string `parquet:"color,enum"`
+}
+
+func TestEnumValues(t *testing.T) {
+	t.Run("unknown value", func(t *testing.T) {
+		w := parquet.NewGenericWriter[enumRow](new(bytes.Buffer), parquet.EnumValues(colors.Names(), "color"))
+		_, err := w.Write([]enumRow{{"RED"}, {"PURPLE"}})
+		if !errors.Is(err, parquet.ErrUnknownEnumValue) {
+			t.Fatalf("expected ErrUnknownEnumValue, got %v", err)
+		}
+		var rowErr *parquet.RowError
+		if !errors.As(err, &rowErr) || rowErr.Row != 1 {
+			t.Fatalf("expected error on row 1, got %v", err)
+		}
+	})
+
+	t.Run("dictionary encoding", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[enumRow](buf, parquet.EnumValues(colors.Names(), "color"))
+		if _, err := w.Write([]enumRow{{"RED"}, {"BLUE"}, {"RED"}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		encodings := f.Metadata().RowGroups[0].Columns[0].MetaData.Encoding
+		if !slices.Contains(encodings, format.RLEDictionary) {
+			t.Errorf("column is not dictionary-encoded: %v", encodings)
+		}
+
+		pages := f.RowGroups()[0].ColumnChunks()[0].Pages()
+		defer pages.Close()
+		page, err := pages.ReadPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values := make([]parquet.Value, page.NumValues())
+		if _, err := page.Values().ReadValues(values); err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+
+		decoded := make([]color, len(values))
+		if _, err := colors.Decode(decoded, values); err != nil {
+			t.Fatal(err)
+		}
+		if want := []color{red, blue, red}; !slices.Equal(decoded, want) {
+			t.Errorf("wrong decoded values: %v != %v", decoded, want)
+		}
+	})
+}
+
+func TestEnumMappingLookup(t *testing.T) {
+	c, err := colors.Lookup(parquet.ByteArrayValue([]byte("GREEN")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != green {
+		t.Errorf("wrong constant: %d", c)
+	}
+	if _, err := colors.Lookup(parquet.ByteArrayValue([]byte("PURPLE"))); !errors.Is(err, parquet.ErrUnknownEnumValue) {
+		t.Errorf("expected ErrUnknownEnumValue, got %v", err)
+	}
+	if c, err := colors.Lookup(parquet.NullValue()); err != nil || c != 0 {
+		t.Errorf("null value: %d, %v", c, err)
+	}
+}
@@ -3,6 +3,7 @@ package parquet
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -51,10 +52,52 @@ var (
 	// decode definition levels into a page which is part of a required column.
 	ErrUnexpectedDefinitionLevels = errors.New("unexpected definition levels")
 
+	// ErrInvalidDefinitionLevels is returned when reading a data page where the
+	// definition levels are inconsistent with the number of values in the
+	// page, if the file was opened with the CheckDefinitionLevels option. The
+	// RepairDefinitionLevels option may be used to read files from writers
+	// known to produce such pages.
+	ErrInvalidDefinitionLevels = errors.New("definition levels do not match the number of values in the page")
+
+	// ErrTooManyRepeatedValues is returned when reading a row which holds more
+	// values in one of its columns than the limit configured with the
+	// MaxRepeatedValues option.
+	ErrTooManyRepeatedValues = errors.New("too many values in a repeated column of a row")
+
+	// ErrInvalidUTF8 is returned by readers and writers configured with the
+	// UTF8Validate policy when a value of a STRING column is not valid UTF-8.
+	ErrInvalidUTF8 = errors.New("invalid UTF-8 sequence in STRING value")
+
+	// ErrUnknownEnumValue is returned when writing a value which is not part of
+	// the values declared with the EnumValues option, or when decoding a value
+	// which is not part of an EnumMapping.
+	ErrUnknownEnumValue = errors.New("unknown enum value")
+
 	// ErrTooManyRowGroups is returned when attempting to generate a parquet
 	// file with more than MaxRowGroups row groups.
 	ErrTooManyRowGroups = errors.New("the limit of 32767 row groups has been reached")
 
+	// ErrTooManyLevels is the error wrapped when constructing a schema, or
+	// opening a file, with columns nested deeper than MaxColumnDepth or with
+	// more repetition or definition levels than MaxRepetitionLevel and
+	// MaxDefinitionLevel.
+	ErrTooManyLevels = errors.New("parquet column nested beyond the levels supported by this package")
+
+	// ErrNarrowingConversion is returned by readers converting the values of
+	// columns to narrower types of the read schema, when a value does not fit
+	// in the narrower type or when the NarrowingReject policy is configured.
+	ErrNarrowingConversion = errors.New("narrowing conversion of parquet values")
+
+	// ErrColumnMasked is returned when reading bytes of a file returned by
+	// File.Masked which are not part of the column chunks it exposes.
+	ErrColumnMasked = errors.New("read of masked parquet column")
+
+	// ErrNotDurable is wrapped in the errors returned by WriteFileAtomic when
+	// the file was published at its final path, but the directory holding it
+	// could not be synced to stable storage; the file may not survive a crash
+	// of the system.
+	ErrNotDurable = errors.New("parquet file was written but may not be durable")
+
 	// ErrConversion is used to indicate that a conversion betwen two values
 	// cannot be done because there are no rules to translate between their
 	// physical types.
@@ -78,6 +121,78 @@ func (e errno) check() {
 	}
 }
 
+// RowError is the error type returned by writers configured with StrictWrite
+// when they receive an invalid row, or by readers and writers rejecting a row
+// because of their UTF8Policy.
+type RowError struct {
+	// Index of the invalid row in the list of rows passed to the write method,
+	// or index of the row in the reader when returned by a read method.
+	Row int
+	// Path to the column holding the invalid values, nil if the error does not
+	// concern a specific column.
+	Path []string
+	// The validation error.
+	Err error
+}
+
+func (e *RowError) Error() string {
+	if e.Path == nil {
+		return fmt.Sprintf("invalid row at index %d: %v", e.Row, e.Err)
+	}
+	return fmt.Sprintf("invalid row at index %d: column %s: %v", e.Row, columnPath(e.Path), e.Err)
+}
+
+func (e *RowError) Unwrap() error { return e.Err }
+
+// ValidationError is the error type describing the issues found by
+// ValidateFile.
+type ValidationError struct {
+	// Index of the row group where the issue was found, or -1 if the issue
+	// concerns the whole file.
+	RowGroup int
+	// Path to the column where the issue was found, nil if the issue does not
+	// concern a specific column.
+	Path []string
+	// Index of the data page where the issue was found, or -1 if the issue
+	// does not concern a specific page.
+	Page int
+	// The description of the issue.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	if e.RowGroup >= 0 {
+		fmt.Fprintf(&b, "row group %d: ", e.RowGroup)
+	}
+	if e.Path != nil {
+		fmt.Fprintf(&b, "column %s: ", columnPath(e.Path))
+	}
+	if e.Page >= 0 {
+		fmt.Fprintf(&b, "page %d: ", e.Page)
+	}
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// NodeMismatchError is the error type returned by CompareNodes to describe the
+// first difference found between two nodes.
+type NodeMismatchError struct {
+	// Path to the mismatching node, nil if the root nodes differ.
+	Path []string
+	// Human-readable description of the difference.
+	Reason string
+}
+
+func (e *NodeMismatchError) Error() string {
+	if len(e.Path) == 0 {
+		return "nodes mismatch: " + e.Reason
+	}
+	return fmt.Sprintf("nodes mismatch at %s: %s", columnPath(e.Path), e.Reason)
+}
+
 func errRowIndexOutOfBounds(rowIndex, rowCount int64) error {
 	return fmt.Errorf("row index out of bounds: %d/%d", rowIndex, rowCount)
 }
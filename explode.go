@@ -0,0 +1,335 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Explode returns a view of rowGroup where each element of the list column at
+// the given dot-separated path becomes its own row, with the values of the
+// other columns duplicated in each of the rows produced from the same row.
+//
+// The column may be a group of the LIST logical type, in which case the
+// elements of the list replace the group in the schema of the returned row
+// group, or a repeated field, which becomes a required field. Rows where the
+// list is null or empty do not produce any rows.
+//
+// The rows are produced by rewriting the repetition and definition levels of
+// the values read from rowGroup, without going through Go values. The column
+// chunks of the returned row group are materialized in memory the first time
+// their pages are read.
+//
+// The function panics if the column does not exist, is not a list, or is
+// nested within a repeated field.
+func Explode(rowGroup RowGroup, listColumn string) RowGroup {
+	path := strings.Split(listColumn, ".")
+	schema := rowGroup.Schema()
+
+	root, definitionLevel, shift, err := explodedNodeOf(schema, path, 0)
+	if err != nil {
+		panic(fmt.Errorf("cannot explode column %q of parquet schema %s: %w", listColumn, schema.Name(), err))
+	}
+
+	g := &explodedRowGroup{
+		base:            rowGroup,
+		schema:          NewSchema(schema.Name(), root),
+		first:           -1,
+		definitionLevel: definitionLevel,
+		shift:           shift,
+	}
+
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		if len(leaf.path) >= len(path) && leaf.path[:len(path)].equal(path) {
+			if g.first < 0 {
+				g.first = int(leaf.columnIndex)
+			}
+			g.last = int(leaf.columnIndex) + 1
+		}
+	})
+
+	forEachLeafColumnOf(g.schema, func(leaf leafColumn) {
+		columnIndex := int(leaf.columnIndex)
+		g.columns = append(g.columns, &lazyColumnChunk{
+			typ:    leaf.node.Type(),
+			column: columnIndex,
+			load: func() (ColumnChunk, error) {
+				buffer, err := g.materialize()
+				if err != nil {
+					return nil, fmt.Errorf("exploding row group: %w", err)
+				}
+				return buffer.ColumnChunks()[columnIndex], nil
+			},
+		})
+	})
+
+	for _, sortingColumn := range rowGroup.SortingColumns() {
+		sortingPath := sortingColumn.Path()
+		if columnPath(sortingPath[:min(len(path), len(sortingPath))]).equal(path) {
+			break
+		}
+		if _, ok := g.schema.Lookup(sortingPath...); !ok {
+			break
+		}
+		g.sorting = append(g.sorting, sortingColumn)
+	}
+
+	g.numRows, g.err = g.countElements()
+	return g
+}
+
+// explodedNodeOf returns a copy of node where the list at the given path is
+// replaced by its elements. The function also returns the definition level at
+// which list elements exist, and the number of definition levels that are
+// removed from the values of the elements.
+func explodedNodeOf(node Node, path []string, definitionLevel byte) (Node, byte, byte, error) {
+	if len(path) == 0 {
+		switch fields := node.Fields(); {
+		case node.Repeated():
+			return Required(node), definitionLevel + 1, 1, nil
+		case isListGroup(node) && len(fields) == 1 && fields[0].Repeated():
+			shift := byte(1)
+			if node.Optional() {
+				definitionLevel++
+				shift++
+			}
+			var element Node = Required(fields[0])
+			if !isLegacyListElement(node, fields[0]) {
+				element = fields[0].Fields()[0]
+			}
+			return element, definitionLevel + 1, shift, nil
+		default:
+			return nil, 0, 0, errors.New("the column is not a list nor a repeated field")
+		}
+	}
+
+	if node.Leaf() {
+		return nil, 0, 0, errors.New("the column does not exist")
+	}
+	if node.Repeated() {
+		return nil, 0, 0, errors.New("the column is nested within a repeated field")
+	}
+	if node.Optional() {
+		definitionLevel++
+	}
+
+	fields := node.Fields()
+	for i, field := range fields {
+		if field.Name() != path[0] {
+			continue
+		}
+		newField, level, shift, err := explodedNodeOf(field, path[1:], definitionLevel)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		newFields := make([]Field, len(fields))
+		copy(newFields, fields)
+		newFields[i] = &groupField{Node: newField, name: field.Name()}
+		return &groupNodeWithFields{Node: node, fields: newFields}, level, shift, nil
+	}
+	return nil, 0, 0, errors.New("the column does not exist")
+}
+
+type explodedRowGroup struct {
+	base    RowGroup
+	schema  *Schema
+	columns []ColumnChunk
+	sorting []SortingColumn
+	numRows int64
+	err     error
+	// Range of leaf columns of the list, the definition level at which list
+	// elements exist, and the number of definition levels removed from the
+	// values of elements.
+	first           int
+	last            int
+	definitionLevel byte
+	shift           byte
+
+	once   sync.Once
+	buffer *Buffer
+	bufErr error
+}
+
+func (g *explodedRowGroup) NumRows() int64                  { return g.numRows }
+func (g *explodedRowGroup) ColumnChunks() []ColumnChunk     { return g.columns }
+func (g *explodedRowGroup) Schema() *Schema                 { return g.schema }
+func (g *explodedRowGroup) SortingColumns() []SortingColumn { return g.sorting }
+
+func (g *explodedRowGroup) Rows() Rows {
+	return &explodedRows{
+		group:  g,
+		rows:   g.base.Rows(),
+		buffer: make([]Row, defaultRowBufferSize),
+		bounds: make([][]int, g.last-g.first),
+		err:    g.err,
+	}
+}
+
+// countElements counts the elements of the lists of the row group, which only
+// requires reading the levels of the first leaf column of the list.
+func (g *explodedRowGroup) countElements() (numRows int64, err error) {
+	chunk := g.base.ColumnChunks()[g.first]
+	err = ReadLevels(chunk, func(levels PageLevels) error {
+		for i, repetitionLevel := range levels.RepetitionLevels {
+			if repetitionLevel <= 1 && levels.DefinitionLevels[i] >= g.definitionLevel {
+				numRows++
+			}
+		}
+		return nil
+	})
+	return numRows, err
+}
+
+// materialize returns a buffer holding the exploded rows, which is used to
+// serve the column chunks.
+func (g *explodedRowGroup) materialize() (*Buffer, error) {
+	g.once.Do(func() {
+		rows := g.Rows()
+		defer rows.Close()
+		g.buffer = NewBuffer(g.schema)
+		_, g.bufErr = CopyRows(g.buffer, rows)
+	})
+	return g.buffer, g.bufErr
+}
+
+type explodedRows struct {
+	group  *explodedRowGroup
+	rows   Rows
+	buffer []Row
+	skip   []Row
+	index  int
+	count  int
+	err    error
+	// Offsets of the values of each element of the current row, for each
+	// leaf column of the list, and the range of values of list columns.
+	bounds   [][]int
+	element  int
+	head     int
+	tail     int
+	rowIndex int64
+}
+
+func (r *explodedRows) ReadRows(rows []Row) (int, error) {
+	n := 0
+	for n < len(rows) {
+		if r.index == r.count {
+			// Reading more rows would invalidate the values of the rows
+			// returned by this call.
+			if n > 0 {
+				break
+			}
+			if r.err != nil {
+				return 0, r.err
+			}
+			clearRows(r.buffer[:r.count])
+			r.index = 0
+			r.count, r.err = r.rows.ReadRows(r.buffer)
+			if r.count == 0 && r.err == nil {
+				r.err = io.ErrNoProgress
+			}
+			if r.count > 0 {
+				r.split(r.buffer[0])
+			}
+			continue
+		}
+		if r.element >= len(r.bounds[0])-1 {
+			if r.index++; r.index < r.count {
+				r.split(r.buffer[r.index])
+			}
+			continue
+		}
+		rows[n] = r.appendElement(rows[n][:0], r.buffer[r.index], r.element)
+		r.element++
+		r.rowIndex++
+		n++
+	}
+	return n, nil
+}
+
+// split locates the values of each element of the list in row. Values of rows
+// are ordered by column, so the values of the list are contiguous and each
+// column ends where the next one starts.
+func (r *explodedRows) split(row Row) {
+	g := r.group
+	r.element = 0
+	r.head = -1
+	r.tail = len(row)
+	for i := range r.bounds {
+		r.bounds[i] = r.bounds[i][:0]
+	}
+
+	prev := -1
+	for i, v := range row {
+		c := v.Column()
+		if c < g.first {
+			continue
+		}
+		if c >= g.last {
+			r.tail = i
+			break
+		}
+		if prev < 0 {
+			r.head = i
+		} else if c != prev {
+			r.bounds[prev-g.first] = append(r.bounds[prev-g.first], i)
+		}
+		prev = c
+		if v.repetitionLevel <= 1 && v.definitionLevel >= g.definitionLevel {
+			r.bounds[c-g.first] = append(r.bounds[c-g.first], i)
+		}
+	}
+
+	if prev < 0 {
+		r.head = r.tail
+	} else {
+		r.bounds[prev-g.first] = append(r.bounds[prev-g.first], r.tail)
+	}
+}
+
+func (r *explodedRows) appendElement(dst Row, row Row, element int) Row {
+	g := r.group
+	dst = append(dst, row[:r.head]...)
+	for _, bounds := range r.bounds {
+		for _, v := range row[bounds[element]:bounds[element+1]] {
+			repetitionLevel := 0
+			if v.repetitionLevel > 1 {
+				repetitionLevel = int(v.repetitionLevel) - 1
+			}
+			definitionLevel := int(v.definitionLevel - g.shift)
+			dst = append(dst, v.Level(repetitionLevel, definitionLevel, v.Column()))
+		}
+	}
+	return append(dst, row[r.tail:]...)
+}
+
+func (r *explodedRows) SeekToRow(rowIndex int64) error {
+	if rowIndex < 0 {
+		return fmt.Errorf("SeekToRow: cannot seek to negative row index %d", rowIndex)
+	}
+	if rowIndex < r.rowIndex {
+		if err := r.rows.SeekToRow(0); err != nil {
+			return err
+		}
+		clearRows(r.buffer[:r.count])
+		r.index, r.count, r.err, r.rowIndex = 0, 0, r.group.err, 0
+	}
+	if r.skip == nil && rowIndex > r.rowIndex {
+		r.skip = make([]Row, defaultRowBufferSize)
+	}
+	for r.rowIndex < rowIndex {
+		n := min(int(rowIndex-r.rowIndex), len(r.skip))
+		if _, err := r.ReadRows(r.skip[:n]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *explodedRows) Close() error { return r.rows.Close() }
+
+func (r *explodedRows) Schema() *Schema { return r.group.schema }
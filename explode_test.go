@@ -0,0 +1,158 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestExplode(t *testing.T) {
+	type Point struct {
+		X int64
+		Y string
+	}
+	type Row struct {
+		ID     int64
+		Tags   []string `parquet:",list"`
+		Points []Point  `parquet:",list"`
+		Score  float64
+	}
+	type ExplodedRow struct {
+		ID     int64
+		Tags   []string `parquet:",list"`
+		Points Point
+		Score  float64
+	}
+
+	rows := []Row{
+		{ID: 1, Tags: []string{"a", "b"}, Points: []Point{{1, "x"}, {2, "y"}, {3, "z"}}, Score: 0.5},
+		{ID: 2, Tags: []string{"c"}, Score: 1.5},
+		{ID: 3, Points: []Point{{4, "w"}}, Score: 2.5},
+	}
+	want := []ExplodedRow{
+		{ID: 1, Tags: []string{"a", "b"}, Points: Point{1, "x"}, Score: 0.5},
+		{ID: 1, Tags: []string{"a", "b"}, Points: Point{2, "y"}, Score: 0.5},
+		{ID: 1, Tags: []string{"a", "b"}, Points: Point{3, "z"}, Score: 0.5},
+		{ID: 3, Tags: []string{}, Points: Point{4, "w"}, Score: 2.5},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exploded := parquet.Explode(f.RowGroups()[0], "Points")
+	if n := exploded.NumRows(); n != int64(len(want)) {
+		t.Errorf("wrong number of rows: want=%d got=%d", len(want), n)
+	}
+	if got, want := exploded.Schema().Columns(), parquet.SchemaOf(ExplodedRow{}).Columns(); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong columns:\nwant = %q\ngot  = %q", want, got)
+	}
+
+	readRows := func(rows parquet.Rows) []ExplodedRow {
+		t.Helper()
+		schema := parquet.SchemaOf(ExplodedRow{})
+		buffer := make([]parquet.Row, 2)
+		values := []ExplodedRow{}
+		for {
+			n, err := rows.ReadRows(buffer)
+			for _, row := range buffer[:n] {
+				var v ExplodedRow
+				if err := schema.Reconstruct(&v, row); err != nil {
+					t.Fatal(err)
+				}
+				values = append(values, v)
+			}
+			if err == io.EOF {
+				return values
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	rs := exploded.Rows()
+	defer rs.Close()
+	if got := readRows(rs); !reflect.DeepEqual(got, want) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", want, got)
+	}
+	if err := rs.SeekToRow(2); err != nil {
+		t.Fatal(err)
+	}
+	if got := readRows(rs); !reflect.DeepEqual(got, want[2:]) {
+		t.Errorf("rows mismatch after seek:\nwant = %+v\ngot  = %+v", want[2:], got)
+	}
+
+	// The column chunks hold the values of the exploded rows.
+	chunk := exploded.ColumnChunks()[2]
+	if n := chunk.NumValues(); n != int64(len(want)) {
+		t.Errorf("wrong number of values in column chunk: want=%d got=%d", len(want), n)
+	}
+	values := make([]parquet.Value, len(want))
+	pages := chunk.Pages()
+	defer pages.Close()
+	page, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := page.Values().ReadValues(values); err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	for i, v := range values {
+		if v.Int64() != want[i].Points.X || v.RepetitionLevel() != 0 || v.DefinitionLevel() != 0 {
+			t.Errorf("wrong value at index %d: %+v", i, v)
+		}
+	}
+}
+
+func TestExplodeRepeatedField(t *testing.T) {
+	type Row struct {
+		Name   string
+		Values []int32
+	}
+
+	buffer := parquet.NewBuffer(parquet.SchemaOf(Row{}))
+	for _, row := range []Row{
+		{Name: "a", Values: []int32{1, 2}},
+		{Name: "b"},
+		{Name: "c", Values: []int32{3}},
+	} {
+		if err := buffer.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exploded := parquet.Explode(buffer, "Values")
+	if n := exploded.NumRows(); n != 3 {
+		t.Errorf("wrong number of rows: want=3 got=%d", n)
+	}
+
+	rows := make([]parquet.Row, 4)
+	rs := exploded.Rows()
+	defer rs.Close()
+	n, err := rs.ReadRows(rows)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	want := []parquet.Row{
+		{parquet.ValueOf("a").Level(0, 0, 0), parquet.ValueOf(int32(1)).Level(0, 0, 1)},
+		{parquet.ValueOf("a").Level(0, 0, 0), parquet.ValueOf(int32(2)).Level(0, 0, 1)},
+		{parquet.ValueOf("c").Level(0, 0, 0), parquet.ValueOf(int32(3)).Level(0, 0, 1)},
+	}
+	if n != len(want) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d", len(want), n)
+	}
+	for i := range want {
+		if !want[i].Equal(rows[i]) {
+			t.Errorf("wrong row at index %d:\nwant = %v\ngot  = %v", i, want[i], rows[i])
+		}
+	}
+}
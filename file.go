@@ -2,10 +2,12 @@ package parquet
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"hash/crc32"
 	"io"
+	"math"
 	"sort"
 	"strings"
 	"sync"
@@ -33,6 +35,8 @@ type File struct {
 	offsetIndexes []format.OffsetIndex
 	rowGroups     []RowGroup
 	config        *FileConfig
+	externalFiles map[string]io.ReaderAt
+	mask          *fileMask
 }
 
 // OpenFile opens a parquet file and reads the content between offset 0 and the given
@@ -42,6 +46,12 @@ type File struct {
 // parts of the file are left untouched; this means that successfully opening
 // a file does not validate that the pages have valid checksums.
 func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
+	return openFileRowGroups(r, size, nil, options)
+}
+
+// openFileRowGroups opens the file of the given size in r. When selected is not
+// nil, only the row groups at these indices are decoded from the footer.
+func openFileRowGroups(r io.ReaderAt, size int64, selected []int, options []FileOption) (*File, error) {
 	b := make([]byte, 8)
 	c, err := NewFileConfig(options...)
 	if err != nil {
@@ -75,14 +85,33 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	if _, err := f.readAt(footerData, size-(footerSize+8)); err != nil {
 		return nil, fmt.Errorf("reading footer of parquet file: %w", err)
 	}
+	if selected != nil {
+		if footerData, err = selectRowGroups(footerData, selected); err != nil {
+			return nil, fmt.Errorf("reading parquet file metadata: %w", err)
+		}
+	}
 	if err := thrift.Unmarshal(&f.protocol, footerData, &f.metadata); err != nil {
 		return nil, fmt.Errorf("reading parquet file metadata: %w", err)
 	}
 	if len(f.metadata.Schema) == 0 {
 		return nil, ErrMissingRootColumn
 	}
+	if selected != nil {
+		f.metadata.NumRows = 0
+		for i := range f.metadata.RowGroups {
+			f.metadata.NumRows += f.metadata.RowGroups[i].NumRows
+		}
+	}
+
+	if c.ResolveFile != nil {
+		if err := f.resolveExternalFiles(c.ResolveFile); err != nil {
+			return nil, err
+		}
+	}
 
-	if !c.SkipPageIndex {
+	// The page index of column chunks stored in external files is not in the
+	// footer section of f, it is read lazily from each file instead.
+	if !c.SkipPageIndex && len(f.externalFiles) == 0 {
 		if f.columnIndexes, f.offsetIndexes, err = f.ReadPageIndex(); err != nil {
 			return nil, fmt.Errorf("reading page index of parquet file: %w", err)
 		}
@@ -97,14 +126,19 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 		schema = c.Schema
 	} else {
 		schema = NewSchema(f.root.Name(), f.root)
+		if value, ok := f.Lookup(ColumnDefaultsKey); ok {
+			// Malformed defaults are ignored, they must not prevent reading
+			// files which do not need to be converted.
+			schema.defaults, _ = decodeColumnDefaults(value)
+		}
 	}
 	columns := make([]*Column, 0, numLeafColumnsOf(f.root))
 	f.schema = schema
 	f.root.forEachLeaf(func(c *Column) { columns = append(columns, c) })
 
-	rowGroups := make([]fileRowGroup, len(f.metadata.RowGroups))
+	rowGroups := make([]FileRowGroup, len(f.metadata.RowGroups))
 	for i := range rowGroups {
-		rowGroups[i].init(f, schema, columns, &f.metadata.RowGroups[i])
+		rowGroups[i].init(f, i, schema, columns, &f.metadata.RowGroups[i])
 	}
 	f.rowGroups = make([]RowGroup, len(rowGroups))
 	for i := range rowGroups {
@@ -124,9 +158,14 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 			g := &rowGroups[i]
 
 			for j := range g.columns {
-				c := g.columns[j].(*fileColumnChunk)
+				c := g.columns[j].(*FileColumnChunk)
 
 				if offset := c.chunk.MetaData.BloomFilterOffset; offset > 0 {
+					reader, section := r, section
+					if c.reader != f {
+						reader = c.reader
+						section = io.NewSectionReader(reader, 0, math.MaxInt64)
+					}
 					section.Seek(offset, io.SeekStart)
 					rbuf.Reset(section)
 
@@ -138,13 +177,13 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 					offset, _ = section.Seek(0, io.SeekCurrent)
 					offset -= int64(rbuf.Buffered())
 
-					if cast, ok := r.(interface{ SetBloomFilterSection(offset, length int64) }); ok {
+					if cast, ok := reader.(interface{ SetBloomFilterSection(offset, length int64) }); ok {
 						bloomFilterOffset := c.chunk.MetaData.BloomFilterOffset
 						bloomFilterLength := (offset - bloomFilterOffset) + int64(header.NumBytes)
 						cast.SetBloomFilterSection(bloomFilterOffset, bloomFilterLength)
 					}
 
-					c.bloomFilter = newBloomFilter(r, offset, &header)
+					c.bloomFilter = newBloomFilter(reader, offset, &header)
 				}
 			}
 		}
@@ -154,6 +193,55 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	return f, nil
 }
 
+// DecodeFileMetaData decodes the footer of the parquet file of the given size
+// in r, without opening the file.
+//
+// Unlike OpenFile, which loads the whole footer in memory before decoding it,
+// the function streams the footer through a buffer of FileConfig.ReadBufferSize
+// bytes, so the memory footprint is bounded by the size of the decoded metadata.
+// This is useful for files with very large footers, for example when they carry
+// statistics for a large number of column chunks. Note that each refill of the
+// buffer results in a call to the ReadAt method of r, programs reading from
+// remote storage may want to configure a larger ReadBufferSize.
+func DecodeFileMetaData(r io.ReaderAt, size int64, options ...FileOption) (*format.FileMetaData, error) {
+	config, err := NewFileConfig(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 8)
+	if _, err := readAt(r, b, size-8); err != nil {
+		return nil, fmt.Errorf("reading magic footer of parquet file: %w", err)
+	}
+	if string(b[4:8]) != "PAR1" {
+		return nil, fmt.Errorf("invalid magic footer of parquet file: %q", b[4:8])
+	}
+
+	footerSize := int64(binary.LittleEndian.Uint32(b[:4]))
+	if footerSize > size-8 {
+		return nil, fmt.Errorf("invalid footer size of parquet file: %d > %d", footerSize, size-8)
+	}
+
+	section := io.NewSectionReader(r, size-(footerSize+8), footerSize)
+	rbuf, rbufpool := getBufioReader(section, config.ReadBufferSize)
+	defer putBufioReader(rbuf, rbufpool)
+
+	metadata := new(format.FileMetaData)
+	protocol := thrift.CompactProtocol{}
+	decoder := thrift.NewDecoder(protocol.NewReader(rbuf))
+
+	if err := decoder.Decode(metadata); err != nil {
+		return nil, fmt.Errorf("reading parquet file metadata: %w", err)
+	}
+	if offset, _ := section.Seek(0, io.SeekCurrent); offset-int64(rbuf.Buffered()) != footerSize {
+		return nil, fmt.Errorf("reading parquet file metadata: %d trailing bytes after the footer", footerSize-(offset-int64(rbuf.Buffered())))
+	}
+	if len(metadata.Schema) == 0 {
+		return nil, ErrMissingRootColumn
+	}
+	return metadata, nil
+}
+
 // ReadPageIndex reads the page index section of the parquet file f.
 //
 // If the file did not contain a page index, the method returns two empty slices
@@ -185,10 +273,8 @@ func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, erro
 		return nil, nil, nil
 	}
 
-	columnIndexOffset := f.metadata.RowGroups[0].Columns[0].ColumnIndexOffset
-	offsetIndexOffset := f.metadata.RowGroups[0].Columns[0].OffsetIndexOffset
-	columnIndexLength := int64(0)
-	offsetIndexLength := int64(0)
+	columnIndexOffset, columnIndexEnd := int64(0), int64(0)
+	offsetIndexOffset, offsetIndexEnd := int64(0), int64(0)
 
 	forEachColumnChunk := func(do func(int, int, *format.ColumnChunk) error) error {
 		for i := range f.metadata.RowGroups {
@@ -202,11 +288,25 @@ func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, erro
 		return nil
 	}
 
+	// The indexes are usually contiguous, but the row groups of files opened
+	// with OpenRowGroups may be a subset of those of the page index section.
 	forEachColumnChunk(func(_, _ int, c *format.ColumnChunk) error {
-		columnIndexLength += int64(c.ColumnIndexLength)
-		offsetIndexLength += int64(c.OffsetIndexLength)
+		if c.ColumnIndexOffset > 0 {
+			if columnIndexOffset == 0 || c.ColumnIndexOffset < columnIndexOffset {
+				columnIndexOffset = c.ColumnIndexOffset
+			}
+			columnIndexEnd = max(columnIndexEnd, c.ColumnIndexOffset+int64(c.ColumnIndexLength))
+		}
+		if c.OffsetIndexOffset > 0 {
+			if offsetIndexOffset == 0 || c.OffsetIndexOffset < offsetIndexOffset {
+				offsetIndexOffset = c.OffsetIndexOffset
+			}
+			offsetIndexEnd = max(offsetIndexEnd, c.OffsetIndexOffset+int64(c.OffsetIndexLength))
+		}
 		return nil
 	})
+	columnIndexLength := columnIndexEnd - columnIndexOffset
+	offsetIndexLength := offsetIndexEnd - offsetIndexOffset
 
 	if columnIndexLength == 0 && offsetIndexLength == 0 {
 		return nil, nil, nil
@@ -279,6 +379,40 @@ func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, erro
 	return columnIndexes, offsetIndexes, nil
 }
 
+// resolveExternalFiles opens the files referenced by the column chunks of f.
+func (f *File) resolveExternalFiles(resolve func(string) (io.ReaderAt, error)) error {
+	for i := range f.metadata.RowGroups {
+		for j := range f.metadata.RowGroups[i].Columns {
+			path := f.metadata.RowGroups[i].Columns[j].FilePath
+			if path == "" {
+				continue
+			}
+			if _, ok := f.externalFiles[path]; ok {
+				continue
+			}
+			r, err := resolve(path)
+			if err != nil {
+				return fmt.Errorf("resolving file %q of column chunk %d in row group %d: %w", path, j, i, err)
+			}
+			if f.externalFiles == nil {
+				f.externalFiles = make(map[string]io.ReaderAt)
+			}
+			f.externalFiles[path] = r
+		}
+	}
+	return nil
+}
+
+// columnChunkReader returns the reader of the content of the given column
+// chunk, which is f itself unless the column chunk is stored in an external
+// file.
+func (f *File) columnChunkReader(chunk *format.ColumnChunk) io.ReaderAt {
+	if r, ok := f.externalFiles[chunk.FilePath]; ok {
+		return r
+	}
+	return f
+}
+
 // NumRows returns the number of rows in the file.
 func (f *File) NumRows() int64 { return f.metadata.NumRows }
 
@@ -336,6 +470,49 @@ func (f *File) Lookup(key string) (value string, ok bool) {
 	return lookupKeyValueMetadata(f.metadata.KeyValueMetadata, key)
 }
 
+// ReadPage reads the data page at pageIndex in the column chunk of the given
+// row group and column.
+//
+// The page is located with the offset index of the column chunk, and only the
+// bytes of the page are read from the file, which is cheaper than iterating
+// over the pages of the column chunk when programs only need one page, for
+// example to serve point lookups or to populate page caches. Pages of
+// dictionary encoded column chunks also require reading the dictionary page.
+// The index of the first row of the page in the row group can be obtained from
+// the offset index.
+//
+// The method returns ErrMissingOffsetIndex if the column chunk has no offset
+// index. The program must call Release on the returned page when it is no
+// longer needed.
+func (f *File) ReadPage(rowGroup, column, pageIndex int) (Page, error) {
+	if rowGroup < 0 || rowGroup >= len(f.rowGroups) {
+		return nil, fmt.Errorf("row group index out of range: %d/%d", rowGroup, len(f.rowGroups))
+	}
+	chunks := f.rowGroups[rowGroup].ColumnChunks()
+	if column < 0 || column >= len(chunks) {
+		return nil, fmt.Errorf("column index out of range: %d/%d", column, len(chunks))
+	}
+	chunk := chunks[column].(*FileColumnChunk)
+
+	offsetIndex, err := chunk.OffsetIndex()
+	if err != nil {
+		return nil, err
+	}
+	if pageIndex < 0 || pageIndex >= offsetIndex.NumPages() {
+		return nil, fmt.Errorf("page index out of range: %d/%d", pageIndex, offsetIndex.NumPages())
+	}
+
+	pages := new(filePages)
+	pages.init(chunk)
+	defer pages.Close()
+
+	page, err := pages.readPageAt(offsetIndex.Offset(pageIndex), offsetIndex.CompressedPageSize(pageIndex))
+	if err != nil {
+		return nil, fmt.Errorf("reading page %d of column %q in row group %d: %w", pageIndex, pages.columnPath(), rowGroup, err)
+	}
+	return page, nil
+}
+
 func (f *File) hasIndexes() bool {
 	return f.columnIndexes != nil && f.offsetIndexes != nil
 }
@@ -365,32 +542,41 @@ func lookupKeyValueMetadata(keyValueMetadata []format.KeyValue, key string) (val
 	return keyValueMetadata[i].Value, true
 }
 
-type fileRowGroup struct {
+// FileRowGroup is the implementation of the RowGroup interface for row groups
+// of parquet files.
+//
+// Programs may type-assert the row groups returned by File.RowGroups to
+// *FileRowGroup to access their location in the file, for example to split
+// files at row group boundaries and process the byte ranges in parallel.
+type FileRowGroup struct {
 	schema   *Schema
 	rowGroup *format.RowGroup
+	ordinal  int
 	columns  []ColumnChunk
 	sorting  []SortingColumn
 	config   *FileConfig
 }
 
-func (g *fileRowGroup) init(file *File, schema *Schema, columns []*Column, rowGroup *format.RowGroup) {
+func (g *FileRowGroup) init(file *File, ordinal int, schema *Schema, columns []*Column, rowGroup *format.RowGroup) {
 	g.schema = schema
+	g.ordinal = ordinal
 	g.rowGroup = rowGroup
 	g.config = file.config
 	g.columns = make([]ColumnChunk, len(rowGroup.Columns))
 	g.sorting = make([]SortingColumn, len(rowGroup.SortingColumns))
-	fileColumnChunks := make([]fileColumnChunk, len(rowGroup.Columns))
+	fileColumnChunks := make([]FileColumnChunk, len(rowGroup.Columns))
 
 	for i := range g.columns {
-		fileColumnChunks[i] = fileColumnChunk{
+		fileColumnChunks[i] = FileColumnChunk{
 			file:     file,
+			reader:   file.columnChunkReader(&rowGroup.Columns[i]),
 			column:   columns[i],
 			rowGroup: rowGroup,
 			chunk:    &rowGroup.Columns[i],
 		}
 
 		if file.hasIndexes() {
-			j := (int(rowGroup.Ordinal) * len(columns)) + i
+			j := (ordinal * len(columns)) + i
 			fileColumnChunks[i].columnIndex = &file.columnIndexes[j]
 			fileColumnChunks[i].offsetIndex = &file.offsetIndexes[j]
 		}
@@ -407,11 +593,40 @@ func (g *fileRowGroup) init(file *File, schema *Schema, columns []*Column, rowGr
 	}
 }
 
-func (g *fileRowGroup) Schema() *Schema                 { return g.schema }
-func (g *fileRowGroup) NumRows() int64                  { return g.rowGroup.NumRows }
-func (g *fileRowGroup) ColumnChunks() []ColumnChunk     { return g.columns }
-func (g *fileRowGroup) SortingColumns() []SortingColumn { return g.sorting }
-func (g *fileRowGroup) Rows() Rows                      { return newRowGroupRows(g, g.config.ReadMode) }
+func (g *FileRowGroup) Schema() *Schema                 { return g.schema }
+func (g *FileRowGroup) NumRows() int64                  { return g.rowGroup.NumRows }
+func (g *FileRowGroup) ColumnChunks() []ColumnChunk     { return g.columns }
+func (g *FileRowGroup) SortingColumns() []SortingColumn { return g.sorting }
+func (g *FileRowGroup) Rows() Rows                      { return newFileRowGroupRows(g, g.config) }
+
+// Ordinal returns the position of the row group in the file, starting at zero.
+func (g *FileRowGroup) Ordinal() int { return g.ordinal }
+
+// FileOffset returns the offset of the first byte of the row group in the
+// file, which is the offset of the first page of its first column chunk.
+func (g *FileRowGroup) FileOffset() int64 {
+	if g.rowGroup.FileOffset != 0 || len(g.rowGroup.Columns) == 0 {
+		return g.rowGroup.FileOffset
+	}
+	// The field is optional and was not set by older writers, fallback to
+	// the offsets of the first column chunk.
+	return columnChunkOffset(&g.rowGroup.Columns[0].MetaData)
+}
+
+// TotalCompressedSize returns the size of the column chunks of the row group
+// in the file, including the headers of their pages. The pages of the row
+// group are stored in the byte range starting at FileOffset and spanning
+// TotalCompressedSize bytes.
+func (g *FileRowGroup) TotalCompressedSize() int64 {
+	if g.rowGroup.TotalCompressedSize != 0 {
+		return g.rowGroup.TotalCompressedSize
+	}
+	size := int64(0)
+	for i := range g.rowGroup.Columns {
+		size += g.rowGroup.Columns[i].MetaData.TotalCompressedSize
+	}
+	return size
+}
 
 type fileSortingColumn struct {
 	column     *Column
@@ -437,8 +652,15 @@ func (s *fileSortingColumn) String() string {
 	return b.String()
 }
 
-type fileColumnChunk struct {
+// FileColumnChunk is the implementation of the ColumnChunk interface for
+// column chunks of parquet files.
+//
+// Programs may type-assert the column chunks of row groups returned by
+// File.RowGroups to *FileColumnChunk to access features that are specific to
+// column chunks read from files.
+type FileColumnChunk struct {
 	file        *File
+	reader      io.ReaderAt
 	column      *Column
 	bloomFilter *bloomFilter
 	rowGroup    *format.RowGroup
@@ -447,21 +669,21 @@ type fileColumnChunk struct {
 	chunk       *format.ColumnChunk
 }
 
-func (c *fileColumnChunk) Type() Type {
+func (c *FileColumnChunk) Type() Type {
 	return c.column.Type()
 }
 
-func (c *fileColumnChunk) Column() int {
+func (c *FileColumnChunk) Column() int {
 	return int(c.column.Index())
 }
 
-func (c *fileColumnChunk) Pages() Pages {
+func (c *FileColumnChunk) Pages() Pages {
 	r := new(filePages)
 	r.init(c)
 	return r
 }
 
-func (c *fileColumnChunk) ColumnIndex() (ColumnIndex, error) {
+func (c *FileColumnChunk) ColumnIndex() (ColumnIndex, error) {
 	if err := c.readColumnIndex(); err != nil {
 		return nil, err
 	}
@@ -471,7 +693,7 @@ func (c *fileColumnChunk) ColumnIndex() (ColumnIndex, error) {
 	return fileColumnIndex{c}, nil
 }
 
-func (c *fileColumnChunk) OffsetIndex() (OffsetIndex, error) {
+func (c *FileColumnChunk) OffsetIndex() (OffsetIndex, error) {
 	if err := c.readOffsetIndex(); err != nil {
 		return nil, err
 	}
@@ -481,30 +703,181 @@ func (c *fileColumnChunk) OffsetIndex() (OffsetIndex, error) {
 	return (*fileOffsetIndex)(c.offsetIndex), nil
 }
 
-func (c *fileColumnChunk) BloomFilter() BloomFilter {
+func (c *FileColumnChunk) BloomFilter() BloomFilter {
 	if c.bloomFilter == nil {
 		return nil
 	}
 	return c.bloomFilter
 }
 
-func (c *fileColumnChunk) NumValues() int64 {
+func (c *FileColumnChunk) NumValues() int64 {
 	return c.chunk.MetaData.NumValues
 }
 
-func (c *fileColumnChunk) readColumnIndex() error {
+// Bounds returns the min and max values recorded in the statistics of the
+// column chunk.
+//
+// The ok boolean is false when the column chunk has no statistics or when they
+// cannot be trusted: the deprecated min and max fields were computed with
+// signed comparisons and are ignored for types ordered otherwise (see
+// SortOrderOf), like the min and max fields of files which do not declare the
+// order of the column (see Column.ColumnOrder), and the statistics of files
+// written by applications with known bugs are ignored (see
+// ApplicationVersion.HasCorrectStatistics). Statistics are always trusted when
+// the min and max values are equal since the order does not matter then.
+//
+// The values have the physical kind of the column, they must be compared with
+// the Compare method of the column type, which implements its sort order; for
+// example the bounds of UINT64 columns are INT64 values compared as unsigned
+// integers.
+func (c *FileColumnChunk) Bounds() (min, max Value, ok bool) {
+	stats := &c.chunk.MetaData.Statistics
+	minValue, maxValue, deprecated := stats.MinValue, stats.MaxValue, false
+	if minValue == nil || maxValue == nil {
+		minValue, maxValue, deprecated = stats.Min, stats.Max, true
+		if minValue == nil || maxValue == nil {
+			return min, max, false
+		}
+	}
+
+	columnType := c.Type()
+	if !bytes.Equal(minValue, maxValue) {
+		if order := SortOrderOf(columnType); order != SignedSortOrder {
+			if deprecated || !c.column.hasTypeDefinedOrder() {
+				return min, max, false
+			}
+		}
+		if !c.file.ApplicationVersion().HasCorrectStatistics(columnType) {
+			return min, max, false
+		}
+	}
+
+	kind := columnType.Kind()
+	return kind.Value(minValue), kind.Value(maxValue), true
+}
+
+// PageHeaders returns an iterator over the headers of pages in the column
+// chunk.
+//
+// Only the page headers are decoded, the content of pages is skipped. This
+// makes the method useful to audit the encodings, sizes, and compression
+// ratios of pages without paying the cost of decoding the values.
+//
+// The returned iterator must be closed when the program does not need it
+// anymore.
+//
+// The method implements the ColumnChunkWithPageHeaders interface.
+func (c *FileColumnChunk) PageHeaders() *FilePageHeaders {
+	h := new(FilePageHeaders)
+	h.init(c)
+	return h
+}
+
+// IsDictionaryEncoded returns true if the encoding statistics of the column
+// chunk prove that all its data pages are dictionary encoded.
+//
+// The method returns false if the column chunk metadata does not have encoding
+// statistics, since the encodings of pages cannot be determined without reading
+// all the page headers in this case.
+func (c *FileColumnChunk) IsDictionaryEncoded() bool {
+	stats := c.chunk.MetaData.EncodingStats
+	hasDictionaryPage := false
+
+	for _, s := range stats {
+		switch s.PageType {
+		case format.DictionaryPage:
+			hasDictionaryPage = s.Count > 0
+		case format.DataPage, format.DataPageV2:
+			if s.Count > 0 && !isDictionaryFormat(s.Encoding) {
+				return false
+			}
+		}
+	}
+
+	return hasDictionaryPage
+}
+
+// MayContain returns false if none of the values exist in the column chunk.
+//
+// When the column chunk is entirely dictionary encoded (see IsDictionaryEncoded),
+// the values are searched in the dictionary page, which allows programs to
+// skip reading data pages of column chunks which cannot match an equality
+// predicate; ScanColumnIn does so before scanning the pages. The values are
+// binary searched in dictionaries declared sorted by their page header (see
+// the SortedDictionaries writer option), unless the file was written by an
+// application known to compare values of the column type incorrectly (see
+// ApplicationVersion.HasCorrectStatistics). In all other cases, or when the
+// values are null, the method conservatively returns true.
+func (c *FileColumnChunk) MayContain(values ...Value) (bool, error) {
+	if len(values) == 0 {
+		return false, nil
+	}
+	if !c.IsDictionaryEncoded() {
+		return true, nil
+	}
+
+	columnType := c.Type()
+	columnKind := columnType.Kind()
+	for _, value := range values {
+		if value.IsNull() || value.Kind() != columnKind {
+			return true, nil
+		}
+	}
+
+	pages := new(filePages)
+	pages.init(c)
+	defer pages.Close()
+
+	if err := pages.readDictionary(); err != nil {
+		return true, fmt.Errorf("reading dictionary of column %q: %w", pages.columnPath(), err)
+	}
+
+	// The order of sorted dictionaries is only relied on when the writer is
+	// not known to have compared values of the column type incorrectly.
+	dict := pages.dictionary
+	if pages.dictionarySorted && c.file.ApplicationVersion().HasCorrectStatistics(columnType) {
+		for _, value := range values {
+			if searchSortedDictionary(dict, columnType, value) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	for i, n := int32(0), int32(dict.Len()); i < n; i++ {
+		v := dict.Index(i)
+		for _, value := range values {
+			if columnType.Compare(v, value) == 0 {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// searchSortedDictionary returns true if the value exists in the dictionary,
+// which must be sorted in the order of the column type.
+func searchSortedDictionary(dict Dictionary, columnType Type, value Value) bool {
+	n := dict.Len()
+	i := sort.Search(n, func(i int) bool {
+		return columnType.Compare(dict.Index(int32(i)), value) >= 0
+	})
+	return i < n && columnType.Compare(dict.Index(int32(i)), value) == 0
+}
+
+func (c *FileColumnChunk) readColumnIndex() error {
 	if c.columnIndex != nil {
 		return nil
 	}
-	chunkMeta := c.file.metadata.RowGroups[c.rowGroup.Ordinal].Columns[c.Column()]
-	offset, length := chunkMeta.ColumnIndexOffset, chunkMeta.ColumnIndexLength
+	offset, length := c.chunk.ColumnIndexOffset, c.chunk.ColumnIndexLength
 	if offset == 0 {
 		return nil
 	}
 
 	indexData := make([]byte, int(length))
 	var columnIndex format.ColumnIndex
-	if _, err := readAt(c.file.reader, indexData, offset); err != nil {
+	if _, err := readAt(c.reader, indexData, offset); err != nil {
 		return fmt.Errorf("read %d bytes column index at offset %d: %w", length, offset, err)
 	}
 	if err := thrift.Unmarshal(&c.file.protocol, indexData, &columnIndex); err != nil {
@@ -514,19 +887,18 @@ func (c *fileColumnChunk) readColumnIndex() error {
 	return nil
 }
 
-func (c *fileColumnChunk) readOffsetIndex() error {
+func (c *FileColumnChunk) readOffsetIndex() error {
 	if c.offsetIndex != nil {
 		return nil
 	}
-	chunkMeta := c.file.metadata.RowGroups[c.rowGroup.Ordinal].Columns[c.Column()]
-	offset, length := chunkMeta.OffsetIndexOffset, chunkMeta.OffsetIndexLength
+	offset, length := c.chunk.OffsetIndexOffset, c.chunk.OffsetIndexLength
 	if offset == 0 {
 		return nil
 	}
 
 	indexData := make([]byte, int(length))
 	var offsetIndex format.OffsetIndex
-	if _, err := readAt(c.file.reader, indexData, offset); err != nil {
+	if _, err := readAt(c.reader, indexData, offset); err != nil {
 		return fmt.Errorf("read %d bytes offset index at offset %d: %w", length, offset, err)
 	}
 	if err := thrift.Unmarshal(&c.file.protocol, indexData, &offsetIndex); err != nil {
@@ -536,8 +908,89 @@ func (c *fileColumnChunk) readOffsetIndex() error {
 	return nil
 }
 
+// FilePageHeader represents the header of a page in a parquet file.
+type FilePageHeader struct {
+	// Offset of the page header, starting from the beginning of the file.
+	Offset int64
+	// Size of the encoded page header. The page content starts at offset
+	// Offset+HeaderSize, and is Header.CompressedPageSize bytes long.
+	HeaderSize int64
+	// The decoded page header.
+	Header *format.PageHeader
+}
+
+// FilePageHeaders is an iterator over the page headers of a column chunk.
+//
+// Instances of FilePageHeaders are created by calling the PageHeaders method
+// of FileColumnChunk.
+type FilePageHeaders struct {
+	rbuf     *bufio.Reader
+	rbufpool *sync.Pool
+	section  *io.SectionReader
+	protocol thrift.CompactProtocol
+	decoder  thrift.Decoder
+	offset   int64
+	size     int64
+	read     int64
+}
+
+func (h *FilePageHeaders) init(c *FileColumnChunk) {
+	h.offset = c.chunk.MetaData.DataPageOffset
+	if offset := c.chunk.MetaData.DictionaryPageOffset; offset != 0 && offset < h.offset {
+		h.offset = offset
+	}
+	h.size = c.chunk.MetaData.TotalCompressedSize
+	h.section = io.NewSectionReader(c.reader, h.offset, h.size)
+	h.rbuf, h.rbufpool = getBufioReader(h.section, c.file.config.ReadBufferSize)
+	h.decoder.Reset(h.protocol.NewReader(h.rbuf))
+}
+
+// ReadPageHeader reads the next page header of the column chunk. The method
+// returns io.EOF after the last page header was read.
+func (h *FilePageHeaders) ReadPageHeader() (FilePageHeader, error) {
+	if h.rbuf == nil || h.read >= h.size {
+		return FilePageHeader{}, io.EOF
+	}
+
+	header := new(format.PageHeader)
+	if err := h.decoder.Decode(header); err != nil {
+		return FilePageHeader{}, fmt.Errorf("decoding page header at offset %d: %w", h.offset, err)
+	}
+
+	sectionOffset, _ := h.section.Seek(0, io.SeekCurrent)
+	headerSize := (sectionOffset - int64(h.rbuf.Buffered())) - h.read
+
+	pageSize := headerSize + int64(header.CompressedPageSize)
+	// Skip the page content; when it was not already buffered, seek past the
+	// page to avoid reading its content from the file.
+	if n := int(header.CompressedPageSize); n <= h.rbuf.Buffered() {
+		h.rbuf.Discard(n)
+	} else {
+		if _, err := h.section.Seek(h.read+pageSize, io.SeekStart); err != nil {
+			return FilePageHeader{}, fmt.Errorf("skipping page at offset %d: %w", h.offset, err)
+		}
+		h.rbuf.Reset(h.section)
+	}
+
+	pageHeader := FilePageHeader{
+		Offset:     h.offset,
+		HeaderSize: headerSize,
+		Header:     header,
+	}
+	h.offset += pageSize
+	h.read += pageSize
+	return pageHeader, nil
+}
+
+// Close releases the resources held by the iterator.
+func (h *FilePageHeaders) Close() error {
+	putBufioReader(h.rbuf, h.rbufpool)
+	h.rbuf, h.rbufpool = nil, nil
+	return nil
+}
+
 type filePages struct {
-	chunk    *fileColumnChunk
+	chunk    *FileColumnChunk
 	rbuf     *bufio.Reader
 	rbufpool *sync.Pool
 	section  io.SectionReader
@@ -551,11 +1004,14 @@ type filePages struct {
 	index      int
 	skip       int64
 	dictionary Dictionary
+	// True when the dictionary page header declared the values of the
+	// dictionary as sorted.
+	dictionarySorted bool
 
 	bufferSize int
 }
 
-func (f *filePages) init(c *fileColumnChunk) {
+func (f *filePages) init(c *FileColumnChunk) {
 	f.chunk = c
 	f.baseOffset = c.chunk.MetaData.DataPageOffset
 	f.dataOffset = f.baseOffset
@@ -566,7 +1022,7 @@ func (f *filePages) init(c *fileColumnChunk) {
 		f.dictOffset = f.baseOffset
 	}
 
-	f.section = *io.NewSectionReader(c.file, f.baseOffset, c.chunk.MetaData.TotalCompressedSize)
+	f.section = *io.NewSectionReader(c.reader, f.baseOffset, c.chunk.MetaData.TotalCompressedSize)
 	f.rbuf, f.rbufpool = getBufioReader(&f.section, f.bufferSize)
 	f.decoder.Reset(f.protocol.NewReader(f.rbuf))
 }
@@ -593,17 +1049,30 @@ func (f *filePages) ReadPage() (Page, error) {
 		if err := f.decoder.Decode(header); err != nil {
 			return nil, err
 		}
+
+		// When seeking to a row past the page, the page is discarded without
+		// reading its content if the header tells how many rows it contains.
+		if numRows, ok := f.numRowsOfPage(header); ok && numRows <= f.skip {
+			if _, err := f.rbuf.Discard(int(header.CompressedPageSize)); err != nil {
+				return nil, err
+			}
+			f.skip -= numRows
+			f.index++
+			continue
+		}
+
 		data, err := f.readPage(header, f.rbuf)
 		if err != nil {
 			return nil, err
 		}
 
 		var page Page
+		var skipped int
 		switch header.Type {
 		case format.DataPageV2:
-			page, err = f.readDataPageV2(header, data)
+			page, skipped, err = f.readDataPageV2(header, data)
 		case format.DataPage:
-			page, err = f.readDataPageV1(header, data)
+			page, skipped, err = f.readDataPageV1(header, data)
 		case format.DictionaryPage:
 			// Sometimes parquet files do not have the dictionary page offset
 			// recorded in the column metadata. We account for this by lazily
@@ -624,12 +1093,13 @@ func (f *filePages) ReadPage() (Page, error) {
 		}
 
 		f.index++
+		f.skip -= int64(skipped)
 		if f.skip == 0 {
 			return page, nil
 		}
 
-		// TODO: what about pages that don't embed the number of rows?
-		// (data page v1 with no offset index in the column chunk).
+		// The decoder may not have skipped all the rows, for example when the
+		// encoding of values does not allow it or the column is repeated.
 		numRows := page.NumRows()
 
 		if numRows <= f.skip {
@@ -646,7 +1116,7 @@ func (f *filePages) ReadPage() (Page, error) {
 }
 
 func (f *filePages) readDictionary() error {
-	chunk := io.NewSectionReader(f.chunk.file, f.baseOffset, f.chunk.chunk.MetaData.TotalCompressedSize)
+	chunk := io.NewSectionReader(f.chunk.reader, f.baseOffset, f.chunk.chunk.MetaData.TotalCompressedSize)
 	rbuf, pool := getBufioReader(chunk, f.bufferSize)
 	defer putBufioReader(rbuf, pool)
 
@@ -677,34 +1147,57 @@ func (f *filePages) readDictionaryPage(header *format.PageHeader, page *buffer)
 		return err
 	}
 	f.dictionary = d
+	f.dictionarySorted = header.DictionaryPageHeader.IsSorted
 	return nil
 }
 
-func (f *filePages) readDataPageV1(header *format.PageHeader, page *buffer) (Page, error) {
+// numRowsOfPage returns the number of rows in the data page of the given header,
+// and whether it could be determined without reading the page.
+func (f *filePages) numRowsOfPage(header *format.PageHeader) (int64, bool) {
+	switch header.Type {
+	case format.DataPageV2:
+		if header.DataPageHeaderV2 != nil {
+			return int64(header.DataPageHeaderV2.NumRows), true
+		}
+	case format.DataPage:
+		// Without repetition levels, each value of the page is a row.
+		if header.DataPageHeader != nil && f.chunk.column.maxRepetitionLevel == 0 {
+			return int64(header.DataPageHeader.NumValues), true
+		}
+	}
+	return 0, false
+}
+
+// skipRowsOfPage returns the number of rows that the page decoder may skip.
+func (f *filePages) skipRowsOfPage() int {
+	return int(min(f.skip, math.MaxInt32))
+}
+
+func (f *filePages) readDataPageV1(header *format.PageHeader, page *buffer) (Page, int, error) {
 	if header.DataPageHeader == nil {
-		return nil, ErrMissingPageHeader
+		return nil, 0, ErrMissingPageHeader
 	}
 	if isDictionaryFormat(header.DataPageHeader.Encoding) && f.dictionary == nil {
 		if err := f.readDictionary(); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 	}
-	return f.chunk.column.decodeDataPageV1(DataPageHeaderV1{header.DataPageHeader}, page, f.dictionary, header.UncompressedPageSize)
+	return f.chunk.column.decodeDataPageV1(DataPageHeaderV1{header.DataPageHeader}, page, f.dictionary, header.UncompressedPageSize, f.skipRowsOfPage())
 }
 
-func (f *filePages) readDataPageV2(header *format.PageHeader, page *buffer) (Page, error) {
+func (f *filePages) readDataPageV2(header *format.PageHeader, page *buffer) (Page, int, error) {
 	if header.DataPageHeaderV2 == nil {
-		return nil, ErrMissingPageHeader
+		return nil, 0, ErrMissingPageHeader
 	}
 	if isDictionaryFormat(header.DataPageHeaderV2.Encoding) && f.dictionary == nil {
 		// If the program seeked to a row passed the first page, the dictionary
 		// page may not have been seen, in which case we have to lazily load it
 		// from the beginning of column chunk.
 		if err := f.readDictionary(); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 	}
-	return f.chunk.column.decodeDataPageV2(DataPageHeaderV2{header.DataPageHeaderV2}, page, f.dictionary, header.UncompressedPageSize)
+	return f.chunk.column.decodeDataPageV2(DataPageHeaderV2{header.DataPageHeaderV2}, page, f.dictionary, header.UncompressedPageSize, f.skipRowsOfPage())
 }
 
 func (f *filePages) readPage(header *format.PageHeader, reader *bufio.Reader) (*buffer, error) {
@@ -768,6 +1261,17 @@ func (f *filePages) SeekToRow(rowIndex int64) (err error) {
 	return err
 }
 
+// readPageAt reads the data page of the given size at offset in the file.
+func (f *filePages) readPageAt(offset, size int64) (Page, error) {
+	f.section = *io.NewSectionReader(f.chunk.reader, offset, size)
+	f.rbuf.Reset(&f.section)
+	page, err := f.ReadPage()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return page, err
+}
+
 func (f *filePages) Close() error {
 	putBufioReader(f.rbuf, f.rbufpool)
 	f.chunk = nil
@@ -789,6 +1293,10 @@ func (f *filePages) columnPath() columnPath {
 
 type putBufioReaderFunc func()
 
+var (
+	_ ColumnChunkWithPageHeaders = (*FileColumnChunk)(nil)
+)
+
 var (
 	bufioReaderPoolLock sync.Mutex
 	bufioReaderPool     = map[int]*sync.Pool{}
@@ -826,6 +1334,9 @@ func getBufioReaderPool(size int) *sync.Pool {
 }
 
 func (f *File) readAt(p []byte, off int64) (int, error) {
+	if f.mask != nil && !f.mask.contains(off, int64(len(p))) {
+		return 0, fmt.Errorf("reading %d bytes at offset %d: %w", len(p), off, ErrColumnMasked)
+	}
 	return readAt(f.reader, p, off)
 }
 
@@ -0,0 +1,267 @@
+package parquet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// Masked returns a view of f which only exposes the columns at the given
+// dot-separated paths, the other columns of f are absent from the schema,
+// metadata, row groups, and page index of the returned file. The path of a
+// group allows all the columns nested in it, and paths which do not exist in
+// f are ignored.
+//
+// The method is intended to enforce column-level access control in programs
+// handing files or row groups to less-trusted code: the masked file can only
+// read the bytes of the column chunks that it exposes, other reads (including
+// calls to ReadAt, or ReadPageIndex when the page index spans masked columns)
+// fail with ErrColumnMasked. Readers reading rows with a schema that has
+// masked columns produce null or zero values for them, like they do for
+// columns missing from files.
+//
+// The returned file shares the underlying reader and configuration of f, and
+// may be masked further.
+func (f *File) Masked(allowed []string) *File {
+	mask := schemaMask{
+		elements: f.metadata.Schema,
+		allowed:  make([]columnPath, len(allowed)),
+	}
+	for i, path := range allowed {
+		mask.allowed[i] = columnPath(strings.Split(path, "."))
+	}
+	mask.walk(0, nil)
+
+	m := &File{
+		metadata:      f.metadata,
+		reader:        f,
+		size:          f.size,
+		config:        f.config,
+		externalFiles: f.externalFiles,
+		mask:          new(fileMask),
+	}
+	m.metadata.Schema = mask.masked
+
+	if len(f.metadata.ColumnOrders) == mask.numColumns {
+		m.metadata.ColumnOrders = make([]format.ColumnOrder, len(mask.columns))
+		for i, j := range mask.columns {
+			m.metadata.ColumnOrders[i] = f.metadata.ColumnOrders[j]
+		}
+	} else {
+		m.metadata.ColumnOrders = nil
+	}
+
+	m.metadata.RowGroups = make([]format.RowGroup, len(f.metadata.RowGroups))
+	for i := range m.metadata.RowGroups {
+		m.metadata.RowGroups[i] = maskRowGroup(&f.metadata.RowGroups[i], mask.columns)
+
+		for j := range m.metadata.RowGroups[i].Columns {
+			m.mask.addColumnChunk(&m.metadata.RowGroups[i].Columns[j])
+		}
+	}
+	m.mask.merge()
+
+	if f.hasIndexes() {
+		numColumnChunks := len(f.metadata.RowGroups) * len(mask.columns)
+		m.columnIndexes = make([]format.ColumnIndex, 0, numColumnChunks)
+		m.offsetIndexes = make([]format.OffsetIndex, 0, numColumnChunks)
+
+		for i := range f.metadata.RowGroups {
+			for _, j := range mask.columns {
+				k := (i * mask.numColumns) + j
+				m.columnIndexes = append(m.columnIndexes, f.columnIndexes[k])
+				m.offsetIndexes = append(m.offsetIndexes, f.offsetIndexes[k])
+			}
+		}
+	}
+
+	root, err := openColumns(m)
+	if err != nil {
+		// The columns of m are a subset of those of f, which were opened
+		// successfully, so this is not expected to happen.
+		panic(fmt.Errorf("opening masked columns of parquet file: %w", err))
+	}
+	m.root = root
+	m.schema = NewSchema(root.Name(), root)
+
+	columns := make([]*Column, 0, len(mask.columns))
+	root.forEachLeaf(func(c *Column) { columns = append(columns, c) })
+
+	rowGroups := make([]FileRowGroup, len(m.metadata.RowGroups))
+	m.rowGroups = make([]RowGroup, len(rowGroups))
+
+	for i := range rowGroups {
+		g := &rowGroups[i]
+		g.init(m, i, m.schema, columns, &m.metadata.RowGroups[i])
+		// Bloom filters and page indexes loaded by the column chunks of f are
+		// shared with those of the masked file instead of being read again.
+		chunks := f.rowGroups[i].(*FileRowGroup).columns
+		for j, k := range mask.columns {
+			c := g.columns[j].(*FileColumnChunk)
+			from := chunks[k].(*FileColumnChunk)
+			c.bloomFilter = from.bloomFilter
+			if c.columnIndex == nil {
+				c.columnIndex = from.columnIndex
+			}
+			if c.offsetIndex == nil {
+				c.offsetIndex = from.offsetIndex
+			}
+		}
+		m.rowGroups[i] = g
+	}
+
+	return m
+}
+
+// maskRowGroup returns a copy of the row group metadata which only retains the
+// column chunks at the given indexes.
+func maskRowGroup(rowGroup *format.RowGroup, columns []int) format.RowGroup {
+	masked := *rowGroup
+	masked.Columns = make([]format.ColumnChunk, len(columns))
+	masked.TotalByteSize = 0
+	masked.TotalCompressedSize = 0
+	masked.FileOffset = 0
+
+	for i, j := range columns {
+		c := rowGroup.Columns[j]
+		masked.Columns[i] = c
+		masked.TotalByteSize += c.MetaData.TotalUncompressedSize
+		masked.TotalCompressedSize += c.MetaData.TotalCompressedSize
+		if i == 0 {
+			masked.FileOffset = columnChunkOffset(&c.MetaData)
+		}
+	}
+
+	// Like for converted row groups, sorting columns are retained up to the
+	// first one which is masked, otherwise the rows would not be advertised in
+	// the right order.
+	masked.SortingColumns = make([]format.SortingColumn, 0, len(rowGroup.SortingColumns))
+	for _, sorting := range rowGroup.SortingColumns {
+		i := sort.SearchInts(columns, int(sorting.ColumnIdx))
+		if i == len(columns) || columns[i] != int(sorting.ColumnIdx) {
+			break
+		}
+		sorting.ColumnIdx = int32(i)
+		masked.SortingColumns = append(masked.SortingColumns, sorting)
+	}
+	return masked
+}
+
+func columnChunkOffset(metadata *format.ColumnMetaData) int64 {
+	if metadata.DictionaryPageOffset != 0 && metadata.DictionaryPageOffset < metadata.DataPageOffset {
+		return metadata.DictionaryPageOffset
+	}
+	return metadata.DataPageOffset
+}
+
+// schemaMask filters the flattened schema elements of a file to retain the
+// leaf columns matching the allowed paths and the groups they are nested in.
+type schemaMask struct {
+	elements   []format.SchemaElement
+	allowed    []columnPath
+	masked     []format.SchemaElement
+	columns    []int // indexes of the leaf columns retained
+	numColumns int
+}
+
+func (m *schemaMask) allows(path columnPath) bool {
+	for _, allowed := range m.allowed {
+		if len(allowed) <= len(path) && allowed.equal(path[:len(allowed)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// walk filters the schema element at index i and its children, returning the
+// index of the next element and whether the element was retained. The root
+// element is always retained.
+func (m *schemaMask) walk(i int, path columnPath) (int, bool) {
+	if i >= len(m.elements) {
+		return i, false
+	}
+	elem := &m.elements[i]
+	if i > 0 {
+		path = path.append(elem.Name)
+	}
+
+	if i > 0 && elem.NumChildren == 0 {
+		if !m.allows(path) {
+			m.numColumns++
+			return i + 1, false
+		}
+		m.masked = append(m.masked, *elem)
+		m.columns = append(m.columns, m.numColumns)
+		m.numColumns++
+		return i + 1, true
+	}
+
+	index := len(m.masked)
+	m.masked = append(m.masked, *elem)
+	numChildren := int32(0)
+	next := i + 1
+
+	for n := int32(0); n < elem.NumChildren; n++ {
+		var retained bool
+		if next, retained = m.walk(next, path); retained {
+			numChildren++
+		}
+	}
+
+	if i > 0 && numChildren == 0 {
+		m.masked = m.masked[:index]
+		return next, false
+	}
+	m.masked[index].NumChildren = numChildren
+	return next, true
+}
+
+// fileMask is the list of byte ranges that can be read from a masked file.
+type fileMask struct {
+	ranges []fileRange
+}
+
+type fileRange struct {
+	offset int64
+	end    int64
+}
+
+func (m *fileMask) add(offset, length int64) {
+	if length > 0 {
+		m.ranges = append(m.ranges, fileRange{offset: offset, end: offset + length})
+	}
+}
+
+func (m *fileMask) addColumnChunk(chunk *format.ColumnChunk) {
+	// Column chunks stored in external files are not read from the masked
+	// file, the readers of these files are only reachable by the column
+	// chunks which were retained.
+	if chunk.FilePath != "" {
+		return
+	}
+	m.add(columnChunkOffset(&chunk.MetaData), chunk.MetaData.TotalCompressedSize)
+	m.add(chunk.ColumnIndexOffset, int64(chunk.ColumnIndexLength))
+	m.add(chunk.OffsetIndexOffset, int64(chunk.OffsetIndexLength))
+}
+
+// merge sorts the ranges and merges those that overlap or are contiguous, so
+// reads spanning multiple ranges can be checked against a single one.
+func (m *fileMask) merge() {
+	sort.Slice(m.ranges, func(i, j int) bool { return m.ranges[i].offset < m.ranges[j].offset })
+	merged := m.ranges[:0]
+	for _, r := range m.ranges {
+		if n := len(merged); n > 0 && r.offset <= merged[n-1].end {
+			merged[n-1].end = max(merged[n-1].end, r.end)
+		} else {
+			merged = append(merged, r)
+		}
+	}
+	m.ranges = merged
+}
+
+func (m *fileMask) contains(offset, length int64) bool {
+	i := sort.Search(len(m.ranges), func(i int) bool { return m.ranges[i].end > offset })
+	return i < len(m.ranges) && m.ranges[i].offset <= offset && offset+length <= m.ranges[i].end
+}
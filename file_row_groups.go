@@ -0,0 +1,177 @@
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/segmentio/encoding/thrift"
+)
+
+// OpenRowGroups opens the parquet file of the given size in r, decoding only
+// the metadata of the row groups at the given indices.
+//
+// The metadata of row groups, which holds the statistics of all their column
+// chunks, dominates the size of the footer of files with many row groups. This
+// function is intended for programs where a coordinator assigns the row groups
+// of a large file to workers: the metadata of the other row groups is skipped
+// without being decoded, so each worker only pays for the row groups that it
+// was assigned.
+//
+// The returned file exposes the selected row groups in the order they appear
+// in the file, regardless of the order of indices; duplicate indices are
+// ignored. The metadata of the file, including its number of rows, reflects
+// only the selected row groups. The function returns an error if an index is
+// out of range of the row groups of the file.
+func OpenRowGroups(r io.ReaderAt, size int64, indices []int, options ...FileOption) (*File, error) {
+	if indices == nil {
+		indices = []int{}
+	}
+	return openFileRowGroups(r, size, indices, options)
+}
+
+// Identifier of the row_groups field of the FileMetaData thrift struct.
+const fileMetaDataRowGroupsFieldID = 4
+
+// selectRowGroups returns a copy of the thrift-encoded footer where the list of
+// row groups only contains the row groups at the given indices, leaving the
+// other fields untouched. The row groups are located by skipping over their
+// encoded representation, which is much cheaper than decoding them.
+func selectRowGroups(footer []byte, indices []int) ([]byte, error) {
+	protocol := thrift.CompactProtocol{}
+	input := bytes.NewReader(footer)
+	r := protocol.NewReader(input)
+	offset := func() int { return len(footer) - input.Len() }
+	lastFieldID := int16(0)
+
+	for {
+		field, err := r.ReadField()
+		if err != nil {
+			return nil, err
+		}
+		if field.Type == thrift.STOP {
+			break
+		}
+		if field.Delta {
+			field.ID += lastFieldID
+		}
+		lastFieldID = field.ID
+
+		if field.ID != fileMetaDataRowGroupsFieldID || field.Type != thrift.LIST {
+			if err := skipThriftField(r, field.Type); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		listOffset := offset()
+		list, err := r.ReadList()
+		if err != nil {
+			return nil, err
+		}
+		bounds := make([]int, list.Size+1)
+		bounds[0] = offset()
+		for i := 1; i < len(bounds); i++ {
+			if err := skipThriftValue(r, list.Type); err != nil {
+				return nil, fmt.Errorf("skipping row group %d: %w", i-1, err)
+			}
+			bounds[i] = offset()
+		}
+
+		selected, err := selectedRowGroupsOf(indices, int(list.Size))
+		if err != nil {
+			return nil, err
+		}
+		b := bytes.NewBuffer(make([]byte, 0, len(footer)))
+		b.Write(footer[:listOffset])
+		if err := protocol.NewWriter(b).WriteList(thrift.List{Size: int32(len(selected)), Type: list.Type}); err != nil {
+			return nil, err
+		}
+		for _, i := range selected {
+			b.Write(footer[bounds[i]:bounds[i+1]])
+		}
+		b.Write(footer[bounds[len(bounds)-1]:])
+		return b.Bytes(), nil
+	}
+
+	// The file has no row groups.
+	if _, err := selectedRowGroupsOf(indices, 0); err != nil {
+		return nil, err
+	}
+	return footer, nil
+}
+
+func selectedRowGroupsOf(indices []int, numRowGroups int) ([]int, error) {
+	selected := slices.Clone(indices)
+	for _, i := range selected {
+		if i < 0 || i >= numRowGroups {
+			return nil, fmt.Errorf("row group index out of range: %d/%d", i, numRowGroups)
+		}
+	}
+	slices.Sort(selected)
+	return slices.Compact(selected), nil
+}
+
+// skipThriftField skips the value of a struct field of type t. Boolean fields
+// are encoded in the type of the field header by the compact protocol, they do
+// not have a value.
+func skipThriftField(r thrift.Reader, t thrift.Type) error {
+	if t == thrift.TRUE || t == thrift.FALSE {
+		return nil
+	}
+	return skipThriftValue(r, t)
+}
+
+func skipThriftValue(r thrift.Reader, t thrift.Type) (err error) {
+	switch t {
+	case thrift.TRUE, thrift.FALSE:
+		_, err = r.ReadBool()
+	case thrift.I8:
+		_, err = r.ReadInt8()
+	case thrift.I16:
+		_, err = r.ReadInt16()
+	case thrift.I32:
+		_, err = r.ReadInt32()
+	case thrift.I64:
+		_, err = r.ReadInt64()
+	case thrift.DOUBLE:
+		_, err = r.ReadFloat64()
+	case thrift.BINARY:
+		_, err = r.ReadBytes()
+	case thrift.LIST, thrift.SET:
+		var list thrift.List
+		if list, err = r.ReadList(); err != nil {
+			return err
+		}
+		for i := int32(0); i < list.Size && err == nil; i++ {
+			err = skipThriftValue(r, list.Type)
+		}
+	case thrift.MAP:
+		var m thrift.Map
+		if m, err = r.ReadMap(); err != nil {
+			return err
+		}
+		for i := int32(0); i < m.Size && err == nil; i++ {
+			if err = skipThriftValue(r, m.Key); err == nil {
+				err = skipThriftValue(r, m.Value)
+			}
+		}
+	case thrift.STRUCT:
+		for {
+			field, err := r.ReadField()
+			if err != nil || field.Type == thrift.STOP {
+				return err
+			}
+			if err := skipThriftField(r, field.Type); err != nil {
+				return err
+			}
+		}
+	default:
+		err = fmt.Errorf("cannot skip thrift value of type %s", t)
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -1,14 +1,21 @@
 package parquet_test
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
 )
 
 var testdataFiles []string
@@ -53,6 +60,43 @@ func TestOpenFile(t *testing.T) {
 	}
 }
 
+func TestDecodeFileMetaData(t *testing.T) {
+	for _, path := range testdataFiles {
+		t.Run(path, func(t *testing.T) {
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			s, err := f.Stat()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := parquet.OpenFile(f, s.Size(), parquet.SkipPageIndex(true), parquet.SkipBloomFilters(true))
+			if err != nil {
+				t.Skip(err)
+			}
+
+			// Use the smallest buffer size to exercise refills of the buffer.
+			metadata, err := parquet.DecodeFileMetaData(f, s.Size(), parquet.ReadBufferSize(16))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// OpenFile sorts the key/value metadata, exclude it from the comparison.
+			want := *p.Metadata()
+			want.KeyValueMetadata = nil
+			got := *metadata
+			got.KeyValueMetadata = nil
+			if !reflect.DeepEqual(&want, &got) {
+				t.Error("file metadata mismatch")
+			}
+		})
+	}
+}
+
 func TestOpenFileWithoutPageIndex(t *testing.T) {
 	for _, path := range testdataFiles {
 		t.Run(path, func(t *testing.T) {
@@ -212,3 +256,916 @@ func TestFileKeyValueMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestFileColumnChunkPageHeaders(t *testing.T) {
+	for _, path := range testdataFiles {
+		t.Run(path, func(t *testing.T) {
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			s, err := f.Stat()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := parquet.OpenFile(f, s.Size())
+			if err != nil {
+				t.Skip(err)
+			}
+
+			for _, rowGroup := range p.RowGroups() {
+				for _, columnChunk := range rowGroup.ColumnChunks() {
+					chunk, ok := columnChunk.(parquet.ColumnChunkWithPageHeaders)
+					if !ok {
+						t.Fatalf("column chunk of type %T does not implement parquet.ColumnChunkWithPageHeaders", columnChunk)
+					}
+					offsetIndex, err := chunk.OffsetIndex()
+					if err != nil {
+						continue
+					}
+
+					headers := chunk.PageHeaders()
+					dataPages := 0
+					for {
+						h, err := headers.ReadPageHeader()
+						if err == io.EOF {
+							break
+						}
+						if err != nil {
+							t.Fatal(err)
+						}
+						if h.Header.Type == format.DictionaryPage {
+							continue
+						}
+						if dataPages < offsetIndex.NumPages() {
+							if offset := offsetIndex.Offset(dataPages); offset != h.Offset {
+								t.Errorf("page %d: offset mismatch: want=%d got=%d", dataPages, offset, h.Offset)
+							}
+							if size := offsetIndex.CompressedPageSize(dataPages); size != h.HeaderSize+int64(h.Header.CompressedPageSize) {
+								t.Errorf("page %d: size mismatch: want=%d got=%d", dataPages, size, h.HeaderSize+int64(h.Header.CompressedPageSize))
+							}
+						}
+						dataPages++
+					}
+					headers.Close()
+
+					if dataPages != offsetIndex.NumPages() {
+						t.Errorf("number of data pages mismatch: want=%d got=%d", offsetIndex.NumPages(), dataPages)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFileColumnChunkMayContain(t *testing.T) {
+	type Row struct {
+		Dict  string `parquet:"dict,dict"`
+		Plain string `parquet:"plain"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		v := strconv.Itoa(i % 10)
+		rows[i] = Row{Dict: v, Plain: v}
+	}
+
+	b := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](b)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := f.RowGroups()[0].ColumnChunks()
+	dict := columns[0].(*parquet.FileColumnChunk)
+	plain := columns[1].(*parquet.FileColumnChunk)
+
+	if !dict.IsDictionaryEncoded() {
+		t.Error("dictionary encoded column chunk was not detected")
+	}
+	if plain.IsDictionaryEncoded() {
+		t.Error("plain encoded column chunk was detected as dictionary encoded")
+	}
+
+	for _, test := range []struct {
+		chunk  *parquet.FileColumnChunk
+		values []parquet.Value
+		want   bool
+	}{
+		{chunk: dict, values: []parquet.Value{parquet.ValueOf("3")}, want: true},
+		{chunk: dict, values: []parquet.Value{parquet.ValueOf("42")}, want: false},
+		{chunk: dict, values: []parquet.Value{parquet.ValueOf("42"), parquet.ValueOf("9")}, want: true},
+		{chunk: dict, values: []parquet.Value{parquet.ValueOf(nil)}, want: true},
+		{chunk: plain, values: []parquet.Value{parquet.ValueOf("42")}, want: true},
+	} {
+		got, err := test.chunk.MayContain(test.values...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("%v: want=%t got=%t", test.values, test.want, got)
+		}
+	}
+}
+
+func TestFileMaxRepeatedValues(t *testing.T) {
+	type Row struct {
+		Values []int64 `parquet:"values"`
+	}
+
+	rows := []Row{
+		{Values: []int64{1, 2, 3}},
+		{Values: make([]int64, 100)},
+	}
+
+	b := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](b)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		limit int
+		err   error
+	}{
+		{limit: 0},
+		{limit: 100},
+		{limit: 10, err: parquet.ErrTooManyRepeatedValues},
+	} {
+		f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()), parquet.MaxRepeatedValues(test.limit))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := parquet.NewGenericReader[Row](f)
+		_, err = r.Read(make([]Row, len(rows)))
+		r.Close()
+
+		if err == io.EOF {
+			err = nil
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("limit=%d: want error %v, got %v", test.limit, test.err, err)
+		}
+	}
+
+	if _, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()), parquet.MaxRepeatedValues(-1)); err == nil {
+		t.Error("expected an error for a negative limit")
+	}
+}
+
+func TestFileConvertedTypesOnly(t *testing.T) {
+	type Row struct {
+		Name   string            `parquet:"name"`
+		Amount int64             `parquet:"amount,decimal(2:18)"`
+		Time   int64             `parquet:"time,timestamp(millisecond)"`
+		Attrs  map[string]string `parquet:"attrs"`
+	}
+
+	rows := []Row{
+		{Name: "a", Amount: 1234, Time: 1700000000000, Attrs: map[string]string{"k": "v"}},
+		{Name: "b", Amount: -5, Time: 1700000001000, Attrs: map[string]string{}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.LegacyConvertedTypes(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, element := range f.Metadata().Schema {
+		if element.Name == "key_value" {
+			if element.ConvertedType == nil || *element.ConvertedType != deprecated.MapKeyValue {
+				t.Errorf("key_value group is not annotated with MAP_KEY_VALUE: %v", element.ConvertedType)
+			}
+		}
+	}
+
+	// Rewrite the footer of the file to strip the logical types, leaving only
+	// the converted types as older parquet writers would have done.
+	legacy := rewriteFileMetadata(t, f, buf.Bytes(), func(metadata *format.FileMetaData) {
+		for i := range metadata.Schema {
+			metadata.Schema[i].LogicalType = nil
+		}
+	})
+
+	f, err = parquet.OpenFile(bytes.NewReader(legacy), int64(len(legacy)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := f.Root()
+	if lt := root.Column("name").Type().LogicalType(); lt == nil || lt.UTF8 == nil {
+		t.Errorf("name: expected STRING logical type, got %v", lt)
+	}
+	if lt := root.Column("amount").Type().LogicalType(); lt == nil || lt.Decimal == nil || lt.Decimal.Scale != 2 || lt.Decimal.Precision != 18 {
+		t.Errorf("amount: expected DECIMAL(18,2) logical type, got %v", lt)
+	}
+	if lt := root.Column("time").Type().LogicalType(); lt == nil || lt.Timestamp == nil || lt.Timestamp.Unit.Millis == nil {
+		t.Errorf("time: expected TIMESTAMP(MILLIS) logical type, got %v", lt)
+	}
+	if lt := root.Column("attrs").Type().LogicalType(); lt == nil || lt.Map == nil {
+		t.Errorf("attrs: expected MAP logical type, got %v", lt)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(legacy), int64(len(legacy)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+}
+
+func TestFileRowGroupLocation(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer, parquet.MaxRowsPerRowGroup(10))
+	for i := 0; i < 35; i++ {
+		if _, err := writer.Write([]Row{{ID: int64(i), Name: strconv.Itoa(i % 3)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rowGroups := file.RowGroups()
+	if len(rowGroups) != 4 {
+		t.Fatalf("wrong number of row groups: %d", len(rowGroups))
+	}
+
+	offset := int64(4) // "PAR1"
+	for i, rowGroup := range rowGroups {
+		g := rowGroup.(*parquet.FileRowGroup)
+		if g.Ordinal() != i {
+			t.Errorf("row group %d: wrong ordinal: %d", i, g.Ordinal())
+		}
+		if g.FileOffset() != offset {
+			t.Errorf("row group %d: wrong file offset: want=%d got=%d", i, offset, g.FileOffset())
+		}
+		size := int64(0)
+		for _, chunk := range file.Metadata().RowGroups[i].Columns {
+			size += chunk.MetaData.TotalCompressedSize
+		}
+		if g.TotalCompressedSize() != size {
+			t.Errorf("row group %d: wrong total compressed size: want=%d got=%d", i, size, g.TotalCompressedSize())
+		}
+		offset += size
+	}
+}
+
+func TestFileReadPage(t *testing.T) {
+	type Row struct {
+		ID   int64   `parquet:"id"`
+		Name string  `parquet:"name,dict"`
+		Tags []int32 `parquet:"tags"`
+	}
+
+	rows := make([]Row, 500)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: "name-" + strconv.Itoa(i%10)}
+		for j := 0; j < i%3; j++ {
+			rows[i].Tags = append(rows[i].Tags, int32(j))
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(256), parquet.MaxRowsPerRowGroup(200)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readValues := func(t *testing.T, page parquet.Page) []parquet.Value {
+		t.Helper()
+		values := make([]parquet.Value, page.NumValues())
+		n, err := page.Values().ReadValues(values)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		return values[:n]
+	}
+
+	for i, rowGroup := range f.RowGroups() {
+		for j, chunk := range rowGroup.ColumnChunks() {
+			offsetIndex, err := chunk.OffsetIndex()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pages := chunk.Pages()
+			for k := 0; ; k++ {
+				want, err := pages.ReadPage()
+				if err == io.EOF {
+					if k != offsetIndex.NumPages() {
+						t.Fatalf("wrong number of pages in column chunk %d of row group %d: %d!=%d", j, i, k, offsetIndex.NumPages())
+					}
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got, err := f.ReadPage(i, j, k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(readValues(t, got), readValues(t, want)) {
+					t.Errorf("values of page %d of column chunk %d in row group %d mismatch", k, j, i)
+				}
+				parquet.Release(got)
+				parquet.Release(want)
+			}
+			pages.Close()
+
+			if k := offsetIndex.NumPages(); k < 2 && j == 0 {
+				t.Errorf("expected multiple pages in column chunk %d of row group %d, got %d", j, i, k)
+			}
+		}
+	}
+
+	if _, err := f.ReadPage(0, 0, 1000); err == nil {
+		t.Error("expected an error reading a page index out of range")
+	}
+	if _, err := f.ReadPage(3, 0, 0); err == nil {
+		t.Error("expected an error reading a row group index out of range")
+	}
+}
+
+func TestFileMasked(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city"`
+		Zip  string `parquet:"zip"`
+	}
+	type Row struct {
+		ID      int64   `parquet:"id"`
+		SSN     string  `parquet:"ssn"`
+		Address Address `parquet:"address"`
+		Scores  []int32 `parquet:"scores"`
+	}
+	type Public struct {
+		ID      int64   `parquet:"id"`
+		Address Address `parquet:"address"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{
+			ID:      int64(i),
+			SSN:     "ssn-" + strconv.Itoa(i),
+			Address: Address{City: "city-" + strconv.Itoa(i%7), Zip: strconv.Itoa(10000 + i)},
+			Scores:  []int32{int32(i), int32(2 * i)},
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.MaxRowsPerRowGroup(40)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := f.Masked([]string{"id", "address", "unknown"})
+
+	var paths []string
+	for _, path := range m.Schema().Columns() {
+		paths = append(paths, strings.Join(path, "."))
+	}
+	if want := []string{"id", "address.city", "address.zip"}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("wrong columns in masked schema: %q != %q", paths, want)
+	}
+	if n := len(m.Metadata().RowGroups); n != 3 {
+		t.Fatalf("wrong number of row groups in masked file: %d", n)
+	}
+	for i, rowGroup := range m.RowGroups() {
+		if n := len(rowGroup.ColumnChunks()); n != 3 {
+			t.Fatalf("wrong number of column chunks in row group %d: %d", i, n)
+		}
+	}
+	if n := len(m.ColumnIndexes()); n != 3*3 {
+		t.Errorf("wrong number of column indexes in masked file: %d", n)
+	}
+
+	public := make([]Public, len(rows))
+	if n, err := parquet.NewGenericReader[Public](m).Read(public); n != len(rows) {
+		t.Fatalf("reading rows of masked file: %d/%d: %v", n, len(rows), err)
+	}
+	for i, row := range public {
+		if want := (Public{ID: rows[i].ID, Address: rows[i].Address}); row != want {
+			t.Fatalf("wrong row at index %d: %+v != %+v", i, row, want)
+		}
+	}
+
+	// Reading with the schema of the original rows produces zero values for
+	// the masked columns.
+	masked := make([]Row, len(rows))
+	if n, err := parquet.NewGenericReader[Row](m).Read(masked); n != len(rows) {
+		t.Fatalf("reading rows of masked file: %d/%d: %v", n, len(rows), err)
+	}
+	for i, row := range masked {
+		if row.SSN != "" || len(row.Scores) != 0 || row.ID != rows[i].ID {
+			t.Fatalf("wrong row at index %d: %+v", i, row)
+		}
+	}
+
+	if _, err := m.ReadAt(make([]byte, 4), 0); !errors.Is(err, parquet.ErrColumnMasked) {
+		t.Errorf("expected reading the file header to fail with ErrColumnMasked, got %v", err)
+	}
+	chunk := f.Metadata().RowGroups[0].Columns[1].MetaData
+	if _, err := m.ReadAt(make([]byte, 8), chunk.DataPageOffset); !errors.Is(err, parquet.ErrColumnMasked) {
+		t.Errorf("expected reading a masked column chunk to fail with ErrColumnMasked, got %v", err)
+	}
+
+	ids := m.Masked([]string{"id"})
+	if n := len(ids.Schema().Columns()); n != 1 {
+		t.Errorf("wrong number of columns in file masked twice: %d", n)
+	}
+	if n, err := parquet.NewGenericReader[Row](ids).Read(masked); n != len(rows) {
+		t.Errorf("reading rows of file masked twice: %d/%d: %v", n, len(rows), err)
+	}
+}
+
+func TestFileResolver(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	files := map[string][]byte{}
+	metadata := []*format.FileMetaData{}
+	for i, name := range []string{"part-0.parquet", "part-1.parquet"} {
+		rows := make([]Row, 100)
+		for j := range rows {
+			rows[j] = Row{ID: int64(100*i + j), Name: "name-" + strconv.Itoa(100*i+j)}
+		}
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows,
+			parquet.PageBufferSize(256),
+			parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+		); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = buf.Bytes()
+		metadata = append(metadata, f.Metadata())
+	}
+
+	// Build a summary file holding the footer of both files, with column
+	// chunks referencing the data files.
+	summary := *metadata[0]
+	summary.NumRows = 0
+	summary.RowGroups = nil
+	for i, m := range metadata {
+		for _, rowGroup := range m.RowGroups {
+			rowGroup.Ordinal = int16(len(summary.RowGroups))
+			rowGroup.Columns = append([]format.ColumnChunk{}, rowGroup.Columns...)
+			for j := range rowGroup.Columns {
+				rowGroup.Columns[j].FilePath = "part-" + strconv.Itoa(i) + ".parquet"
+			}
+			summary.RowGroups = append(summary.RowGroups, rowGroup)
+			summary.NumRows += rowGroup.NumRows
+		}
+	}
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &summary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := append([]byte("PAR1"), footer...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(footer)))
+	data = append(data, "PAR1"...)
+
+	var resolved []string
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)),
+		parquet.FileResolver(func(path string) (io.ReaderAt, error) {
+			resolved = append(resolved, path)
+			b, ok := files[path]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return bytes.NewReader(b), nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resolved, []string{"part-0.parquet", "part-1.parquet"}) {
+		t.Errorf("wrong files resolved: %q", resolved)
+	}
+
+	rows := make([]Row, 300)
+	n, err := parquet.NewGenericReader[Row](f).Read(rows)
+	if err != io.EOF {
+		t.Fatal(err)
+	}
+	if n != 200 {
+		t.Fatalf("wrong number of rows: %d", n)
+	}
+	for i, row := range rows[:n] {
+		if row.ID != int64(i) || row.Name != "name-"+strconv.Itoa(i) {
+			t.Fatalf("wrong row at index %d: %+v", i, row)
+		}
+	}
+
+	chunk := f.RowGroups()[1].ColumnChunks()[1]
+	if ok, err := chunk.BloomFilter().Check(parquet.ValueOf("name-150")); err != nil || !ok {
+		t.Errorf("bloom filter of external column chunk does not contain the value: ok=%t err=%v", ok, err)
+	}
+	offsetIndex, err := chunk.OffsetIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offsetIndex.NumPages() < 2 {
+		t.Errorf("expected multiple pages in the offset index, got %d", offsetIndex.NumPages())
+	}
+
+	_, err = parquet.OpenFile(bytes.NewReader(data), int64(len(data)),
+		parquet.FileResolver(func(path string) (io.ReaderAt, error) { return nil, os.ErrNotExist }),
+	)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected an error resolving external files, got %v", err)
+	}
+}
+
+func TestOpenRowGroups(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,optional"`
+		Flag bool   `parquet:"flag"`
+	}
+
+	rows := make([]Row, 50)
+	for i := range rows {
+		rows[i] = Row{ID: int64(len(rows) - i), Name: strconv.Itoa(i), Flag: i%3 == 0}
+	}
+	buf := new(bytes.Buffer)
+	writer := parquet.NewSortingWriter[Row](buf, 10,
+		parquet.MaxRowsPerRowGroup(10),
+		parquet.PageBufferSize(64),
+		parquet.KeyValueMetadata("key", "value"),
+		parquet.SortingWriterConfig(
+			parquet.SortingColumns(parquet.NullsFirst(parquet.Descending("id"))),
+		),
+	)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(buf.Bytes())
+
+	full, err := parquet.OpenFile(r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(full.RowGroups()); n != 5 {
+		t.Fatalf("wrong number of row groups: %d", n)
+	}
+
+	f, err := parquet.OpenRowGroups(r, r.Size(), []int{3, 1, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != 2 {
+		t.Fatalf("wrong number of selected row groups: %d", n)
+	}
+	if f.NumRows() != 20 {
+		t.Errorf("wrong number of rows: %d", f.NumRows())
+	}
+	if v, ok := f.Lookup("key"); !ok || v != "value" {
+		t.Errorf("wrong key/value metadata: %q %t", v, ok)
+	}
+	for i, j := range []int{1, 3} {
+		want, got := &full.Metadata().RowGroups[j], &f.Metadata().RowGroups[i]
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("metadata of row group %d mismatch:\nwant = %+v\ngot  = %+v", j, want, got)
+		}
+		if !reflect.DeepEqual(f.ColumnIndexes()[i*3:(i+1)*3], full.ColumnIndexes()[j*3:(j+1)*3]) {
+			t.Errorf("column indexes of row group %d mismatch", j)
+		}
+		if len(f.RowGroups()[i].SortingColumns()) != 1 {
+			t.Errorf("missing sorting columns in row group %d", j)
+		}
+	}
+
+	values := make([]Row, 30)
+	n, err := parquet.NewGenericReader[Row](f).Read(values)
+	if err != io.EOF {
+		t.Fatal(err)
+	}
+	if want := append(rows[10:20:20], rows[30:40]...); !reflect.DeepEqual(values[:n], want) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", want, values[:n])
+	}
+
+	f, err = parquet.OpenRowGroups(r, r.Size(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.RowGroups()) != 0 || f.NumRows() != 0 {
+		t.Errorf("expected no row groups, got %d (%d rows)", len(f.RowGroups()), f.NumRows())
+	}
+
+	if _, err := parquet.OpenRowGroups(r, r.Size(), []int{5}); err == nil {
+		t.Error("expected an error opening a row group index out of range")
+	}
+}
+
+func TestLazyValueDecoding(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name string   `parquet:"name"`
+		Path string   `parquet:"path,delta"`
+		Code string   `parquet:"code,dict"`
+		Note *string  `parquet:"note,optional"`
+		Tags []string `parquet:"tags,list"`
+	}
+
+	rows := make([]Row, 500)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i),
+			Name: "name-" + strconv.Itoa(i),
+			Path: "/var/lib/data/" + strconv.Itoa(i/10) + "/" + strconv.Itoa(i),
+			Code: "code-" + strconv.Itoa(i%4),
+			Tags: []string{},
+		}
+		if i%3 != 0 {
+			note := strings.Repeat("n", i%7)
+			rows[i].Note = &note
+		}
+		for j := 0; j < i%3; j++ {
+			rows[i].Tags = append(rows[i].Tags, "tag-"+strconv.Itoa(j))
+		}
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run("v"+strconv.Itoa(version), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf,
+				parquet.DataPageVersion(version),
+				parquet.PageBufferSize(256),
+			)
+			if _, err := w.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()),
+				parquet.LazyValueDecoding(true),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := parquet.NewGenericReader[Row](f)
+			defer r.Close()
+			read := make([]Row, len(rows))
+			if n, err := r.Read(read); n != len(rows) {
+				t.Fatalf("reading rows: %d/%d: %v", n, len(rows), err)
+			}
+			if !reflect.DeepEqual(read, rows) {
+				t.Fatal("rows read with lazy decoding mismatch")
+			}
+
+			for _, rowIndex := range []int{0, 3, 99, 250, 499} {
+				if err := r.SeekToRow(int64(rowIndex)); err != nil {
+					t.Fatal(err)
+				}
+				n, err := r.Read(read[:1])
+				if n != 1 {
+					t.Fatalf("reading row %d: %v", rowIndex, err)
+				}
+				if !reflect.DeepEqual(read[0], rows[rowIndex]) {
+					t.Errorf("row %d mismatch:\nwant = %+v\ngot  = %+v", rowIndex, rows[rowIndex], read[0])
+				}
+			}
+
+			for _, column := range []string{"name", "path"} {
+				pages := f.Root().Column(column).Pages()
+				page, err := pages.ReadPage()
+				if err != nil {
+					t.Fatal(err)
+				}
+				numRows := page.NumRows()
+				values := make([]parquet.Value, numRows)
+				if n, err := page.Slice(1, numRows).Values().ReadValues(values); int64(n) != numRows-1 {
+					t.Fatalf("reading values of page of column %q: %d/%d: %v", column, n, numRows-1, err)
+				}
+				for i, v := range values[:numRows-1] {
+					want := reflect.ValueOf(rows[i+1]).FieldByNameFunc(func(name string) bool {
+						return strings.EqualFold(name, column)
+					}).String()
+					if v.String() != want {
+						t.Errorf("value %d of column %q mismatch: want %q, got %q", i+1, column, want, v.String())
+					}
+				}
+				parquet.Release(page)
+				pages.Close()
+			}
+		})
+	}
+}
+
+func TestStreamingDecompression(t *testing.T) {
+	type Row struct {
+		ID    int64    `parquet:"id"`
+		Gzip  string   `parquet:"gzip,plain,gzip"`
+		Zstd  *string  `parquet:"zstd,plain,optional,zstd"`
+		Tags  []string `parquet:"tags,plain,list,brotli"`
+		Other string   `parquet:"other,plain,snappy"`
+	}
+
+	rows := make([]Row, 2000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:    int64(i),
+			Gzip:  strings.Repeat("g", i%50),
+			Tags:  []string{},
+			Other: "other-" + strconv.Itoa(i),
+		}
+		if i%4 != 0 {
+			value := "zstd-" + strconv.Itoa(i)
+			rows[i].Zstd = &value
+		}
+		for j := 0; j < i%3; j++ {
+			rows[i].Tags = append(rows[i].Tags, "tag-"+strconv.Itoa(i+j))
+		}
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run("v"+strconv.Itoa(version), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf,
+				parquet.DataPageVersion(version),
+				parquet.PageBufferSize(4096),
+			)
+			if _, err := w.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()),
+				parquet.StreamingDecompression(1024),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := parquet.NewGenericReader[Row](f)
+			defer r.Close()
+
+			read := make([]Row, len(rows))
+			if n, err := r.Read(read); n != len(rows) {
+				t.Fatalf("reading rows: %d/%d: %v", n, len(rows), err)
+			}
+			if !reflect.DeepEqual(read, rows) {
+				t.Fatal("rows read with streaming decompression mismatch")
+			}
+
+			if err := r.SeekToRow(1234); err != nil {
+				t.Fatal(err)
+			}
+			if n, err := r.Read(read[:1]); n != 1 {
+				t.Fatalf("reading row after seeking: %v", err)
+			}
+			if !reflect.DeepEqual(read[0], rows[1234]) {
+				t.Errorf("row mismatch after seeking:\nwant = %+v\ngot  = %+v", rows[1234], read[0])
+			}
+		})
+	}
+}
+
+func TestFileLegacyMapStructure(t *testing.T) {
+	type Row struct {
+		ID    int64             `parquet:"id"`
+		Attrs map[string]string `parquet:"attrs"`
+	}
+
+	rows := []Row{
+		{ID: 1, Attrs: map[string]string{"a": "1", "b": "2"}},
+		{ID: 2, Attrs: map[string]string{}},
+		{ID: 3, Attrs: map[string]string{"c": "3"}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.LegacyConvertedTypes(true)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Older writers named the repeated group of maps "map" and only annotated
+	// it with the MAP_KEY_VALUE converted type, e.g.
+	//
+	//	optional group attrs (MAP) {
+	//	  repeated group map (MAP_KEY_VALUE) {
+	//	    required binary key (UTF8);
+	//	    optional binary value (UTF8);
+	//	  }
+	//	}
+	legacy := rewriteFileMetadata(t, f, buf.Bytes(), func(metadata *format.FileMetaData) {
+		for i := range metadata.Schema {
+			metadata.Schema[i].LogicalType = nil
+			if metadata.Schema[i].Name == "key_value" {
+				metadata.Schema[i].Name = "map"
+			}
+		}
+		for i := range metadata.RowGroups {
+			for j := range metadata.RowGroups[i].Columns {
+				path := metadata.RowGroups[i].Columns[j].MetaData.PathInSchema
+				for k := range path {
+					if path[k] == "key_value" {
+						path[k] = "map"
+					}
+				}
+			}
+		}
+	})
+
+	f, err = parquet.OpenFile(bytes.NewReader(legacy), int64(len(legacy)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	attrs := f.Root().Column("attrs")
+	if lt := attrs.Type().LogicalType(); lt == nil || lt.Map == nil {
+		t.Fatalf("attrs: expected MAP logical type, got %v", lt)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(legacy), int64(len(legacy)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+
+	// Writing with the schema of the file must use the name of its map group.
+	output := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](output, f.Schema())
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, err = parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch after rewrite:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+}
+
+func rewriteFileMetadata(t *testing.T, f *parquet.File, data []byte, rewrite func(*format.FileMetaData)) []byte {
+	t.Helper()
+	metadata := *f.Metadata()
+	metadata.Schema = append([]format.SchemaElement{}, metadata.Schema...)
+	rewrite(&metadata)
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	footerSize := binary.LittleEndian.Uint32(data[len(data)-8:])
+	rewritten := append([]byte{}, data[:len(data)-8-int(footerSize)]...)
+	rewritten = append(rewritten, footer...)
+	rewritten = binary.LittleEndian.AppendUint32(rewritten, uint32(len(footer)))
+	return append(rewritten, "PAR1"...)
+}
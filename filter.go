@@ -1,5 +1,16 @@
 package parquet
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"regexp/syntax"
+
+	"github.com/parquet-go/parquet-go/encoding"
+)
+
 // FilterRowReader constructs a RowReader which exposes rows from reader for
 // which the predicate has returned true.
 func FilterRowReader(reader RowReader, predicate func(Row) bool) RowReader {
@@ -34,7 +45,10 @@ func (f *filterRowReader) ReadRows(rows []Row) (n int, err error) {
 			}
 		}
 
-		if err != nil {
+		// The values of rows may reference memory of the reader which is only
+		// valid until the next call to ReadRows, so the rows are returned as
+		// soon as any of them was retained.
+		if err != nil || n > 0 {
 			break
 		}
 	}
@@ -88,3 +102,757 @@ func (f *filterRowWriter) WriteRows(rows []Row) (n int, err error) {
 
 	return n, err
 }
+
+// WriteFiltered writes the rows of src for which keep returned true to dst,
+// returning the number of rows written.
+//
+// The function is intended to rewrite row groups while dropping a subset of
+// their rows, for example to apply deletes or expire data from large files.
+// Rows are written to dst in the order they appear in src, so the output
+// retains the ordering of the source row group.
+//
+// The filters describe the pages for which keep is known to return true for
+// all rows: when the predicate of a filter returns true for the min and max
+// values of a page of its column (see FilterPages), the rows of the page are
+// copied to dst column by column, without reconstructing rows nor calling keep.
+// For example, when expiring data older than a cutoff, a filter on the time
+// column returning true for pages with a min value after the cutoff lets the
+// function copy most of the pages, and only evaluate keep on the rows of pages
+// that straddle the cutoff. Filters are ignored on columns without a page
+// index, and when dst applies write hooks, validation, clustering or content
+// defined row group chunking, since those require writing rows.
+//
+// The schema of src must be equal to the schema of dst, or dst must not have a
+// schema yet, in which case it is configured with the schema of src.
+func WriteFiltered(dst *Writer, src RowGroup, keep func(Row) bool, filters ...PageFilter) (int64, error) {
+	schema := src.Schema()
+	switch {
+	case schema == nil:
+		return 0, ErrRowGroupSchemaMissing
+	case dst.schema == nil:
+		dst.configure(schema)
+	case !nodesAreEqual(dst.schema, schema):
+		return 0, ErrRowGroupSchemaMismatch
+	}
+
+	var kept RowSelection
+	if dst.writer.copiesValues() {
+		var err error
+		if kept, err = keptPages(schema, src, filters); err != nil {
+			return 0, err
+		}
+	}
+
+	rows := src.Rows()
+	defer rows.Close()
+
+	copier := &rowGroupCopier{columns: src.ColumnChunks()}
+	defer copier.close()
+
+	numRows := src.NumRows()
+	rowIndex := int64(0)
+	written := int64(0)
+
+	for _, r := range append(kept, RowRange{Start: numRows, End: numRows}) {
+		if rowIndex < r.Start {
+			selection := RowSelection{{Start: rowIndex, End: r.Start}}
+			n, err := CopyRows(dst.writer, FilterRowReader(SelectRows(rows, selection), keep))
+			written += n
+			if err != nil {
+				return written, err
+			}
+		}
+		if r.Start < r.End {
+			n, err := copier.copyRows(dst.writer, r)
+			written += n
+			if err != nil {
+				return written, err
+			}
+		}
+		rowIndex = r.End
+	}
+
+	return written, nil
+}
+
+// keptPages returns the union of the selections of rows held by pages for
+// which the predicates of filters returned true.
+func keptPages(schema *Schema, src RowGroup, filters []PageFilter) (RowSelection, error) {
+	var kept RowSelection
+	chunks := src.ColumnChunks()
+
+	for _, filter := range filters {
+		leaf, ok := schema.Lookup(filter.Path...)
+		if !ok {
+			return nil, fmt.Errorf("cannot write filtered rows: page filter column %q does not exist", columnPath(filter.Path))
+		}
+		chunk := chunks[leaf.ColumnIndex]
+		// FilterPages selects all the rows of column chunks without a usable
+		// page index, which would not prove anything about the rows here.
+		if !hasCorrectStatistics(chunk) {
+			continue
+		}
+		if _, err := chunk.ColumnIndex(); err != nil {
+			continue
+		}
+		if _, err := chunk.OffsetIndex(); err != nil {
+			continue
+		}
+		kept = kept.union(FilterPages(chunk, filter.Predicate))
+	}
+
+	return kept, nil
+}
+
+// rowGroupCopier copies ranges of rows of a row group to a writer by reading
+// the pages of each column and writing their values to the matching column of
+// the writer.
+type rowGroupCopier struct {
+	columns []ColumnChunk
+	pages   []columnPageCopier
+	values  []Value
+}
+
+type columnPageCopier struct {
+	pages Pages
+	page  Page // page that the remaining rows were sliced from
+	rows  Page // remaining rows of the current page
+}
+
+func (c *rowGroupCopier) close() {
+	for i := range c.pages {
+		c.pages[i].release()
+		c.pages[i].pages.Close()
+	}
+}
+
+func (c *rowGroupCopier) copyRows(w *writer, r RowRange) (int64, error) {
+	if c.pages == nil {
+		c.pages = make([]columnPageCopier, len(c.columns))
+		for i, column := range c.columns {
+			c.pages[i].pages = column.Pages()
+		}
+	}
+	for i := range c.pages {
+		if err := c.pages[i].seekToRow(r.Start); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.writeRows(int(r.NumRows()), func(i, j int) (int, error) {
+		for k := range c.pages {
+			if err := c.pages[k].copyRows(w.columns[k], int64(j-i), &c.values); err != nil {
+				return 0, err
+			}
+		}
+		return j - i, nil
+	})
+	return int64(n), err
+}
+
+func (c *columnPageCopier) release() {
+	if c.page != nil {
+		Release(c.page)
+		c.page, c.rows = nil, nil
+	}
+}
+
+func (c *columnPageCopier) seekToRow(rowIndex int64) error {
+	c.release()
+	return c.pages.SeekToRow(rowIndex)
+}
+
+func (c *columnPageCopier) copyRows(column *writerColumn, numRows int64, buffer *[]Value) error {
+	for numRows > 0 {
+		if c.rows == nil {
+			page, err := c.pages.ReadPage()
+			if err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				return err
+			}
+			c.page, c.rows = page, page
+		}
+
+		pageRows := c.rows.NumRows()
+		if pageRows == 0 {
+			c.release()
+			continue
+		}
+
+		// The values of whole rows are written at once so the column does not
+		// flush a page in the middle of a row.
+		n := min(numRows, pageRows)
+		values, err := readAllValues(c.rows.Slice(0, n).Values(), (*buffer)[:0])
+		*buffer = values
+		if err != nil {
+			return err
+		}
+		err = column.writeRows(values)
+		clearValues(values)
+		if err != nil {
+			return err
+		}
+
+		if n == pageRows {
+			c.release()
+		} else {
+			c.rows = c.rows.Slice(n, pageRows)
+		}
+		numRows -= n
+	}
+	return nil
+}
+
+func readAllValues(reader ValueReader, values []Value) ([]Value, error) {
+	for {
+		if len(values) == cap(values) {
+			values = append(values, Value{})[:len(values)]
+		}
+		n, err := reader.ReadValues(values[len(values):cap(values)])
+		values = values[:len(values)+n]
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return values, err
+		}
+		if n == 0 {
+			return values, io.ErrNoProgress
+		}
+	}
+}
+
+// FilterPages evaluates the predicate on the page index of chunk, returning the
+// selection of rows held by the pages for which it returned true.
+//
+// The predicate receives the min and max values recorded in the column index
+// for each page, in their PLAIN encoded form (without a length prefix for byte
+// arrays), and whether the page contains only null values. For null pages, the
+// min and max values are nil. Adjacent pages which are selected are merged into
+// a single row range.
+//
+// The min and max values are ordered by the sort order of the column type (see
+// SortOrderOf), the predicate must compare them accordingly; for example the
+// bounds of UINT64 columns are ordered as unsigned integers, and the bounds of
+// strings as unsigned bytes. Note that min and max values of BYTE_ARRAY
+// columns may have been truncated by the writer, see ColumnIndexSizeLimit; the
+// predicate must treat them as lower and upper bounds rather than actual
+// values of the page.
+//
+// If the column chunk has no page index, no pages can be filtered, and the
+// returned selection contains all the rows of the column chunk. This is also
+// the case when the column chunk was read from a file which does not declare
+// the order of a column not ordered by signed comparisons (see
+// Column.ColumnOrder), or which was written by an application with known bugs
+// in its statistics (see ApplicationVersion.HasCorrectStatistics).
+func FilterPages(chunk ColumnChunk, pred func(min, max []byte, nullPage bool) bool) RowSelection {
+	numRows := numRowsOfColumnChunk(chunk)
+	if !hasCorrectStatistics(chunk) {
+		return RowSelection{{Start: 0, End: numRows}}
+	}
+	columnIndex, err := chunk.ColumnIndex()
+	if err != nil {
+		return RowSelection{{Start: 0, End: numRows}}
+	}
+	offsetIndex, err := chunk.OffsetIndex()
+	if err != nil || offsetIndex.NumPages() != columnIndex.NumPages() {
+		return RowSelection{{Start: 0, End: numRows}}
+	}
+
+	var selection RowSelection
+	numPages := columnIndex.NumPages()
+
+	for i := 0; i < numPages; i++ {
+		var keep bool
+		if columnIndex.NullPage(i) {
+			keep = pred(nil, nil, true)
+		} else {
+			keep = pred(columnIndex.MinValue(i).Bytes(), columnIndex.MaxValue(i).Bytes(), false)
+		}
+		if !keep {
+			continue
+		}
+
+		start := offsetIndex.FirstRowIndex(i)
+		end := numRows
+		if i+1 < numPages {
+			end = offsetIndex.FirstRowIndex(i + 1)
+		}
+
+		if n := len(selection); n > 0 && selection[n-1].End == start {
+			selection[n-1].End = end
+		} else {
+			selection = append(selection, RowRange{Start: start, End: end})
+		}
+	}
+
+	return selection
+}
+
+// hasCorrectStatistics returns false if chunk was read from a file written by
+// an application known to write incorrect statistics for the column type, or
+// if the file does not declare the order used to compute the statistics of a
+// column which is not ordered by signed comparisons.
+func hasCorrectStatistics(chunk ColumnChunk) bool {
+	if c, ok := chunk.(*FileColumnChunk); ok {
+		columnType := c.Type()
+		if SortOrderOf(columnType) != SignedSortOrder && !c.column.hasTypeDefinedOrder() {
+			return false
+		}
+		return c.file.ApplicationVersion().HasCorrectStatistics(columnType)
+	}
+	return true
+}
+
+// ScanColumn evaluates fn on the values of chunk, returning the selection of
+// rows which have at least one value for which fn returned true.
+//
+// The function is intended to be a building block of query engines which need
+// to evaluate arbitrary predicates on columns: the selections produced for
+// multiple columns of a row group can be combined with RowSelection.Intersect
+// and passed to SelectRows to read the matching rows.
+//
+// Null values are passed to fn like other values, with levels indicating where
+// the null occurs in the row. When pages are dictionary encoded, fn is called
+// at most once per value of the dictionary, and the result is reused for all
+// the occurrences of the value, so predicates on low cardinality columns are
+// evaluated in time proportional to the number of distinct values.
+//
+// The values passed to fn must not be retained after it returned, they may
+// reference memory of the pages which is reused.
+func ScanColumn(chunk ColumnChunk, fn func(Value) bool) (RowSelection, error) {
+	s := &columnScan{fn: fn, rowIndex: -1}
+	return s.scan(chunk)
+}
+
+// ScanColumnIn returns the selection of rows of chunk which have at least one
+// value equal to one of the given values, as defined by the SQL IN operator.
+// Null values never match.
+//
+// Values are compared by their physical representation, they must have the
+// kind of the column to match any of its values.
+//
+// When pages are dictionary encoded, the values of the dictionary are looked
+// up once in the set of values, and the rows are selected by testing the
+// indexes of the pages against the resulting bitmap, without decoding the
+// values. Filtering low cardinality columns on a list of tags is therefore
+// roughly as expensive as reading their dictionary indexes. When a column chunk
+// read from a file is entirely dictionary encoded and none of the values are
+// found in its dictionary (see FileColumnChunk.MayContain), no data pages are
+// read at all.
+func ScanColumnIn(chunk ColumnChunk, values ...Value) (RowSelection, error) {
+	if c, ok := chunk.(*FileColumnChunk); ok {
+		mayContain, err := c.MayContain(values...)
+		if err != nil {
+			return nil, err
+		}
+		if !mayContain {
+			return nil, nil
+		}
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if !v.IsNull() {
+			set[string(v.Bytes())] = struct{}{}
+		}
+	}
+	s := &columnScan{rowIndex: -1, eager: true}
+	s.fn = func(v Value) bool {
+		if v.IsNull() {
+			return false
+		}
+		_, ok := set[string(v.Bytes())]
+		return ok
+	}
+	return s.scan(chunk)
+}
+
+// ScanColumnPrefix returns the selection of rows of chunk which have at least
+// one value starting with prefix. The column must hold byte arrays.
+//
+// Pages are pruned using the min and max values of the column index when it
+// is available and the values of the column are ordered as unsigned bytes (see
+// SortOrderOf), so only the pages which may contain values starting with the
+// prefix are read. Dictionary encoded pages are scanned like by ScanColumnIn,
+// testing each value of the dictionary once.
+func ScanColumnPrefix(chunk ColumnChunk, prefix []byte) (RowSelection, error) {
+	if err := checkByteArrayColumn(chunk); err != nil {
+		return nil, err
+	}
+	s := &columnScan{rowIndex: -1, eager: true}
+	s.fn = func(v Value) bool { return !v.IsNull() && bytes.HasPrefix(v.byteArray(), prefix) }
+	if !hasUnsignedSortOrder(chunk) {
+		return s.scan(chunk)
+	}
+	return s.scanSelection(chunk, FilterPages(chunk, prefixPageFilter(prefix)))
+}
+
+// ScanColumnRegexp returns the selection of rows of chunk which have at least
+// one value matching re. The column must hold byte arrays.
+//
+// When the expression is anchored at the beginning of the text and starts with
+// a literal prefix (e.g. "^/api/v1/.*"), pages are pruned like by
+// ScanColumnPrefix. Dictionary encoded pages are scanned by matching each value
+// of the dictionary once.
+func ScanColumnRegexp(chunk ColumnChunk, re *regexp.Regexp) (RowSelection, error) {
+	if err := checkByteArrayColumn(chunk); err != nil {
+		return nil, err
+	}
+	s := &columnScan{rowIndex: -1, eager: true}
+	s.fn = func(v Value) bool { return !v.IsNull() && re.Match(v.byteArray()) }
+	if prefix := anchoredLiteralPrefix(re); len(prefix) > 0 && hasUnsignedSortOrder(chunk) {
+		return s.scanSelection(chunk, FilterPages(chunk, prefixPageFilter(prefix)))
+	}
+	return s.scan(chunk)
+}
+
+// ScanColumnNulls returns the selection of rows of chunk which have at least
+// one null value when null is true (IS NULL), or at least one non-null value
+// when null is false (IS NOT NULL). The values of repeated columns are null
+// where rows hold empty lists.
+//
+// The null pages and null counts recorded in the column index are used to
+// select or skip the pages which contain only nulls or no nulls at all
+// without reading them; only the pages mixing null and non-null values are
+// decoded.
+func ScanColumnNulls(chunk ColumnChunk, null bool) (RowSelection, error) {
+	s := &columnScan{rowIndex: -1, fn: func(v Value) bool { return v.IsNull() == null }}
+
+	columnIndex, err := chunk.ColumnIndex()
+	if err != nil {
+		return s.scan(chunk)
+	}
+	offsetIndex, err := chunk.OffsetIndex()
+	if err != nil || offsetIndex.NumPages() != columnIndex.NumPages() {
+		return s.scan(chunk)
+	}
+
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	numRows := numRowsOfColumnChunk(chunk)
+	numPages := columnIndex.NumPages()
+	nullCounts := nullCountsOf(columnIndex)
+
+	for i := 0; i < numPages; i++ {
+		rows := RowRange{Start: offsetIndex.FirstRowIndex(i), End: numRows}
+		if i+1 < numPages {
+			rows.End = offsetIndex.FirstRowIndex(i + 1)
+		}
+
+		allNulls := columnIndex.NullPage(i)
+		noNulls := !allNulls && i < len(nullCounts) && nullCounts[i] == 0
+		if allNulls || noNulls {
+			if allNulls == null {
+				s.selectRows(rows)
+			}
+			continue
+		}
+
+		if err := s.scanRows(pages, rows); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("scanning column chunk: %w", err)
+		}
+	}
+	return s.selection, nil
+}
+
+// nullCountsOf returns the null counts of pages recorded in a column index read
+// from a file, or nil if they are unknown. The column indexes of in-memory
+// pages do not always track null counts, which are then reported as zero.
+func nullCountsOf(columnIndex ColumnIndex) []int64 {
+	switch index := columnIndex.(type) {
+	case fileColumnIndex:
+		return index.chunk.columnIndex.NullCounts
+	case *formatColumnIndex:
+		return index.index.NullCounts
+	default:
+		return nil
+	}
+}
+
+func checkByteArrayColumn(chunk ColumnChunk) error {
+	switch kind := chunk.Type().Kind(); kind {
+	case ByteArray, FixedLenByteArray:
+		return nil
+	default:
+		return fmt.Errorf("cannot scan column of type %s for byte array values", kind)
+	}
+}
+
+// hasUnsignedSortOrder returns true if the values of chunk are ordered by
+// unsigned byte-wise comparisons, which prefixPageFilter relies on to prune
+// pages. Byte arrays holding decimals, for example, are ordered as signed
+// integers.
+func hasUnsignedSortOrder(chunk ColumnChunk) bool {
+	return SortOrderOf(chunk.Type()) == UnsignedSortOrder
+}
+
+// prefixPageFilter returns a predicate for FilterPages selecting the pages
+// which may contain values starting with prefix. The min and max values may
+// have been truncated, but they remain lower and upper bounds of the values
+// of the page.
+func prefixPageFilter(prefix []byte) func(min, max []byte, nullPage bool) bool {
+	return func(min, max []byte, nullPage bool) bool {
+		if nullPage {
+			return false
+		}
+		if len(min) > len(prefix) {
+			min = min[:len(prefix)]
+		}
+		return bytes.Compare(min, prefix) <= 0 && bytes.Compare(max, prefix) >= 0
+	}
+}
+
+// anchoredLiteralPrefix returns the literal prefix that all the values matched
+// by re must start with, or nil if the expression is not anchored at the
+// beginning of the text.
+func anchoredLiteralPrefix(re *regexp.Regexp) []byte {
+	expr, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	expr = expr.Simplify()
+	if expr.Op != syntax.OpConcat || len(expr.Sub) < 2 {
+		return nil
+	}
+	anchor, literal := expr.Sub[0], expr.Sub[1]
+	if anchor.Op != syntax.OpBeginText || literal.Op != syntax.OpLiteral || literal.Flags&syntax.FoldCase != 0 {
+		return nil
+	}
+	return []byte(string(literal.Rune))
+}
+
+type columnScan struct {
+	fn        func(Value) bool
+	values    []Value
+	selection RowSelection
+	rowIndex  int64
+	// Results of the predicate for the values of the current dictionary,
+	// indexed by position in the dictionary: zero if the predicate was not
+	// evaluated yet, one if it returned false, two if it returned true.
+	dict      Dictionary
+	matches   []int8
+	noMatches bool
+	// When eager is true, the predicate is evaluated on all the values of
+	// dictionaries before scanning their pages, which are then scanned by
+	// looking up the results of their indexes instead of decoding values.
+	// Pages are skipped when no values of the dictionary matched.
+	eager bool
+}
+
+func (s *columnScan) scan(chunk ColumnChunk) (RowSelection, error) {
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	for {
+		page, err := pages.ReadPage()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("scanning column chunk: %w", err)
+		}
+		err = s.scanPage(page)
+		Release(page)
+		if err != nil {
+			return nil, fmt.Errorf("scanning column chunk: %w", err)
+		}
+	}
+	return s.selection, nil
+}
+
+// scanSelection scans the rows of the selection, seeking over the pages which
+// hold no selected rows.
+func (s *columnScan) scanSelection(chunk ColumnChunk, selection RowSelection) (RowSelection, error) {
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	for _, rows := range selection {
+		if err := s.scanRows(pages, rows); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("scanning column chunk: %w", err)
+		}
+	}
+	return s.selection, nil
+}
+
+// scanRows scans the pages holding the given range of rows, seeking to the
+// first row of the range if the pages were not positioned on it.
+func (s *columnScan) scanRows(pages Pages, rows RowRange) error {
+	if s.rowIndex >= rows.End-1 {
+		return nil // already scanned with the pages of a previous range
+	}
+	if s.rowIndex < rows.Start-1 {
+		if err := pages.SeekToRow(rows.Start); err != nil {
+			return err
+		}
+		s.rowIndex = rows.Start - 1
+	}
+	for s.rowIndex < rows.End-1 {
+		page, err := pages.ReadPage()
+		if err != nil {
+			return err
+		}
+		err = s.scanPage(page)
+		Release(page)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *columnScan) scanPage(page Page) error {
+	var indexes []int32
+	if dict := page.Dictionary(); dict != nil {
+		if data := page.Data(); data.Kind() == encoding.Int32 {
+			if dict != s.dict {
+				s.dict, s.matches = dict, make([]int8, dict.Len())
+				if s.eager {
+					s.matchDictionary(dict)
+				}
+			}
+			indexes = data.Int32()
+			if s.eager && s.noMatches {
+				s.rowIndex += page.NumRows()
+				return nil
+			}
+			if s.eager {
+				s.scanIndexes(indexes, page.RepetitionLevels(), page.DefinitionLevels())
+				return nil
+			}
+		}
+	}
+
+	if s.values == nil {
+		s.values = make([]Value, defaultValueBufferSize)
+	}
+	defer clearValues(s.values)
+
+	reader := page.Values()
+	for {
+		n, err := reader.ReadValues(s.values)
+		for _, v := range s.values[:n] {
+			if v.repetitionLevel == 0 {
+				s.rowIndex++
+			}
+			var index int32 = -1
+			if !v.IsNull() && len(indexes) > 0 {
+				index, indexes = indexes[0], indexes[1:]
+			}
+			if s.selected(s.rowIndex) {
+				continue
+			}
+			if s.match(v, index) {
+				s.selectRow(s.rowIndex)
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// match evaluates the predicate on v, using the cached result if v is at the
+// given index of the dictionary. The index is negative if v is null or the
+// page is not dictionary encoded.
+func (s *columnScan) match(v Value, index int32) bool {
+	if index < 0 {
+		return s.fn(v)
+	}
+	if s.matches[index] == 0 {
+		s.matches[index] = 1
+		if s.fn(v) {
+			s.matches[index] = 2
+		}
+	}
+	return s.matches[index] == 2
+}
+
+func (s *columnScan) matchDictionary(dict Dictionary) {
+	s.noMatches = true
+	for i := range s.matches {
+		s.matches[i] = 1
+		if s.fn(dict.Index(int32(i))) {
+			s.matches[i] = 2
+			s.noMatches = false
+		}
+	}
+}
+
+// scanIndexes selects the rows of a dictionary encoded page from the indexes
+// and levels of the page, after the predicate was evaluated on all the values
+// of the dictionary.
+func (s *columnScan) scanIndexes(indexes []int32, repetitionLevels, definitionLevels []byte) {
+	if len(definitionLevels) == 0 {
+		for _, index := range indexes {
+			s.rowIndex++
+			if s.matches[index] == 2 && !s.selected(s.rowIndex) {
+				s.selectRow(s.rowIndex)
+			}
+		}
+		return
+	}
+
+	// The page holds one index for each non-null value, which are the values
+	// at the maximum definition level. Since the page has at least one index
+	// when it is not empty, the maximum definition level is the greatest level
+	// of the page.
+	maxDefinitionLevel := byte(0)
+	if len(indexes) > 0 {
+		for _, level := range definitionLevels {
+			maxDefinitionLevel = max(maxDefinitionLevel, level)
+		}
+	}
+
+	for i, definitionLevel := range definitionLevels {
+		if len(repetitionLevels) == 0 || repetitionLevels[i] == 0 {
+			s.rowIndex++
+		}
+		if len(indexes) == 0 || definitionLevel != maxDefinitionLevel {
+			continue
+		}
+		index := indexes[0]
+		indexes = indexes[1:]
+		if s.matches[index] == 2 && !s.selected(s.rowIndex) {
+			s.selectRow(s.rowIndex)
+		}
+	}
+}
+
+func (s *columnScan) selected(rowIndex int64) bool {
+	n := len(s.selection)
+	return n > 0 && s.selection[n-1].End > rowIndex
+}
+
+func (s *columnScan) selectRow(rowIndex int64) {
+	s.selectRows(RowRange{Start: rowIndex, End: rowIndex + 1})
+}
+
+func (s *columnScan) selectRows(rows RowRange) {
+	if n := len(s.selection); n > 0 && s.selection[n-1].End >= rows.Start {
+		s.selection[n-1].End = rows.End
+	} else {
+		s.selection = append(s.selection, rows)
+	}
+}
+
+// numRowsOfColumnChunk returns the number of rows in the row group that chunk
+// belongs to. When the row group is not known, the number of values is used;
+// it is an upper bound of the number of rows since each row has at least one
+// value in each column.
+func numRowsOfColumnChunk(chunk ColumnChunk) int64 {
+	if c, ok := chunk.(*FileColumnChunk); ok {
+		return c.rowGroup.NumRows
+	}
+	return chunk.NumValues()
+}
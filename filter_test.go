@@ -1,6 +1,14 @@
 package parquet_test
 
 import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/parquet-go/parquet-go"
@@ -65,3 +73,649 @@ func TestFilterRowWriter(t *testing.T) {
 
 	assertEqualRows(t, want, buffer.rows)
 }
+
+func TestWriteFiltered(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,optional"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i)}
+		if i%7 != 0 {
+			rows[i].Name = fmt.Sprintf("name-%d", i)
+		}
+	}
+
+	input := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](input, parquet.PageBufferSize(256))
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := parquet.OpenFile(bytes.NewReader(input.Bytes()), int64(input.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rows are expired when their id is a multiple of 3 and lower than 500,
+	// the pages with a min id of 500 or more are known to be entirely kept.
+	keepCalls := 0
+	keep := func(row parquet.Row) bool {
+		keepCalls++
+		if id := row[0].Int64(); id >= 600 {
+			t.Errorf("keep called on row %d of a page which should have been copied", id)
+		}
+		return row[0].Int64()%3 != 0 || row[0].Int64() >= 500
+	}
+	filter := parquet.PageFilter{
+		Path: []string{"id"},
+		Predicate: func(min, max []byte, nullPage bool) bool {
+			return !nullPage && int64(binary.LittleEndian.Uint64(min)) >= 500
+		},
+	}
+
+	output := new(bytes.Buffer)
+	dst := parquet.NewWriter(output, parquet.MaxRowsPerRowGroup(300))
+	n, err := parquet.WriteFiltered(dst, file.RowGroups()[0], keep, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if keepCalls >= len(rows) || keepCalls < 500 {
+		t.Errorf("wrong number of calls to keep: %d", keepCalls)
+	}
+
+	want := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		if row.ID%3 != 0 || row.ID >= 500 {
+			want = append(want, row)
+		}
+	}
+	if n != int64(len(want)) {
+		t.Fatalf("wrong number of rows written: want=%d got=%d", len(want), n)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, want, got)
+}
+
+func TestWriteFilteredWithoutPageFilters(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: "name"}
+	}
+
+	buffer := parquet.NewGenericBuffer[Row]()
+	if _, err := buffer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+
+	output := new(bytes.Buffer)
+	dst := parquet.NewWriter(output)
+	n, err := parquet.WriteFiltered(dst, buffer, func(row parquet.Row) bool {
+		return row[0].Int64()%3 != 0
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		if row.ID%3 != 0 {
+			want = append(want, row)
+		}
+	}
+	if n != int64(len(want)) {
+		t.Fatalf("wrong number of rows written: want=%d got=%d", len(want), n)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, want, got)
+}
+
+func TestFilterPages(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].ID = int64(i)
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer, parquet.PageBufferSize(256))
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowGroup := file.RowGroups()[0]
+	chunk := rowGroup.ColumnChunks()[0]
+
+	if index, _ := chunk.OffsetIndex(); index.NumPages() < 4 {
+		t.Fatalf("expected multiple pages, got %d", index.NumPages())
+	}
+
+	selection := parquet.FilterPages(chunk, func(min, max []byte, nullPage bool) bool {
+		minID := int64(binary.LittleEndian.Uint64(min))
+		maxID := int64(binary.LittleEndian.Uint64(max))
+		return !nullPage && minID <= 600 && maxID >= 400
+	})
+	if len(selection) != 1 {
+		t.Fatalf("expected adjacent pages to be merged into one range, got %v", selection)
+	}
+	if r := selection[0]; r.Start > 400 || r.End <= 600 || r.NumRows() >= 1000 {
+		t.Fatalf("selected row range does not match the predicate: %+v", r)
+	}
+
+	reader := rowGroup.Rows()
+	defer reader.Close()
+
+	selected := make([]parquet.Row, 0, selection.NumRows())
+	buf := make([]parquet.Row, 10)
+	selectRows := parquet.SelectRows(reader, selection)
+	for {
+		n, err := selectRows.ReadRows(buf)
+		for _, row := range buf[:n] {
+			selected = append(selected, row.Clone())
+		}
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	if int64(len(selected)) != selection.NumRows() {
+		t.Fatalf("wrong number of selected rows: want=%d got=%d", selection.NumRows(), len(selected))
+	}
+	for i, row := range selected {
+		if id := row[0].Int64(); id != selection[0].Start+int64(i) {
+			t.Fatalf("wrong row at index %d: want=%d got=%d", i, selection[0].Start+int64(i), id)
+		}
+	}
+}
+
+func TestRowSelectionIntersect(t *testing.T) {
+	a := parquet.RowSelection{{Start: 0, End: 10}, {Start: 20, End: 30}, {Start: 40, End: 50}}
+	b := parquet.RowSelection{{Start: 5, End: 25}, {Start: 45, End: 60}}
+
+	want := parquet.RowSelection{{Start: 5, End: 10}, {Start: 20, End: 25}, {Start: 45, End: 50}}
+	if got := a.Intersect(b); !reflect.DeepEqual(got, want) {
+		t.Errorf("intersection mismatch:\nwant: %v\ngot:  %v", want, got)
+	}
+	if n := want.NumRows(); n != 15 {
+		t.Errorf("wrong number of rows: want=15 got=%d", n)
+	}
+}
+
+func TestScanColumn(t *testing.T) {
+	type Row struct {
+		Color string   `parquet:"color,dict"`
+		Tags  []string `parquet:"tags"`
+		Score *int64   `parquet:"score,optional"`
+	}
+
+	colors := []string{"red", "green", "blue"}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].Color = colors[i%len(colors)]
+		for j := 0; j < i%4; j++ {
+			rows[i].Tags = append(rows[i].Tags, strconv.Itoa(j))
+		}
+		if i%5 != 0 {
+			score := int64(i)
+			rows[i].Score = &score
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(512)); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := file.RowGroups()[0].ColumnChunks()
+
+	// selectionOf returns the selection of rows for which keep returns true.
+	selectionOf := func(keep func(Row) bool) (selection parquet.RowSelection) {
+		for i, row := range rows {
+			if !keep(row) {
+				continue
+			}
+			if n := len(selection); n > 0 && selection[n-1].End == int64(i) {
+				selection[n-1].End++
+			} else {
+				selection = append(selection, parquet.RowRange{Start: int64(i), End: int64(i) + 1})
+			}
+		}
+		return selection
+	}
+
+	tests := []struct {
+		scenario string
+		column   int
+		match    func(parquet.Value) bool
+		keep     func(Row) bool
+	}{
+		{
+			scenario: "dictionary encoded column",
+			column:   0,
+			match:    func(v parquet.Value) bool { return v.String() != "green" },
+			keep:     func(r Row) bool { return r.Color != "green" },
+		},
+		{
+			scenario: "repeated column",
+			column:   1,
+			match:    func(v parquet.Value) bool { return v.String() == "2" },
+			keep:     func(r Row) bool { return len(r.Tags) > 2 },
+		},
+		{
+			scenario: "null values",
+			column:   2,
+			match:    func(v parquet.Value) bool { return v.IsNull() || v.Int64() >= 900 },
+			keep:     func(r Row) bool { return r.Score == nil || *r.Score >= 900 },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			calls := 0
+			got, err := parquet.ScanColumn(chunks[test.column], func(v parquet.Value) bool {
+				calls++
+				return test.match(v)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := selectionOf(test.keep); !reflect.DeepEqual(got, want) {
+				t.Errorf("selection mismatch:\nwant: %v\ngot:  %v", want, got)
+			}
+			if test.column == 0 && calls > len(colors) {
+				t.Errorf("predicate evaluated %d times on a dictionary of %d values", calls, len(colors))
+			}
+		})
+	}
+}
+
+func TestScanColumnIn(t *testing.T) {
+	type Row struct {
+		Color string   `parquet:"color,dict"`
+		Tags  []string `parquet:"tags,dict"`
+		Score *int64   `parquet:"score,optional,dict"`
+		Name  string   `parquet:"name"`
+	}
+
+	colors := []string{"red", "green", "blue"}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].Color = colors[i%len(colors)]
+		for j := 0; j < i%4; j++ {
+			rows[i].Tags = append(rows[i].Tags, strconv.Itoa(j))
+		}
+		if i%5 != 0 {
+			score := int64(i % 7)
+			rows[i].Score = &score
+		}
+		rows[i].Name = "name-" + strconv.Itoa(i%10)
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(512)); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := file.RowGroups()[0].ColumnChunks()
+
+	tests := []struct {
+		scenario string
+		column   int
+		values   []parquet.Value
+		keep     func(Row) bool
+	}{
+		{
+			scenario: "dictionary encoded column",
+			column:   0,
+			values:   []parquet.Value{parquet.ValueOf("red"), parquet.ValueOf("blue")},
+			keep:     func(r Row) bool { return r.Color == "red" || r.Color == "blue" },
+		},
+		{
+			scenario: "no matches",
+			column:   0,
+			values:   []parquet.Value{parquet.ValueOf("yellow")},
+			keep:     func(r Row) bool { return false },
+		},
+		{
+			scenario: "repeated column",
+			column:   1,
+			values:   []parquet.Value{parquet.ValueOf("2")},
+			keep:     func(r Row) bool { return len(r.Tags) > 2 },
+		},
+		{
+			scenario: "optional column",
+			column:   2,
+			values:   []parquet.Value{parquet.ValueOf(int64(3)), parquet.ValueOf(nil)},
+			keep:     func(r Row) bool { return r.Score != nil && *r.Score == 3 },
+		},
+		{
+			scenario: "plain encoded column",
+			column:   3,
+			values:   []parquet.Value{parquet.ValueOf("name-1"), parquet.ValueOf("name-4")},
+			keep:     func(r Row) bool { return r.Name == "name-1" || r.Name == "name-4" },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			got, err := parquet.ScanColumnIn(chunks[test.column], test.values...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want parquet.RowSelection
+			for i, row := range rows {
+				if !test.keep(row) {
+					continue
+				}
+				if n := len(want); n > 0 && want[n-1].End == int64(i) {
+					want[n-1].End++
+				} else {
+					want = append(want, parquet.RowRange{Start: int64(i), End: int64(i) + 1})
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("selection mismatch:\nwant: %v\ngot:  %v", want, got)
+			}
+		})
+	}
+}
+
+func TestScanColumnInSkipsDataPages(t *testing.T) {
+	type Row struct {
+		Color string `parquet:"color,dict"`
+	}
+	colors := []string{"red", "green", "blue"}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].Color = colors[i%len(colors)]
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(512)); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Overwrite the data pages of the column chunk so reading them fails, only
+	// the dictionary page remains readable.
+	data := bytes.Clone(buffer.Bytes())
+	metadata := file.Metadata().RowGroups[0].Columns[0].MetaData
+	dataPages := data[metadata.DataPageOffset : metadata.DictionaryPageOffset+metadata.TotalCompressedSize]
+	for i := range dataPages {
+		dataPages[i] = 0xFF
+	}
+	file, err = parquet.OpenFile(bytes.NewReader(data), int64(len(data)), parquet.SkipPageIndex(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunk := file.RowGroups()[0].ColumnChunks()[0]
+
+	selection, err := parquet.ScanColumnIn(chunk, parquet.ValueOf("yellow"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selection) != 0 {
+		t.Errorf("expected no rows to be selected, got %v", selection)
+	}
+	if _, err := parquet.ScanColumnIn(chunk, parquet.ValueOf("red")); err == nil {
+		t.Error("expected an error reading the corrupted data pages")
+	}
+}
+
+func BenchmarkScanColumnIn(b *testing.B) {
+	type Row struct {
+		Tag string `parquet:"tag,dict"`
+	}
+	rows := make([]Row, 100e3)
+	for i := range rows {
+		rows[i].Tag = "tag-" + strconv.Itoa(i%16)
+	}
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		b.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	chunk := file.RowGroups()[0].ColumnChunks()[0]
+	values := []parquet.Value{parquet.ValueOf("tag-1"), parquet.ValueOf("tag-7")}
+
+	for i := 0; i < b.N; i++ {
+		if _, err := parquet.ScanColumnIn(chunk, values...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestScanColumnPrefix(t *testing.T) {
+	type Row struct {
+		Path    string `parquet:"path"`
+		Service string `parquet:"service,dict"`
+		Count   int64  `parquet:"count"`
+	}
+
+	services := []string{"api-gateway", "api-users", "billing", "search"}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		// Paths are sorted so the page index can be used to prune pages.
+		rows[i].Path = fmt.Sprintf("/%s/%04d", []string{"a", "b", "c", "d"}[i/250], i)
+		rows[i].Service = services[i%len(services)]
+		rows[i].Count = int64(i)
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := file.RowGroups()[0].ColumnChunks()
+
+	tests := []struct {
+		scenario string
+		column   int
+		prefix   string
+		regexp   string
+		keep     func(Row) bool
+	}{
+		{
+			scenario: "sorted column",
+			column:   0,
+			prefix:   "/b/",
+			keep:     func(r Row) bool { return strings.HasPrefix(r.Path, "/b/") },
+		},
+		{
+			scenario: "no matches",
+			column:   0,
+			prefix:   "/e/",
+			keep:     func(r Row) bool { return false },
+		},
+		{
+			scenario: "dictionary encoded column",
+			column:   1,
+			prefix:   "api-",
+			keep:     func(r Row) bool { return strings.HasPrefix(r.Service, "api-") },
+		},
+		{
+			scenario: "anchored regexp",
+			column:   0,
+			regexp:   `^/c/0[0-9]*5$`,
+			keep:     func(r Row) bool { return strings.HasPrefix(r.Path, "/c/0") && strings.HasSuffix(r.Path, "5") },
+		},
+		{
+			scenario: "unanchored regexp",
+			column:   1,
+			regexp:   `(users|search)`,
+			keep:     func(r Row) bool { return r.Service == "api-users" || r.Service == "search" },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			var got parquet.RowSelection
+			var err error
+			if test.regexp != "" {
+				got, err = parquet.ScanColumnRegexp(chunks[test.column], regexp.MustCompile(test.regexp))
+			} else {
+				got, err = parquet.ScanColumnPrefix(chunks[test.column], []byte(test.prefix))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want parquet.RowSelection
+			for i, row := range rows {
+				if !test.keep(row) {
+					continue
+				}
+				if n := len(want); n > 0 && want[n-1].End == int64(i) {
+					want[n-1].End++
+				} else {
+					want = append(want, parquet.RowRange{Start: int64(i), End: int64(i) + 1})
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("selection mismatch:\nwant: %v\ngot:  %v", want, got)
+			}
+		})
+	}
+
+	if _, err := parquet.ScanColumnPrefix(chunks[2], []byte("1")); err == nil {
+		t.Error("expected an error scanning a column of integers for a prefix")
+	}
+}
+
+func TestScanColumnNulls(t *testing.T) {
+	type Row struct {
+		Value *int64  `parquet:"value,optional"`
+		Tags  []int64 `parquet:"tags"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		// The first rows are all nulls, the following have no nulls, and the
+		// last ones mix null and non-null values.
+		switch {
+		case i < 300:
+		case i < 600 || i%3 != 0:
+			value := int64(i)
+			rows[i].Value = &value
+		}
+		for j := 0; j < i%3; j++ {
+			rows[i].Tags = append(rows[i].Tags, int64(j))
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := file.RowGroups()[0].ColumnChunks()
+
+	tests := []struct {
+		scenario string
+		column   int
+		null     bool
+		keep     func(Row) bool
+	}{
+		{
+			scenario: "is null",
+			column:   0,
+			null:     true,
+			keep:     func(r Row) bool { return r.Value == nil },
+		},
+		{
+			scenario: "is not null",
+			column:   0,
+			null:     false,
+			keep:     func(r Row) bool { return r.Value != nil },
+		},
+		{
+			scenario: "empty lists",
+			column:   1,
+			null:     true,
+			keep:     func(r Row) bool { return len(r.Tags) == 0 },
+		},
+		{
+			scenario: "non-empty lists",
+			column:   1,
+			null:     false,
+			keep:     func(r Row) bool { return len(r.Tags) != 0 },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			got, err := parquet.ScanColumnNulls(chunks[test.column], test.null)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want parquet.RowSelection
+			for i, row := range rows {
+				if !test.keep(row) {
+					continue
+				}
+				if n := len(want); n > 0 && want[n-1].End == int64(i) {
+					want[n-1].End++
+				} else {
+					want = append(want, parquet.RowRange{Start: int64(i), End: int64(i) + 1})
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("selection mismatch:\nwant: %v\ngot:  %v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package format
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MarshalJSON returns a JSON representation of the file metadata.
+//
+// The output is intended for debugging and comparing footers produced by
+// different writers: struct fields are emitted in their declaration order,
+// optional fields which are not set are omitted, enumerations are represented
+// by their names, and byte arrays are encoded in hexadecimal.
+func (m *FileMetaData) MarshalJSON() ([]byte, error) { return marshalJSON(m) }
+
+// MarshalJSON returns a JSON representation of the page header, using the same
+// format as FileMetaData.MarshalJSON.
+func (h *PageHeader) MarshalJSON() ([]byte, error) { return marshalJSON(h) }
+
+// MarshalJSON returns a JSON representation of the column index, using the same
+// format as FileMetaData.MarshalJSON.
+func (i *ColumnIndex) MarshalJSON() ([]byte, error) { return marshalJSON(i) }
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	return appendJSON(nil, reflect.ValueOf(v).Elem())
+}
+
+var (
+	byteSliceType = reflect.TypeOf([]byte(nil))
+	stringerType  = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+func appendJSON(b []byte, v reflect.Value) ([]byte, error) {
+	t := v.Type()
+
+	if t.Kind() == reflect.Int32 && t.Implements(stringerType) {
+		// Enumerations with unknown values are represented by their integer
+		// value so no information gets lost in the output.
+		if s := v.Interface().(fmt.Stringer).String(); !strings.HasSuffix(s, "(?)") {
+			return appendJSONString(b, s), nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return strconv.AppendBool(b, v.Bool()), nil
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, v.Int(), 10), nil
+
+	case reflect.String:
+		return appendJSONString(b, v.String()), nil
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return append(b, "null"...), nil
+		}
+		return appendJSON(b, v.Elem())
+
+	case reflect.Slice:
+		if t == byteSliceType {
+			return appendJSONString(b, hex.EncodeToString(v.Bytes())), nil
+		}
+		b = append(b, '[')
+		for i := 0; i < v.Len(); i++ {
+			if i != 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = appendJSON(b, v.Index(i)); err != nil {
+				return b, err
+			}
+		}
+		return append(b, ']'), nil
+
+	case reflect.Struct:
+		b = append(b, '{')
+		n := 0
+		for i := 0; i < t.NumField(); i++ {
+			f, fv := t.Field(i), v.Field(i)
+			if fv.IsZero() && !isRequiredField(f) {
+				continue
+			}
+			if n != 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, f.Name)
+			b = append(b, ':')
+			var err error
+			if b, err = appendJSON(b, fv); err != nil {
+				return b, err
+			}
+			n++
+		}
+		return append(b, '}'), nil
+
+	default:
+		return b, fmt.Errorf("cannot marshal value of type %s to JSON", t)
+	}
+}
+
+// appendJSONString appends s to b as a JSON string; control characters are
+// escaped and invalid UTF-8 sequences are replaced by the replacement rune.
+func appendJSONString(b []byte, s string) []byte {
+	j, _ := json.Marshal(s)
+	return append(b, j...)
+}
+
+func isRequiredField(f reflect.StructField) bool {
+	return strings.HasSuffix(f.Tag.Get("thrift"), ",required")
+}
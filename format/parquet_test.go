@@ -1,6 +1,7 @@
 package format_test
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -36,3 +37,100 @@ func TestMarshalUnmarshalSchemaMetadata(t *testing.T) {
 		t.Logf("found:\n%#v", decoded)
 	}
 }
+
+func TestMarshalJSONFileMetaData(t *testing.T) {
+	typ := format.Int64
+	repetition := format.Optional
+	metadata := &format.FileMetaData{
+		Version: 1,
+		Schema: []format.SchemaElement{
+			{Name: "root", NumChildren: 1},
+			{Name: "id", Type: &typ, RepetitionType: &repetition, FieldID: 1},
+		},
+		NumRows: 10,
+		RowGroups: []format.RowGroup{{
+			Columns: []format.ColumnChunk{{
+				MetaData: format.ColumnMetaData{
+					Type:         format.Int64,
+					Encoding:     []format.Encoding{format.Plain, format.Encoding(42)},
+					PathInSchema: []string{"id"},
+					Codec:        format.Snappy,
+					NumValues:    10,
+					Statistics: format.Statistics{
+						MinValue: []byte{0x01, 0x00},
+						MaxValue: []byte{0xff, 0x10},
+					},
+				},
+			}},
+			NumRows: 10,
+		}},
+		CreatedBy: "test",
+	}
+
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `{"Version":1,` +
+		`"Schema":[{"Name":"root","NumChildren":1},{"Type":"INT64","RepetitionType":"OPTIONAL","Name":"id","FieldID":1}],` +
+		`"NumRows":10,` +
+		`"RowGroups":[{"Columns":[{"FileOffset":0,"MetaData":{"Type":"INT64","Encoding":["PLAIN",42],"PathInSchema":["id"],"Codec":"SNAPPY","NumValues":10,"TotalUncompressedSize":0,"TotalCompressedSize":0,"DataPageOffset":0,"Statistics":{"MaxValue":"ff10","MinValue":"0100"}}}],"TotalByteSize":0,"NumRows":10}],` +
+		`"CreatedBy":"test"}`
+
+	if string(b) != want {
+		t.Errorf("JSON mismatch:\nwant: %s\ngot:  %s", want, b)
+	}
+}
+
+func TestMarshalJSONEscapeStrings(t *testing.T) {
+	metadata := &format.FileMetaData{
+		Version:   1,
+		Schema:    []format.SchemaElement{{Name: "root\x00\a"}},
+		CreatedBy: "writer \"quoted\"\n\xff",
+		KeyValueMetadata: []format.KeyValue{
+			{Key: "\x1b[0m", Value: "tab\tseparated"},
+		},
+	}
+
+	b, err := metadata.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Schema           []struct{ Name string }
+		CreatedBy        string
+		KeyValueMetadata []struct{ Key, Value string }
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, b)
+	}
+	if decoded.Schema[0].Name != "root\x00\a" {
+		t.Errorf("wrong schema name: %q", decoded.Schema[0].Name)
+	}
+	if decoded.CreatedBy != "writer \"quoted\"\n\ufffd" {
+		t.Errorf("wrong created by: %q", decoded.CreatedBy)
+	}
+	if kv := decoded.KeyValueMetadata[0]; kv.Key != "\x1b[0m" || kv.Value != "tab\tseparated" {
+		t.Errorf("wrong key/value metadata: %q=%q", kv.Key, kv.Value)
+	}
+}
+
+func TestMarshalJSONColumnIndex(t *testing.T) {
+	b, err := json.Marshal(&format.ColumnIndex{
+		NullPages:     []bool{false, true},
+		MinValues:     [][]byte{{0x61}, {}},
+		MaxValues:     [][]byte{{0x7a}, {}},
+		BoundaryOrder: format.Ascending,
+		NullCounts:    []int64{0, 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `{"NullPages":[false,true],"MinValues":["61",""],"MaxValues":["7a",""],"BoundaryOrder":"ASCENDING","NullCounts":[0,3]}`
+	if string(b) != want {
+		t.Errorf("JSON mismatch:\nwant: %s\ngot:  %s", want, b)
+	}
+}
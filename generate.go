@@ -0,0 +1,315 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+const (
+	// Probability of generating null values for optional fields, and maximum
+	// number of elements generated for repeated fields, lists and maps.
+	generateNullProbability = 0.25
+	generateMaxElements     = 3
+)
+
+// GenerateRows returns n random rows of the given schema, using prng as source
+// of randomness. The same rows are generated when prng is seeded with the same
+// value, which allows programs to build reproducible benchmarks and fuzz tests
+// for their own schemas.
+//
+// The rows are valid rows of the schema: optional fields are null a quarter of
+// the time, repeated fields, lists and maps hold between zero and three
+// elements, and the keys of maps are unique. Leaf values respect the logical
+// types of columns; for example strings are valid UTF-8, JSON values are valid
+// documents, integers are within the range of their bit width, decimals within
+// their precision, and dates, times and timestamps are between the unix epoch
+// and the end of the 21st century.
+func GenerateRows(schema *Schema, n int, prng *rand.Rand) []Row {
+	_, generate := generateFuncOf(0, schema)
+	numColumns := len(schema.Columns())
+	rows := make([]Row, n)
+
+	for i := range rows {
+		columns := make([][]Value, numColumns)
+		generate(columns, levels{}, prng)
+		rows[i] = appendRow(nil, columns)
+	}
+	return rows
+}
+
+// generateFunc appends random values at the given levels to the columns of a
+// node.
+type generateFunc func(columns [][]Value, levels levels, prng *rand.Rand)
+
+func generateFuncOf(columnIndex int16, node Node) (int16, generateFunc) {
+	switch {
+	case node.Optional():
+		return generateFuncOfOptional(columnIndex, node)
+	case node.Repeated():
+		return generateFuncOfRepeated(columnIndex, node)
+	case mapKeyValueGroupOf(node) != nil:
+		return generateFuncOfMap(columnIndex, node)
+	case node.Leaf():
+		return generateFuncOfLeaf(columnIndex, node)
+	default:
+		return generateFuncOfGroup(columnIndex, node)
+	}
+}
+
+func generateFuncOfOptional(columnIndex int16, node Node) (int16, generateFunc) {
+	nextColumnIndex, generate := generateFuncOf(columnIndex, Required(node))
+	return nextColumnIndex, func(columns [][]Value, levels levels, prng *rand.Rand) {
+		if prng.Float64() < generateNullProbability {
+			generateNulls(columns, levels, columnIndex, nextColumnIndex)
+			return
+		}
+		levels.definitionLevel++
+		generate(columns, levels, prng)
+	}
+}
+
+func generateFuncOfRepeated(columnIndex int16, node Node) (int16, generateFunc) {
+	nextColumnIndex, generate := generateFuncOf(columnIndex, Required(node))
+	return nextColumnIndex, func(columns [][]Value, levels levels, prng *rand.Rand) {
+		n := prng.Intn(generateMaxElements + 1)
+		if n == 0 {
+			generateNulls(columns, levels, columnIndex, nextColumnIndex)
+			return
+		}
+		levels.repetitionDepth++
+		levels.definitionLevel++
+
+		for i := 0; i < n; i++ {
+			generate(columns, levels, prng)
+			levels.repetitionLevel = levels.repetitionDepth
+		}
+	}
+}
+
+// generateFuncOfMap generates the entries of MAP groups, skipping the entries
+// with keys that were already generated for the same map.
+func generateFuncOfMap(columnIndex int16, node Node) (int16, generateFunc) {
+	keyValue := mapKeyValueGroupOf(node)
+	fields := keyValue.Fields()
+	if len(fields) != 2 || fields[0].Name() != "key" || !fields[0].Leaf() {
+		return generateFuncOfGroup(columnIndex, node)
+	}
+	valueColumnIndex, generateKey := generateFuncOf(columnIndex, fields[0])
+	nextColumnIndex, generateValue := generateFuncOf(valueColumnIndex, fields[1])
+	var key []byte
+
+	return nextColumnIndex, func(columns [][]Value, levels levels, prng *rand.Rand) {
+		n := prng.Intn(generateMaxElements + 1)
+		keys := make(map[string]struct{}, n)
+		entryLevels := levels
+		entryLevels.repetitionDepth++
+		entryLevels.definitionLevel++
+
+		for i := 0; i < n; i++ {
+			generateKey(columns, entryLevels, prng)
+			keyColumn := columns[columnIndex]
+			key = keyColumn[len(keyColumn)-1].AppendBytes(key[:0])
+			if _, exists := keys[string(key)]; exists {
+				columns[columnIndex] = keyColumn[:len(keyColumn)-1]
+				continue
+			}
+			keys[string(key)] = struct{}{}
+			generateValue(columns, entryLevels, prng)
+			entryLevels.repetitionLevel = entryLevels.repetitionDepth
+		}
+
+		if len(keys) == 0 {
+			generateNulls(columns, levels, columnIndex, nextColumnIndex)
+		}
+	}
+}
+
+func generateFuncOfGroup(columnIndex int16, node Node) (int16, generateFunc) {
+	fields := node.Fields()
+	funcs := make([]generateFunc, len(fields))
+	for i, field := range fields {
+		columnIndex, funcs[i] = generateFuncOf(columnIndex, field)
+	}
+	return columnIndex, func(columns [][]Value, levels levels, prng *rand.Rand) {
+		for _, generate := range funcs {
+			generate(columns, levels, prng)
+		}
+	}
+}
+
+func generateFuncOfLeaf(columnIndex int16, node Node) (int16, generateFunc) {
+	if columnIndex > MaxColumnIndex {
+		panic(fmt.Sprintf("rows cannot be generated because the schema has more than %d columns", MaxColumnIndex))
+	}
+	generate := generateValueFuncOf(node.Type())
+	return columnIndex + 1, func(columns [][]Value, levels levels, prng *rand.Rand) {
+		v := generate(prng)
+		v.repetitionLevel = levels.repetitionLevel
+		v.definitionLevel = levels.definitionLevel
+		v.columnIndex = ^columnIndex
+		columns[columnIndex] = append(columns[columnIndex], v)
+	}
+}
+
+func generateNulls(columns [][]Value, levels levels, firstColumnIndex, lastColumnIndex int16) {
+	for columnIndex := firstColumnIndex; columnIndex < lastColumnIndex; columnIndex++ {
+		v := Value{}
+		v.repetitionLevel = levels.repetitionLevel
+		v.definitionLevel = levels.definitionLevel
+		v.columnIndex = ^columnIndex
+		columns[columnIndex] = append(columns[columnIndex], v)
+	}
+}
+
+// generateValueFuncOf returns a function generating random values of type t.
+func generateValueFuncOf(t Type) func(*rand.Rand) Value {
+	kind := t.Kind()
+	lt := t.LogicalType()
+
+	switch {
+	case lt == nil:
+	case lt.UTF8 != nil, lt.Enum != nil:
+		return func(prng *rand.Rand) Value {
+			return makeValueBytes(kind, generateWord(nil, prng))
+		}
+	case lt.Json != nil:
+		return func(prng *rand.Rand) Value {
+			b := fmt.Appendf(nil, `{"id":%d,"name":"`, prng.Int31())
+			b = append(generateWord(b, prng), `"}`...)
+			return makeValueBytes(kind, b)
+		}
+	case lt.UUID != nil:
+		return func(prng *rand.Rand) Value {
+			b := make([]byte, 16)
+			prng.Read(b)
+			b[6] = (b[6] & 0x0f) | 0x40 // version 4
+			b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+			return makeValueBytes(kind, b)
+		}
+	case lt.Decimal != nil:
+		return generateDecimalFuncOf(t, int(lt.Decimal.Precision))
+	case lt.Date != nil:
+		return func(prng *rand.Rand) Value {
+			return makeValueInt32(prng.Int31n(generateMaxDays))
+		}
+	case lt.Time != nil:
+		day := int64(24 * time.Hour / timeUnitDuration(lt.Time.Unit))
+		if kind == Int32 {
+			return func(prng *rand.Rand) Value { return makeValueInt32(int32(prng.Int63n(day))) }
+		}
+		return func(prng *rand.Rand) Value { return makeValueInt64(prng.Int63n(day)) }
+	case lt.Timestamp != nil:
+		bound := generateMaxDays * int64(24*time.Hour/timeUnitDuration(lt.Timestamp.Unit))
+		return func(prng *rand.Rand) Value { return makeValueInt64(prng.Int63n(bound)) }
+	case lt.Integer != nil:
+		return generateIntegerFuncOf(kind, lt.Integer)
+	}
+
+	switch kind {
+	case Boolean:
+		return func(prng *rand.Rand) Value { return makeValueBoolean(prng.Intn(2) == 0) }
+	case Int32:
+		return func(prng *rand.Rand) Value { return makeValueInt32(int32(prng.Uint32())) }
+	case Int64:
+		return func(prng *rand.Rand) Value { return makeValueInt64(int64(prng.Uint64())) }
+	case Int96:
+		return func(prng *rand.Rand) Value {
+			return makeValueInt96(deprecated.Int96{prng.Uint32(), prng.Uint32(), prng.Uint32()})
+		}
+	case Float:
+		return func(prng *rand.Rand) Value { return makeValueFloat(float32(prng.NormFloat64())) }
+	case Double:
+		return func(prng *rand.Rand) Value { return makeValueDouble(prng.NormFloat64()) }
+	case ByteArray:
+		return func(prng *rand.Rand) Value {
+			b := make([]byte, prng.Intn(16))
+			prng.Read(b)
+			return makeValueBytes(kind, b)
+		}
+	default:
+		size := t.Length()
+		return func(prng *rand.Rand) Value {
+			b := make([]byte, size)
+			prng.Read(b)
+			return makeValueBytes(kind, b)
+		}
+	}
+}
+
+// Number of days between the unix epoch and the year 2100.
+const generateMaxDays = 47482
+
+func generateWord(b []byte, prng *rand.Rand) []byte {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	for i, n := 0, 1+prng.Intn(12); i < n; i++ {
+		b = append(b, letters[prng.Intn(len(letters))])
+	}
+	return b
+}
+
+func generateIntegerFuncOf(kind Kind, integer *format.IntType) func(*rand.Rand) Value {
+	bitWidth := uint(integer.BitWidth)
+	if bitWidth == 0 || bitWidth > 64 {
+		bitWidth = 64
+	}
+	mask := uint64(math.MaxUint64) >> (64 - bitWidth)
+	return func(prng *rand.Rand) Value {
+		u := prng.Uint64() & mask
+		if integer.IsSigned && bitWidth < 64 && u>>(bitWidth-1) != 0 {
+			u |= ^mask // sign extension
+		}
+		if kind == Int32 {
+			return makeValueInt32(int32(u))
+		}
+		return makeValueInt64(int64(u))
+	}
+}
+
+// generateDecimalFuncOf returns a function generating decimal values with at
+// most the given number of digits, and at most 18 digits so the unscaled
+// values fit in 64 bits.
+func generateDecimalFuncOf(t Type, precision int) func(*rand.Rand) Value {
+	kind := t.Kind()
+	bound := int64(1)
+	for i := 0; i < min(precision, 18); i++ {
+		bound *= 10
+	}
+	size := 8
+	if kind == FixedLenByteArray {
+		size = t.Length()
+	}
+	return func(prng *rand.Rand) Value {
+		unscaled := prng.Int63n(bound)
+		if prng.Intn(2) == 0 {
+			unscaled = -unscaled
+		}
+		switch kind {
+		case Int32:
+			return makeValueInt32(int32(unscaled))
+		case Int64:
+			return makeValueInt64(unscaled)
+		}
+		// Big-endian two's complement representation of the unscaled value,
+		// sign extended to the size of the value.
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(unscaled))
+		value := make([]byte, size)
+		if unscaled < 0 {
+			for i := range value {
+				value[i] = 0xff
+			}
+		}
+		if size < len(b) {
+			copy(value, b[len(b)-size:])
+		} else {
+			copy(value[size-len(b):], b[:])
+		}
+		return makeValueBytes(kind, value)
+	}
+}
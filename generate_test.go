@@ -0,0 +1,103 @@
+package parquet_test
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestGenerateRows(t *testing.T) {
+	type Item struct {
+		SKU      string   `parquet:"sku"`
+		Quantity int8     `parquet:"quantity"`
+		Labels   []string `parquet:"labels,list"`
+	}
+	type Order struct {
+		ID       uuid.UUID               `parquet:"id"`
+		Customer *string                 `parquet:"customer,optional"`
+		Total    int64                   `parquet:"total,decimal(2:12)"`
+		Discount [9]byte                 `parquet:"discount,decimal(4:20)"`
+		Day      int32                   `parquet:"day,date"`
+		Created  time.Time               `parquet:"created,timestamp(microsecond)"`
+		Shipped  *time.Time              `parquet:"shipped,optional"`
+		Flags    uint16                  `parquet:"flags"`
+		Score    float32                 `parquet:"score"`
+		Meta     string                  `parquet:"meta,json"`
+		Payload  []byte                  `parquet:"payload"`
+		Items    []Item                  `parquet:"items,list"`
+		Prices   map[string]*float64     `parquet:"prices" parquet-value:",optional"`
+		Counts   map[bool]int32          `parquet:"counts"`
+		Nested   map[string]map[int]bool `parquet:"nested,optional"`
+	}
+
+	schema := parquet.SchemaOf(Order{})
+	rows := parquet.GenerateRows(schema, 100, rand.New(rand.NewSource(1)))
+	if len(rows) != 100 {
+		t.Fatalf("wrong number of rows: %d", len(rows))
+	}
+
+	again := parquet.GenerateRows(schema, 100, rand.New(rand.NewSource(1)))
+	for i := range rows {
+		if !rows[i].Equal(again[i]) {
+			t.Fatalf("rows generated with the same seed differ at index %d", i)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, schema)
+	if _, err := writer.WriteRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	orders, err := parquet.Read[Order](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != len(rows) {
+		t.Fatalf("wrong number of rows read: %d", len(orders))
+	}
+
+	var nulls, elements int
+	for i, order := range orders {
+		var want Order
+		if err := schema.Reconstruct(&want, rows[i]); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("row %d does not round trip:\nwant = %+v\ngot  = %+v", i, want, order)
+		}
+		if order.Customer == nil {
+			nulls++
+		}
+		elements += len(order.Items)
+		if order.ID.Version() != 4 {
+			t.Errorf("row %d has a UUID of version %d", i, order.ID.Version())
+		}
+		if year := order.Created.Year(); year < 1970 || year >= 2100 {
+			t.Errorf("row %d has a timestamp out of range: %s", i, order.Created)
+		}
+	}
+	if nulls == 0 || nulls == len(orders) {
+		t.Errorf("wrong number of null values: %d", nulls)
+	}
+	if elements == 0 {
+		t.Error("no list elements were generated")
+	}
+
+	quantity, _ := schema.Lookup("items", "list", "element", "quantity")
+	for _, row := range rows {
+		for _, v := range row {
+			if v.Column() == quantity.ColumnIndex && !v.IsNull() && (v.Int32() < -128 || v.Int32() > 127) {
+				t.Errorf("value out of range of the INT(8) column: %d", v.Int32())
+			}
+		}
+	}
+}
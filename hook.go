@@ -0,0 +1,116 @@
+package parquet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// WriteHooksKey is the key of the footer key/value metadata holding the JSON
+// object which maps the dot-separated paths of columns transformed by write
+// hooks to the annotations set with AnnotateWriteHook.
+const WriteHooksKey = "parquet-go.write.hooks"
+
+// ReadHook creates a configuration option which sets a function applied by
+// readers to the values of the column at the given dot-separated path before
+// they are reconstructed into Go values.
+//
+// Hooks allow programs to transform or validate the values of specific columns
+// (e.g. decrypting values, converting units) without making an extra pass over
+// the rows after they were read. The function is called for each non-null
+// value of the column; the repetition and definition levels of the values it
+// returns are preserved. When the function returns an error, the read fails
+// with a *RowError wrapping it.
+//
+// Multiple hooks may be set on the same column, they are applied in the order
+// the options were given.
+func ReadHook(path string, fn func(Value) (Value, error)) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.ReadHooks = appendHook(config.ReadHooks, path, fn) })
+}
+
+// WriteHook creates a configuration option which sets a function applied by
+// writers to the values of the column at the given dot-separated path before
+// they are encoded.
+//
+// Hooks allow programs to transform the values of specific columns (e.g.
+// tokenizing, hashing, or encrypting them) without making a copy of the rows
+// they write. The function is called for each non-null value of the column; the
+// repetition and definition levels of the values it returns are preserved, and
+// their type must match the type of the column. When the function returns an
+// error, the write fails with a *RowError wrapping it. The values of the rows
+// passed to the writer are not modified.
+//
+// Multiple hooks may be set on the same column, they are applied in the order
+// the options were given. See ReadHook for the symmetric reader option.
+func WriteHook(path string, fn func(Value) (Value, error)) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.WriteHooks = appendHook(config.WriteHooks, path, fn) })
+}
+
+// AnnotateWriteHook creates a configuration option which records an annotation
+// describing the transformation applied by the write hook of the column at the
+// given path (e.g. "sha256" or "aes-gcm:key-2024"). The annotations are written
+// under WriteHooksKey in the footer of files, and can be obtained with
+// WriteHookAnnotationsOf to configure the read hooks reversing them.
+func AnnotateWriteHook(path, annotation string) WriterOption {
+	return writerOption(func(config *WriterConfig) {
+		annotations := map[string]string{}
+		if value, ok := config.KeyValueMetadata[WriteHooksKey]; ok {
+			if err := json.Unmarshal([]byte(value), &annotations); err != nil {
+				panic(fmt.Errorf("decoding parquet write hook annotations: %w", err))
+			}
+		}
+		annotations[path] = annotation
+		b, err := json.Marshal(annotations)
+		if err != nil {
+			panic(fmt.Errorf("encoding parquet write hook annotations: %w", err))
+		}
+		KeyValueMetadata(WriteHooksKey, string(b)).ConfigureWriter(config)
+	})
+}
+
+// WriteHookAnnotationsOf returns the write hook annotations recorded in the
+// metadata of f, indexed by dot-separated column paths.
+//
+// The function returns nil annotations and no error if f has no annotations.
+func WriteHookAnnotationsOf(f *File) (map[string]string, error) {
+	value, ok := f.Lookup(WriteHooksKey)
+	if !ok {
+		return nil, nil
+	}
+	var annotations map[string]string
+	if err := json.Unmarshal([]byte(value), &annotations); err != nil {
+		return nil, fmt.Errorf("decoding parquet write hook annotations: %w", err)
+	}
+	return annotations, nil
+}
+
+// appendHook returns a copy of hooks where fn is chained after the hook that
+// may already exist for path.
+func appendHook(hooks map[string]func(Value) (Value, error), path string, fn func(Value) (Value, error)) map[string]func(Value) (Value, error) {
+	newHooks := make(map[string]func(Value) (Value, error), len(hooks)+1)
+	for k, v := range hooks {
+		newHooks[k] = v
+	}
+	if prev := hooks[path]; prev != nil {
+		newHooks[path] = func(v Value) (Value, error) {
+			v, err := prev(v)
+			if err != nil {
+				return v, err
+			}
+			return fn(v)
+		}
+	} else {
+		newHooks[path] = fn
+	}
+	return newHooks
+}
+
+// columnHooksOf returns the hooks of the leaf columns of schema, indexed by
+// column index, with nil entries for columns which have no hooks.
+func columnHooksOf(schema *Schema, hooks map[string]func(Value) (Value, error)) []func(Value) (Value, error) {
+	var columns []func(Value) (Value, error)
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		columns = append(columns, hooks[strings.Join(leaf.path, ".")])
+	})
+	return columns
+}
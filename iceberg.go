@@ -0,0 +1,164 @@
+package parquet
+
+// NameMapping represents the mapping of column names to field IDs used by
+// Apache Iceberg to read parquet files which do not embed field IDs in their
+// schema.
+//
+// The JSON representation of a NameMapping value matches the format expected
+// in the "schema.name-mapping.default" property of Iceberg tables.
+//
+// See: https://iceberg.apache.org/spec/#name-mapping-serialization
+type NameMapping []MappedField
+
+// MappedField is the mapping of a field of a parquet schema to its field ID.
+type MappedField struct {
+	FieldID int         `json:"field-id"`
+	Names   []string    `json:"names"`
+	Fields  NameMapping `json:"fields,omitempty"`
+}
+
+// NameMappingOf returns the name mapping of the given node.
+//
+// If the node has field IDs (for example because it was created by calling
+// SchemaOf with the SequentialFieldIDs option), the IDs of the node are used
+// in the mapping. Otherwise, the mapping is built with the IDs that would have
+// been assigned by SequentialFieldIDs.
+func NameMappingOf(node Node) NameMapping {
+	if !hasFieldIDs(node) {
+		node = withSequentialFieldIDs(node)
+	}
+	return nameMappingOf(node.Fields())
+}
+
+// Lookup returns the field ID associated with the given path in the mapping.
+func (m NameMapping) Lookup(path ...string) (int, bool) {
+	if len(path) == 0 {
+		return 0, false
+	}
+	for i := range m {
+		f := &m[i]
+		for _, name := range f.Names {
+			if name == path[0] {
+				if len(path) == 1 {
+					return f.FieldID, true
+				}
+				return f.Fields.Lookup(path[1:]...)
+			}
+		}
+	}
+	return 0, false
+}
+
+func nameMappingOf(fields []Field) NameMapping {
+	if len(fields) == 0 {
+		return nil
+	}
+	mapping := make(NameMapping, len(fields))
+	for i, field := range fields {
+		mapping[i] = MappedField{
+			FieldID: field.ID(),
+			Names:   []string{field.Name()},
+			Fields:  nameMappingOf(icebergChildrenOf(field)),
+		}
+	}
+	return mapping
+}
+
+// icebergChildrenOf returns the fields of node which are assigned IDs in the
+// Iceberg data model. The repeated groups of LIST and MAP types do not exist
+// in Iceberg, the element of lists, or the key and value of maps are viewed as
+// direct children of the field.
+func icebergChildrenOf(node Node) []Field {
+	if node.Leaf() {
+		return nil
+	}
+	fields := node.Fields()
+	if (isList(node) || isMap(node)) && len(fields) == 1 && !fields[0].Leaf() {
+		return fields[0].Fields()
+	}
+	return fields
+}
+
+func hasFieldIDs(node Node) bool {
+	if node.Leaf() {
+		return false
+	}
+	for _, field := range node.Fields() {
+		if field.ID() != 0 || hasFieldIDs(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// withSequentialFieldIDs returns a copy of node where each field is assigned a
+// field ID, in the order that Iceberg assigns IDs to the columns of a schema:
+// all fields of a group are given consecutive IDs before the IDs of their
+// children are assigned.
+func withSequentialFieldIDs(node Node) Node {
+	nextID := 1
+	return &fieldIDGroup{
+		Node:   node,
+		fields: assignFieldIDs(node, &nextID),
+	}
+}
+
+func assignFieldIDs(node Node, nextID *int) []Field {
+	fields := node.Fields()
+	group := make([]fieldIDField, len(fields))
+
+	for i, field := range fields {
+		group[i] = fieldIDField{Field: field, id: *nextID}
+		*nextID++
+	}
+
+	for i, field := range fields {
+		if field.Leaf() {
+			continue
+		}
+		f := &group[i]
+		children := field.Fields()
+		if (isList(field) || isMap(field)) && len(children) == 1 && !children[0].Leaf() {
+			// The repeated group of LIST and MAP types is not assigned an ID,
+			// its fields are numbered as if they were children of the field.
+			f.fields = []Field{&fieldIDField{
+				Field:  children[0],
+				id:     children[0].ID(),
+				fields: assignFieldIDs(children[0], nextID),
+			}}
+		} else {
+			f.fields = assignFieldIDs(field, nextID)
+		}
+	}
+
+	result := make([]Field, len(group))
+	for i := range group {
+		result[i] = &group[i]
+	}
+	return result
+}
+
+type fieldIDGroup struct {
+	Node
+	fields []Field
+}
+
+func (g *fieldIDGroup) String() string  { return sprint("", g) }
+func (g *fieldIDGroup) Fields() []Field { return g.fields }
+
+type fieldIDField struct {
+	Field
+	id     int
+	fields []Field
+}
+
+func (f *fieldIDField) ID() int { return f.id }
+
+func (f *fieldIDField) String() string { return sprint(f.Name(), f) }
+
+func (f *fieldIDField) Fields() []Field {
+	if f.Leaf() {
+		return f.Field.Fields()
+	}
+	return f.fields
+}
@@ -0,0 +1,141 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type icebergAddress struct {
+	Street string `parquet:"street"`
+	City   string `parquet:"city"`
+}
+
+type icebergRecord struct {
+	ID      int64             `parquet:"id,id(42)"`
+	Name    string            `parquet:"name"`
+	Address icebergAddress    `parquet:"address"`
+	Tags    []string          `parquet:"tags,list"`
+	Attrs   map[string]string `parquet:"attrs"`
+}
+
+func TestSequentialFieldIDs(t *testing.T) {
+	schema := parquet.SchemaOf(new(icebergRecord), parquet.SequentialFieldIDs(true))
+
+	const want = `message icebergRecord {
+	required int64 id (INT(64,true)) = 1;
+	required binary name (STRING) = 2;
+	required group address = 3 {
+		required binary street (STRING) = 6;
+		required binary city (STRING) = 7;
+	}
+	required group tags (LIST) = 4 {
+		repeated group list {
+			required binary element (STRING) = 8;
+		}
+	}
+	required group attrs (MAP) = 5 {
+		repeated group key_value {
+			required binary key (STRING) = 9;
+			required binary value (STRING) = 10;
+		}
+	}
+}`
+
+	if got := schema.String(); got != want {
+		t.Errorf("schema mismatch:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	record := icebergRecord{
+		ID:      1,
+		Name:    "Luke",
+		Address: icebergAddress{Street: "Main", City: "Paris"},
+		Tags:    []string{"a", "b"},
+		Attrs:   map[string]string{"k": "v"},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[icebergRecord](buffer, schema)
+	if _, err := writer.Write([]icebergRecord{record}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := file.Schema().Fields()
+	if id := fields[2].ID(); id != 3 {
+		t.Errorf("address: want field id 3, got %d", id)
+	}
+	if id := fields[3].Fields()[0].Fields()[0].ID(); id != 8 {
+		t.Errorf("tags.list.element: want field id 8, got %d", id)
+	}
+
+	records := make([]icebergRecord, 1)
+	if _, err := parquet.NewGenericReader[icebergRecord](file).Read(records); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(records[0], record) {
+		t.Errorf("record mismatch:\nwant: %+v\ngot:  %+v", record, records[0])
+	}
+}
+
+func TestNameMappingOf(t *testing.T) {
+	mapping := parquet.NameMappingOf(parquet.SchemaOf(new(struct {
+		Name    string         `parquet:"name"`
+		Address icebergAddress `parquet:"address"`
+		Tags    []string       `parquet:"tags,list"`
+	})))
+
+	b, err := json.Marshal(mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `[` +
+		`{"field-id":1,"names":["name"]},` +
+		`{"field-id":2,"names":["address"],"fields":[` +
+		`{"field-id":4,"names":["street"]},` +
+		`{"field-id":5,"names":["city"]}]},` +
+		`{"field-id":3,"names":["tags"],"fields":[` +
+		`{"field-id":6,"names":["element"]}]}]`
+
+	if string(b) != want {
+		t.Errorf("name mapping mismatch:\nwant: %s\ngot:  %s", want, b)
+	}
+
+	for _, test := range []struct {
+		path []string
+		id   int
+		ok   bool
+	}{
+		{path: []string{"name"}, id: 1, ok: true},
+		{path: []string{"address", "city"}, id: 5, ok: true},
+		{path: []string{"tags", "element"}, id: 6, ok: true},
+		{path: []string{"address", "zip"}},
+		{path: nil},
+	} {
+		id, ok := mapping.Lookup(test.path...)
+		if id != test.id || ok != test.ok {
+			t.Errorf("%q: want (%d,%t), got (%d,%t)", test.path, test.id, test.ok, id, ok)
+		}
+	}
+}
+
+func TestNameMappingOfFieldIDs(t *testing.T) {
+	mapping := parquet.NameMappingOf(parquet.SchemaOf(new(struct {
+		A int64 `parquet:"a,id(10)"`
+		B int64 `parquet:"b,id(20)"`
+	})))
+
+	if id, _ := mapping.Lookup("b"); id != 20 {
+		t.Errorf("want field id 20, got %d", id)
+	}
+}
@@ -3,6 +3,7 @@
 package bitpack
 
 import (
+	"github.com/parquet-go/parquet-go/internal/byteorder"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
 )
 
@@ -14,10 +15,10 @@ func unpackInt32(dst []int32, src []byte, bitWidth uint) {
 	for n := range dst {
 		i := bitOffset / 32
 		j := bitOffset % 32
-		d := (bits[i] & (bitMask << j)) >> j
+		d := (byteorder.LittleEndian32(bits[i]) & (bitMask << j)) >> j
 		if j+bitWidth > 32 {
 			k := 32 - j
-			d |= (bits[i+1] & (bitMask >> k)) << k
+			d |= (byteorder.LittleEndian32(bits[i+1]) & (bitMask >> k)) << k
 		}
 		dst[n] = int32(d)
 		bitOffset += bitWidth
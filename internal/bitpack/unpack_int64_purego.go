@@ -2,7 +2,10 @@
 
 package bitpack
 
-import "github.com/parquet-go/parquet-go/internal/unsafecast"
+import (
+	"github.com/parquet-go/parquet-go/internal/byteorder"
+	"github.com/parquet-go/parquet-go/internal/unsafecast"
+)
 
 func unpackInt64(dst []int64, src []byte, bitWidth uint) {
 	bits := unsafecast.BytesToUint32(src)
@@ -12,13 +15,13 @@ func unpackInt64(dst []int64, src []byte, bitWidth uint) {
 	for n := range dst {
 		i := bitOffset / 32
 		j := bitOffset % 32
-		d := (uint64(bits[i]) & (bitMask << j)) >> j
+		d := (uint64(byteorder.LittleEndian32(bits[i])) & (bitMask << j)) >> j
 		if j+bitWidth > 32 {
 			k := 32 - j
-			d |= (uint64(bits[i+1]) & (bitMask >> k)) << k
+			d |= (uint64(byteorder.LittleEndian32(bits[i+1])) & (bitMask >> k)) << k
 			if j+bitWidth > 64 {
 				k := 64 - j
-				d |= (uint64(bits[i+2]) & (bitMask >> k)) << k
+				d |= (uint64(byteorder.LittleEndian32(bits[i+2])) & (bitMask >> k)) << k
 			}
 		}
 		dst[n] = int64(d)
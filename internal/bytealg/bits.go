@@ -0,0 +1,32 @@
+package bytealg
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// CountBits returns the number of bits set among the first n bits of data,
+// where the bits of each byte are ordered from the least significant to the
+// most significant, like in bit-packed boolean values.
+//
+// The function panics if data is shorter than n bits.
+func CountBits(data []byte, n int) int {
+	data = data[:(n+7)/8]
+	count := 0
+
+	if r := n % 8; r != 0 {
+		last := len(data) - 1
+		count += bits.OnesCount8(data[last] & (1<<r - 1))
+		data = data[:last]
+	}
+
+	for len(data) >= 8 {
+		count += bits.OnesCount64(binary.LittleEndian.Uint64(data))
+		data = data[8:]
+	}
+
+	for _, b := range data {
+		count += bits.OnesCount8(b)
+	}
+	return count
+}
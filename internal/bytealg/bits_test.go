@@ -0,0 +1,27 @@
+package bytealg_test
+
+import (
+	"testing"
+
+	"github.com/parquet-go/parquet-go/internal/bytealg"
+	"github.com/parquet-go/parquet-go/internal/quick"
+)
+
+func TestCountBits(t *testing.T) {
+	err := quick.Check(func(data []byte) bool {
+		for n := 0; n <= 8*len(data); n += 1 + n/3 {
+			want := 0
+			for i := 0; i < n; i++ {
+				want += int(data[i/8]>>(i%8)) & 1
+			}
+			if got := bytealg.CountBits(data, n); got != want {
+				t.Errorf("n=%d: got=%d want=%d", n, got, want)
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,95 @@
+// Package byteorder implements conversions between the native byte order of
+// the platform and the little-endian byte order of values in parquet files.
+//
+// The encodings of this module often reinterpret the memory of slices of
+// numbers as raw bytes to avoid copies, which is only correct on little-endian
+// platforms. The functions of this package keep the fast paths on those
+// platforms, and convert values one at a time on big-endian platforms like
+// s390x or ppc64.
+package byteorder
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"slices"
+)
+
+// AppendUint32 appends the little-endian representation of the values of src
+// to dst, returning the extended slice.
+func AppendUint32(dst []byte, src []uint32) []byte { return appendUint32(dst, src) }
+
+// AppendUint64 appends the little-endian representation of the values of src
+// to dst, returning the extended slice.
+func AppendUint64(dst []byte, src []uint64) []byte { return appendUint64(dst, src) }
+
+// DecodeUint32 appends the values of the little-endian representation in src
+// to dst, returning the extended slice. Trailing bytes of src which do not form
+// a full value are ignored.
+//
+// The values may be decoded in place: dst may share the backing array of src
+// as long as they start at the same address.
+func DecodeUint32(dst []uint32, src []byte) []uint32 { return decodeUint32(dst, src) }
+
+// DecodeUint64 is like DecodeUint32 but for 64 bits values.
+func DecodeUint64(dst []uint64, src []byte) []uint64 { return decodeUint64(dst, src) }
+
+// LittleEndian32 converts x between its native and little-endian in-memory
+// representations; the conversion is its own inverse.
+func LittleEndian32(x uint32) uint32 {
+	if NativeLittleEndian {
+		return x
+	}
+	return bits.ReverseBytes32(x)
+}
+
+// LittleEndian64 is like LittleEndian32 but for 64 bits values.
+func LittleEndian64(x uint64) uint64 {
+	if NativeLittleEndian {
+		return x
+	}
+	return bits.ReverseBytes64(x)
+}
+
+// The functions below are the portable implementations used on big-endian
+// platforms; they are compiled on all platforms so they can be tested against
+// the fast paths.
+
+func appendUint32Portable(dst []byte, src []uint32) []byte {
+	offset := len(dst)
+	dst = slices.Grow(dst, 4*len(src))[:offset+4*len(src)]
+	for i, v := range src {
+		binary.LittleEndian.PutUint32(dst[offset+4*i:], v)
+	}
+	return dst
+}
+
+func appendUint64Portable(dst []byte, src []uint64) []byte {
+	offset := len(dst)
+	dst = slices.Grow(dst, 8*len(src))[:offset+8*len(src)]
+	for i, v := range src {
+		binary.LittleEndian.PutUint64(dst[offset+8*i:], v)
+	}
+	return dst
+}
+
+func decodeUint32Portable(dst []uint32, src []byte) []uint32 {
+	n := len(src) / 4
+	offset := len(dst)
+	dst = slices.Grow(dst, n)[:offset+n]
+	// Each value is read before being written to dst, which makes it safe to
+	// decode in place.
+	for i := range dst[offset:] {
+		dst[offset+i] = binary.LittleEndian.Uint32(src[4*i:])
+	}
+	return dst
+}
+
+func decodeUint64Portable(dst []uint64, src []byte) []uint64 {
+	n := len(src) / 8
+	offset := len(dst)
+	dst = slices.Grow(dst, n)[:offset+n]
+	for i := range dst[offset:] {
+		dst[offset+i] = binary.LittleEndian.Uint64(src[8*i:])
+	}
+	return dst
+}
@@ -0,0 +1,15 @@
+//go:build armbe || arm64be || m68k || mips || mips64 || mips64p32 || ppc || ppc64 || s390 || s390x || shbe || sparc || sparc64
+
+package byteorder
+
+// NativeLittleEndian is true when the platform stores values in little-endian
+// byte order, in which case the values do not need to be converted.
+const NativeLittleEndian = false
+
+func appendUint32(dst []byte, src []uint32) []byte { return appendUint32Portable(dst, src) }
+
+func appendUint64(dst []byte, src []uint64) []byte { return appendUint64Portable(dst, src) }
+
+func decodeUint32(dst []uint32, src []byte) []uint32 { return decodeUint32Portable(dst, src) }
+
+func decodeUint64(dst []uint64, src []byte) []uint64 { return decodeUint64Portable(dst, src) }
@@ -0,0 +1,25 @@
+//go:build !(armbe || arm64be || m68k || mips || mips64 || mips64p32 || ppc || ppc64 || s390 || s390x || shbe || sparc || sparc64)
+
+package byteorder
+
+import "github.com/parquet-go/parquet-go/internal/unsafecast"
+
+// NativeLittleEndian is true when the platform stores values in little-endian
+// byte order, in which case the values do not need to be converted.
+const NativeLittleEndian = true
+
+func appendUint32(dst []byte, src []uint32) []byte {
+	return append(dst, unsafecast.Slice[byte](src)...)
+}
+
+func appendUint64(dst []byte, src []uint64) []byte {
+	return append(dst, unsafecast.Slice[byte](src)...)
+}
+
+func decodeUint32(dst []uint32, src []byte) []uint32 {
+	return append(dst, unsafecast.Slice[uint32](src[:len(src)&^3])...)
+}
+
+func decodeUint64(dst []uint64, src []byte) []uint64 {
+	return append(dst, unsafecast.Slice[uint64](src[:len(src)&^7])...)
+}
@@ -0,0 +1,99 @@
+package byteorder
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/parquet-go/parquet-go/internal/unsafecast"
+)
+
+func TestAppendUint32(t *testing.T) {
+	src := []uint32{0x01020304, 0xA0B0C0D0, 0}
+	want := []byte{0xFF, 4, 3, 2, 1, 0xD0, 0xC0, 0xB0, 0xA0, 0, 0, 0, 0}
+
+	for _, test := range []struct {
+		name   string
+		append func([]byte, []uint32) []byte
+	}{
+		{"native", AppendUint32},
+		{"portable", appendUint32Portable},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.append([]byte{0xFF}, src); !bytes.Equal(got, want) {
+				t.Errorf("wrong encoding:\nwant = %x\ngot  = %x", want, got)
+			}
+		})
+	}
+}
+
+func TestAppendUint64(t *testing.T) {
+	src := []uint64{0x0102030405060708, 0}
+	want := []byte{0xFF, 8, 7, 6, 5, 4, 3, 2, 1, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	for _, test := range []struct {
+		name   string
+		append func([]byte, []uint64) []byte
+	}{
+		{"native", AppendUint64},
+		{"portable", appendUint64Portable},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.append([]byte{0xFF}, src); !bytes.Equal(got, want) {
+				t.Errorf("wrong encoding:\nwant = %x\ngot  = %x", want, got)
+			}
+		})
+	}
+}
+
+func TestDecodeUint32(t *testing.T) {
+	src := []byte{4, 3, 2, 1, 0xD0, 0xC0, 0xB0, 0xA0, 0xFF}
+	want := []uint32{42, 0x01020304, 0xA0B0C0D0}
+
+	for _, test := range []struct {
+		name   string
+		decode func([]uint32, []byte) []uint32
+	}{
+		{"native", DecodeUint32},
+		{"portable", decodeUint32Portable},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.decode([]uint32{42}, src); !slices.Equal(got, want) {
+				t.Errorf("wrong decoding:\nwant = %x\ngot  = %x", want, got)
+			}
+		})
+	}
+}
+
+func TestDecodeUint32InPlace(t *testing.T) {
+	src := []byte{4, 3, 2, 1, 0xD0, 0xC0, 0xB0, 0xA0}
+	want := []uint32{0x01020304, 0xA0B0C0D0}
+
+	buf := slices.Clone(src)
+	dst := decodeUint32Portable(unsafecast.Slice[uint32](buf)[:0], buf)
+	if !slices.Equal(dst, want) {
+		t.Errorf("wrong decoding:\nwant = %x\ngot  = %x", want, dst)
+	}
+	if &dst[0] != &unsafecast.Slice[uint32](buf)[0] {
+		t.Error("values were not decoded in place")
+	}
+}
+
+func TestDecodeUint64(t *testing.T) {
+	src := []byte{8, 7, 6, 5, 4, 3, 2, 1, 0xFF}
+	want := []uint64{42, 0x0102030405060708}
+
+	for _, test := range []struct {
+		name   string
+		decode func([]uint64, []byte) []uint64
+	}{
+		{"native", DecodeUint64},
+		{"portable", decodeUint64Portable},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.decode([]uint64{42}, src); !slices.Equal(got, want) {
+				t.Errorf("wrong decoding:\nwant = %x\ngot  = %x", want, got)
+			}
+		})
+	}
+}
@@ -81,6 +81,13 @@ func MakeValueFuncOf(t reflect.Type) MakeValueFunc {
 			sec := r.Int63n(2524608000) // 2050-01-01
 			v.Set(reflect.ValueOf(time.Unix(sec, 0).UTC()))
 		}
+	case reflect.TypeOf(time.Duration(0)):
+		return func(v reflect.Value, r *rand.Rand) {
+			// Durations are generated as times of day rounded to the
+			// millisecond so they match in all precisions of TIME columns.
+			msec := r.Int63n(int64(24 * time.Hour / time.Millisecond))
+			v.Set(reflect.ValueOf(time.Duration(msec) * time.Millisecond))
+		}
 	}
 
 	switch t.Kind() {
@@ -0,0 +1,9 @@
+// Package wasmtest contains smoke tests for the WebAssembly builds of the
+// parquet package, which select the low memory build profile.
+//
+// The tests run on all platforms, and can be run under WebAssembly with:
+//
+//	make test-wasm
+//
+// which requires node to be installed to execute the js/wasm test binary.
+package wasmtest
@@ -0,0 +1,145 @@
+package wasmtest_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type Contact struct {
+	Name        string `parquet:"name,dict"`
+	PhoneNumber string `parquet:"phoneNumber,optional,zstd"`
+}
+
+type Row struct {
+	ID       int64     `parquet:"id,delta"`
+	Score    float64   `parquet:"score"`
+	Owner    string    `parquet:"owner,snappy"`
+	Tags     []string  `parquet:"tags,gzip"`
+	Contacts []Contact `parquet:"contacts"`
+}
+
+func makeRows(n int) []Row {
+	rows := make([]Row, n)
+	for i := range rows {
+		rows[i] = Row{
+			ID:    int64(i),
+			Score: float64(i) / 4,
+			Owner: fmt.Sprintf("owner-%03d", i%100),
+			Tags:  []string{"a", "b", "c"}[:i%4],
+			Contacts: []Contact{
+				{Name: "Alice", PhoneNumber: "+15505551234"},
+				{Name: fmt.Sprintf("Bob-%d", i%7)},
+			}[:i%3],
+		}
+	}
+	return rows
+}
+
+func TestLowMemoryProfile(t *testing.T) {
+	if runtime.GOARCH != "wasm" {
+		t.Skip("the low memory profile is not selected by default on", runtime.GOARCH)
+	}
+	if parquet.DefaultPageBufferSize > 32*1024 {
+		t.Errorf("page buffer size too large for WebAssembly: %d", parquet.DefaultPageBufferSize)
+	}
+	if parquet.DefaultBloomFilterMemoryBudget > 1024*1024 {
+		t.Errorf("bloom filter memory budget too large for WebAssembly: %d", parquet.DefaultBloomFilterMemoryBudget)
+	}
+}
+
+func TestWriteAndReadFile(t *testing.T) {
+	rows := makeRows(5000)
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.MaxRowsPerRowGroup(2000),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "owner")),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != 3 {
+		t.Fatalf("wrong number of row groups: want=3 got=%d", n)
+	}
+
+	r := parquet.NewGenericReader[Row](f)
+	defer r.Close()
+
+	read := make([]Row, 0, len(rows))
+	batch := make([]Row, 100)
+	for {
+		n, err := r.Read(batch)
+		read = append(read, batch[:n]...)
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	if len(read) != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), len(read))
+	}
+	for i := range rows {
+		if fmt.Sprint(rows[i]) != fmt.Sprint(read[i]) {
+			t.Fatalf("rows at index %d mismatch:\nwant = %+v\ngot  = %+v", i, rows[i], read[i])
+		}
+	}
+}
+
+func TestBloomFilterAndPageIndex(t *testing.T) {
+	rows := makeRows(1000)
+
+	buf := new(bytes.Buffer)
+	err := parquet.Write(buf, rows,
+		parquet.PageBufferSize(1024),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "owner")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, _ := parquet.SchemaOf(Row{}).Lookup("owner")
+	id, _ := parquet.SchemaOf(Row{}).Lookup("id")
+	chunks := f.RowGroups()[0].ColumnChunks()
+
+	filter := chunks[owner.ColumnIndex].BloomFilter()
+	if filter == nil {
+		t.Fatal("missing bloom filter of the owner column")
+	}
+	for _, value := range []string{"owner-000", "owner-042", "owner-099"} {
+		if ok, err := filter.Check(parquet.ValueOf(value)); err != nil || !ok {
+			t.Errorf("value %q not found in the bloom filter: %v", value, err)
+		}
+	}
+
+	columnIndex, err := chunks[id.ColumnIndex].ColumnIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if columnIndex.NumPages() < 2 {
+		t.Fatalf("expected multiple pages, got %d", columnIndex.NumPages())
+	}
+	i := parquet.Search(columnIndex, parquet.ValueOf(int64(999)), parquet.Int64Type)
+	if i != columnIndex.NumPages()-1 {
+		t.Errorf("last value found in page %d/%d", i, columnIndex.NumPages())
+	}
+}
@@ -10,6 +10,33 @@ func countLevelsNotEqual(levels []byte, value byte) int {
 	return len(levels) - countLevelsEqual(levels, value)
 }
 
+// repairDefinitionLevels rewrites the levels greater than maxDefinitionLevel to
+// the maximum definition level. Legacy writers produce such levels when they
+// count required columns nested in optional groups as optional; a level above
+// the maximum can only mean that the value is present.
+//
+// The function returns false, leaving levels unmodified, if the number of
+// levels which would then be equal to maxDefinitionLevel does not match
+// numValues. Other inconsistencies cannot be repaired without guessing which
+// values are null, which could read corrupted pages as valid.
+func repairDefinitionLevels(levels []byte, maxDefinitionLevel byte, numValues int) bool {
+	count := 0
+	for _, level := range levels {
+		if level >= maxDefinitionLevel {
+			count++
+		}
+	}
+	if count != numValues {
+		return false
+	}
+	for i, level := range levels {
+		if level > maxDefinitionLevel {
+			levels[i] = maxDefinitionLevel
+		}
+	}
+	return true
+}
+
 func appendLevel(levels []byte, value byte, count int) []byte {
 	i := len(levels)
 	n := len(levels) + count
@@ -0,0 +1,133 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestRepairDefinitionLevels(t *testing.T) {
+	type inner struct {
+		B int32
+	}
+	type middle struct {
+		Inner *inner
+	}
+	type row struct {
+		Middle *middle
+	}
+
+	buf := new(bytes.Buffer)
+	if err := Write(buf, []row{{}}, Compression(&Uncompressed)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each test case describes the definition levels of a page of the
+	// Middle.Inner.B column (maximum definition level 2) holding 2 values.
+	// Only levels above the maximum can be repaired, the other cases would
+	// require guessing which values are null.
+	tests := []struct {
+		scenario string
+		levels   []byte
+		repaired []byte
+	}{
+		{
+			scenario: "required column counted as optional",
+			levels:   []byte{0, 3, 3, 1},
+			repaired: []byte{0, 2, 2, 1},
+		},
+		{
+			scenario: "levels of the parent group",
+			levels:   []byte{0, 1, 1, 0},
+		},
+		{
+			scenario: "no levels written",
+			levels:   []byte{0, 0},
+		},
+		{
+			scenario: "too many values counted as optional",
+			levels:   []byte{3, 3, 2, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			for _, option := range []FileOption{
+				CheckDefinitionLevels(false),
+				CheckDefinitionLevels(true),
+				RepairDefinitionLevels(true),
+			} {
+				config := DefaultFileConfig()
+				config.Apply(option)
+				f, err := OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), option)
+				if err != nil {
+					t.Fatal(err)
+				}
+				column := f.Root().Column("Middle").Column("Inner").Column("B")
+
+				header := DataPageHeaderV1{&format.DataPageHeader{
+					NumValues:               int32(len(test.levels)),
+					Encoding:                format.Plain,
+					DefinitionLevelEncoding: format.RLE,
+					RepetitionLevelEncoding: format.RLE,
+				}}
+				page, err := column.DecodeDataPageV1(header, makeLegacyPage(test.levels, 10, 20), nil)
+
+				switch {
+				case !config.CheckDefinitionLevels && !config.RepairDefinitionLevels:
+					// The levels are not verified by default.
+					if err != nil {
+						t.Fatal(err)
+					}
+					continue
+				case !config.RepairDefinitionLevels || test.repaired == nil:
+					if !errors.Is(err, ErrInvalidDefinitionLevels) {
+						t.Fatalf("expected ErrInvalidDefinitionLevels but got %v", err)
+					}
+					continue
+				case err != nil:
+					t.Fatal(err)
+				}
+
+				values := make([]Value, page.NumValues())
+				n, _ := page.Values().ReadValues(values)
+				values = values[:n]
+
+				levels := make([]byte, len(values))
+				present := []int32{}
+				for i, v := range values {
+					levels[i] = byte(v.DefinitionLevel())
+					if !v.IsNull() {
+						present = append(present, v.Int32())
+					}
+				}
+				if !bytes.Equal(levels, test.repaired) {
+					t.Errorf("definition levels mismatch: want=%v got=%v", test.repaired, levels)
+				}
+				if want := []int32{10, 20}; !reflect.DeepEqual(present, want) {
+					t.Errorf("values mismatch: want=%v got=%v", want, present)
+				}
+			}
+		})
+	}
+}
+
+// makeLegacyPage constructs the content of a data page v1 holding the given
+// definition levels, encoded as one RLE run per level, followed by the PLAIN
+// encoding of the values.
+func makeLegacyPage(levels []byte, values ...int32) []byte {
+	runs := []byte{}
+	for _, level := range levels {
+		runs = append(runs, 1<<1, level)
+	}
+	page := binary.LittleEndian.AppendUint32(nil, uint32(len(runs)))
+	page = append(page, runs...)
+	for _, value := range values {
+		page = binary.LittleEndian.AppendUint32(page, uint32(value))
+	}
+	return page
+}
@@ -0,0 +1,148 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// PageLevels holds the repetition and definition levels of the values of a
+// page, see ReadLevels.
+type PageLevels struct {
+	// Number of values in the page, including null values.
+	NumValues int64
+	// Repetition and definition levels of the values in the page. The slices
+	// are empty if the maximum repetition or definition level of the column
+	// is zero, in which case all the levels are zero.
+	RepetitionLevels []byte
+	DefinitionLevels []byte
+}
+
+// NumRows returns the number of rows which start in the page.
+func (p *PageLevels) NumRows() int64 {
+	if len(p.RepetitionLevels) == 0 {
+		return p.NumValues
+	}
+	return int64(countLevelsEqual(p.RepetitionLevels, 0))
+}
+
+// ReadLevels reads the repetition and definition levels of the values of chunk,
+// calling fn with the levels of each page.
+//
+// The values of pages read from files are not decoded, nor decompressed when
+// the pages are data pages v2, which makes the function a cheap way to answer
+// questions about the structure of nested data without reading it, for example
+// counting the elements of lists in each row to plan the expansion of a column.
+// The number of values of a row is the number of levels from a repetition level
+// of zero to the next; the definition levels tell whether the values are null,
+// or at which level of nesting a list is empty.
+//
+// The slices of levels passed to fn are only valid until it returns. If fn
+// returns an error, ReadLevels stops and returns the error.
+func ReadLevels(chunk ColumnChunk, fn func(PageLevels) error) error {
+	if c, ok := chunk.(*FileColumnChunk); ok {
+		pages := new(filePages)
+		pages.init(c)
+		defer pages.Close()
+		return pages.readLevels(fn)
+	}
+
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	for {
+		page, err := pages.ReadPage()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		err = fn(PageLevels{
+			NumValues:        page.NumValues(),
+			RepetitionLevels: page.RepetitionLevels(),
+			DefinitionLevels: page.DefinitionLevels(),
+		})
+		Release(page)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (f *filePages) readLevels(fn func(PageLevels) error) error {
+	for {
+		header := new(format.PageHeader)
+		if err := f.decoder.Decode(header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		data, err := f.readPage(header, f.rbuf)
+		if err != nil {
+			return err
+		}
+		err = f.chunk.column.decodeLevelsOfPage(header, data, fn)
+		data.unref()
+		if err != nil {
+			return fmt.Errorf("decoding levels of page %d of column %q: %w", f.index, f.columnPath(), err)
+		}
+		if header.Type == format.DataPage || header.Type == format.DataPageV2 {
+			f.index++
+		}
+	}
+}
+
+// decodeLevelsOfPage decodes the levels of a data page without decoding its
+// values, and calls fn with the result. Other pages are ignored.
+func (c *Column) decodeLevelsOfPage(header *format.PageHeader, page *buffer, fn func(PageLevels) error) error {
+	var numValues int64
+	var repetitionLevels, definitionLevels *buffer
+	var err error
+
+	switch header.Type {
+	case format.DataPage:
+		if header.DataPageHeader == nil {
+			return ErrMissingPageHeader
+		}
+		h := DataPageHeaderV1{header.DataPageHeader}
+		data := page.data
+		if isCompressed(c.compression) {
+			if page, err = c.decompress(data, header.UncompressedPageSize); err != nil {
+				return fmt.Errorf("decompressing data page v1: %w", err)
+			}
+			defer page.unref()
+			data = page.data
+		}
+		numValues = h.NumValues()
+		_, repetitionLevels, definitionLevels, _, err = c.decodeLevelsOfDataPageV1(h, data)
+
+	case format.DataPageV2:
+		if header.DataPageHeaderV2 == nil {
+			return ErrMissingPageHeader
+		}
+		h := DataPageHeaderV2{header.DataPageHeaderV2}
+		numValues = h.NumValues()
+		_, repetitionLevels, definitionLevels, _, err = c.decodeLevelsOfDataPageV2(h, page.data)
+
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+	defer unrefLevels(repetitionLevels, definitionLevels)
+
+	levels := PageLevels{NumValues: numValues}
+	if repetitionLevels != nil {
+		levels.RepetitionLevels = repetitionLevels.data
+	}
+	if definitionLevels != nil {
+		levels.DefinitionLevels = definitionLevels.data
+	}
+	return fn(levels)
+}
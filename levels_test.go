@@ -0,0 +1,108 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestReadLevels(t *testing.T) {
+	type Row struct {
+		ID   int64   `parquet:"id"`
+		Tags []int32 `parquet:"tags"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		for j := 0; j < i%5; j++ {
+			rows[i].Tags = append(rows[i].Tags, int32(j))
+		}
+	}
+	want := make([]int, len(rows))
+	for i, row := range rows {
+		want[i] = len(row.Tags)
+	}
+
+	// elementsPerRow counts the number of list elements of each row from the
+	// levels of a repeated column.
+	elementsPerRow := func(t *testing.T, chunk parquet.ColumnChunk) []int {
+		t.Helper()
+		var counts []int
+		var numRows int64
+		err := parquet.ReadLevels(chunk, func(levels parquet.PageLevels) error {
+			if int64(len(levels.RepetitionLevels)) != levels.NumValues {
+				t.Errorf("wrong number of repetition levels: %d!=%d", len(levels.RepetitionLevels), levels.NumValues)
+			}
+			numRows += levels.NumRows()
+			for i, repetitionLevel := range levels.RepetitionLevels {
+				if repetitionLevel == 0 {
+					counts = append(counts, 0)
+				}
+				if levels.DefinitionLevels[i] == 1 {
+					counts[len(counts)-1]++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if numRows != int64(len(counts)) {
+			t.Errorf("wrong number of rows: %d!=%d", numRows, len(counts))
+		}
+		return counts
+	}
+
+	for _, test := range []struct {
+		scenario string
+		options  []parquet.WriterOption
+	}{
+		{"data page v1", []parquet.WriterOption{parquet.DataPageVersion(1), parquet.Compression(&parquet.Snappy)}},
+		{"data page v2", []parquet.WriterOption{parquet.DataPageVersion(2), parquet.Compression(&parquet.Zstd)}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			options := append(test.options, parquet.PageBufferSize(512))
+			if err := parquet.Write(buf, rows, options...); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunks := f.RowGroups()[0].ColumnChunks()
+
+			if got := elementsPerRow(t, chunks[1]); !reflect.DeepEqual(got, want) {
+				t.Errorf("wrong number of elements per row:\nwant: %v\ngot:  %v", want, got)
+			}
+
+			numValues := int64(0)
+			err = parquet.ReadLevels(chunks[0], func(levels parquet.PageLevels) error {
+				if len(levels.RepetitionLevels) != 0 || len(levels.DefinitionLevels) != 0 {
+					t.Errorf("unexpected levels in required column")
+				}
+				numValues += levels.NumRows()
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if numValues != int64(len(rows)) {
+				t.Errorf("wrong number of values in required column: %d!=%d", numValues, len(rows))
+			}
+		})
+	}
+
+	t.Run("buffer", func(t *testing.T) {
+		buffer := parquet.NewGenericBuffer[Row]()
+		if _, err := buffer.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if got := elementsPerRow(t, buffer.ColumnChunks()[1]); !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong number of elements per row:\nwant: %v\ngot:  %v", want, got)
+		}
+	})
+}
@@ -33,6 +33,22 @@ const (
 	estimatedSizeOfByteArrayValues = 20
 )
 
+// checkColumnLevels returns an error wrapping ErrTooManyLevels if a column of
+// the given depth and levels cannot be represented by the package. The levels
+// are held in bytes, values beyond the limits would silently wrap around and
+// corrupt the levels of pages.
+func checkColumnLevels(path columnPath, depth, repetitionLevel, definitionLevel int) error {
+	switch {
+	case depth > MaxColumnDepth:
+		return fmt.Errorf("%w: column %s has %d nested levels, the limit is %d", ErrTooManyLevels, path, depth, MaxColumnDepth)
+	case repetitionLevel > MaxRepetitionLevel:
+		return fmt.Errorf("%w: column %s has %d repetition levels, the limit is %d", ErrTooManyLevels, path, repetitionLevel, MaxRepetitionLevel)
+	case definitionLevel > MaxDefinitionLevel:
+		return fmt.Errorf("%w: column %s has %d definition levels, the limit is %d", ErrTooManyLevels, path, definitionLevel, MaxDefinitionLevel)
+	}
+	return nil
+}
+
 func makeRepetitionLevel(i int) byte {
 	checkIndexRange("repetition level", i, 0, MaxRepetitionLevel)
 	return byte(i)
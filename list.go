@@ -0,0 +1,192 @@
+package parquet
+
+import (
+	"reflect"
+
+	"github.com/parquet-go/parquet-go/deprecated"
+)
+
+// LegacyLists enables compatibility with the legacy two-level structure of
+// LIST columns, where the repeated field holds the list elements directly
+// instead of being wrapped in a list.element group:
+//
+//	optional group values (LIST) {
+//	  repeated int32 array;
+//	}
+//
+// This structure was produced by old versions of parquet-mr and some Impala
+// writers. When passed to a reader, the two-level lists of the files are
+// exposed as standard three-level lists, so they map transparently to Go
+// slices. The backward compatibility rules of the parquet specification are
+// applied to determine which field represents the list elements:
+// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#backward-compatibility-rules
+//
+// When passed to a writer, lists with required elements are written using the
+// two-level structure, with the repeated field named "array". Lists of optional
+// elements cannot be represented with two levels and are written using the
+// standard structure.
+//
+// LegacyLists values implement both the WriterOption and ReaderOption
+// interfaces, for example:
+//
+//	reader := parquet.NewGenericReader[Row](input, parquet.LegacyLists(true))
+type LegacyLists bool
+
+// ConfigureWriter satisfies the WriterOption interface.
+func (l LegacyLists) ConfigureWriter(config *WriterConfig) { config.LegacyLists = bool(l) }
+
+// ConfigureReader satisfies the ReaderOption interface.
+func (l LegacyLists) ConfigureReader(config *ReaderConfig) { config.LegacyLists = bool(l) }
+
+// groupNodeWithFields wraps a group node to substitute its fields. Unlike
+// Group, the order of fields is retained, which matters for nodes that were
+// read from parquet files.
+type groupNodeWithFields struct {
+	Node
+	fields []Field
+}
+
+func (n *groupNodeWithFields) Fields() []Field { return n.fields }
+
+func (n *groupNodeWithFields) GoType() reflect.Type { return goTypeOf(n) }
+
+func (n *groupNodeWithFields) String() string { return sprint("", n) }
+
+// standardListsOf returns a version of node where all LIST groups use the
+// standard three-level structure. The boolean return value is false if node
+// did not contain lists that needed to be rewritten, in which case the node is
+// returned unchanged.
+func standardListsOf(node Node) (Node, bool) {
+	if node.Leaf() {
+		return node, false
+	}
+
+	fields := node.Fields()
+	if isListGroup(node) && len(fields) == 1 && fields[0].Repeated() {
+		repeated := fields[0]
+		if isLegacyListElement(node, repeated) {
+			element, _ := standardListsOf(repeated)
+			return standardListOf(node, Required(element)), true
+		}
+		child := repeated.Fields()[0]
+		element, changed := standardListsOf(child)
+		if !changed && repeated.Name() == "list" && child.Name() == "element" {
+			return node, false
+		}
+		return standardListOf(node, element), true
+	}
+
+	changed := false
+	newFields := make([]Field, len(fields))
+	for i, field := range fields {
+		newField, ok := standardListsOf(field)
+		if ok {
+			newFields[i], changed = &groupField{Node: newField, name: field.Name()}, true
+		} else {
+			newFields[i] = field
+		}
+	}
+	if !changed {
+		return node, false
+	}
+	return &groupNodeWithFields{Node: node, fields: newFields}, true
+}
+
+func standardListOf(list, element Node) Node {
+	return &groupNodeWithFields{
+		Node: list,
+		fields: []Field{
+			&groupField{Node: Repeated(Group{"element": element}), name: "list"},
+		},
+	}
+}
+
+// isListGroup returns true if node is a group of the LIST logical type. Columns
+// of parquet files do not expose the logical type of groups, so the schema
+// element of the column is inspected instead.
+func isListGroup(node Node) bool {
+	if c, ok := node.(*Column); ok {
+		if lt := c.schema.LogicalType; lt != nil && lt.List != nil {
+			return true
+		}
+		ct := c.schema.ConvertedType
+		return ct != nil && *ct == deprecated.List
+	}
+	return isList(node)
+}
+
+// isLegacyListElement returns true if the repeated field of the list node
+// represents the list elements, according to the backward compatibility rules
+// of the parquet specification.
+func isLegacyListElement(list Node, repeated Field) bool {
+	if repeated.Leaf() {
+		return true
+	}
+	if fields := repeated.Fields(); len(fields) != 1 {
+		return true
+	}
+	if name := repeated.Name(); name == "array" {
+		return true
+	} else if listField, ok := list.(Field); ok && name == listField.Name()+"_tuple" {
+		return true
+	}
+	return false
+}
+
+// legacyListsOf returns a version of node where all LIST groups with required
+// elements use the legacy two-level structure. The boolean return value is
+// false if node did not contain lists that could be rewritten, in which case
+// the node is returned unchanged.
+func legacyListsOf(node Node) (Node, bool) {
+	if node.Leaf() {
+		return node, false
+	}
+
+	fields := node.Fields()
+	if isList(node) {
+		element := listElementOf(node)
+		if !element.Required() {
+			return node, false
+		}
+		newElement, _ := legacyListsOf(element)
+		return &groupNodeWithFields{
+			Node: node,
+			fields: []Field{
+				&groupField{Node: Repeated(newElement), name: "array"},
+			},
+		}, true
+	}
+
+	changed := false
+	newFields := make([]Field, len(fields))
+	for i, field := range fields {
+		newField, ok := legacyListsOf(field)
+		if ok {
+			newFields[i], changed = &groupField{Node: newField, name: field.Name()}, true
+		} else {
+			newFields[i] = field
+		}
+	}
+	if !changed {
+		return node, false
+	}
+	return &groupNodeWithFields{Node: node, fields: newFields}, true
+}
+
+// standardListsRowGroup returns a view of group where the schema uses the
+// standard three-level structure for LIST groups. The levels of the columns
+// are the same in both structures, only the column paths differ.
+func standardListsRowGroup(group RowGroup) RowGroup {
+	schema := group.Schema()
+	root, ok := standardListsOf(schema)
+	if !ok {
+		return group
+	}
+	// The sorting columns are dropped because their paths refer to the
+	// original structure of the schema.
+	return &rowGroup{
+		schema:  NewSchema(schema.Name(), root),
+		numRows: group.NumRows(),
+		columns: group.ColumnChunks(),
+	}
+}
@@ -0,0 +1,90 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestLegacyLists(t *testing.T) {
+	type Point struct {
+		X int64
+		Y string
+	}
+	type Row struct {
+		Name   string
+		Values []int32 `parquet:",list"`
+		Points []Point `parquet:",list"`
+	}
+
+	rows := []Row{
+		{Name: "a", Values: []int32{1, 2, 3}, Points: []Point{{1, "x"}, {2, "y"}}},
+		{Name: "b"},
+		{Name: "c", Values: []int32{4}, Points: []Point{{3, "z"}}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.LegacyLists(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := f.Schema().String()
+	for _, want := range []string{
+		"group Values {\n\t\trepeated int32 array (INT(32,true));\n\t}",
+		"group Points {\n\t\trepeated group array {\n\t\t\trequired int64 X",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("file schema does not contain %q:\n%s", want, schema)
+		}
+	}
+	for _, element := range f.Metadata().Schema {
+		if element.Name == "Values" || element.Name == "Points" {
+			if element.LogicalType == nil || element.LogicalType.List == nil {
+				t.Errorf("%s: missing LIST logical type", element.Name)
+			}
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.LegacyLists(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range rows {
+		// Empty lists are read back as empty slices.
+		if rows[i].Values == nil {
+			rows[i].Values = []int32{}
+		}
+		if rows[i].Points == nil {
+			rows[i].Points = []Point{}
+		}
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(buf.Bytes()), parquet.LegacyLists(true))
+	if schema := reader.Schema().String(); strings.Contains(schema, "array") {
+		t.Errorf("reader schema was not converted to three-level lists:\n%s", schema)
+	}
+	reader.Close()
+
+	// Files using the standard structure are read unchanged.
+	buf.Reset()
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err = parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.LegacyLists(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+}
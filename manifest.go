@@ -0,0 +1,287 @@
+package parquet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ErrManifestMismatch is the error wrapped by the errors returned by
+// VerifyManifest when the files do not match the manifest.
+var ErrManifestMismatch = errors.New("parquet files do not match the manifest")
+
+// Manifest describes a set of parquet files, for example the files exported to
+// a directory, so their integrity can be verified after they were copied or
+// archived.
+//
+// Manifests are built by BuildManifest and checked by VerifyManifest. They can
+// be serialized to JSON to be stored alongside the files they describe.
+type Manifest struct {
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes a parquet file of a Manifest.
+type ManifestFile struct {
+	// Slash-separated path of the file, relative to the root of the file
+	// system that the manifest was built from.
+	Path string `json:"path"`
+	// Size of the file in bytes.
+	Size int64 `json:"size"`
+	// Number of rows in the file.
+	NumRows int64 `json:"num_rows"`
+	// Hex-encoded SHA-256 of the schema of the file, as printed by the String
+	// method of Schema.
+	SchemaFingerprint string `json:"schema_fingerprint"`
+	// Hex-encoded SHA-256 of the footer of the file, which holds the metadata
+	// and the length of the metadata followed by the magic bytes.
+	FooterChecksum string `json:"footer_checksum"`
+	// Statistics of the leaf columns of the file, in the order of the columns
+	// of the schema.
+	Columns []ManifestColumn `json:"columns"`
+}
+
+// ManifestColumn holds the statistics of a column of a ManifestFile, computed
+// from the metadata of its column chunks.
+type ManifestColumn struct {
+	// Dot-separated path of the column.
+	Path string `json:"path"`
+	// Number of values in the column, including nulls.
+	NumValues int64 `json:"num_values"`
+	// Number of null values in the column.
+	NullCount int64 `json:"null_count"`
+	// Plain encoding of the minimum and maximum values of the column. Both are
+	// nil when not all the column chunks recorded their bounds, or when the
+	// column holds only null values.
+	MinValue []byte `json:"min_value,omitempty"`
+	MaxValue []byte `json:"max_value,omitempty"`
+}
+
+// BuildManifest builds the manifest of the parquet files found in fsys, which
+// are the regular files with a ".parquet" extension. The file system is walked
+// recursively, and the files are listed in lexical order of their paths.
+//
+// Only the footers of the files are read: the row count and statistics of the
+// columns are those recorded in the file metadata.
+//
+// To build the manifest of a directory, use os.DirFS:
+//
+//	manifest, err := parquet.BuildManifest(os.DirFS("path/to/dataset"))
+func BuildManifest(fsys fs.FS) (*Manifest, error) {
+	paths, err := manifestPathsOf(fsys)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{Files: make([]ManifestFile, len(paths))}
+	for i, name := range paths {
+		if err := buildManifestFile(&manifest.Files[i], fsys, name); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// VerifyManifest checks that the parquet files found in fsys match manifest.
+//
+// The function reports files listed in the manifest which are missing from
+// fsys, parquet files of fsys which are not listed in the manifest, and files
+// of which the size, row count, schema, footer or column statistics differ.
+// All the differences are reported in the returned error, which wraps
+// ErrManifestMismatch; other errors are returned when the files cannot be read.
+func VerifyManifest(fsys fs.FS, manifest *Manifest) error {
+	paths, err := manifestPathsOf(fsys)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	mismatch := func(name, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %w: "+format, append([]any{name, ErrManifestMismatch}, args...)...))
+	}
+
+	listed := make(map[string]bool, len(manifest.Files))
+	for i := range manifest.Files {
+		want := &manifest.Files[i]
+		listed[want.Path] = true
+
+		got := new(ManifestFile)
+		if err := buildManifestFile(got, fsys, want.Path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				mismatch(want.Path, "file is missing")
+				continue
+			}
+			return err
+		}
+		if got.Size != want.Size {
+			mismatch(want.Path, "size is %d bytes instead of %d", got.Size, want.Size)
+		}
+		if got.NumRows != want.NumRows {
+			mismatch(want.Path, "file has %d rows instead of %d", got.NumRows, want.NumRows)
+		}
+		if got.SchemaFingerprint != want.SchemaFingerprint {
+			mismatch(want.Path, "schema fingerprint is %s instead of %s", got.SchemaFingerprint, want.SchemaFingerprint)
+		}
+		if got.FooterChecksum != want.FooterChecksum {
+			mismatch(want.Path, "footer checksum is %s instead of %s", got.FooterChecksum, want.FooterChecksum)
+		}
+		if !slices.EqualFunc(got.Columns, want.Columns, manifestColumnsAreEqual) {
+			mismatch(want.Path, "column statistics differ")
+		}
+	}
+
+	for _, name := range paths {
+		if !listed[name] {
+			mismatch(name, "file is not listed in the manifest")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func manifestColumnsAreEqual(c1, c2 ManifestColumn) bool {
+	return c1.Path == c2.Path &&
+		c1.NumValues == c2.NumValues &&
+		c1.NullCount == c2.NullCount &&
+		bytes.Equal(c1.MinValue, c2.MinValue) &&
+		bytes.Equal(c1.MaxValue, c2.MaxValue)
+}
+
+func manifestPathsOf(fsys fs.FS) ([]string, error) {
+	var paths []string
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() && strings.EqualFold(path.Ext(name), ".parquet") {
+			paths = append(paths, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+	return paths, nil
+}
+
+func buildManifestFile(m *ManifestFile, fsys fs.FS, name string) error {
+	r, size, err := openManifestFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	f, err := OpenFile(r, size, SkipPageIndex(true), SkipBloomFilters(true))
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	footerChecksum, err := footerChecksumOf(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	schemaFingerprint := sha256.Sum256([]byte(f.Schema().String()))
+
+	*m = ManifestFile{
+		Path:              name,
+		Size:              size,
+		NumRows:           f.NumRows(),
+		SchemaFingerprint: hex.EncodeToString(schemaFingerprint[:]),
+		FooterChecksum:    footerChecksum,
+		Columns:           manifestColumnsOf(f),
+	}
+	return nil
+}
+
+// openManifestFile opens the file of fsys at the given path for random access.
+// Files which do not implement io.ReaderAt are loaded in memory.
+func openManifestFile(fsys fs.FS, name string) (manifestFileReader, int64, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	if r, ok := file.(manifestFileReader); ok {
+		return r, stat.Size(), nil
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, 0, err
+	}
+	return nopCloserReaderAt{bytes.NewReader(data)}, int64(len(data)), nil
+}
+
+type manifestFileReader interface {
+	io.ReaderAt
+	io.Closer
+}
+
+type nopCloserReaderAt struct{ io.ReaderAt }
+
+func (nopCloserReaderAt) Close() error { return nil }
+
+func footerChecksumOf(f *File) (string, error) {
+	var b [8]byte
+	if _, err := f.ReadAt(b[:], f.Size()-8); err != nil {
+		return "", fmt.Errorf("reading magic footer of parquet file: %w", err)
+	}
+	footerSize := int64(binary.LittleEndian.Uint32(b[:4])) + 8
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.NewSectionReader(f, f.Size()-footerSize, footerSize)); err != nil {
+		return "", fmt.Errorf("reading footer of parquet file: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func manifestColumnsOf(f *File) []ManifestColumn {
+	leaves := f.Schema().Columns()
+	columns := make([]ManifestColumn, len(leaves))
+
+	for i, path := range leaves {
+		leaf, _ := f.Schema().Lookup(path...)
+		typ := leaf.Node.Type()
+		column := &columns[i]
+		column.Path = columnPath(path).String()
+		hasBounds := true
+
+		for _, rowGroup := range f.Metadata().RowGroups {
+			if i >= len(rowGroup.Columns) {
+				continue
+			}
+			metadata := &rowGroup.Columns[i].MetaData
+			stats := &metadata.Statistics
+			column.NumValues += metadata.NumValues
+			column.NullCount += stats.NullCount
+
+			if metadata.NumValues == stats.NullCount {
+				continue // only nulls, the column chunk has no bounds
+			}
+			if stats.MinValue == nil || stats.MaxValue == nil {
+				hasBounds = false
+				continue
+			}
+			minValue := typ.Kind().Value(stats.MinValue)
+			maxValue := typ.Kind().Value(stats.MaxValue)
+			if column.MinValue == nil || typ.Compare(minValue, typ.Kind().Value(column.MinValue)) < 0 {
+				column.MinValue = stats.MinValue
+			}
+			if column.MaxValue == nil || typ.Compare(maxValue, typ.Kind().Value(column.MaxValue)) > 0 {
+				column.MaxValue = stats.MaxValue
+			}
+		}
+
+		if !hasBounds {
+			column.MinValue, column.MaxValue = nil, nil
+		}
+	}
+	return columns
+}
@@ -0,0 +1,113 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestManifest(t *testing.T) {
+	type Row struct {
+		ID   int64   `parquet:"id"`
+		Name *string `parquet:"name,optional"`
+	}
+
+	name := func(s string) *string { return &s }
+
+	fileOf := func(t *testing.T, rows []Row) []byte {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows, parquet.MaxRowsPerRowGroup(2)); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"a.parquet":       fileOf(t, []Row{{ID: 3, Name: name("c")}, {ID: 1}, {ID: 2, Name: name("b")}}),
+		"part/b.parquet":  fileOf(t, []Row{{ID: 10}, {ID: 11}}),
+		"part/README.txt": []byte("not a parquet file"),
+	}
+	for path, data := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifest, err := parquet.BuildManifest(os.DirFS(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Files) != 2 || manifest.Files[0].Path != "a.parquet" || manifest.Files[1].Path != "part/b.parquet" {
+		t.Fatalf("wrong files in manifest: %+v", manifest.Files)
+	}
+
+	a := manifest.Files[0]
+	if a.Size != int64(len(files["a.parquet"])) || a.NumRows != 3 {
+		t.Errorf("wrong size or row count: size=%d rows=%d", a.Size, a.NumRows)
+	}
+	if a.SchemaFingerprint != manifest.Files[1].SchemaFingerprint {
+		t.Errorf("files with the same schema have different fingerprints")
+	}
+	idColumn, nameColumn := a.Columns[0], a.Columns[1]
+	if idColumn.Path != "id" || idColumn.NumValues != 3 || idColumn.NullCount != 0 ||
+		parquet.Int64.Value(idColumn.MinValue).Int64() != 1 ||
+		parquet.Int64.Value(idColumn.MaxValue).Int64() != 3 {
+		t.Errorf("wrong statistics of column id: %+v", idColumn)
+	}
+	if nameColumn.Path != "name" || nameColumn.NullCount != 1 || string(nameColumn.MinValue) != "b" || string(nameColumn.MaxValue) != "c" {
+		t.Errorf("wrong statistics of column name: %+v", nameColumn)
+	}
+
+	// Manifests round trip through JSON, and verify against file systems which
+	// do not support random access.
+	b, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(parquet.Manifest)
+	if err := json.Unmarshal(b, decoded); err != nil {
+		t.Fatal(err)
+	}
+	mapFS := fstest.MapFS{}
+	for path, data := range files {
+		mapFS[path] = &fstest.MapFile{Data: data}
+	}
+	if err := parquet.VerifyManifest(mapFS, decoded); err != nil {
+		t.Errorf("unexpected error verifying the manifest: %v", err)
+	}
+
+	mapFS["a.parquet"] = &fstest.MapFile{Data: fileOf(t, []Row{{ID: 3, Name: name("c")}, {ID: 1}, {ID: 2, Name: name("z")}})}
+	mapFS["c.parquet"] = &fstest.MapFile{Data: files["part/b.parquet"]}
+	delete(mapFS, "part/b.parquet")
+
+	err = parquet.VerifyManifest(mapFS, decoded)
+	if !errors.Is(err, parquet.ErrManifestMismatch) {
+		t.Fatalf("expected a manifest mismatch error, got %v", err)
+	}
+	for _, want := range []string{
+		"a.parquet: parquet files do not match the manifest: footer checksum is",
+		"a.parquet: parquet files do not match the manifest: column statistics differ",
+		"part/b.parquet: parquet files do not match the manifest: file is missing",
+		"c.parquet: parquet files do not match the manifest: file is not listed in the manifest",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing error %q in:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "rows instead of") {
+		t.Errorf("unexpected row count mismatch:\n%v", err)
+	}
+}
@@ -0,0 +1,303 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ProjectMapKey returns a virtual column chunk holding, for each row of
+// rowGroup, the value associated with key in the MAP column at the given
+// dot-separated path, for example the value of attributes['host'] with:
+//
+//	host := parquet.ProjectMapKey(rowGroup, "attributes", parquet.ValueOf("host"))
+//
+// The column chunk has one value per row, which is null if the map is null,
+// does not contain the key, or associates a null value to the key. If the map
+// contains the key more than once, the first value is retained. The type of
+// the column chunk is the type of the map values, and its column index is the
+// index of the leaf column of the map values in rowGroup.
+//
+// Only the pages of the key and value columns of the map are read, and only
+// when the content of the column chunk is first accessed. Keys are compared
+// with Equal, so key must have the kind of the physical type of the keys.
+//
+// The function panics if the column does not exist, is not a map with leaf
+// keys and values, or is nested within a repeated field.
+func ProjectMapKey(rowGroup RowGroup, mapColumn string, key Value) ColumnChunk {
+	schema := rowGroup.Schema()
+	p, err := mapKeyProjectionOf(schema, strings.Split(mapColumn, "."))
+	if err != nil {
+		panic(fmt.Errorf("cannot project key of column %q of parquet schema %s: %w", mapColumn, schema.Name(), err))
+	}
+	p.key = key
+	return &lazyColumnChunk{
+		typ:    p.valueType,
+		column: p.valueColumn,
+		load: func() (ColumnChunk, error) {
+			columns := rowGroup.ColumnChunks()
+			return p.project(columns[p.keyColumn], columns[p.valueColumn])
+		},
+	}
+}
+
+// MapKeys returns the distinct keys of the MAP column at the given dot-separated
+// path in rowGroup, which allows programs to know whether a key exists at all
+// in the row group before decoding the values of the map, for example before
+// using ProjectMapKey.
+//
+// When the column chunk of the keys is read from a file and all its data pages
+// are dictionary encoded, the keys are read from the dictionary page alone, in
+// the order of the dictionary. Otherwise, the values of the key column are
+// scanned and the keys are returned in the order they first appear.
+//
+// The function returns an error if the column does not exist or is not a map.
+func MapKeys(rowGroup RowGroup, mapColumn string) ([]Value, error) {
+	schema := rowGroup.Schema()
+	_, keyColumn, _, err := mapColumnOf(schema, strings.Split(mapColumn, "."))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read keys of column %q of parquet schema %s: %w", mapColumn, schema.Name(), err)
+	}
+	chunk := rowGroup.ColumnChunks()[keyColumn]
+
+	if c, ok := chunk.(*FileColumnChunk); ok && c.IsDictionaryEncoded() {
+		pages := new(filePages)
+		pages.init(c)
+		defer pages.Close()
+
+		if err := pages.readDictionary(); err != nil {
+			return nil, fmt.Errorf("reading dictionary of column %q: %w", pages.columnPath(), err)
+		}
+		dict := pages.dictionary
+		keys := make([]Value, dict.Len())
+		for i := range keys {
+			keys[i] = dict.Index(int32(i)).Clone()
+		}
+		return keys, nil
+	}
+
+	values := newColumnChunkValueReader(chunk)
+	defer values.Close()
+
+	var keys []Value
+	var scratch []byte
+	seen := make(map[string]struct{})
+	buffer := make([]Value, defaultValueBufferSize)
+	for {
+		n, err := values.ReadValues(buffer)
+		for _, v := range buffer[:n] {
+			if v.IsNull() {
+				continue
+			}
+			scratch = v.AppendBytes(scratch[:0])
+			if _, ok := seen[string(scratch)]; !ok {
+				seen[string(scratch)] = struct{}{}
+				keys = append(keys, v.Level(0, 0, keyColumn).Clone())
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				return keys, nil
+			}
+			return nil, fmt.Errorf("reading map keys: %w", err)
+		}
+	}
+}
+
+// mapKeyProjection holds the properties of the key and value columns of a map
+// needed to project the values associated with a key.
+type mapKeyProjection struct {
+	key         Value
+	keyColumn   int
+	valueColumn int
+	valueType   Type
+	// Definition levels at which map entries and non-null values exist.
+	entryDefinitionLevel byte
+	valueDefinitionLevel byte
+}
+
+func mapKeyProjectionOf(schema *Schema, path []string) (*mapKeyProjection, error) {
+	keyValue, keyColumn, definitionLevel, err := mapColumnOf(schema, path)
+	if err != nil {
+		return nil, err
+	}
+	keyNode, valueNode := keyValue.Fields()[0], keyValue.Fields()[1]
+	if !keyNode.Leaf() || !valueNode.Leaf() {
+		return nil, errors.New("the keys and values of the map are not leaf columns")
+	}
+
+	p := &mapKeyProjection{
+		keyColumn:            keyColumn,
+		valueColumn:          keyColumn + 1,
+		valueType:            valueNode.Type(),
+		entryDefinitionLevel: definitionLevel + 1,
+		valueDefinitionLevel: definitionLevel + 1,
+	}
+	if valueNode.Optional() {
+		p.valueDefinitionLevel++
+	}
+	return p, nil
+}
+
+// mapColumnOf looks up the MAP column at the given path, returning the repeated
+// group of key/value pairs, the index of the leaf column of the keys, and the
+// definition level of the map.
+func mapColumnOf(schema *Schema, path []string) (keyValue Field, keyColumn int, definitionLevel byte, err error) {
+	var node Node = schema
+
+	for _, name := range path {
+		if node.Leaf() {
+			return nil, 0, 0, errors.New("the column does not exist")
+		}
+		if node = fieldByName(node, name); node == nil {
+			return nil, 0, 0, errors.New("the column does not exist")
+		}
+		if node.Repeated() {
+			return nil, 0, 0, errors.New("the column is nested within a repeated field")
+		}
+		if node.Optional() {
+			definitionLevel++
+		}
+	}
+
+	fields := node.Fields()
+	if !isMap(node) || len(fields) != 1 || !fields[0].Repeated() || len(fields[0].Fields()) != 2 {
+		return nil, 0, 0, errors.New("the column is not a map")
+	}
+	keyValue = fields[0]
+	keyNode := keyValue.Fields()[0]
+	if !keyNode.Leaf() {
+		return nil, 0, 0, errors.New("the keys of the map are not a leaf column")
+	}
+
+	leaf, _ := schema.Lookup(columnPath(path).append(keyValue.Name(), keyNode.Name())...)
+	return keyValue, leaf.ColumnIndex, definitionLevel, nil
+}
+
+// project reads the keys and values of the map in lockstep, writing the value
+// associated with the key in each row to an optional column buffer.
+func (p *mapKeyProjection) project(keyChunk, valueChunk ColumnChunk) (ColumnChunk, error) {
+	keys := newColumnChunkValueReader(keyChunk)
+	defer keys.Close()
+	values := newColumnChunkValueReader(valueChunk)
+	defer values.Close()
+
+	column := newOptionalColumnBuffer(p.valueType.NewColumnBuffer(p.valueColumn, 0), 1, nullsGoLast)
+	keyBuffer := make([]Value, defaultValueBufferSize)
+	valueBuffer := make([]Value, defaultValueBufferSize)
+	output := [1]Value{}
+	numKeys, keyIndex := 0, 0
+	inRow, found := false, false
+
+	endRow := func() error {
+		if inRow && !found {
+			output[0] = Value{}.Level(0, 0, p.valueColumn)
+			if _, err := column.WriteValues(output[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for {
+		if keyIndex == numKeys {
+			n, err := keys.ReadValues(keyBuffer)
+			if n == 0 {
+				if err == io.EOF {
+					break
+				}
+				return nil, fmt.Errorf("reading map keys: %w", err)
+			}
+			numKeys, keyIndex = n, 0
+		}
+
+		// The value reader may return fewer values than requested at the end
+		// of pages, the remaining keys are paired with values on the next
+		// iteration.
+		n, err := values.ReadValues(valueBuffer[:numKeys-keyIndex])
+		if n == 0 {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("reading map values: %w", err)
+		}
+
+		for i, v := range valueBuffer[:n] {
+			k := keyBuffer[keyIndex+i]
+			if k.repetitionLevel == 0 {
+				if err := endRow(); err != nil {
+					return nil, err
+				}
+				inRow, found = true, false
+			}
+			if found || k.definitionLevel < p.entryDefinitionLevel || !Equal(k, p.key) {
+				continue
+			}
+			found = true
+			if v.definitionLevel == p.valueDefinitionLevel {
+				output[0] = v.Level(0, 1, p.valueColumn)
+			} else {
+				output[0] = Value{}.Level(0, 0, p.valueColumn)
+			}
+			if _, err := column.WriteValues(output[:]); err != nil {
+				return nil, err
+			}
+		}
+		keyIndex += n
+	}
+
+	if err := endRow(); err != nil {
+		return nil, err
+	}
+	return column, nil
+}
+
+// columnChunkValueReader reads the values of all the pages of a column chunk.
+// The values returned by ReadValues remain valid until the next call, since
+// pages are only released when the reader moves to the next page.
+type columnChunkValueReader struct {
+	pages  Pages
+	page   Page
+	values ValueReader
+}
+
+func newColumnChunkValueReader(chunk ColumnChunk) *columnChunkValueReader {
+	return &columnChunkValueReader{pages: chunk.Pages()}
+}
+
+func (r *columnChunkValueReader) ReadValues(values []Value) (int, error) {
+	for {
+		if r.values == nil {
+			page, err := r.pages.ReadPage()
+			if err != nil {
+				return 0, err
+			}
+			r.page, r.values = page, page.Values()
+		}
+
+		n, err := r.values.ReadValues(values)
+		if n > 0 || (err != nil && err != io.EOF) {
+			if err == io.EOF {
+				err = nil
+			}
+			return n, err
+		}
+		if err == nil {
+			return 0, io.ErrNoProgress
+		}
+		r.release()
+	}
+}
+
+func (r *columnChunkValueReader) release() {
+	if r.page != nil {
+		Release(r.page)
+		r.page, r.values = nil, nil
+	}
+}
+
+func (r *columnChunkValueReader) Close() error {
+	r.release()
+	return r.pages.Close()
+}
@@ -0,0 +1,143 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestProjectMapKey(t *testing.T) {
+	type Row struct {
+		ID         int64
+		Attributes map[string]string
+	}
+
+	rows := []Row{
+		{ID: 1, Attributes: map[string]string{"host": "a", "zone": "x"}},
+		{ID: 2},
+		{ID: 3, Attributes: map[string]string{"zone": "y"}},
+		{ID: 4, Attributes: map[string]string{"host": "b"}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(64)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunk := parquet.ProjectMapKey(f.RowGroups()[0], "Attributes", parquet.ValueOf("host"))
+	if chunk.Column() != 2 {
+		t.Errorf("wrong column index: want=2 got=%d", chunk.Column())
+	}
+	if n := chunk.NumValues(); n != int64(len(rows)) {
+		t.Errorf("wrong number of values: want=%d got=%d", len(rows), n)
+	}
+
+	pages := chunk.Pages()
+	defer pages.Close()
+	page, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make([]parquet.Value, len(rows))
+	n, err := page.Values().ReadValues(values)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	want := []string{"a", "", "", "b"}
+	if n != len(want) {
+		t.Fatalf("wrong number of values read: want=%d got=%d", len(want), n)
+	}
+	for i, v := range values {
+		switch {
+		case want[i] == "" && !v.IsNull():
+			t.Errorf("value at index %d is not null: %v", i, v)
+		case want[i] != "" && v.String() != want[i]:
+			t.Errorf("wrong value at index %d: want=%q got=%v", i, want[i], v)
+		}
+	}
+
+	missing := parquet.ProjectMapKey(f.RowGroups()[0], "Attributes", parquet.ValueOf("region"))
+	index, err := missing.ColumnIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nulls := index.NullCount(0); nulls != int64(len(rows)) {
+		t.Errorf("wrong null count for a missing key: want=%d got=%d", len(rows), nulls)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	type Row struct {
+		ID         int64
+		Attributes map[string]string
+	}
+	type DictRow struct {
+		ID         int64
+		Attributes map[string]string `parquet-key:",dict"`
+	}
+
+	rows := []Row{
+		{ID: 1, Attributes: map[string]string{"host": "a"}},
+		{ID: 2},
+		{ID: 3, Attributes: map[string]string{"zone": "y"}},
+		{ID: 4, Attributes: map[string]string{"host": "b"}},
+	}
+	dictRows := make([]DictRow, len(rows))
+	for i, row := range rows {
+		dictRows[i] = DictRow(row)
+	}
+
+	for _, test := range []struct {
+		scenario string
+		write    func(*bytes.Buffer) error
+	}{
+		{
+			scenario: "plain",
+			write:    func(buf *bytes.Buffer) error { return parquet.Write(buf, rows) },
+		},
+		{
+			scenario: "dictionary",
+			write:    func(buf *bytes.Buffer) error { return parquet.Write(buf, dictRows) },
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := test.write(buf); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunk := f.RowGroups()[0].ColumnChunks()[1].(*parquet.FileColumnChunk)
+			if dict := test.scenario == "dictionary"; chunk.IsDictionaryEncoded() != dict {
+				t.Fatalf("wrong encoding of the key column: dictionary=%t", !dict)
+			}
+
+			keys, err := parquet.MapKeys(f.RowGroups()[0], "Attributes")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, len(keys))
+			for i, key := range keys {
+				got[i] = key.String()
+			}
+			sort.Strings(got)
+			if want := []string{"host", "zone"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("wrong keys: want=%q got=%q", want, got)
+			}
+		})
+	}
+
+	if _, err := parquet.MapKeys(parquet.NewBuffer(parquet.SchemaOf(Row{})), "ID"); err == nil {
+		t.Error("expected an error for a column which is not a map")
+	}
+}
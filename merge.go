@@ -10,11 +10,17 @@ import (
 
 // MergeRowGroups constructs a row group which is a merged view of rowGroups. If
 // rowGroups are sorted and the passed options include sorting, the merged row
-// group will also be sorted.
+// group will also be sorted. Rows which are equal according to the sorting
+// columns are produced in the order of the row groups they come from.
 //
 // The function validates the input to ensure that the merge operation is
 // possible, ensuring that the schemas match or can be converted to an
 // optionally configured target schema passed as argument in the option list.
+// When the types of columns differ, a casting plan is validated before the
+// merged row group is returned: DECIMAL values are rescaled to the target
+// type, and impossible casts, such as reducing the scale of DECIMAL values or
+// changing the length of FIXED_LEN_BYTE_ARRAY values, are reported with a
+// *ConvertError.
 //
 // The sorting columns of each row group are also consulted to determine whether
 // the output can be represented. If sorting columns are configured on the merge
@@ -32,8 +38,16 @@ func MergeRowGroups(rowGroups []RowGroup, options ...RowGroupOption) (RowGroup,
 	if schema == nil {
 		schema = rowGroups[0].Schema()
 
+		if config.ColumnOrder != nil {
+			schema = NewSchema(schema.Name(), orderColumnsOf(schema, config.ColumnOrder))
+		}
+
 		for _, rowGroup := range rowGroups[1:] {
-			if !nodesAreEqual(schema, rowGroup.Schema()) {
+			var rowGroupSchema Node = rowGroup.Schema()
+			if config.ColumnOrder != nil {
+				rowGroupSchema = orderColumnsOf(rowGroupSchema, config.ColumnOrder)
+			}
+			if !nodesAreEqual(schema, rowGroupSchema) {
 				return nil, ErrRowGroupSchemaMismatch
 			}
 		}
@@ -42,24 +56,44 @@ func MergeRowGroups(rowGroups []RowGroup, options ...RowGroupOption) (RowGroup,
 	mergedRowGroups := make([]RowGroup, len(rowGroups))
 	copy(mergedRowGroups, rowGroups)
 
+	converted := false
 	for i, rowGroup := range mergedRowGroups {
 		if rowGroupSchema := rowGroup.Schema(); !nodesAreEqual(schema, rowGroupSchema) {
-			conv, err := Convert(schema, rowGroupSchema)
+			conv, err := convert(schema, rowGroupSchema, castColumnType, nil)
 			if err != nil {
 				return nil, fmt.Errorf("cannot merge row groups: %w", err)
 			}
 			mergedRowGroups[i] = ConvertRowGroup(rowGroup, conv)
+			converted = true
 		}
 	}
 
+	filtered, err := filterRowGroupPages(schema, mergedRowGroups, config.PageFilters)
+	if err != nil {
+		return nil, err
+	}
+
 	m := &mergedRowGroup{sorting: config.Sorting.SortingColumns}
 	m.init(schema, mergedRowGroups)
 
 	if len(m.sorting) == 0 {
+		if len(config.SequenceColumn) > 0 {
+			v, err := newVersionedRowGroup(m, m.sorting, config)
+			if err != nil {
+				return nil, err
+			}
+			return v, nil
+		}
 		// When the row group has no ordering, use a simpler version of the
 		// merger which simply concatenates rows from each of the row groups.
 		// This is preferable because it makes the output deterministic, the
 		// heap merge may otherwise reorder rows across groups.
+		// Converted and filtered row groups must be read with their own row
+		// readers, the values of their column chunks are not converted and
+		// include the rows of pruned pages.
+		if converted || filtered {
+			return &concatRowGroup{&m.multiRowGroup}, nil
+		}
 		return &m.multiRowGroup, nil
 	}
 
@@ -70,6 +104,14 @@ func MergeRowGroups(rowGroups []RowGroup, options ...RowGroupOption) (RowGroup,
 	}
 
 	m.compare = compareRowsFuncOf(schema, m.sorting)
+
+	if len(config.SequenceColumn) > 0 {
+		v, err := newVersionedRowGroup(m, m.sorting, config)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
 	return m, nil
 }
 
@@ -179,6 +221,7 @@ func makeBufferedRowReaders(numReaders int, readerAt func(int) RowReader) []*buf
 
 	for i := range readers {
 		buffers[i].rows = readerAt(i)
+		buffers[i].index = i
 		readers[i] = &buffers[i]
 	}
 
@@ -272,8 +315,11 @@ func (m *mergedRowReader) ReadRows(rows []Row) (n int, err error) {
 	return n, err
 }
 
+// Less orders rows which compare equal by the index of their reader, so rows
+// of the first readers are produced first and the merge is stable.
 func (m *mergedRowReader) Less(i, j int) bool {
-	return m.compare(m.readers[i].head(), m.readers[j].head()) < 0
+	c := m.compare(m.readers[i].head(), m.readers[j].head())
+	return c < 0 || (c == 0 && m.readers[i].index < m.readers[j].index)
 }
 
 func (m *mergedRowReader) Len() int {
@@ -296,10 +342,11 @@ func (m *mergedRowReader) Pop() interface{} {
 }
 
 type bufferedRowReader struct {
-	rows RowReader
-	off  int32
-	end  int32
-	buf  [10]Row
+	rows  RowReader
+	index int
+	off   int32
+	end   int32
+	buf   [10]Row
 }
 
 func (r *bufferedRowReader) head() Row {
@@ -340,6 +387,7 @@ type mergeBuffer struct {
 	rows    []Rows
 	buffer  [][]Row
 	head    []int
+	order   []int
 	len     int
 	copy    [mergeBufferSize]Row
 }
@@ -369,6 +417,10 @@ func (m *mergeBuffer) setup(rows []Rows, compare func(Row, Row) int) {
 		m.buffer = append(m.buffer, b...)
 		m.head = append(m.head, make([]int, extra)...)
 	}
+	m.order = m.order[:0]
+	for i := range rows {
+		m.order = append(m.order, i)
+	}
 	m.len = size
 }
 
@@ -421,7 +473,10 @@ func (m *mergeBuffer) Less(i, j int) bool {
 	if len(y) == 0 {
 		return true
 	}
-	return m.compare(x[m.head[i]], y[m.head[j]]) == -1
+	// Rows which compare equal are ordered by the index of their row group, so
+	// the merge is stable.
+	c := m.compare(x[m.head[i]], y[m.head[j]])
+	return c < 0 || (c == 0 && m.order[i] < m.order[j])
 }
 
 func (m *mergeBuffer) Pop() interface{} {
@@ -435,8 +490,12 @@ func (m *mergeBuffer) Len() int {
 }
 
 func (m *mergeBuffer) Swap(i, j int) {
+	// The rows must be swapped with their buffers, fill reads the rows at the
+	// same index as the buffers it refills.
+	m.rows[i], m.rows[j] = m.rows[j], m.rows[i]
 	m.buffer[i], m.buffer[j] = m.buffer[j], m.buffer[i]
 	m.head[i], m.head[j] = m.head[j], m.head[i]
+	m.order[i], m.order[j] = m.order[j], m.order[i]
 }
 
 func (m *mergeBuffer) Push(x interface{}) {
@@ -490,7 +549,10 @@ func (m *mergeBuffer) read() (n int64) {
 			// There is still rows in this row group. Adjust  the heap
 			heap.Fix(m, 0)
 		} else {
-			heap.Pop(m)
+			// The buffered rows are exhausted but the row group may have more
+			// rows (reads stop at page boundaries), which must be compared to
+			// the rows of other row groups before merging further.
+			break
 		}
 	}
 	return
@@ -0,0 +1,145 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// castColumnType builds the casting plan of a column which has different types
+// in the schema of a merged row group and in one of the row groups being
+// merged.
+//
+// Type.ConvertValue only looks at the physical types, and would silently
+// truncate or pad FIXED_LEN_BYTE_ARRAY values of different lengths, or convert
+// DECIMAL values without adjusting their scale. Those casts are validated when
+// the row groups are merged, so impossible casts are reported before any rows
+// were read, and DECIMAL values are rescaled to the target type.
+func castColumnType(targetType, sourceType Type) (conversionFunc, error) {
+	targetDecimal := decimalTypeOf(targetType)
+	sourceDecimal := decimalTypeOf(sourceType)
+
+	switch {
+	case targetDecimal != nil && sourceDecimal != nil:
+		return castDecimal(targetType.Kind(), targetType.Length(), targetDecimal, sourceDecimal)
+	case targetDecimal != nil:
+		return nil, fmt.Errorf("only DECIMAL values can be cast to %s", targetDecimal)
+	case sourceDecimal != nil:
+		return nil, fmt.Errorf("%s values can only be cast to DECIMAL types", sourceDecimal)
+	}
+
+	if targetType.Kind() == FixedLenByteArray && sourceType.Kind() == FixedLenByteArray {
+		if targetLength, sourceLength := targetType.Length(), sourceType.Length(); targetLength != sourceLength {
+			return nil, fmt.Errorf("values of %d bytes cannot be cast to FIXED_LEN_BYTE_ARRAY(%d)", sourceLength, targetLength)
+		}
+	}
+
+	return convertToType(targetType, sourceType), nil
+}
+
+func decimalTypeOf(t Type) *format.DecimalType {
+	if lt := t.LogicalType(); lt != nil {
+		return lt.Decimal
+	}
+	return nil
+}
+
+// castDecimal returns a conversion function rescaling decimal values to the
+// target scale, and encoding them in the target physical type. BYTE_ARRAY
+// values have no fixed length, they hold the minimal two's complement
+// representation of the unscaled value, which must fit in the precision of the
+// target type.
+//
+// Reducing the scale would lose precision, and the target type must have at
+// least as many digits before the decimal point as the source type, which
+// guarantees that all values of the source type can be represented.
+func castDecimal(kind Kind, length int, target, source *format.DecimalType) (conversionFunc, error) {
+	if target.Scale < source.Scale {
+		return nil, fmt.Errorf("reducing the scale of %s values to %s would lose precision", source, target)
+	}
+	if target.Precision-target.Scale < source.Precision-source.Scale {
+		return nil, fmt.Errorf("%s values may not fit in %s", source, target)
+	}
+
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(target.Scale-source.Scale)), nil)
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(target.Precision)), nil)
+
+	return func(column []Value) error {
+		for i, v := range column {
+			if v.IsNull() {
+				continue
+			}
+			unscaled := decimalUnscaledValueOf(v)
+			unscaled.Mul(unscaled, factor)
+
+			var err error
+			switch kind {
+			case Int32:
+				if !unscaled.IsInt64() || unscaled.Int64() < math.MinInt32 || unscaled.Int64() > math.MaxInt32 {
+					err = errDecimalOverflow
+				} else {
+					v = v.convertToInt32(int32(unscaled.Int64()))
+				}
+			case Int64:
+				if !unscaled.IsInt64() {
+					err = errDecimalOverflow
+				} else {
+					v = v.convertToInt64(unscaled.Int64())
+				}
+			case ByteArray:
+				if new(big.Int).Abs(unscaled).Cmp(limit) >= 0 {
+					err = errDecimalPrecision
+				} else {
+					var b []byte
+					if b, err = decimalBytesOf(unscaled, decimalByteLength(unscaled)); err == nil {
+						v = v.convertToByteArray(b)
+					}
+				}
+			default:
+				var b []byte
+				if b, err = decimalBytesOf(unscaled, length); err == nil {
+					v = v.convertToFixedLenByteArray(b)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("%s to %s: %s: %w: %w", source, target, formatDecimal(column[i], int(source.Scale)), err, ErrInvalidConversion)
+			}
+			column[i] = v
+		}
+		return nil
+	}, nil
+}
+
+var (
+	errDecimalOverflow  = errors.New("value overflows the physical type")
+	errDecimalPrecision = errors.New("value exceeds the decimal precision")
+)
+
+// decimalByteLength returns the minimal number of bytes holding the big-endian
+// two's complement representation of unscaled.
+func decimalByteLength(unscaled *big.Int) int {
+	if unscaled.Sign() < 0 {
+		return new(big.Int).Not(unscaled).BitLen()/8 + 1
+	}
+	return unscaled.BitLen()/8 + 1
+}
+
+// decimalBytesOf returns the big-endian two's complement representation of
+// unscaled in a byte array of the given length.
+func decimalBytesOf(unscaled *big.Int, length int) ([]byte, error) {
+	b := make([]byte, length)
+	if unscaled.Sign() >= 0 {
+		if unscaled.BitLen() >= 8*length {
+			return nil, errDecimalOverflow
+		}
+		return unscaled.FillBytes(b), nil
+	}
+	if magnitude := new(big.Int).Not(unscaled); magnitude.BitLen() >= 8*length {
+		return nil, errDecimalOverflow
+	}
+	complement := new(big.Int).Lsh(big.NewInt(1), uint(8*length))
+	return complement.Add(complement, unscaled).FillBytes(b), nil
+}
@@ -0,0 +1,216 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// PageFilter associates a predicate evaluated on the page index of a column
+// with the path to the column, see MergePageFilter.
+type PageFilter struct {
+	Path      []string
+	Predicate func(min, max []byte, nullPage bool) bool
+}
+
+// filterRowGroupPages replaces the row groups with views exposing only the rows
+// of pages selected by the filters. The row groups must all have the given
+// schema. The function returns true if the pages of any of the row groups were
+// pruned.
+func filterRowGroupPages(schema *Schema, rowGroups []RowGroup, filters []PageFilter) (bool, error) {
+	if len(filters) == 0 {
+		return false, nil
+	}
+
+	columns := make([]int, len(filters))
+	for i, filter := range filters {
+		leaf, ok := schema.Lookup(filter.Path...)
+		if !ok {
+			return false, fmt.Errorf("cannot merge row groups: page filter column %q does not exist", columnPath(filter.Path))
+		}
+		columns[i] = leaf.ColumnIndex
+	}
+
+	filtered := false
+	for i, rowGroup := range rowGroups {
+		numRows := rowGroup.NumRows()
+		chunks := rowGroup.ColumnChunks()
+		selection := RowSelection{{Start: 0, End: numRows}}
+
+		for j, filter := range filters {
+			selection = selection.Intersect(FilterPages(chunks[columns[j]], filter.Predicate))
+		}
+
+		if selection.NumRows() < numRows {
+			rowGroups[i] = newFilteredRowGroup(rowGroup, selection)
+			filtered = true
+		}
+	}
+	return filtered, nil
+}
+
+// filteredRowGroup is a view of a row group which only exposes the rows of a
+// selection. The column chunks hold the values of the selected rows, they are
+// materialized in memory the first time their content is accessed.
+type filteredRowGroup struct {
+	RowGroup
+	selection RowSelection
+	columns   []ColumnChunk
+	once      sync.Once
+	buffer    *Buffer
+	bufErr    error
+}
+
+func newFilteredRowGroup(rowGroup RowGroup, selection RowSelection) *filteredRowGroup {
+	g := &filteredRowGroup{RowGroup: rowGroup, selection: selection}
+	g.columns = make([]ColumnChunk, len(rowGroup.ColumnChunks()))
+	for i, c := range rowGroup.ColumnChunks() {
+		columnIndex := i
+		g.columns[i] = &lazyColumnChunk{
+			typ:    c.Type(),
+			column: columnIndex,
+			load: func() (ColumnChunk, error) {
+				buffer, err := g.materialize()
+				if err != nil {
+					return nil, fmt.Errorf("filtering pages of merged rows: %w", err)
+				}
+				return buffer.ColumnChunks()[columnIndex], nil
+			},
+		}
+	}
+	return g
+}
+
+func (g *filteredRowGroup) NumRows() int64 { return g.selection.NumRows() }
+
+func (g *filteredRowGroup) ColumnChunks() []ColumnChunk { return g.columns }
+
+// materialize returns a buffer holding the selected rows, which is used to
+// serve the column chunks.
+func (g *filteredRowGroup) materialize() (*Buffer, error) {
+	g.once.Do(func() {
+		rows := g.Rows()
+		defer rows.Close()
+		g.buffer = NewBuffer(g.Schema())
+		_, g.bufErr = CopyRows(g.buffer, rows)
+	})
+	return g.buffer, g.bufErr
+}
+
+func (g *filteredRowGroup) Rows() Rows {
+	rows := g.RowGroup.Rows()
+	return &filteredRowGroupRows{
+		rows:      rows,
+		selection: g.selection,
+		reader:    selectRowReader{rows: rows, selection: g.selection},
+	}
+}
+
+type filteredRowGroupRows struct {
+	rows      Rows
+	selection RowSelection
+	reader    selectRowReader
+}
+
+func (r *filteredRowGroupRows) ReadRows(rows []Row) (int, error) {
+	return r.reader.ReadRows(rows)
+}
+
+func (r *filteredRowGroupRows) SeekToRow(rowIndex int64) error {
+	if rowIndex < 0 {
+		return fmt.Errorf("SeekToRow: cannot seek to negative row index %d", rowIndex)
+	}
+	for i, rowRange := range r.selection {
+		if rowIndex < rowRange.NumRows() {
+			physicalRowIndex := rowRange.Start + rowIndex
+			if err := r.rows.SeekToRow(physicalRowIndex); err != nil {
+				return err
+			}
+			r.reader = selectRowReader{rows: r.rows, selection: r.selection[i:], rowIndex: physicalRowIndex}
+			return nil
+		}
+		rowIndex -= rowRange.NumRows()
+	}
+	r.reader.selection = nil
+	return nil
+}
+
+func (r *filteredRowGroupRows) Close() error { return r.rows.Close() }
+
+func (r *filteredRowGroupRows) Schema() *Schema { return r.rows.Schema() }
+
+// concatRowGroup is a multi row group which reads rows from each of its row
+// groups instead of reading values from its column chunks, which is necessary
+// when the row groups expose a subset of the rows of their column chunks, or
+// convert the values of their column chunks.
+type concatRowGroup struct{ *multiRowGroup }
+
+func (c *concatRowGroup) Rows() Rows {
+	rows := make([]Rows, len(c.rowGroups))
+	for i, rowGroup := range c.rowGroups {
+		rows[i] = rowGroup.Rows()
+	}
+	return &concatRows{rowGroups: c.rowGroups, rows: rows, schema: c.schema}
+}
+
+type concatRows struct {
+	rowGroups []RowGroup
+	rows      []Rows
+	index     int
+	schema    *Schema
+}
+
+func (r *concatRows) ReadRows(rows []Row) (int, error) {
+	for r.index < len(r.rows) {
+		n, err := r.rows[r.index].ReadRows(rows)
+		if err == io.EOF {
+			r.index++
+			err = nil
+		}
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+	return 0, io.EOF
+}
+
+func (r *concatRows) SeekToRow(rowIndex int64) error {
+	if rowIndex < 0 {
+		return fmt.Errorf("SeekToRow: cannot seek to negative row index %d", rowIndex)
+	}
+	r.index = len(r.rows)
+	for i, rowGroup := range r.rowGroups {
+		switch numRows := rowGroup.NumRows(); {
+		case r.index < i:
+			// Row groups after the seek position must be rewound in case they
+			// had already been read.
+			if err := r.rows[i].SeekToRow(0); err != nil {
+				return err
+			}
+		case rowIndex < numRows:
+			if err := r.rows[i].SeekToRow(rowIndex); err != nil {
+				return err
+			}
+			r.index = i
+		default:
+			rowIndex -= numRows
+		}
+	}
+	return nil
+}
+
+func (r *concatRows) Close() (lastErr error) {
+	for _, rows := range r.rows {
+		if err := rows.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (r *concatRows) Schema() *Schema { return r.schema }
+
+var (
+	_ Rows = (*filteredRowGroupRows)(nil)
+	_ Rows = (*concatRows)(nil)
+)
@@ -2,6 +2,7 @@ package parquet_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -475,6 +476,62 @@ func TestMergeRowGroupsCursorsAreClosed(t *testing.T) {
 	}
 }
 
+func TestMergeRowGroupsWriteRowsToSmallPages(t *testing.T) {
+	type Record struct {
+		A string `parquet:"a"`
+	}
+	sorting := parquet.SortingColumns(parquet.Ascending("a"))
+	prng := rand.New(rand.NewSource(0))
+
+	// Reads of rows stop at page boundaries, so with small pages the rows of
+	// each row group are buffered in multiple batches during the merge.
+	rowGroups := make([]parquet.RowGroup, 10)
+	for i := range rowGroups {
+		records := make([]Record, 1000)
+		for j := range records {
+			records[j].A = fmt.Sprintf("%016x", prng.Uint64())
+		}
+		sort.Slice(records, func(i, j int) bool { return records[i].A < records[j].A })
+
+		buf := new(bytes.Buffer)
+		err := parquet.Write(buf, records, parquet.PageBufferSize(4096), parquet.SortingWriterConfig(sorting))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rowGroups[i] = f.RowGroups()[0]
+	}
+
+	merged, err := parquet.MergeRowGroups(rowGroups, parquet.SortingRowGroupConfig(sorting))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer := parquet.NewGenericBuffer[Record]()
+	rows := merged.Rows()
+	defer rows.Close()
+	if _, err := parquet.CopyRows(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	records := make([]Record, buffer.NumRows())
+	reader := parquet.NewGenericRowGroupReader[Record](buffer)
+	defer reader.Close()
+	if n, err := reader.Read(records); n != len(records) {
+		t.Fatalf("reading merged rows: %d/%d: %v", n, len(records), err)
+	}
+	if len(records) != 10*1000 {
+		t.Fatalf("wrong number of merged rows: %d", len(records))
+	}
+	for i := 1; i < len(records); i++ {
+		if records[i].A < records[i-1].A {
+			t.Fatalf("rows at index %d and %d are not sorted: %q > %q", i-1, i, records[i-1].A, records[i].A)
+		}
+	}
+}
+
 func TestMergeRowGroupsSeekToRow(t *testing.T) {
 	type model struct {
 		A int
@@ -676,3 +733,227 @@ func BenchmarkMergeFiles(b *testing.B) {
 		})
 	}
 }
+
+func TestMergeRowGroupsPageFilter(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	options := []parquet.RowGroupOption{
+		parquet.SortingRowGroupConfig(
+			parquet.SortingColumns(parquet.Ascending("id")),
+		),
+	}
+
+	// Each file contains every other ID so the merge interleaves their rows.
+	rowGroups := make([]parquet.RowGroup, 2)
+	for i := range rowGroups {
+		rows := make([]Row, 1000)
+		for j := range rows {
+			rows[j].ID = int64(2*j + i)
+		}
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewGenericWriter[Row](buffer,
+			parquet.PageBufferSize(256),
+			parquet.SortingWriterConfig(parquet.SortingColumns(parquet.Ascending("id"))),
+		)
+		if _, err := writer.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rowGroups[i] = f.RowGroups()[0]
+	}
+
+	filter := parquet.MergePageFilter(func(min, max []byte, nullPage bool) bool {
+		minID := int64(binary.LittleEndian.Uint64(min))
+		maxID := int64(binary.LittleEndian.Uint64(max))
+		return !nullPage && minID <= 1100 && maxID >= 900
+	}, "id")
+
+	for _, test := range []struct {
+		scenario string
+		options  []parquet.RowGroupOption
+	}{
+		{scenario: "concat", options: []parquet.RowGroupOption{filter}},
+		{scenario: "sorted", options: append([]parquet.RowGroupOption{filter}, options...)},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			merged, err := parquet.MergeRowGroups(rowGroups, test.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if numRows := merged.NumRows(); numRows >= 2000 {
+				t.Fatalf("pages were not pruned: %d rows", numRows)
+			}
+			for i, chunk := range merged.ColumnChunks() {
+				if numValues := chunk.NumValues(); numValues != merged.NumRows() {
+					t.Errorf("column chunk %d holds values of pruned pages: want=%d got=%d", i, merged.NumRows(), numValues)
+				}
+			}
+
+			rows := merged.Rows()
+			defer rows.Close()
+
+			var ids []int64
+			for {
+				buf := make([]parquet.Row, 10)
+				n, err := rows.ReadRows(buf)
+				for _, row := range buf[:n] {
+					ids = append(ids, row[0].Int64())
+				}
+				if err != nil {
+					if !errors.Is(err, io.EOF) {
+						t.Fatal(err)
+					}
+					break
+				}
+			}
+
+			if int64(len(ids)) != merged.NumRows() {
+				t.Errorf("wrong number of rows read: want=%d got=%d", merged.NumRows(), len(ids))
+			}
+			matches := 0
+			for _, id := range ids {
+				if id >= 900 && id <= 1100 {
+					matches++
+				}
+			}
+			if matches != 201 {
+				t.Errorf("rows matching the filter were pruned: want=201 got=%d", matches)
+			}
+			if test.scenario == "sorted" && !sort.SliceIsSorted(ids, func(i, j int) bool { return ids[i] < ids[j] }) {
+				t.Errorf("merged rows are not sorted")
+			}
+
+			if test.scenario == "sorted" {
+				return // sorted merges cannot seek backward
+			}
+			if err := rows.SeekToRow(1); err != nil {
+				t.Fatal(err)
+			}
+			buf := make([]parquet.Row, 1)
+			if _, err := rows.ReadRows(buf); err != nil {
+				t.Fatal(err)
+			}
+			if id := buf[0][0].Int64(); id != ids[1] {
+				t.Errorf("wrong row after seeking: want=%d got=%d", ids[1], id)
+			}
+		})
+	}
+
+	_, err := parquet.MergeRowGroups(rowGroups, parquet.MergePageFilter(nil, "missing"))
+	if err == nil {
+		t.Error("expected an error for a page filter on a missing column")
+	}
+}
+
+func TestMergeRowGroupsCastingPlan(t *testing.T) {
+	newRowGroup := func(node parquet.Node, values ...parquet.Value) parquet.RowGroup {
+		buffer := parquet.NewBuffer(parquet.NewSchema("test", parquet.Group{"amount": node}))
+		for _, v := range values {
+			if _, err := buffer.WriteRows([]parquet.Row{{v.Level(0, 0, 0)}}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return buffer
+	}
+
+	cents := newRowGroup(parquet.Decimal(2, 9, parquet.Int32Type), parquet.ValueOf(int32(-1234)), parquet.ValueOf(int32(500)))
+	millis := newRowGroup(parquet.Decimal(3, 10, parquet.Int64Type), parquet.ValueOf(int64(42)))
+
+	t.Run("rescale", func(t *testing.T) {
+		for _, target := range []parquet.Node{
+			parquet.Decimal(4, 18, parquet.Int64Type),
+			parquet.Decimal(4, 20, parquet.FixedLenByteArrayType(9)),
+			parquet.Decimal(4, 20, parquet.ByteArrayType),
+		} {
+			schema := parquet.NewSchema("test", parquet.Group{"amount": target})
+			merged, err := parquet.MergeRowGroups([]parquet.RowGroup{cents, millis}, schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows := merged.Rows()
+			defer rows.Close()
+
+			var got []any
+			for {
+				buf := make([]parquet.Row, 2)
+				n, err := rows.ReadRows(buf)
+				for _, row := range buf[:n] {
+					got = append(got, row[0].GoValue(target))
+				}
+				if err != nil {
+					if !errors.Is(err, io.EOF) {
+						t.Fatal(err)
+					}
+					break
+				}
+			}
+			want := []any{"-12.3400", "5.0000", "0.0420"}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("%s: wrong values: want=%v got=%v", target, want, got)
+			}
+		}
+	})
+
+	t.Run("precision", func(t *testing.T) {
+		// The value has more digits than its declared precision, it cannot be
+		// represented in a BYTE_ARRAY column of the same precision.
+		source := newRowGroup(parquet.Decimal(2, 4, parquet.Int32Type), parquet.ValueOf(int32(123456)))
+		schema := parquet.NewSchema("test", parquet.Group{"amount": parquet.Decimal(2, 4, parquet.ByteArrayType)})
+		merged, err := parquet.MergeRowGroups([]parquet.RowGroup{source}, schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := merged.Rows()
+		defer rows.Close()
+		if _, err := rows.ReadRows(make([]parquet.Row, 1)); !errors.Is(err, parquet.ErrInvalidConversion) {
+			t.Fatalf("expected an invalid conversion error, got %v", err)
+		}
+	})
+
+	for _, test := range []struct {
+		scenario string
+		source   parquet.RowGroup
+		target   parquet.Node
+	}{
+		{
+			scenario: "reduce decimal scale",
+			source:   millis,
+			target:   parquet.Decimal(2, 10, parquet.Int64Type),
+		},
+		{
+			scenario: "decimal overflow",
+			source:   millis,
+			target:   parquet.Decimal(3, 9, parquet.Int32Type),
+		},
+		{
+			scenario: "decimal to integer",
+			source:   cents,
+			target:   parquet.Leaf(parquet.Int64Type),
+		},
+		{
+			scenario: "fixed length",
+			source:   newRowGroup(parquet.Leaf(parquet.FixedLenByteArrayType(8)), parquet.ValueOf([8]byte{})),
+			target:   parquet.Leaf(parquet.FixedLenByteArrayType(16)),
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			schema := parquet.NewSchema("test", parquet.Group{"amount": test.target})
+			_, err := parquet.MergeRowGroups([]parquet.RowGroup{test.source}, schema)
+			var convertError *parquet.ConvertError
+			if !errors.As(err, &convertError) {
+				t.Fatalf("expected a conversion error, got %v", err)
+			}
+			if convertError.Err == nil {
+				t.Errorf("conversion error does not explain why the cast is impossible: %v", err)
+			}
+		})
+	}
+}
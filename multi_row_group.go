@@ -7,10 +7,10 @@ import (
 // MultiRowGroup wraps multiple row groups to appear as if it was a single
 // RowGroup. RowGroups must have the same schema or it will error.
 func MultiRowGroup(rowGroups ...RowGroup) RowGroup {
-	return newMultiRowGroup(ReadModeSync, rowGroups...)
+	return newMultiRowGroup(DefaultFileConfig(), rowGroups...)
 }
 
-func newMultiRowGroup(pageReadMode ReadMode, rowGroups ...RowGroup) RowGroup {
+func newMultiRowGroup(config *FileConfig, rowGroups ...RowGroup) RowGroup {
 	if len(rowGroups) == 0 {
 		return &emptyRowGroup{}
 	}
@@ -27,7 +27,7 @@ func newMultiRowGroup(pageReadMode ReadMode, rowGroups ...RowGroup) RowGroup {
 	copy(rowGroupsCopy, rowGroups)
 
 	c := &multiRowGroup{
-		pageReadMode: pageReadMode,
+		config: config,
 	}
 	c.init(schema, rowGroupsCopy)
 	return c
@@ -90,10 +90,10 @@ func compatibleSchemaOf(rowGroups []RowGroup) (*Schema, error) {
 }
 
 type multiRowGroup struct {
-	schema       *Schema
-	rowGroups    []RowGroup
-	columns      []ColumnChunk
-	pageReadMode ReadMode
+	schema    *Schema
+	rowGroups []RowGroup
+	columns   []ColumnChunk
+	config    *FileConfig
 }
 
 func (c *multiRowGroup) NumRows() (numRows int64) {
@@ -109,7 +109,12 @@ func (c *multiRowGroup) SortingColumns() []SortingColumn { return nil }
 
 func (c *multiRowGroup) Schema() *Schema { return c.schema }
 
-func (c *multiRowGroup) Rows() Rows { return newRowGroupRows(c, c.pageReadMode) }
+func (c *multiRowGroup) Rows() Rows {
+	if c.config == nil {
+		return newRowGroupRows(c, ReadModeSync)
+	}
+	return newFileRowGroupRows(c, c.config)
+}
 
 type multiColumnChunk struct {
 	rowGroup *multiRowGroup
@@ -157,44 +162,7 @@ func (c *multiColumnChunk) BloomFilter() BloomFilter {
 type multiBloomFilter struct{ *multiColumnChunk }
 
 func (f multiBloomFilter) ReadAt(b []byte, off int64) (int, error) {
-	// TODO: add a test for this function
-	i := 0
-
-	for i < len(f.chunks) {
-		if r := f.chunks[i].BloomFilter(); r != nil {
-			size := r.Size()
-			if off < size {
-				break
-			}
-			off -= size
-		}
-		i++
-	}
-
-	if i == len(f.chunks) {
-		return 0, io.EOF
-	}
-
-	rn := int(0)
-	for len(b) > 0 {
-		if r := f.chunks[i].BloomFilter(); r != nil {
-			n, err := r.ReadAt(b, off)
-			rn += n
-			if err != nil {
-				return rn, err
-			}
-			if b = b[n:]; len(b) == 0 {
-				return rn, nil
-			}
-			off += int64(n)
-		}
-		i++
-	}
-
-	if i == len(f.chunks) {
-		return rn, io.EOF
-	}
-	return rn, nil
+	return f.filters().ReadAt(b, off)
 }
 
 func (f multiBloomFilter) Size() int64 {
@@ -218,6 +186,20 @@ func (f multiBloomFilter) Check(v Value) (bool, error) {
 	return false, nil
 }
 
+func (f multiBloomFilter) CheckMany(values []Value, found []bool) error {
+	return f.filters().CheckMany(values, found)
+}
+
+func (f multiBloomFilter) filters() bloomFilterSet {
+	filters := make(bloomFilterSet, 0, len(f.chunks))
+	for _, c := range f.chunks {
+		if b := c.BloomFilter(); b != nil {
+			filters = append(filters, b)
+		}
+	}
+	return filters
+}
+
 type multiPages struct {
 	pages  Pages
 	index  int
@@ -0,0 +1,125 @@
+package parquet
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+
+	"github.com/parquet-go/parquet-go/internal/unsafecast"
+	"github.com/parquet-go/parquet-go/sparse"
+)
+
+var (
+	netipAddrType   = reflect.TypeOf(netip.Addr{})
+	netipPrefixType = reflect.TypeOf(netip.Prefix{})
+)
+
+// netipPrefixSize is the size of the binary representation of prefixes: the 16
+// bytes of the address followed by the number of bits of the prefix.
+const netipPrefixSize = 17
+
+// isNetipColumn returns true if values of type t are stored in binary form in
+// columns of type typ, which is the case when the ip tag was used on fields of
+// type netip.Addr or netip.Prefix. Untagged fields of these types use the text
+// fallback and are stored in STRING columns.
+func isNetipColumn(t reflect.Type, typ Type) bool {
+	switch t {
+	case netipAddrType:
+		return typ.Kind() == FixedLenByteArray && typ.Length() == 16
+	case netipPrefixType:
+		return typ.Kind() == ByteArray && typ.LogicalType() == nil
+	}
+	return false
+}
+
+// netipAddrOf decodes the binary representation of addresses, which is the
+// value returned by As16: IPv4 addresses are stored as IPv4-mapped IPv6
+// addresses so the addresses of both families compare in the same space, and
+// are unmapped when read back.
+func netipAddrOf(b []byte) (netip.Addr, error) {
+	if len(b) != 16 {
+		return netip.Addr{}, fmt.Errorf("invalid ip address length: %d", len(b))
+	}
+	return netip.AddrFrom16([16]byte(b)).Unmap(), nil
+}
+
+// appendNetipPrefix appends the binary representation of prefix to b. The
+// address comes first and the number of bits last, so prefixes compared as
+// bytes are ordered by address and then by length. The zero Prefix is
+// represented by an empty byte array.
+func appendNetipPrefix(b []byte, prefix netip.Prefix) []byte {
+	if !prefix.IsValid() {
+		return b
+	}
+	addr := prefix.Addr().As16()
+	return append(append(b, addr[:]...), byte(prefix.Bits()))
+}
+
+func netipPrefixOf(b []byte) (netip.Prefix, error) {
+	switch len(b) {
+	case 0:
+		return netip.Prefix{}, nil
+	case netipPrefixSize:
+		addr := netip.AddrFrom16([16]byte(b[:16])).Unmap()
+		prefix := netip.PrefixFrom(addr, int(b[16]))
+		if !prefix.IsValid() {
+			return netip.Prefix{}, fmt.Errorf("invalid ip prefix length: /%d", b[16])
+		}
+		return prefix, nil
+	default:
+		return netip.Prefix{}, fmt.Errorf("invalid ip prefix length: %d", len(b))
+	}
+}
+
+func assignNetipValue(dst reflect.Value, b []byte) error {
+	switch dst.Type() {
+	case netipAddrType:
+		addr, err := netipAddrOf(b)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(addr))
+	case netipPrefixType:
+		prefix, err := netipPrefixOf(b)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(prefix))
+	}
+	return nil
+}
+
+func writeRowsFuncOfNetip(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	if t == netipAddrType {
+		writer := writeRowsFuncOfRequired(reflect.TypeOf([16]byte{}), schema, path)
+
+		return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+			if rows.Len() == 0 {
+				return writer(columns, rows, levels)
+			}
+			addrs := make([][16]byte, rows.Len())
+			for i := range addrs {
+				addrs[i] = (*(*netip.Addr)(rows.Index(i))).As16()
+			}
+			return writer(columns, makeArrayOf(addrs), levels)
+		}
+	}
+
+	writer := writeRowsFuncOfRequired(reflect.TypeOf(""), schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writer(columns, rows, levels)
+		}
+		// The buffer is allocated upfront so the strings referencing it remain
+		// valid, the values are copied when written to the column buffer.
+		buffer := make([]byte, 0, netipPrefixSize*rows.Len())
+		prefixes := make([]string, rows.Len())
+		for i := range prefixes {
+			offset := len(buffer)
+			buffer = appendNetipPrefix(buffer, *(*netip.Prefix)(rows.Index(i)))
+			prefixes[i] = unsafecast.BytesToString(buffer[offset:])
+		}
+		return writer(columns, makeArrayString(prefixes), levels)
+	}
+}
@@ -0,0 +1,93 @@
+package parquet_test
+
+import (
+	"bytes"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestNetipBinaryColumns(t *testing.T) {
+	type Flow struct {
+		Src     netip.Addr   `parquet:"src,ip"`
+		Dst     *netip.Addr  `parquet:"dst,ip,optional"`
+		Network netip.Prefix `parquet:"network,ip"`
+	}
+
+	addr := func(s string) *netip.Addr {
+		a := netip.MustParseAddr(s)
+		return &a
+	}
+
+	rows := []Flow{
+		{Src: netip.MustParseAddr("10.0.0.1"), Dst: addr("2001:db8::1"), Network: netip.MustParsePrefix("10.0.0.0/8")},
+		{Src: netip.MustParseAddr("192.168.1.20"), Network: netip.MustParsePrefix("2001:db8::/32")},
+		{Src: netip.MustParseAddr("fe80::1"), Dst: addr("172.16.0.3")},
+		{Src: netip.MustParseAddr("10.0.0.0"), Dst: addr("::"), Network: netip.MustParsePrefix("10.0.0.0/16")},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := f.Schema().Fields()
+	for i, want := range []parquet.Kind{parquet.FixedLenByteArray, parquet.FixedLenByteArray, parquet.ByteArray} {
+		if typ := fields[i].Type(); typ.Kind() != want || typ.LogicalType() != nil {
+			t.Errorf("wrong type of column %q: %s", fields[i].Name(), typ)
+		}
+	}
+
+	values, err := parquet.Read[Flow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, rows) {
+		t.Errorf("rows mismatch:\nwant = %v\ngot  = %v", rows, values)
+	}
+
+	// Values compare as bytes in the order of addresses, then prefix lengths.
+	index, err := f.RowGroups()[0].ColumnChunks()[0].ColumnIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	minAddr, maxAddr := netip.AddrFrom16([16]byte(index.MinValue(0).ByteArray())), netip.AddrFrom16([16]byte(index.MaxValue(0).ByteArray()))
+	if minAddr.Unmap() != netip.MustParseAddr("10.0.0.0") || maxAddr != netip.MustParseAddr("fe80::1") {
+		t.Errorf("wrong statistics of addresses: min=%s max=%s", minAddr, maxAddr)
+	}
+
+	// Sorting prefixes orders them by address, then by length.
+	buf.Reset()
+	writer := parquet.NewSortingWriter[Flow](buf, 10,
+		parquet.SortingWriterConfig(
+			parquet.SortingColumns(parquet.Ascending("network")),
+		),
+	)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	sorted, err := parquet.Read[Flow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []netip.Prefix{
+		{},
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("10.0.0.0/16"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+	for i, row := range sorted {
+		if row.Network != want[i] {
+			t.Errorf("wrong prefix at row %d: want=%s got=%s", i, want[i], row.Network)
+		}
+	}
+}
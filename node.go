@@ -1,6 +1,7 @@
 package parquet
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"unicode"
@@ -429,19 +430,37 @@ func listElementOf(node Node) Node {
 	panic("node with logical type LIST is not composed of a repeated .list.element")
 }
 
+// mapKeyValueOf returns the repeated group holding the keys and values of the
+// map node. The group is usually named "key_value", but files written by older
+// parquet writers may use other names (e.g. "map"), so it is matched by its
+// structure rather than by name.
 func mapKeyValueOf(node Node) Node {
 	if !node.Leaf() && (node.Required() || node.Optional()) {
-		if keyValue := fieldByName(node, "key_value"); keyValue != nil && !keyValue.Leaf() && keyValue.Repeated() {
-			k := fieldByName(keyValue, "key")
-			v := fieldByName(keyValue, "value")
-			if k != nil && v != nil && k.Required() {
-				return keyValue
+		if fields := node.Fields(); len(fields) == 1 {
+			if keyValue := fields[0]; !keyValue.Leaf() && keyValue.Repeated() {
+				k := fieldByName(keyValue, "key")
+				v := fieldByName(keyValue, "value")
+				if k != nil && v != nil && k.Required() {
+					return keyValue
+				}
 			}
 		}
 	}
 	panic("node with logical type MAP is not composed of a repeated .key_value group with key and value fields")
 }
 
+// mapKeyValuePath returns the path of the repeated group holding the keys and
+// values of the map at the given path in schema, using the name of the group
+// in the schema, or "key_value" if the path does not exist.
+func mapKeyValuePath(schema *Schema, path columnPath) columnPath {
+	if node := nodeByPath(schema, path); node != nil && !node.Leaf() {
+		if fields := node.Fields(); len(fields) == 1 && fields[0].Repeated() {
+			return path.append(fields[0].Name())
+		}
+	}
+	return path.append("key_value")
+}
+
 func encodingOf(node Node) encoding.Encoding {
 	encoding := node.Encoding()
 	// The parquet-format documentation states that the
@@ -477,6 +496,133 @@ func fieldByName(node Node, name string) Field {
 	return nil
 }
 
+// nodeByPath returns the node at the given path in node, or nil if the path
+// does not exist.
+func nodeByPath(node Node, path []string) Node {
+	for _, name := range path {
+		field := fieldByName(node, name)
+		if field == nil {
+			return nil
+		}
+		node = field
+	}
+	return node
+}
+
+// NodeCompareFlags is a set of flags configuring the strictness of node
+// comparisons made by CompareNodes.
+type NodeCompareFlags uint
+
+const (
+	// IgnoreFieldOrder matches fields of groups by name instead of position.
+	IgnoreFieldOrder NodeCompareFlags = 1 << iota
+
+	// IgnoreLogicalTypes compares only the physical types of leaf nodes.
+	IgnoreLogicalTypes
+
+	// IgnoreRepetition skips comparing whether nodes are required, optional,
+	// or repeated.
+	IgnoreRepetition
+
+	// CompareFieldIDs also requires the field IDs of nodes to be equal.
+	CompareFieldIDs
+)
+
+// EqualNodes returns true if node1 and node2 are equal, which means that they
+// have the same fields in the same order, with the same types and repetition.
+// Field IDs are not compared.
+func EqualNodes(node1, node2 Node) bool {
+	return CompareNodes(node1, node2, 0) == nil
+}
+
+// SameNodes is like EqualNodes but ignores the order of fields in groups.
+func SameNodes(node1, node2 Node) bool {
+	return CompareNodes(node1, node2, IgnoreFieldOrder) == nil
+}
+
+// CompareNodes compares node1 and node2 with the strictness configured by
+// flags. The function returns nil if the nodes are equal, or a
+// *NodeMismatchError describing the first difference otherwise.
+//
+// Without flags, the comparison is the same as EqualNodes: groups must have
+// the same fields in the same order, and leaves must have the same physical
+// and logical types, and the same repetition. Logical types of groups (e.g.
+// LIST or MAP) are not compared since they are not retained on the columns of
+// parquet files.
+func CompareNodes(node1, node2 Node, flags NodeCompareFlags) error {
+	return compareNodes(nil, node1, node2, flags)
+}
+
+func compareNodes(path columnPath, node1, node2 Node, flags NodeCompareFlags) error {
+	mismatch := func(format string, args ...any) error {
+		return &NodeMismatchError{Path: path, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	if flags&CompareFieldIDs != 0 && node1.ID() != node2.ID() {
+		return mismatch("field IDs differ: %d != %d", node1.ID(), node2.ID())
+	}
+
+	if flags&IgnoreRepetition == 0 && !repetitionsAreEqual(node1, node2) {
+		return mismatch("repetitions differ: %s != %s", fieldRepetitionTypeOf(node1), fieldRepetitionTypeOf(node2))
+	}
+
+	if node1.Leaf() != node2.Leaf() {
+		if node1.Leaf() {
+			return mismatch("leaf node compared to a group")
+		}
+		return mismatch("group compared to a leaf node")
+	}
+
+	if node1.Leaf() {
+		type1, type2 := node1.Type(), node2.Type()
+		switch {
+		case type1.Kind() != type2.Kind():
+			return mismatch("physical types differ: %s != %s", type1.Kind(), type2.Kind())
+		case type1.Length() != type2.Length():
+			return mismatch("type lengths differ: %d != %d", type1.Length(), type2.Length())
+		case flags&IgnoreLogicalTypes == 0 && !reflect.DeepEqual(type1.LogicalType(), type2.LogicalType()):
+			return mismatch("logical types differ: %s != %s", annotationOf(node1), annotationOf(node2))
+		}
+		return nil
+	}
+
+	fields1 := node1.Fields()
+	fields2 := node2.Fields()
+
+	if flags&IgnoreFieldOrder != 0 {
+		for _, f1 := range fields1 {
+			f2 := fieldByName(node2, f1.Name())
+			if f2 == nil {
+				return mismatch("field %q is missing in the second node", f1.Name())
+			}
+			if err := compareNodes(path.append(f1.Name()), f1, f2, flags); err != nil {
+				return err
+			}
+		}
+		for _, f2 := range fields2 {
+			if fieldByName(node1, f2.Name()) == nil {
+				return mismatch("field %q is missing in the first node", f2.Name())
+			}
+		}
+		return nil
+	}
+
+	for i := 0; i < len(fields1) && i < len(fields2); i++ {
+		f1, f2 := fields1[i], fields2[i]
+		if f1.Name() != f2.Name() {
+			return mismatch("field names at index %d differ: %q != %q", i, f1.Name(), f2.Name())
+		}
+		if err := compareNodes(path.append(f1.Name()), f1, f2, flags); err != nil {
+			return err
+		}
+	}
+
+	if len(fields1) != len(fields2) {
+		return mismatch("numbers of fields differ: %d != %d", len(fields1), len(fields2))
+	}
+	return nil
+}
+
 func nodesAreEqual(node1, node2 Node) bool {
 	if node1.Leaf() {
 		return node2.Leaf() && leafNodesAreEqual(node1, node2)
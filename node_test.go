@@ -0,0 +1,143 @@
+package parquet_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestCompareNodes(t *testing.T) {
+	type RowA struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	type RowB struct {
+		Name string `parquet:"name"`
+		ID   int64  `parquet:"id"`
+	}
+
+	schemaA := parquet.SchemaOf(RowA{})
+	schemaB := parquet.SchemaOf(RowB{})
+
+	tests := []struct {
+		scenario string
+		node1    parquet.Node
+		node2    parquet.Node
+		flags    parquet.NodeCompareFlags
+		path     []string
+		reason   string
+	}{
+		{
+			scenario: "equal",
+			node1:    schemaA,
+			node2:    parquet.SchemaOf(RowA{}),
+		},
+		{
+			scenario: "field order",
+			node1:    schemaA,
+			node2:    schemaB,
+			reason:   `field names at index 0 differ: "id" != "name"`,
+		},
+		{
+			scenario: "ignore field order",
+			node1:    schemaA,
+			node2:    schemaB,
+			flags:    parquet.IgnoreFieldOrder,
+		},
+		{
+			scenario: "missing field",
+			node1:    parquet.Group{"a": parquet.String(), "b": parquet.String()},
+			node2:    parquet.Group{"a": parquet.String()},
+			flags:    parquet.IgnoreFieldOrder,
+			reason:   `field "b" is missing in the second node`,
+		},
+		{
+			scenario: "logical types",
+			node1:    parquet.Group{"a": parquet.Group{"b": parquet.String()}},
+			node2:    parquet.Group{"a": parquet.Group{"b": parquet.Leaf(parquet.ByteArrayType)}},
+			path:     []string{"a", "b"},
+			reason:   "logical types differ: STRING != ",
+		},
+		{
+			scenario: "ignore logical types",
+			node1:    parquet.Group{"a": parquet.Group{"b": parquet.String()}},
+			node2:    parquet.Group{"a": parquet.Group{"b": parquet.Leaf(parquet.ByteArrayType)}},
+			flags:    parquet.IgnoreLogicalTypes,
+		},
+		{
+			scenario: "physical types",
+			node1:    parquet.Group{"a": parquet.Int(32)},
+			node2:    parquet.Group{"a": parquet.Int(64)},
+			flags:    parquet.IgnoreLogicalTypes,
+			path:     []string{"a"},
+			reason:   "physical types differ: INT32 != INT64",
+		},
+		{
+			scenario: "repetition",
+			node1:    parquet.Group{"a": parquet.Optional(parquet.String())},
+			node2:    parquet.Group{"a": parquet.String()},
+			path:     []string{"a"},
+			reason:   "repetitions differ: OPTIONAL != REQUIRED",
+		},
+		{
+			scenario: "ignore repetition",
+			node1:    parquet.Group{"a": parquet.Optional(parquet.String())},
+			node2:    parquet.Group{"a": parquet.String()},
+			flags:    parquet.IgnoreRepetition,
+		},
+		{
+			scenario: "ignore field ids",
+			node1:    parquet.Group{"a": parquet.FieldID(parquet.String(), 1)},
+			node2:    parquet.Group{"a": parquet.FieldID(parquet.String(), 2)},
+		},
+		{
+			scenario: "compare field ids",
+			node1:    parquet.Group{"a": parquet.FieldID(parquet.String(), 1)},
+			node2:    parquet.Group{"a": parquet.FieldID(parquet.String(), 2)},
+			flags:    parquet.CompareFieldIDs,
+			path:     []string{"a"},
+			reason:   "field IDs differ: 1 != 2",
+		},
+		{
+			scenario: "group and leaf",
+			node1:    parquet.Group{"a": parquet.Group{"b": parquet.String()}},
+			node2:    parquet.Group{"a": parquet.String()},
+			path:     []string{"a"},
+			reason:   "group compared to a leaf node",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			err := parquet.CompareNodes(test.node1, test.node2, test.flags)
+			if test.reason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var mismatch *parquet.NodeMismatchError
+			if !errors.As(err, &mismatch) {
+				t.Fatalf("expected *parquet.NodeMismatchError but got %v", err)
+			}
+			if mismatch.Reason != test.reason {
+				t.Errorf("reason mismatch:\nwant = %s\ngot  = %s", test.reason, mismatch.Reason)
+			}
+			if !slices.Equal(mismatch.Path, test.path) {
+				t.Errorf("path mismatch: want=%q got=%q", test.path, mismatch.Path)
+			}
+		})
+	}
+
+	if !parquet.EqualNodes(schemaA, parquet.SchemaOf(RowA{})) {
+		t.Error("EqualNodes returned false for equal schemas")
+	}
+	if parquet.EqualNodes(schemaA, schemaB) {
+		t.Error("EqualNodes returned true for schemas with different field order")
+	}
+	if !parquet.SameNodes(schemaA, schemaB) {
+		t.Error("SameNodes returned false for schemas with different field order")
+	}
+}
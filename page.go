@@ -1394,3 +1394,81 @@ func (page *nullPage) Slice(i, j int64) Page {
 func (page *nullPage) RepetitionLevels() []byte { return nil }
 func (page *nullPage) DefinitionLevels() []byte { return nil }
 func (page *nullPage) Data() encoding.Values    { return encoding.Values{} }
+
+// NullRun represents a sequence of consecutive null values in a page.
+type NullRun struct {
+	// Index of the first null value of the run, counting all values of the page
+	// (including nulls).
+	Index int64
+	// Number of null values in the run.
+	Count int64
+}
+
+// NullRunsOf returns the sequences of consecutive null values in page.
+//
+// For optional columns that are mostly null, the null runs allow programs to
+// skip over the null values without materializing them as individual Value
+// instances; the non-null values can be obtained from the Data method of the
+// page, which only holds the non-null values, and placed at the positions that
+// are not covered by null runs.
+//
+// The runs are computed from the definition levels already decoded in the page,
+// the function does not need to read the page values when the page was read
+// from a parquet file or created from a column buffer.
+func NullRunsOf(page Page) []NullRun {
+	numNulls := page.NumNulls()
+	if numNulls == 0 {
+		return nil
+	}
+	numValues := page.NumValues()
+	if numNulls == numValues {
+		return []NullRun{{Index: 0, Count: numValues}}
+	}
+
+	var maxDefinitionLevel byte
+	switch p := page.(type) {
+	case *optionalPage:
+		maxDefinitionLevel = p.maxDefinitionLevel
+	case *repeatedPage:
+		maxDefinitionLevel = p.maxDefinitionLevel
+	default:
+		return nullRunsOfValues(page)
+	}
+
+	var runs []NullRun
+	for i, level := range page.DefinitionLevels() {
+		if level == maxDefinitionLevel {
+			continue
+		}
+		if n := len(runs); n > 0 && runs[n-1].Index+runs[n-1].Count == int64(i) {
+			runs[n-1].Count++
+		} else {
+			runs = append(runs, NullRun{Index: int64(i), Count: 1})
+		}
+	}
+	return runs
+}
+
+func nullRunsOfValues(page Page) []NullRun {
+	var runs []NullRun
+	var index int64
+	buffer := make([]Value, defaultValueBufferSize)
+	reader := page.Values()
+
+	for {
+		n, err := reader.ReadValues(buffer)
+		for _, v := range buffer[:n] {
+			if v.IsNull() {
+				if k := len(runs); k > 0 && runs[k-1].Index+runs[k-1].Count == index {
+					runs[k-1].Count++
+				} else {
+					runs = append(runs, NullRun{Index: index, Count: 1})
+				}
+			}
+			index++
+		}
+		if err != nil {
+			return runs
+		}
+	}
+}
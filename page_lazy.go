@@ -0,0 +1,181 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/encoding/plain"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// lazyByteArrayPage is a page of byte array values which retains the encoded
+// values of the page, and decodes them on access. Pages are created by columns
+// opened with the LazyValueDecoding option.
+//
+// Values of PLAIN pages are read directly from the encoded data, the values
+// reference the page data instead of being copied. Pages of other encodings
+// are decoded the first time their values are needed.
+type lazyByteArrayPage struct {
+	typ         Type
+	encoding    encoding.Encoding
+	data        []byte
+	numValues   int32
+	columnIndex int16
+	decoded     Page
+}
+
+func newLazyByteArrayPage(typ Type, columnIndex, numValues int, enc encoding.Encoding, data []byte) *lazyByteArrayPage {
+	return &lazyByteArrayPage{
+		typ:         typ,
+		encoding:    enc,
+		data:        data,
+		numValues:   makeNumValues(numValues),
+		columnIndex: ^makeColumnIndex(columnIndex),
+	}
+}
+
+func (page *lazyByteArrayPage) Type() Type { return page.typ }
+
+func (page *lazyByteArrayPage) Column() int { return int(^page.columnIndex) }
+
+func (page *lazyByteArrayPage) Dictionary() Dictionary { return nil }
+
+func (page *lazyByteArrayPage) NumRows() int64 { return int64(page.numValues) }
+
+func (page *lazyByteArrayPage) NumValues() int64 { return int64(page.numValues) }
+
+func (page *lazyByteArrayPage) NumNulls() int64 { return 0 }
+
+func (page *lazyByteArrayPage) Size() int64 { return int64(len(page.data)) }
+
+func (page *lazyByteArrayPage) RepetitionLevels() []byte { return nil }
+
+func (page *lazyByteArrayPage) DefinitionLevels() []byte { return nil }
+
+func (page *lazyByteArrayPage) Bounds() (min, max Value, ok bool) { return page.decode().Bounds() }
+
+func (page *lazyByteArrayPage) Data() encoding.Values { return page.decode().Data() }
+
+func (page *lazyByteArrayPage) Values() ValueReader {
+	if page.isPlain() {
+		return &lazyByteArrayPageValues{page: page, data: page.data}
+	}
+	return page.decode().Values()
+}
+
+func (page *lazyByteArrayPage) Slice(i, j int64) Page {
+	if i < 0 || j > int64(page.numValues) || i > j {
+		panic(errPageBoundsOutOfRange(i, j, int64(page.numValues)))
+	}
+	if page.isPlain() {
+		// The values before i are skipped by reading their lengths, the page
+		// remains lazy.
+		if data, n := skipPlainValues(page.typ, page.data, int(i)); int64(n) == i {
+			return &lazyByteArrayPage{
+				typ:         page.typ,
+				encoding:    page.encoding,
+				data:        data,
+				numValues:   int32(j - i),
+				columnIndex: page.columnIndex,
+			}
+		}
+	}
+	return page.decode().Slice(i, j)
+}
+
+func (page *lazyByteArrayPage) isPlain() bool {
+	return page.encoding.Encoding() == format.Plain
+}
+
+// decode decodes the values of the page on the first call. Decoding errors are
+// reported when reading the values of the returned page.
+func (page *lazyByteArrayPage) decode() Page {
+	if page.decoded != nil {
+		return page.decoded
+	}
+	columnIndex := int(^page.columnIndex)
+	numValues := int(page.numValues)
+	values := page.typ.NewValues(
+		make([]byte, 0, page.typ.EstimateDecodeSize(numValues, page.data, page.encoding)),
+		make([]uint32, 0, numValues+1),
+	)
+	values, err := page.typ.Decode(values, page.data, page.encoding)
+	switch {
+	case err != nil:
+		page.decoded = newErrorPage(page.typ, columnIndex, "decoding values of page: %w", err)
+	case countDecodedValues(page.encoding, values) < numValues:
+		page.decoded = newErrorPage(page.typ, columnIndex, "decoding values of page: %w", io.ErrUnexpectedEOF)
+	default:
+		page.decoded = page.typ.NewPage(columnIndex, numValues, values)
+	}
+	return page.decoded
+}
+
+type lazyByteArrayPageValues struct {
+	page   *lazyByteArrayPage
+	data   []byte
+	offset int
+}
+
+// next returns the next value of the page, which references the encoded data.
+func (r *lazyByteArrayPageValues) next() ([]byte, error) {
+	if len(r.data) < plain.ByteArrayLengthSize {
+		return nil, fmt.Errorf("decoding value %d of page: %w", r.offset, io.ErrUnexpectedEOF)
+	}
+	n := plain.ByteArrayLength(r.data)
+	if n > len(r.data)-plain.ByteArrayLengthSize {
+		return nil, fmt.Errorf("decoding value %d of page: %w", r.offset, io.ErrUnexpectedEOF)
+	}
+	end := plain.ByteArrayLengthSize + n
+	value := r.data[plain.ByteArrayLengthSize:end:end]
+	r.data = r.data[end:]
+	r.offset++
+	return value, nil
+}
+
+func (r *lazyByteArrayPageValues) ReadValues(values []Value) (n int, err error) {
+	numValues := int(r.page.numValues)
+	for n < len(values) && r.offset < numValues {
+		b, err := r.next()
+		if err != nil {
+			return n, err
+		}
+		values[n] = makeValueBytes(ByteArray, b)
+		values[n].columnIndex = r.page.columnIndex
+		n++
+	}
+	if r.offset == numValues {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *lazyByteArrayPageValues) ReadByteArrays(values []byte) (n int, err error) {
+	numValues := int(r.page.numValues)
+	size := 0
+	for r.offset < numValues {
+		if len(r.data) < plain.ByteArrayLengthSize {
+			return n, fmt.Errorf("decoding value %d of page: %w", r.offset, io.ErrUnexpectedEOF)
+		}
+		k := plain.ByteArrayLengthSize + plain.ByteArrayLength(r.data)
+		if k > len(r.data) {
+			return n, fmt.Errorf("decoding value %d of page: %w", r.offset, io.ErrUnexpectedEOF)
+		}
+		if k > len(values)-size {
+			break
+		}
+		// The values are written in the PLAIN encoding, which is the encoding
+		// of the page data.
+		size += copy(values[size:], r.data[:k])
+		r.data = r.data[k:]
+		r.offset++
+		n++
+	}
+	if r.offset == numValues {
+		err = io.EOF
+	} else if n == 0 && len(values) > 0 {
+		err = io.ErrShortBuffer
+	}
+	return n, err
+}
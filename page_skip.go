@@ -0,0 +1,237 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// skipRows removes up to numRows leading rows from the data of a page of a
+// column which is not repeated, before the values are decoded. The function
+// returns the number of rows that were skipped, and the definition levels,
+// encoded values and number of non-null values of the remaining rows.
+//
+// The encoded values are skipped without being decoded when the encoding
+// allows it; the number of rows skipped is less than numRows when it does not,
+// in which case the remaining rows must be discarded after decoding the page.
+func (c *Column) skipRows(enc encoding.Encoding, typ Type, numRows int, levels, data []byte, numValues int) (int, []byte, []byte, int) {
+	skipValues := min(numRows, numValues)
+	if levels != nil {
+		numRows = min(numRows, len(levels))
+		skipValues = countLevelsEqual(levels[:numRows], c.maxDefinitionLevel)
+	}
+
+	data, skippedValues := skipEncodedValues(enc, typ, data, skipValues)
+	skippedRows := skippedValues
+	if levels != nil {
+		// The rows holding the skipped values are skipped, as well as the null
+		// rows which follow them, up to the next non-null value.
+		skippedRows = numRows
+		for i, count := 0, 0; i < numRows; i++ {
+			if levels[i] == c.maxDefinitionLevel {
+				if count == skippedValues {
+					skippedRows = i
+					break
+				}
+				count++
+			}
+		}
+		levels = levels[skippedRows:]
+	}
+	return skippedRows, levels, data, numValues - skippedValues
+}
+
+// skipEncodedValues returns the encoded data following the first n values of
+// data, and the number of values that were skipped, which may be less than n
+// (including zero) when the encoding does not allow skipping values without
+// decoding them.
+//
+// The delta encodings cannot skip values: each value is relative to the ones
+// before it, which must be decoded to reconstruct the values that remain. No
+// values are skipped for these encodings, the page is decoded and its leading
+// rows are discarded instead.
+//
+// The data may be modified when skipping values in the middle of runs of the
+// RLE/bit-packed hybrid encoding, where the header of the run is rewritten
+// before the values that remain, and when skipping byte stream split values,
+// where the streams are moved to be contiguous again, so the function must
+// only be used on buffers owned by the caller.
+func skipEncodedValues(enc encoding.Encoding, typ Type, data []byte, n int) ([]byte, int) {
+	if n <= 0 {
+		return data, 0
+	}
+	switch enc.Encoding() {
+	case format.Plain:
+		return skipPlainValues(typ, data, n)
+	case format.RLEDictionary, format.PlainDictionary:
+		return skipDictionaryIndexes(data, n)
+	case format.RLE:
+		if typ.Kind() == Boolean {
+			return skipRLEBooleans(data, n)
+		}
+	case format.ByteStreamSplit:
+		return skipByteStreamSplitValues(typ, data, n)
+	}
+	return data, 0
+}
+
+func skipPlainValues(typ Type, data []byte, n int) ([]byte, int) {
+	size := 0
+	switch typ.Kind() {
+	case Boolean:
+		// Booleans are bit-packed, only whole bytes can be skipped.
+		n = min(n, 8*len(data)) &^ 7
+		return data[n/8:], n
+	case Int32, Float:
+		size = 4
+	case Int64, Double:
+		size = 8
+	case Int96:
+		size = 12
+	case FixedLenByteArray:
+		size = typ.Length()
+	case ByteArray:
+		i, skipped := 0, 0
+		for skipped < n && len(data)-i >= 4 {
+			length := int(binary.LittleEndian.Uint32(data[i:]))
+			if length > len(data)-(i+4) {
+				break
+			}
+			i += 4 + length
+			skipped++
+		}
+		return data[i:], skipped
+	}
+	if size <= 0 {
+		return data, 0
+	}
+	n = min(n, len(data)/size)
+	return data[n*size:], n
+}
+
+// skipDictionaryIndexes skips the first n indexes encoded with the RLE/bit-packed
+// hybrid encoding, prefixed by their bit width, as used by RLE_DICTIONARY.
+func skipDictionaryIndexes(data []byte, n int) ([]byte, int) {
+	if len(data) == 0 || data[0] > 32 {
+		return data, 0
+	}
+	bitWidth := data[0]
+	offset, skipped := skipHybridRuns(data, 1, int(bitWidth), n)
+	if skipped == 0 {
+		return data, 0
+	}
+	data[offset-1] = bitWidth
+	return data[offset-1:], skipped
+}
+
+// skipRLEBooleans skips the first n boolean values encoded with the RLE
+// encoding, which are runs of the RLE/bit-packed hybrid encoding prefixed by
+// their length in bytes.
+func skipRLEBooleans(data []byte, n int) ([]byte, int) {
+	if len(data) < 4 {
+		return data, 0
+	}
+	length := binary.LittleEndian.Uint32(data)
+	if uint64(length) > uint64(len(data)-4) {
+		return data, 0
+	}
+	end := 4 + int(length)
+	offset, skipped := skipHybridRuns(data[:end], 4, 1, n)
+	if skipped == 0 {
+		return data, 0
+	}
+	binary.LittleEndian.PutUint32(data[offset-4:], uint32(end-offset))
+	return data[offset-4:], skipped
+}
+
+// skipHybridRuns skips the first n values of the runs of the RLE/bit-packed
+// hybrid encoding starting at data[i:], with values of the given bit width. It
+// returns the offset in data where the runs of the remaining values start, and
+// the number of values skipped.
+//
+// Whole runs are skipped by reading their headers. When the last value skipped
+// is in the middle of a run, the header of the run is rewritten to hold only
+// the remaining values; bit-packed runs can only be split at boundaries of
+// groups of 8 values. The new header is never larger than the original one,
+// so when values were skipped, at least i bytes precede the returned offset
+// and the caller can write the prefix of the encoding in front of the runs.
+func skipHybridRuns(data []byte, i, bitWidth, n int) (int, int) {
+	skipped := 0
+
+	for i < len(data) {
+		header, headerSize := binary.Uvarint(data[i:])
+		if headerSize <= 0 || header>>1 > math.MaxInt32 {
+			break
+		}
+		valuesOffset := i + headerSize
+
+		var count, size int
+		if header&1 == 0 { // RLE run
+			count = int(header >> 1)
+			size = (bitWidth + 7) / 8
+		} else { // bit-packed run of groups of 8 values
+			count = 8 * int(header>>1)
+			size = int(header>>1) * bitWidth
+		}
+		if size > len(data)-valuesOffset {
+			break
+		}
+
+		if skipped+count <= n {
+			skipped += count
+			i = valuesOffset + size
+			continue
+		}
+
+		var newHeader uint64
+		var offset int
+		if header&1 == 0 {
+			remain := count - (n - skipped)
+			newHeader = uint64(remain) << 1
+			offset, skipped = valuesOffset, n
+		} else {
+			groups := (n - skipped) / 8
+			if groups == 0 {
+				break
+			}
+			newHeader = (header>>1-uint64(groups))<<1 | 1
+			offset, skipped = valuesOffset+groups*bitWidth, skipped+8*groups
+		}
+
+		var buf [binary.MaxVarintLen64]byte
+		headerLength := binary.PutUvarint(buf[:], newHeader)
+		start := offset - headerLength
+		copy(data[start:], buf[:headerLength])
+		return start, skipped
+	}
+
+	return i, skipped
+}
+
+// skipByteStreamSplitValues skips the first n values encoded with the
+// BYTE_STREAM_SPLIT encoding. The bytes of the values are split in one stream
+// per byte of the type, the streams of the remaining values are moved to the
+// front of data so they are contiguous again.
+func skipByteStreamSplitValues(typ Type, data []byte, n int) ([]byte, int) {
+	size := 0
+	switch typ.Kind() {
+	case Float:
+		size = 4
+	case Double:
+		size = 8
+	default:
+		return data, 0
+	}
+	if len(data)%size != 0 {
+		return data, 0
+	}
+	count := len(data) / size
+	n = min(n, count)
+	remain := count - n
+	for k := 0; k < size; k++ {
+		copy(data[k*remain:], data[k*count+n:(k+1)*count])
+	}
+	return data[:size*remain], n
+}
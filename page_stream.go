@@ -0,0 +1,185 @@
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/encoding/plain"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/parquet-go/parquet-go/internal/unsafecast"
+)
+
+// streamDecoderOf returns the stream decoder that the data page of the given
+// header and uncompressed size should be decompressed with, or nil if the page
+// must be decompressed in memory.
+//
+// Pages are only streamed into the value decoder when the column was opened
+// with the StreamingDecompression option, the page is larger than the
+// threshold, and holds PLAIN byte arrays: the uncompressed values of other
+// encodings are either the decoded representation of the page, or must be
+// held in memory to be decoded.
+func (c *Column) streamDecoderOf(header DataPageHeader, size int32) compress.StreamDecoder {
+	if c.file == nil || c.lazyValues {
+		return nil
+	}
+	threshold := c.file.config.StreamingDecompressionThreshold
+	if threshold <= 0 || int(size) < threshold {
+		return nil
+	}
+	if c.Type().Kind() != ByteArray || header.Encoding() != format.Plain {
+		return nil
+	}
+	stream, _ := c.compression.(compress.StreamDecoder)
+	return stream
+}
+
+func (c *Column) decodeDataPageV1Stream(stream compress.StreamDecoder, header DataPageHeaderV1, compressedPageData []byte, size int32) (Page, int, error) {
+	r, err := newPageStreamReader(stream, compressedPageData, c.file.config.ReadBufferSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decompressing data page v1: %w", err)
+	}
+	defer r.close()
+
+	numValues := int(header.NumValues())
+	remain := int(size)
+	var repetitionLevels, definitionLevels *buffer
+
+	if c.maxRepetitionLevel > 0 {
+		encoding := lookupLevelEncoding(header.RepetitionLevelEncoding(), c.maxRepetitionLevel)
+		repetitionLevels, remain, err = r.readLevelsV1(encoding, numValues, remain)
+		if err != nil {
+			return nil, 0, fmt.Errorf("decoding repetition levels of data page v1: %w", err)
+		}
+		defer repetitionLevels.unref()
+	}
+
+	if c.maxDefinitionLevel > 0 {
+		encoding := lookupLevelEncoding(header.DefinitionLevelEncoding(), c.maxDefinitionLevel)
+		definitionLevels, remain, err = r.readLevelsV1(encoding, numValues, remain)
+		if err != nil {
+			return nil, 0, fmt.Errorf("decoding definition levels of data page v1: %w", err)
+		}
+		defer definitionLevels.unref()
+		numValues -= countLevelsNotEqual(definitionLevels.data, c.maxDefinitionLevel)
+	}
+
+	return c.decodePlainByteArrayStream(r, remain, numValues, repetitionLevels, definitionLevels)
+}
+
+func (c *Column) decodeDataPageV2Stream(stream compress.StreamDecoder, header DataPageHeaderV2, numValues int, repetitionLevels, definitionLevels *buffer, compressedPageData []byte, size int32) (Page, int, error) {
+	r, err := newPageStreamReader(stream, compressedPageData, c.file.config.ReadBufferSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decompressing data page v2: %w", err)
+	}
+	defer r.close()
+	// The uncompressed size of pages v2 includes the levels, which are not
+	// compressed.
+	remain := int(size) - int(header.RepetitionLevelsByteLength()) - int(header.DefinitionLevelsByteLength())
+	return c.decodePlainByteArrayStream(r, remain, numValues, repetitionLevels, definitionLevels)
+}
+
+// decodePlainByteArrayStream decodes numValues PLAIN byte arrays read from r,
+// where size is the number of uncompressed bytes remaining in the page. The
+// values are copied directly from the decompressor to the page buffer, so the
+// uncompressed page is never held in memory.
+func (c *Column) decodePlainByteArrayStream(r *pageStreamReader, size, numValues int, repetitionLevels, definitionLevels *buffer) (Page, int, error) {
+	valuesSize := size - plain.ByteArrayLengthSize*numValues
+	if valuesSize < 0 {
+		return nil, 0, fmt.Errorf("decoding data page: %d values do not fit in %d bytes: %w", numValues, size, io.ErrUnexpectedEOF)
+	}
+
+	vbuf := buffers.get(valuesSize)
+	defer vbuf.unref()
+	obuf := buffers.get(4 * (numValues + 1))
+	defer obuf.unref()
+
+	values := vbuf.data[:0]
+	offsets := unsafecast.BytesToUint32(obuf.data)[:0]
+	var length [plain.ByteArrayLengthSize]byte
+
+	for i := 0; i < numValues; i++ {
+		if _, err := io.ReadFull(r.rbuf, length[:]); err != nil {
+			return nil, 0, fmt.Errorf("decoding value %d of data page: %w", i, unexpectedEOF(err))
+		}
+		n := plain.ByteArrayLength(length[:])
+		if n > cap(values)-len(values) {
+			return nil, 0, fmt.Errorf("decoding value %d of data page: value of length %d exceeds the page size: %w", i, n, io.ErrUnexpectedEOF)
+		}
+		offsets = append(offsets, uint32(len(values)))
+		values = values[:len(values)+n]
+		if _, err := io.ReadFull(r.rbuf, values[len(values)-n:]); err != nil {
+			return nil, 0, fmt.Errorf("decoding value %d of data page: %w", i, unexpectedEOF(err))
+		}
+	}
+	offsets = append(offsets, uint32(len(values)))
+
+	var levels []byte
+	if definitionLevels != nil {
+		levels = definitionLevels.data
+		if c.checkLevels {
+			var err error
+			if numValues, err = c.checkDefinitionLevels(levels, numValues, numValues); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	pageType := c.Type()
+	newPage := pageType.NewPage(c.Index(), numValues, encoding.ByteArrayValues(values, offsets))
+	return c.newDataPage(newPage, repetitionLevels, definitionLevels, levels, vbuf, obuf), 0, nil
+}
+
+// pageStreamReader reads the uncompressed data of a page from a decompressor.
+type pageStreamReader struct {
+	decoder io.ReadCloser
+	rbuf    *bufio.Reader
+	pool    *sync.Pool
+}
+
+func newPageStreamReader(stream compress.StreamDecoder, compressedPageData []byte, bufferSize int) (*pageStreamReader, error) {
+	decoder, err := stream.NewDecodeReader(bytes.NewReader(compressedPageData))
+	if err != nil {
+		return nil, err
+	}
+	rbuf, pool := getBufioReader(decoder, bufferSize)
+	return &pageStreamReader{decoder: decoder, rbuf: rbuf, pool: pool}, nil
+}
+
+func (r *pageStreamReader) close() {
+	putBufioReader(r.rbuf, r.pool)
+	r.decoder.Close()
+}
+
+// readLevelsV1 reads a section of levels prefixed by its length, as found at
+// the beginning of data pages v1. The function returns the decoded levels and
+// the number of uncompressed bytes remaining in the page.
+func (r *pageStreamReader) readLevelsV1(enc encoding.Encoding, numValues, remain int) (*buffer, int, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(r.rbuf, b[:]); err != nil {
+		return nil, remain, unexpectedEOF(err)
+	}
+	n := int(binary.LittleEndian.Uint32(b[:]))
+	if remain -= 4; n > remain {
+		return nil, remain, io.ErrUnexpectedEOF
+	}
+	data := buffers.get(n)
+	defer data.unref()
+	if _, err := io.ReadFull(r.rbuf, data.data); err != nil {
+		return nil, remain, unexpectedEOF(err)
+	}
+	levels, err := decodeLevels(enc, numValues, data.data)
+	return levels, remain - n, err
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -565,3 +565,48 @@ func TestReslicingBooleanPage(t *testing.T) {
 		}
 	}
 }
+
+func TestNullRunsOf(t *testing.T) {
+	type Row struct {
+		Value *int64 `parquet:"value,optional"`
+	}
+
+	values := []*int64{nil, nil, new(int64), nil, new(int64), new(int64), nil, nil, nil}
+	want := []parquet.NullRun{{Index: 0, Count: 2}, {Index: 3, Count: 1}, {Index: 6, Count: 3}}
+
+	buffer := parquet.NewGenericBuffer[Row]()
+	for _, v := range values {
+		if _, err := buffer.Write([]Row{{Value: v}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](b)
+	if _, err := w.WriteRowGroup(buffer); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, chunk := range map[string]parquet.ColumnChunk{
+		"buffer": buffer.ColumnChunks()[0],
+		"file":   f.RowGroups()[0].ColumnChunks()[0],
+	} {
+		pages := chunk.Pages()
+		page, err := pages.ReadPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := parquet.NullRunsOf(page); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: null runs mismatch:\nwant: %+v\ngot:  %+v", name, want, got)
+		}
+		parquet.Release(page)
+		pages.Close()
+	}
+}
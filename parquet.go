@@ -8,9 +8,13 @@
 package parquet
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
 )
 
 // Read reads and returns rows from the parquet file in the given reader.
@@ -92,6 +96,94 @@ func WriteFile[T any](path string, rows []T, options ...WriterOption) error {
 	return Write(f, rows, options...)
 }
 
+// WriteFileAtomic creates a parquet file at the given path, with content
+// produced by the write function.
+//
+// The file is first written to a temporary file in the same directory, which is
+// synced to stable storage then renamed to path once the writer has been
+// closed. This guarantees that readers never observe a partially written file
+// at path, even if the program or the system crashes while the file is being
+// written. The temporary file is removed if an error occurs.
+//
+// The Writer passed to the write function is configured with the options; it
+// must not be closed by the write function. The file is created with mode 0644.
+//
+// Errors returned by the function indicate which step of the write failed and
+// wrap the underlying error. The file exists at path only if the error wraps
+// ErrNotDurable, which is returned when the file was renamed but the directory
+// holding it could not be synced; the file is complete but the rename may be
+// lost if the system crashes. In all other cases of errors, path is left
+// untouched.
+func WriteFileAtomic(path string, write func(*Writer) error, options ...WriterOption) error {
+	return writeFileAtomic(path, write, syncDir, options...)
+}
+
+// writeFileAtomic is the implementation of WriteFileAtomic, syncDir is called
+// to sync the directory holding the file after it was renamed.
+func writeFileAtomic(path string, write func(*Writer) error, syncDir func(string) error, options ...WriterOption) (err error) {
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		return err
+	}
+
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	f, err := os.CreateTemp(dir, "."+name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary file for %s: %w", path, err)
+	}
+	tmp := f.Name()
+	defer func() {
+		// Once renamed, the temporary file is the file at path and must not be
+		// removed.
+		if err != nil && !errors.Is(err, ErrNotDurable) {
+			f.Close()
+			os.Remove(tmp)
+		}
+	}()
+
+	w := NewWriter(f, config)
+	if err := write(w); err != nil {
+		return fmt.Errorf("writing parquet file %s: %w", tmp, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("closing parquet writer of %s: %w", tmp, err)
+	}
+	if err := f.Chmod(0644); err != nil {
+		return fmt.Errorf("setting permissions of %s: %w", tmp, err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("syncing %s: %w", tmp, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("renaming %s to %s: %w", tmp, path, err)
+	}
+	// The rename is only durable once the directory entry is synced. Windows
+	// does not support syncing directories, the rename is durable when it
+	// returns.
+	if runtime.GOOS != "windows" {
+		if err := syncDir(dir); err != nil {
+			return fmt.Errorf("%w: syncing directory %s: %w", ErrNotDurable, dir, err)
+		}
+	}
+	return nil
+}
+
+func syncDir(path string) error {
+	d, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
 func atLeastOne(size int) int {
 	return atLeast(size, 1)
 }
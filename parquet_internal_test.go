@@ -0,0 +1,47 @@
+package parquet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteFileAtomicSyncDirError(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("directories are not synced on windows")
+	}
+
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	errSync := errors.New("sync failed")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.parquet")
+
+	err := writeFileAtomic(path, func(w *Writer) error {
+		return w.Write(Row{ID: 42})
+	}, func(string) error { return errSync }, SchemaOf(Row{}))
+	if !errors.Is(err, ErrNotDurable) || !errors.Is(err, errSync) {
+		t.Fatalf("expected the error to wrap ErrNotDurable and the sync error, got %v", err)
+	}
+
+	// The file was published, the error only reports that it may not survive a
+	// crash of the system.
+	rows, err := ReadFile[Row](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].ID != 42 {
+		t.Errorf("wrong rows: %+v", rows)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("unexpected files in the directory: %v", entries)
+	}
+}
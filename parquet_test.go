@@ -2,11 +2,13 @@ package parquet_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -686,6 +688,21 @@ func (row timeInMillisColumn) generate(prng *rand.Rand) timeInMillisColumn {
 	return timeInMillisColumn{Value: t}
 }
 
+type timeOfDayColumn struct {
+	Millis time.Duration `parquet:",time(millisecond)"`
+	Micros time.Duration `parquet:",time(microsecond)"`
+	Nanos  time.Duration `parquet:",time(nanosecond)"`
+}
+
+func (row timeOfDayColumn) generate(prng *rand.Rand) timeOfDayColumn {
+	d := time.Duration(prng.Int63n(int64(24 * time.Hour)))
+	return timeOfDayColumn{
+		Millis: d.Truncate(time.Millisecond),
+		Micros: d.Truncate(time.Microsecond),
+		Nanos:  d,
+	}
+}
+
 type decimalColumn struct {
 	Value int64 `parquet:",decimal(0:3)"`
 }
@@ -1293,3 +1310,117 @@ func TestReadMapAsAny(t *testing.T) {
 		t.Errorf("value mismatch: want=%+v got=%+v", anyd, anys)
 	}
 }
+
+func TestNullableMapValues(t *testing.T) {
+	type Price struct {
+		Currency string   `parquet:"currency"`
+		Amount   *float64 `parquet:"amount,optional"`
+	}
+	type Offer struct {
+		Prices   map[string]*Price `parquet:"prices,optional"`
+		Tagged   map[string]*Price `parquet:"tagged" parquet-value:",optional"`
+		Defaults map[string]Price  `parquet:"defaults" parquet-value:",optional"`
+	}
+
+	amount := 9.99
+	usd := &Price{Currency: "USD", Amount: &amount}
+	rows := []Offer{
+		{
+			Prices:   map[string]*Price{"us": usd, "fr": nil, "jp": {Currency: "JPY"}},
+			Tagged:   map[string]*Price{"us": usd, "fr": nil},
+			Defaults: map[string]Price{"us": *usd},
+		},
+		{
+			Tagged:   map[string]*Price{},
+			Defaults: map[string]Price{},
+		},
+	}
+
+	schema := parquet.SchemaOf(Offer{})
+	for _, path := range [][]string{
+		{"prices", "key_value", "value", "currency"},
+		{"tagged", "key_value", "value", "currency"},
+		{"defaults", "key_value", "value", "currency"},
+	} {
+		leaf, _ := schema.Lookup(path...)
+		want := 2
+		if path[0] == "prices" {
+			want = 3
+		}
+		if leaf.MaxDefinitionLevel != want {
+			t.Errorf("wrong max definition level of %q: want=%d got=%d", path, want, leaf.MaxDefinitionLevel)
+		}
+	}
+
+	for _, row := range rows {
+		values := schema.Deconstruct(nil, row)
+		var got Offer
+		if err := schema.Reconstruct(&got, values); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, row) {
+			t.Errorf("deconstructed row mismatch:\nwant = %+v\ngot  = %+v", row, got)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := parquet.Write(&buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.Read[Offer](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("written rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.parquet")
+
+	err := parquet.WriteFileAtomic(path, func(w *parquet.Writer) error {
+		for i := 0; i < 10; i++ {
+			if err := w.Write(Row{ID: int64(i)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := parquet.ReadFile[Row](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 10 {
+		t.Errorf("wrong number of rows: %d", len(rows))
+	}
+
+	errWrite := errors.New("write failed")
+	err = parquet.WriteFileAtomic(path, func(w *parquet.Writer) error {
+		return errWrite
+	}, parquet.SchemaOf(Row{}))
+	if !errors.Is(err, errWrite) {
+		t.Fatalf("expected the error of the write function, got %v", err)
+	}
+
+	// The previous file must be left untouched and the temporary file removed.
+	if rows, err := parquet.ReadFile[Row](path); err != nil || len(rows) != 10 {
+		t.Errorf("file was modified by the failed write: %d rows, %v", len(rows), err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temporary files were not removed: %v", entries)
+	}
+}
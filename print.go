@@ -15,11 +15,33 @@ func PrintSchema(w io.Writer, name string, node Node) error {
 }
 
 func PrintSchemaIndent(w io.Writer, name string, node Node, pattern, newline string) error {
+	return PrintSchemaWith(w, name, node, PrintIndent(pattern, newline))
+}
+
+// PrintSchemaWith prints the schema of node to w, applying the given options.
+//
+// By default, the schema is printed in the same format as PrintSchema. The
+// options may be used to also print the encoding and compression codec of
+// leaf columns, for example:
+//
+//	message Row {
+//		required int64 id (INT(64,true)) = 1 [encoding=DELTA_BINARY_PACKED, compression=ZSTD];
+//	}
+//
+// The output is stable: fields are always printed in the order of the node's
+// fields, and the same schema always produces the same output, which makes it
+// suitable to store and compare schema snapshots.
+func PrintSchemaWith(w io.Writer, name string, node Node, options ...PrintOption) error {
+	config := &PrintConfig{Indent: "\t", NewLine: "\n"}
+	for _, opt := range options {
+		opt.ConfigurePrint(config)
+	}
+
 	pw := &printWriter{writer: w}
 	pi := &printIndent{}
 
 	if node.Leaf() {
-		printSchemaWithIndent(pw, "", node, pi)
+		printSchemaWithIndent(pw, "", node, pi, config)
 	} else {
 		pw.WriteString("message ")
 
@@ -30,13 +52,13 @@ func PrintSchemaIndent(w io.Writer, name string, node Node, pattern, newline str
 			pw.WriteString(" {")
 		}
 
-		pi.pattern = pattern
-		pi.newline = newline
+		pi.pattern = config.Indent
+		pi.newline = config.NewLine
 		pi.repeat = 1
 		pi.writeNewLine(pw)
 
 		for _, field := range node.Fields() {
-			printSchemaWithIndent(pw, field.Name(), field, pi)
+			printSchemaWithIndent(pw, field.Name(), field, pi, config)
 			pi.writeNewLine(pw)
 		}
 
@@ -46,7 +68,54 @@ func PrintSchemaIndent(w io.Writer, name string, node Node, pattern, newline str
 	return pw.err
 }
 
-func printSchemaWithIndent(w io.StringWriter, name string, node Node, indent *printIndent) {
+// PrintConfig carries the configuration of PrintSchemaWith.
+type PrintConfig struct {
+	Indent      string
+	NewLine     string
+	Encoding    bool
+	Compression bool
+}
+
+// ConfigurePrint applies configuration options from c to config.
+func (c *PrintConfig) ConfigurePrint(config *PrintConfig) { *config = *c }
+
+// PrintOption is an interface implemented by types that carry configuration
+// options for PrintSchemaWith.
+type PrintOption interface {
+	ConfigurePrint(*PrintConfig)
+}
+
+type printOption func(*PrintConfig)
+
+func (opt printOption) ConfigurePrint(config *PrintConfig) { opt(config) }
+
+// PrintIndent configures the indentation pattern and line separator used when
+// printing schemas.
+//
+// Defaults to a tab and a new line.
+func PrintIndent(pattern, newline string) PrintOption {
+	return printOption(func(config *PrintConfig) {
+		config.Indent, config.NewLine = pattern, newline
+	})
+}
+
+// PrintEncoding configures whether the encodings of leaf columns are printed.
+// Nothing is printed for columns which use the default encoding.
+//
+// Defaults to false.
+func PrintEncoding(enabled bool) PrintOption {
+	return printOption(func(config *PrintConfig) { config.Encoding = enabled })
+}
+
+// PrintCompression configures whether the compression codecs of leaf columns
+// are printed. Nothing is printed for columns which use the default codec.
+//
+// Defaults to false.
+func PrintCompression(enabled bool) PrintOption {
+	return printOption(func(config *PrintConfig) { config.Compression = enabled })
+}
+
+func printSchemaWithIndent(w io.StringWriter, name string, node Node, indent *printIndent, config *PrintConfig) {
 	indent.writeTo(w)
 
 	switch {
@@ -99,6 +168,12 @@ func printSchemaWithIndent(w io.StringWriter, name string, node Node, indent *pr
 			w.WriteString(strconv.Itoa(id))
 		}
 
+		if properties := propertiesOf(node, config); len(properties) > 0 {
+			w.WriteString(" [")
+			w.WriteString(strings.Join(properties, ", "))
+			w.WriteString("]")
+		}
+
 		w.WriteString(";")
 	} else {
 		w.WriteString("group")
@@ -124,7 +199,7 @@ func printSchemaWithIndent(w io.StringWriter, name string, node Node, indent *pr
 		indent.push()
 
 		for _, field := range node.Fields() {
-			printSchemaWithIndent(w, field.Name(), field, indent)
+			printSchemaWithIndent(w, field.Name(), field, indent, config)
 			indent.writeNewLine(w)
 		}
 
@@ -134,6 +209,21 @@ func printSchemaWithIndent(w io.StringWriter, name string, node Node, indent *pr
 	}
 }
 
+func propertiesOf(node Node, config *PrintConfig) []string {
+	var properties []string
+	if config.Encoding {
+		if enc := node.Encoding(); enc != nil {
+			properties = append(properties, "encoding="+enc.Encoding().String())
+		}
+	}
+	if config.Compression {
+		if codec := node.Compression(); codec != nil {
+			properties = append(properties, "compression="+codec.CompressionCodec().String())
+		}
+	}
+	return properties
+}
+
 func annotationOf(node Node) string {
 	if logicalType := node.Type().LogicalType(); logicalType != nil {
 		return logicalType.String()
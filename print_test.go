@@ -265,3 +265,64 @@ func TestPrintSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintSchemaWith(t *testing.T) {
+	node := parquet.Group{
+		"id":     parquet.FieldID(parquet.Compressed(parquet.Encoded(parquet.Int(64), &parquet.DeltaBinaryPacked), &parquet.Zstd), 1),
+		"name":   parquet.FieldID(parquet.Optional(parquet.String()), 2),
+		"amount": parquet.FieldID(parquet.Decimal(2, 18, parquet.Int64Type), 3),
+	}
+
+	tests := []struct {
+		scenario string
+		options  []parquet.PrintOption
+		print    string
+	}{
+		{
+			scenario: "default",
+			print: `message Test {
+	required int64 amount (DECIMAL(18,2)) = 3;
+	required int64 id (INT(64,true)) = 1;
+	optional binary name (STRING) = 2;
+}`,
+		},
+
+		{
+			scenario: "encoding and compression",
+			options: []parquet.PrintOption{
+				parquet.PrintEncoding(true),
+				parquet.PrintCompression(true),
+			},
+			print: `message Test {
+	required int64 amount (DECIMAL(18,2)) = 3;
+	required int64 id (INT(64,true)) = 1 [encoding=DELTA_BINARY_PACKED, compression=ZSTD];
+	optional binary name (STRING) = 2;
+}`,
+		},
+
+		{
+			scenario: "indent",
+			options: []parquet.PrintOption{
+				parquet.PrintIndent("", " "),
+				parquet.PrintCompression(true),
+			},
+			print: `message Test { required int64 amount (DECIMAL(18,2)) = 3;` +
+				` required int64 id (INT(64,true)) = 1 [compression=ZSTD];` +
+				` optional binary name (STRING) = 2; }`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(strings.Builder)
+
+			if err := parquet.PrintSchemaWith(buf, "Test", node, test.options...); err != nil {
+				t.Fatal(err)
+			}
+
+			if buf.String() != test.print {
+				t.Errorf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", test.print, buf)
+			}
+		})
+	}
+}
@@ -38,6 +38,9 @@ func NewGenericReader[T any](input io.ReaderAt, options ...ReaderOption) *Generi
 	}
 
 	rowGroup := fileRowGroupOf(f)
+	if c.LegacyLists {
+		rowGroup = standardListsRowGroup(rowGroup)
+	}
 
 	t := typeOf[T]()
 	if c.Schema == nil {
@@ -53,12 +56,20 @@ func NewGenericReader[T any](input io.ReaderAt, options ...ReaderOption) *Generi
 			file: reader{
 				schema:   c.Schema,
 				rowGroup: rowGroup,
+				utf8:     c.UTF8,
+				hooks:    c.ReadHooks,
+			},
+			read: reader{
+				utf8:  c.UTF8,
+				hooks: c.ReadHooks,
 			},
+			defaults:  c.Defaults,
+			narrowing: c.Narrowing,
 		},
 	}
 
-	if !nodesAreEqual(c.Schema, f.schema) {
-		r.base.file.rowGroup = convertRowGroupTo(r.base.file.rowGroup, c.Schema)
+	if !nodesAreEqual(c.Schema, rowGroup.Schema()) {
+		r.base.file.rowGroup, r.base.err = convertRowGroupTo(r.base.file.rowGroup, c.Schema, r.base.defaults, r.base.narrowing)
 	}
 
 	r.base.read.init(r.base.file.schema, r.base.file.rowGroup)
@@ -72,6 +83,10 @@ func NewGenericRowGroupReader[T any](rowGroup RowGroup, options ...ReaderOption)
 		panic(err)
 	}
 
+	if c.LegacyLists {
+		rowGroup = standardListsRowGroup(rowGroup)
+	}
+
 	t := typeOf[T]()
 	if c.Schema == nil {
 		if t == nil {
@@ -86,12 +101,20 @@ func NewGenericRowGroupReader[T any](rowGroup RowGroup, options ...ReaderOption)
 			file: reader{
 				schema:   c.Schema,
 				rowGroup: rowGroup,
+				utf8:     c.UTF8,
+				hooks:    c.ReadHooks,
 			},
+			read: reader{
+				utf8:  c.UTF8,
+				hooks: c.ReadHooks,
+			},
+			defaults:  c.Defaults,
+			narrowing: c.Narrowing,
 		},
 	}
 
 	if !nodesAreEqual(c.Schema, rowGroup.Schema()) {
-		r.base.file.rowGroup = convertRowGroupTo(r.base.file.rowGroup, c.Schema)
+		r.base.file.rowGroup, r.base.err = convertRowGroupTo(r.base.file.rowGroup, c.Schema, r.base.defaults, r.base.narrowing)
 	}
 
 	r.base.read.init(r.base.file.schema, r.base.file.rowGroup)
@@ -232,11 +255,16 @@ func readFuncOf[T any](t reflect.Type, schema *Schema) readFunc[T] {
 // For programs building with Go 1.18 or later, the GenericReader[T] type
 // supersedes this one.
 type Reader struct {
-	seen     reflect.Type
-	file     reader
-	read     reader
-	rowIndex int64
-	rowbuf   []Row
+	seen      reflect.Type
+	file      reader
+	read      reader
+	rowIndex  int64
+	rowbuf    []Row
+	defaults  ColumnDefaults
+	narrowing NarrowingPolicy
+	// Error reported by all reads when the rows of the file could not be
+	// converted to the schema of the reader.
+	err error
 }
 
 // NewReader constructs a parquet reader reading rows from the given
@@ -272,16 +300,29 @@ func NewReader(input io.ReaderAt, options ...ReaderOption) *Reader {
 		panic(err)
 	}
 
+	rowGroup := fileRowGroupOf(f)
+	if c.LegacyLists {
+		rowGroup = standardListsRowGroup(rowGroup)
+	}
+
 	r := &Reader{
 		file: reader{
-			schema:   f.schema,
-			rowGroup: fileRowGroupOf(f),
+			schema:   rowGroup.Schema(),
+			rowGroup: rowGroup,
+			utf8:     c.UTF8,
+			hooks:    c.ReadHooks,
+		},
+		read: reader{
+			utf8:  c.UTF8,
+			hooks: c.ReadHooks,
 		},
+		defaults:  c.Defaults,
+		narrowing: c.Narrowing,
 	}
 
 	if c.Schema != nil {
 		r.file.schema = c.Schema
-		r.file.rowGroup = convertRowGroupTo(r.file.rowGroup, c.Schema)
+		r.file.rowGroup, r.err = convertRowGroupTo(r.file.rowGroup, c.Schema, r.defaults, r.narrowing)
 	}
 
 	r.read.init(r.file.schema, r.file.rowGroup)
@@ -309,7 +350,7 @@ func fileRowGroupOf(f *File) RowGroup {
 	default:
 		// TODO: should we attempt to merge the row groups via MergeRowGroups
 		// to preserve the global order of sorting columns within the file?
-		return newMultiRowGroup(f.config.ReadMode, rowGroups...)
+		return newMultiRowGroup(f.config, rowGroups...)
 	}
 }
 
@@ -321,33 +362,47 @@ func NewRowGroupReader(rowGroup RowGroup, options ...ReaderOption) *Reader {
 		panic(err)
 	}
 
+	if c.LegacyLists {
+		rowGroup = standardListsRowGroup(rowGroup)
+	}
+
 	if c.Schema != nil {
-		rowGroup = convertRowGroupTo(rowGroup, c.Schema)
+		rowGroup, err = convertRowGroupTo(rowGroup, c.Schema, c.Defaults, c.Narrowing)
 	}
 
 	r := &Reader{
 		file: reader{
 			schema:   rowGroup.Schema(),
 			rowGroup: rowGroup,
+			utf8:     c.UTF8,
+			hooks:    c.ReadHooks,
 		},
+		read: reader{
+			utf8:  c.UTF8,
+			hooks: c.ReadHooks,
+		},
+		defaults:  c.Defaults,
+		narrowing: c.Narrowing,
+		err:       err,
 	}
 
 	r.read.init(r.file.schema, r.file.rowGroup)
 	return r
 }
 
-func convertRowGroupTo(rowGroup RowGroup, schema *Schema) RowGroup {
+// convertRowGroupTo converts rowGroup to schema. The reader constructors do
+// not return errors, the error is retained on the readers and returned by all
+// subsequent reads when the conversion is impossible, for example because it
+// is rejected by the narrowing policy.
+func convertRowGroupTo(rowGroup RowGroup, schema *Schema, defaults ColumnDefaults, narrowing NarrowingPolicy) (RowGroup, error) {
 	if rowGroupSchema := rowGroup.Schema(); !nodesAreEqual(schema, rowGroupSchema) {
-		conv, err := Convert(schema, rowGroupSchema)
+		conv, err := defaults.convert(schema, rowGroupSchema, narrowing)
 		if err != nil {
-			// TODO: this looks like something we should not be panicking on,
-			// but the current NewReader API does not offer a mechanism to
-			// report errors.
-			panic(err)
+			return rowGroup, err
 		}
 		rowGroup = ConvertRowGroup(rowGroup, conv)
 	}
-	return rowGroup
+	return rowGroup, nil
 }
 
 func sizeOf(r io.ReaderAt) (int64, error) {
@@ -383,6 +438,9 @@ func (r *Reader) Reset() {
 //
 // The method returns io.EOF when no more rows can be read from r.
 func (r *Reader) Read(row interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
 	if rowType := dereference(reflect.TypeOf(row)); rowType.Kind() == reflect.Struct {
 		if r.seen != rowType {
 			if err := r.updateReadSchema(rowType); err != nil {
@@ -419,7 +477,7 @@ func (r *Reader) updateReadSchema(rowType reflect.Type) error {
 	if nodesAreEqual(schema, r.file.schema) {
 		r.read.init(schema, r.file.rowGroup)
 	} else {
-		conv, err := Convert(schema, r.file.schema)
+		conv, err := r.defaults.convert(schema, r.file.schema, r.narrowing)
 		if err != nil {
 			return err
 		}
@@ -437,6 +495,9 @@ func (r *Reader) updateReadSchema(rowType reflect.Type) error {
 //
 // The method returns io.EOF when no more rows can be read from r.
 func (r *Reader) ReadRows(rows []Row) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
 	if err := r.file.SeekToRow(r.rowIndex); err != nil {
 		return 0, err
 	}
@@ -453,6 +514,9 @@ func (r *Reader) NumRows() int64 { return r.file.rowGroup.NumRows() }
 
 // SeekToRow positions r at the given row index.
 func (r *Reader) SeekToRow(rowIndex int64) error {
+	if r.err != nil {
+		return r.err
+	}
 	if err := r.file.SeekToRow(rowIndex); err != nil {
 		return err
 	}
@@ -481,11 +545,21 @@ type reader struct {
 	rowGroup RowGroup
 	rows     Rows
 	rowIndex int64
+	// Policy applied to the values of STRING columns, the paths of these
+	// columns are lazily resolved on the first read.
+	utf8    UTF8Policy
+	strings []columnPath
+	// Hooks applied to the values of columns, indexed by column index when
+	// they are lazily resolved on the first read.
+	hooks       map[string]func(Value) (Value, error)
+	columnHooks []func(Value) (Value, error)
 }
 
 func (r *reader) init(schema *Schema, rowGroup RowGroup) {
 	r.schema = schema
 	r.rowGroup = rowGroup
+	r.strings = nil
+	r.columnHooks = nil
 	r.Reset()
 }
 
@@ -524,10 +598,72 @@ func (r *reader) ReadRows(rows []Row) (int, error) {
 		}
 	}
 	n, err := r.rows.ReadRows(rows)
+	if n > 0 && r.utf8 != UTF8PassThrough {
+		if i, err := r.checkUTF8(rows[:n]); err != nil {
+			// Position the rows on the invalid row so the next read reports
+			// the error again instead of skipping it.
+			r.rowIndex += int64(i)
+			if seekErr := r.rows.SeekToRow(r.rowIndex); seekErr != nil {
+				return i, seekErr
+			}
+			return i, err
+		}
+	}
+	if n > 0 && len(r.hooks) > 0 {
+		if i, err := r.applyHooks(rows[:n]); err != nil {
+			r.rowIndex += int64(i)
+			if seekErr := r.rows.SeekToRow(r.rowIndex); seekErr != nil {
+				return i, seekErr
+			}
+			return i, err
+		}
+	}
 	r.rowIndex += int64(n)
 	return n, err
 }
 
+// checkUTF8 applies the UTF-8 policy of r to the values of rows, returning the
+// index of the first invalid row and an error when the policy is UTF8Validate.
+func (r *reader) checkUTF8(rows []Row) (int, error) {
+	if r.strings == nil {
+		r.strings = stringColumnsOf(r.schema)
+	}
+	for i, row := range rows {
+		for j, v := range row {
+			if c := v.Column(); c >= len(r.strings) || r.strings[c] == nil || isValidUTF8(v) {
+				continue
+			}
+			if r.utf8 == UTF8Validate {
+				return i, &RowError{Row: int(r.rowIndex) + i, Path: r.strings[v.Column()], Err: ErrInvalidUTF8}
+			}
+			replaceInvalidUTF8(row[j : j+1])
+		}
+	}
+	return len(rows), nil
+}
+
+// applyHooks applies the read hooks of r to the values of rows, returning the
+// index of the first row for which a hook returned an error.
+func (r *reader) applyHooks(rows []Row) (int, error) {
+	if r.columnHooks == nil {
+		r.columnHooks = columnHooksOf(r.schema, r.hooks)
+	}
+	for i, row := range rows {
+		for j, v := range row {
+			c := v.Column()
+			if c >= len(r.columnHooks) || r.columnHooks[c] == nil || v.IsNull() {
+				continue
+			}
+			w, err := r.columnHooks[c](v)
+			if err != nil {
+				return i, &RowError{Row: int(r.rowIndex) + i, Path: r.schema.Columns()[c], Err: err}
+			}
+			row[j] = w.Level(v.RepetitionLevel(), v.DefinitionLevel(), c)
+		}
+	}
+	return len(rows), nil
+}
+
 func (r *reader) SeekToRow(rowIndex int64) error {
 	if r.rowGroup == nil {
 		return io.ErrClosedPipe
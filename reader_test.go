@@ -9,9 +9,11 @@ import (
 	"math/rand"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/internal/quick"
 )
 
@@ -29,6 +31,7 @@ func TestGenericReader(t *testing.T) {
 	testGenericReader[uuidColumn](t)
 	testGenericReader[timeColumn](t)
 	testGenericReader[timeInMillisColumn](t)
+	testGenericReader[timeOfDayColumn](t)
 	testGenericReader[mapColumn](t)
 	testGenericReader[decimalColumn](t)
 	testGenericReader[addressBook](t)
@@ -377,6 +380,11 @@ var readerTests = []struct {
 		model:    timeInMillisColumn{},
 	},
 
+	{
+		scenario: "TIME",
+		model:    timeOfDayColumn{},
+	},
+
 	{
 		scenario: "DECIMAL",
 		model:    decimalColumn{},
@@ -786,3 +794,285 @@ func TestSeekToRowDictReadMultiplePages(t *testing.T) {
 		t.Fatalf("read != write")
 	}
 }
+
+func TestReadInt96AsRawBytes(t *testing.T) {
+	type Int96Row struct {
+		Value deprecated.Int96 `parquet:"value"`
+	}
+	type RawRow struct {
+		Value [12]byte `parquet:"value,int96"`
+	}
+
+	rows := []RawRow{
+		{Value: [12]byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}},
+		{Value: [12]byte{0xff, 0xfe, 0xfd, 0xfc, 0xfb, 0xfa, 0xf9, 0xf8, 0xf7, 0xf6, 0xf5, 0xf4}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind := f.Schema().Fields()[0].Type().Kind(); kind != parquet.Int96 {
+		t.Fatalf("wrong column type: %s", kind)
+	}
+
+	raw, err := parquet.Read[RawRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(raw, rows) {
+		t.Errorf("raw values mismatch:\nwant = %v\ngot  = %v", rows, raw)
+	}
+
+	values, err := parquet.Read[Int96Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (deprecated.Int96{1, 2, 3}); values[0].Value != want {
+		t.Errorf("wrong INT96 value: want=%v got=%v", want, values[0].Value)
+	}
+
+	// The raw bytes are also read from files written with deprecated.Int96.
+	buf.Reset()
+	if err := parquet.Write(buf, values); err != nil {
+		t.Fatal(err)
+	}
+	var row RawRow
+	schema := parquet.SchemaOf(row)
+	reader := parquet.NewReader(bytes.NewReader(buf.Bytes()), schema)
+	defer reader.Close()
+	if err := reader.Read(&row); err != nil {
+		t.Fatal(err)
+	}
+	if row != rows[0] {
+		t.Errorf("raw value mismatch: want=%v got=%v", rows[0], row)
+	}
+
+	type UntaggedRow struct {
+		Value [12]byte `parquet:"value"`
+	}
+	untagged, err := parquet.Read[UntaggedRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if untagged[1].Value != rows[1].Value {
+		t.Errorf("raw value mismatch: want=%v got=%v", rows[1].Value, untagged[1].Value)
+	}
+}
+
+func TestGenericReaderTypeCoercion(t *testing.T) {
+	type FileRow struct {
+		Int32  int32   `parquet:"int32"`
+		Float  float32 `parquet:"float"`
+		Int64  int64   `parquet:"int64"`
+		Double float64 `parquet:"double"`
+	}
+	type ReadRow struct {
+		Int32  int64   `parquet:"int32"`
+		Float  float64 `parquet:"float"`
+		Int64  int32   `parquet:"int64"`
+		Double float32 `parquet:"double"`
+	}
+
+	write := func(rows ...FileRow) *bytes.Reader {
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, rows); err != nil {
+			t.Fatal(err)
+		}
+		return bytes.NewReader(buffer.Bytes())
+	}
+	read := func(file *bytes.Reader, options ...parquet.ReaderOption) ([]ReadRow, error) {
+		r := parquet.NewGenericReader[ReadRow](file, options...)
+		defer r.Close()
+		rows := make([]ReadRow, r.NumRows())
+		n, err := r.Read(rows)
+		if err == io.EOF {
+			err = nil
+		}
+		return rows[:n], err
+	}
+
+	t.Run("values in range", func(t *testing.T) {
+		rows, err := read(write(
+			FileRow{Int32: -1, Float: 1.5, Int64: math.MaxInt32, Double: 0.25},
+			FileRow{Int32: math.MaxInt32, Float: -2.5, Int64: math.MinInt32, Double: -1e10},
+		))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []ReadRow{
+			{Int32: -1, Float: 1.5, Int64: math.MaxInt32, Double: 0.25},
+			{Int32: math.MaxInt32, Float: -2.5, Int64: math.MinInt32, Double: -1e10},
+		}
+		if !reflect.DeepEqual(rows, want) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, rows)
+		}
+	})
+
+	t.Run("integer overflow", func(t *testing.T) {
+		_, err := read(write(FileRow{Int64: math.MaxInt32 + 1}))
+		if !errors.Is(err, parquet.ErrNarrowingConversion) {
+			t.Errorf("expected error wrapping ErrNarrowingConversion, got %v", err)
+		}
+	})
+
+	t.Run("float overflow", func(t *testing.T) {
+		_, err := read(write(FileRow{Double: math.MaxFloat64}))
+		if !errors.Is(err, parquet.ErrNarrowingConversion) {
+			t.Errorf("expected error wrapping ErrNarrowingConversion, got %v", err)
+		}
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		rows, err := read(write(FileRow{Int64: 1<<32 + 42, Double: math.MaxFloat64}), parquet.NarrowingTruncate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows[0].Int64 != 42 || !math.IsInf(float64(rows[0].Double), +1) {
+			t.Errorf("values were not truncated: %+v", rows[0])
+		}
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		_, err := read(write(FileRow{}), parquet.NarrowingReject)
+		if !errors.Is(err, parquet.ErrNarrowingConversion) {
+			t.Errorf("expected error wrapping ErrNarrowingConversion, got %v", err)
+		}
+	})
+}
+
+func TestGenericReaderNarrowingConversions(t *testing.T) {
+	tests := []struct {
+		scenario string
+		read     func(...parquet.ReaderOption) (any, error)
+		want     any
+	}{
+		{"double to int32", readNarrowed[float64, int32](1e20), nil},
+		{"double to int32 with fraction", readNarrowed[float64, int32](3.9), nil},
+		{"double to int64 with fraction", readNarrowed[float64, int64](3.9), nil},
+		{"float to int64 with fraction", readNarrowed[float32, int64](0.5), nil},
+		{"double to int64 out of range", readNarrowed[float64, int64](1 << 63), nil},
+		{"int64 to double", readNarrowed[int64, float64](1<<53 + 1), nil},
+		{"int64 to float", readNarrowed[int64, float32](1<<24 + 1), nil},
+		{"int32 to float", readNarrowed[int32, float32](1<<24 + 1), nil},
+		{"integral double to int32", readNarrowed[float64, int32](-42), int32(-42)},
+		{"integral double to int64", readNarrowed[float64, int64](-1 << 62), int64(-1 << 62)},
+		{"exact int64 to double", readNarrowed[int64, float64](1 << 53), float64(1 << 53)},
+		{"exact int64 to float", readNarrowed[int64, float32](-1 << 40), float32(-1 << 40)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			got, err := test.read()
+			if test.want == nil {
+				if !errors.Is(err, parquet.ErrNarrowingConversion) {
+					t.Errorf("expected error wrapping ErrNarrowingConversion, got %v (%v)", err, got)
+				}
+			} else if err != nil {
+				t.Error(err)
+			} else if got != test.want {
+				t.Errorf("wrong value: want=%v got=%v", test.want, got)
+			}
+
+			if _, err := test.read(parquet.NarrowingReject); !errors.Is(err, parquet.ErrNarrowingConversion) {
+				t.Errorf("expected the reject policy to return an error wrapping ErrNarrowingConversion, got %v", err)
+			}
+		})
+	}
+}
+
+func readNarrowed[From, To any](value From) func(...parquet.ReaderOption) (any, error) {
+	type FileRow struct {
+		Value From `parquet:"value"`
+	}
+	type ReadRow struct {
+		Value To `parquet:"value"`
+	}
+	return func(options ...parquet.ReaderOption) (any, error) {
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, []FileRow{{Value: value}}); err != nil {
+			return nil, err
+		}
+		r := parquet.NewGenericReader[ReadRow](bytes.NewReader(buffer.Bytes()), options...)
+		defer r.Close()
+		rows := make([]ReadRow, 1)
+		if _, err := r.Read(rows); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return rows[0].Value, nil
+	}
+}
+
+func TestReadHook(t *testing.T) {
+	type Tag struct {
+		Key string `parquet:"key"`
+	}
+	type Row struct {
+		Name    string `parquet:"name"`
+		Seconds int64  `parquet:"seconds"`
+		Tags    []Tag  `parquet:"tags"`
+	}
+
+	buffer := new(bytes.Buffer)
+	rows := []Row{
+		{Name: "a", Seconds: 1, Tags: []Tag{}},
+		{Name: "b", Seconds: 2, Tags: []Tag{{Key: "x"}, {Key: "y"}}},
+		{Name: "", Seconds: 3, Tags: []Tag{}},
+	}
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	file := bytes.NewReader(buffer.Bytes())
+
+	upper := func(v parquet.Value) (parquet.Value, error) {
+		return parquet.ValueOf(strings.ToUpper(v.String())), nil
+	}
+	millis := func(v parquet.Value) (parquet.Value, error) {
+		return parquet.ValueOf(v.Int64() * 1000), nil
+	}
+
+	r := parquet.NewGenericReader[Row](file,
+		parquet.ReadHook("name", upper),
+		parquet.ReadHook("seconds", millis),
+		parquet.ReadHook("seconds", millis),
+		parquet.ReadHook("tags.key", upper),
+	)
+	got := make([]Row, len(rows))
+	if n, err := r.Read(got); n != len(rows) || (err != nil && err != io.EOF) {
+		t.Fatalf("reading rows: n=%d err=%v", n, err)
+	}
+	r.Close()
+
+	want := []Row{
+		{Name: "A", Seconds: 1e6, Tags: []Tag{}},
+		{Name: "B", Seconds: 2e6, Tags: []Tag{{Key: "X"}, {Key: "Y"}}},
+		{Name: "", Seconds: 3e6, Tags: []Tag{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got)
+	}
+
+	errEmptyName := errors.New("empty name")
+	r = parquet.NewGenericReader[Row](file,
+		parquet.ReadHook("name", func(v parquet.Value) (parquet.Value, error) {
+			if v.String() == "" {
+				return v, errEmptyName
+			}
+			return v, nil
+		}),
+	)
+	defer r.Close()
+
+	n, err := r.Read(got)
+	if n != 2 || !errors.Is(err, errEmptyName) {
+		t.Fatalf("expected validation error after 2 rows: n=%d err=%v", n, err)
+	}
+	var rowErr *parquet.RowError
+	if !errors.As(err, &rowErr) || rowErr.Row != 2 || !reflect.DeepEqual(rowErr.Path, []string{"name"}) {
+		t.Errorf("wrong row error: %v", err)
+	}
+}
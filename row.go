@@ -499,13 +499,36 @@ func deconstructFuncOfMap(columnIndex int16, node Node) (int16, deconstructFunc)
 
 		for _, key := range mapValue.MapKeys() {
 			k.Set(key.Convert(keyType))
-			v.Set(mapValue.MapIndex(key).Convert(valueType))
+			v.Set(convertMapValue(mapValue.MapIndex(key), valueType))
 			deconstruct(columns, levels, elem)
 			levels.repetitionLevel = levels.repetitionDepth
 		}
 	}
 }
 
+// convertMapValue converts v to the type t of map values. The Go types of the
+// values of optional groups are pointers, which allows maps to hold null values
+// (e.g. map[string]*T), but maps of non-pointer values may also be used with
+// optional groups, in which case the values are converted from and to pointers;
+// null values are then read as zero values.
+func convertMapValue(v reflect.Value, t reflect.Type) reflect.Value {
+	switch {
+	case v.Type().ConvertibleTo(t):
+		return v.Convert(t)
+	case t.Kind() == reflect.Ptr && v.Type().ConvertibleTo(t.Elem()):
+		p := reflect.New(t.Elem())
+		p.Elem().Set(v.Convert(t.Elem()))
+		return p
+	case v.Kind() == reflect.Ptr && v.Type().Elem().ConvertibleTo(t):
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+		return v.Elem().Convert(t)
+	default:
+		return v.Convert(t) // panics with the conversion error
+	}
+}
+
 //go:noinline
 func deconstructFuncOfGroup(columnIndex int16, node Node) (int16, deconstructFunc) {
 	fields := node.Fields()
@@ -529,7 +552,7 @@ func deconstructFuncOfGroup(columnIndex int16, node Node) (int16, deconstructFun
 //go:noinline
 func deconstructFuncOfLeaf(columnIndex int16, node Node) (int16, deconstructFunc) {
 	if columnIndex > MaxColumnIndex {
-		panic("row cannot be deconstructed because it has more than 127 columns")
+		panic(fmt.Sprintf("row cannot be deconstructed because it has more than %d columns", MaxColumnIndex))
 	}
 	typ := node.Type()
 	kind := typ.Kind()
@@ -747,7 +770,7 @@ func reconstructFuncOfMap(columnIndex int16, node Node) (int16, reconstructFunc)
 				values[j] = column[len(column):len(column):cap(column)]
 			}
 
-			value.SetMapIndex(elem.Field(0).Convert(k), elem.Field(1).Convert(v))
+			value.SetMapIndex(elem.Field(0).Convert(k), convertMapValue(elem.Field(1), v))
 			elem.Set(keyValueZero)
 			levels.repetitionLevel = levels.repetitionDepth
 		}
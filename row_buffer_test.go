@@ -29,6 +29,7 @@ func TestRowBuffer(t *testing.T) {
 	testRowBuffer[uuidColumn](t)
 	testRowBuffer[timeColumn](t)
 	testRowBuffer[timeInMillisColumn](t)
+	testRowBuffer[timeOfDayColumn](t)
 	testRowBuffer[mapColumn](t)
 	testRowBuffer[decimalColumn](t)
 	testRowBuffer[addressBook](t)
@@ -178,6 +178,8 @@ type rowGroupRows struct {
 	closed       bool
 	done         chan<- struct{}
 	pageReadMode ReadMode
+	// Maximum number of values per row in each column, zero means no limit.
+	maxRepeatedValues int
 }
 
 type columnChunkRows struct {
@@ -203,6 +205,12 @@ func newRowGroupRows(rowGroup RowGroup, pageReadMode ReadMode) *rowGroupRows {
 	}
 }
 
+func newFileRowGroupRows(rowGroup RowGroup, config *FileConfig) *rowGroupRows {
+	rows := newRowGroupRows(rowGroup, config.ReadMode)
+	rows.maxRepeatedValues = config.MaxRepeatedValues
+	return rows
+}
+
 func (r *rowGroupRows) init() {
 	columns := r.rowGroup.ColumnChunks()
 
@@ -377,6 +385,7 @@ func (r *rowGroupRows) readRows(rows []Row) (int, error) {
 		for columnIndex := range r.columns {
 			col := &r.columns[columnIndex]
 			buf := r.buffer(columnIndex)
+			rowLength := len(rows[i])
 
 			skip := int32(1)
 			for {
@@ -405,6 +414,10 @@ func (r *rowGroupRows) readRows(rows []Row) (int, error) {
 
 				rows[i] = append(rows[i], buf[col.offset:endOffset]...)
 
+				if r.maxRepeatedValues > 0 && len(rows[i])-rowLength > r.maxRepeatedValues {
+					return i, fmt.Errorf("reading column %d: %w: limit of %d values exceeded", columnIndex, ErrTooManyRepeatedValues, r.maxRepeatedValues)
+				}
+
 				if col.offset = endOffset; col.offset < col.length {
 					break
 				}
@@ -523,6 +536,10 @@ type emptyBloomFilter struct{}
 func (emptyBloomFilter) ReadAt([]byte, int64) (int, error) { return 0, io.EOF }
 func (emptyBloomFilter) Size() int64                       { return 0 }
 func (emptyBloomFilter) Check(Value) (bool, error)         { return false, nil }
+func (emptyBloomFilter) CheckMany(v []Value, found []bool) error {
+	clear(found[:len(v)])
+	return nil
+}
 
 type emptyRows struct{ schema *Schema }
 
@@ -532,6 +549,18 @@ func (r emptyRows) ReadRows([]Row) (int, error)          { return 0, io.EOF }
 func (r emptyRows) SeekToRow(int64) error                { return nil }
 func (r emptyRows) WriteRowsTo(RowWriter) (int64, error) { return 0, nil }
 
+// errorRows is the implementation of Rows returned by row groups which cannot
+// produce their rows, the error is reported by all reads.
+type errorRows struct {
+	schema *Schema
+	err    error
+}
+
+func (r errorRows) Close() error                { return nil }
+func (r errorRows) Schema() *Schema             { return r.schema }
+func (r errorRows) ReadRows([]Row) (int, error) { return 0, r.err }
+func (r errorRows) SeekToRow(int64) error       { return r.err }
+
 type emptyPages struct{}
 
 func (emptyPages) ReadPage() (Page, error) { return nil, io.EOF }
@@ -544,4 +573,6 @@ var (
 
 	_ RowReaderWithSchema = emptyRows{}
 	_ RowWriterTo         = emptyRows{}
+
+	_ RowReaderWithSchema = errorRows{}
 )
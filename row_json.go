@@ -0,0 +1,194 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go/deprecated"
+)
+
+// RowsToJSON writes the rows read from rows to w as newline-delimited JSON,
+// one object per row, with the fields in the order of schema. If schema is
+// nil, the schema of rows is used.
+//
+// Leaf values are rendered according to their logical types: timestamps and
+// dates are formatted as RFC 3339 strings, times of day as HH:MM:SS[.fraction]
+// strings, decimals and UUIDs as strings, and JSON values are embedded as-is.
+// Binary values without a logical type are encoded in base64, INT96 values are
+// rendered as strings holding their decimal representation, and non-finite
+// floating point numbers as the strings "NaN", "Infinity" and "-Infinity".
+// Groups are rendered as objects, lists and repeated fields as arrays, and MAP
+// groups as objects where the keys are formatted with fmt.Sprint.
+//
+// The rows are written in batches as they are read, so the function can export
+// row groups which do not fit in memory. It returns when rows reaches the end
+// of its sequence, without closing it.
+func RowsToJSON(w io.Writer, schema *Schema, rows Rows) error {
+	if schema == nil {
+		schema = rows.Schema()
+	}
+	buffer := make([]Row, defaultRowBufferSize)
+	defer clearRows(buffer)
+	var b []byte
+
+	for {
+		n, err := rows.ReadRows(buffer)
+		b = b[:0]
+		for _, row := range buffer[:n] {
+			b = appendRowJSON(b, schema, RowToMap(schema, row))
+			b = append(b, '\n')
+		}
+		if len(b) > 0 {
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if n == 0 {
+			return io.ErrNoProgress
+		}
+	}
+}
+
+func appendRowJSON(b []byte, node Node, value any) []byte {
+	if elems, ok := value.([]any); ok && node.Repeated() {
+		return appendRowJSONList(b, elems, func(b []byte, elem any) []byte {
+			return appendRowJSONRequired(b, node, elem)
+		})
+	}
+	return appendRowJSONRequired(b, node, value)
+}
+
+func appendRowJSONRequired(b []byte, node Node, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(b, "null"...)
+	case map[string]any:
+		if node.Leaf() {
+			break
+		}
+		if keyValue := mapKeyValueGroupOf(node); keyValue != nil {
+			return appendRowJSONEntries(b, fieldByName(keyValue, "value"), v)
+		}
+		b = append(b, '{')
+		for i, field := range node.Fields() {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, field.Name())
+			b = append(b, ':')
+			b = appendRowJSON(b, field, v[field.Name()])
+		}
+		return append(b, '}')
+	case []any:
+		if element := listElementGroupOf(node); element != nil {
+			return appendRowJSONList(b, v, func(b []byte, elem any) []byte {
+				if element.Repeated() {
+					return appendRowJSONRequired(b, element, elem)
+				}
+				return appendRowJSON(b, element, elem)
+			})
+		}
+	}
+	return appendJSONLeaf(b, node, value)
+}
+
+func appendRowJSONList(b []byte, elems []any, appendElem func([]byte, any) []byte) []byte {
+	b = append(b, '[')
+	for i, elem := range elems {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendElem(b, elem)
+	}
+	return append(b, ']')
+}
+
+func appendRowJSONEntries(b []byte, value Node, entries map[string]any) []byte {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	b = append(b, '{')
+	for i, key := range keys {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONString(b, key)
+		b = append(b, ':')
+		b = appendRowJSON(b, value, entries[key])
+	}
+	return append(b, '}')
+}
+
+// appendJSONLeaf appends the JSON representation of a value returned by the
+// GoValue method of Value for the given leaf node.
+func appendJSONLeaf(b []byte, node Node, value any) []byte {
+	lt := node.Type().LogicalType()
+
+	switch v := value.(type) {
+	case bool:
+		return strconv.AppendBool(b, v)
+	case int64:
+		return strconv.AppendInt(b, v, 10)
+	case uint64:
+		return strconv.AppendUint(b, v, 10)
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return append(b, `"NaN"`...)
+		case math.IsInf(v, +1):
+			return append(b, `"Infinity"`...)
+		case math.IsInf(v, -1):
+			return append(b, `"-Infinity"`...)
+		}
+		bitSize := 64
+		if node.Type().Kind() == Float {
+			bitSize = 32
+		}
+		return strconv.AppendFloat(b, v, 'g', -1, bitSize)
+	case string:
+		if lt != nil && lt.Json != nil {
+			// Compacting the value ensures it does not span multiple lines.
+			buf := bytes.NewBuffer(b)
+			if err := json.Compact(buf, []byte(v)); err == nil {
+				return buf.Bytes()
+			}
+		}
+		return appendJSONString(b, v)
+	case []byte:
+		return appendJSONString(b, base64.StdEncoding.EncodeToString(v))
+	case time.Time:
+		if lt != nil && lt.Date != nil {
+			return appendJSONString(b, v.Format(time.DateOnly))
+		}
+		return appendJSONString(b, v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return appendJSONString(b, TimeOfDay(v).String())
+	case uuid.UUID:
+		return appendJSONString(b, v.String())
+	case deprecated.Int96:
+		return appendJSONString(b, v.String())
+	}
+	return appendJSONString(b, fmt.Sprint(value))
+}
+
+func appendJSONString(b []byte, s string) []byte {
+	j, _ := json.Marshal(s)
+	return append(b, j...)
+}
@@ -0,0 +1,257 @@
+package parquet
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// RowToMap converts row to a map of the field names of schema to the values of
+// the row.
+//
+// Leaf values are converted by calling GoValue with the leaf node of the
+// schema, and null values are represented as nil. Groups are represented as
+// map[string]any values, repeated fields and LIST groups as []any slices, and
+// MAP groups as map[string]any values where the keys are formatted with
+// fmt.Sprint.
+//
+// The function is intended for debugging and generic tooling; applications
+// which know the structure of rows ahead of time should prefer calling the
+// Reconstruct method of Schema.
+func RowToMap(schema *Schema, row Row) map[string]any {
+	columns := make([][]Value, len(schema.Columns()))
+	row.Range(func(columnIndex int, columnValues []Value) bool {
+		if columnIndex < len(columns) {
+			columns[columnIndex] = columnValues
+		}
+		return true
+	})
+	m, _ := rowMapValueOf(schema, levels{}, columns).(map[string]any)
+	return m
+}
+
+// FormatRow returns a compact, human readable representation of row, with the
+// values of columns associated with the names of the schema fields. For
+// example:
+//
+//	{id: 1, name: "Luke", tags: ["jedi", "pilot"], scores: {"math": 0.9}}
+//
+// Fields are formatted in the order of the schema, map entries in the order of
+// their keys.
+func FormatRow(schema *Schema, row Row) string {
+	return string(appendRowMapValue(nil, schema, RowToMap(schema, row)))
+}
+
+func rowMapValueOf(node Node, lv levels, columns [][]Value) any {
+	switch {
+	case node.Optional():
+		lv.definitionLevel++
+		if isNullAtLevels(columns, lv) {
+			return nil
+		}
+	case node.Repeated():
+		return rowMapRepeatedValueOf(lv, columns, func(elemLevels levels, elemColumns [][]Value) any {
+			return rowMapRequiredValueOf(node, elemLevels, elemColumns)
+		})
+	}
+	return rowMapRequiredValueOf(node, lv, columns)
+}
+
+func rowMapRequiredValueOf(node Node, lv levels, columns [][]Value) any {
+	if node.Leaf() {
+		if len(columns) == 0 || len(columns[0]) == 0 {
+			return nil
+		}
+		return columns[0][0].GoValue(node)
+	}
+
+	if keyValue := mapKeyValueGroupOf(node); keyValue != nil {
+		entries := rowMapRepeatedValueOf(lv, columns, func(elemLevels levels, elemColumns [][]Value) any {
+			return rowMapRequiredValueOf(keyValue, elemLevels, elemColumns)
+		})
+		m := make(map[string]any, len(entries))
+		for _, entry := range entries {
+			if kv, ok := entry.(map[string]any); ok {
+				m[fmt.Sprint(kv["key"])] = kv["value"]
+			}
+		}
+		return m
+	}
+
+	if element := listElementGroupOf(node); element != nil {
+		return rowMapRepeatedValueOf(lv, columns, func(elemLevels levels, elemColumns [][]Value) any {
+			if element.Repeated() {
+				return rowMapRequiredValueOf(element, elemLevels, elemColumns)
+			}
+			return rowMapValueOf(element, elemLevels, elemColumns)
+		})
+	}
+
+	fields := node.Fields()
+	m := make(map[string]any, len(fields))
+	offset := 0
+	for _, field := range fields {
+		n := numLeafColumns(field, 0)
+		end := min(offset+n, len(columns))
+		m[field.Name()] = rowMapValueOf(field, lv, columns[offset:end:end])
+		offset = end
+	}
+	return m
+}
+
+func rowMapRepeatedValueOf(levels levels, columns [][]Value, elem func(levels, [][]Value) any) []any {
+	levels.repetitionDepth++
+	levels.definitionLevel++
+
+	if isNullAtLevels(columns, levels) {
+		return []any{}
+	}
+
+	column := columns[0]
+	n := 0
+	for i := 0; i < len(column); {
+		i++
+		n++
+		for i < len(column) && column[i].repetitionLevel > levels.repetitionDepth {
+			i++
+		}
+	}
+
+	values := make([][]Value, len(columns))
+	copy(values, columns)
+	elems := make([]any, n)
+
+	for i := range elems {
+		element := make([][]Value, len(values))
+		for j, column := range values {
+			k := 0
+			if len(column) > 0 {
+				k = 1
+			}
+			for k < len(column) && column[k].repetitionLevel > levels.repetitionDepth {
+				k++
+			}
+			element[j], values[j] = column[:k:k], column[k:]
+		}
+		elems[i] = elem(levels, element)
+	}
+
+	return elems
+}
+
+// isNullAtLevels returns true if the values of columns indicate that the node
+// at the given levels is null, or does not contain any elements.
+func isNullAtLevels(columns [][]Value, levels levels) bool {
+	return len(columns) == 0 || len(columns[0]) == 0 || columns[0][0].definitionLevel < levels.definitionLevel
+}
+
+// mapKeyValueGroupOf returns the repeated key_value group of node if it is a
+// MAP group, or nil otherwise.
+func mapKeyValueGroupOf(node Node) Node {
+	if !isMap(node) {
+		return nil
+	}
+	fields := node.Fields()
+	if len(fields) != 1 || !fields[0].Repeated() || fields[0].Leaf() {
+		return nil
+	}
+	if fieldByName(fields[0], "key") == nil || fieldByName(fields[0], "value") == nil {
+		return nil
+	}
+	return fields[0]
+}
+
+// listElementGroupOf returns the node holding the elements of node if it is a
+// LIST group, or nil otherwise. The returned node is repeated if node uses the
+// legacy two-level structure.
+func listElementGroupOf(node Node) Node {
+	if !isListGroup(node) {
+		return nil
+	}
+	fields := node.Fields()
+	if len(fields) != 1 || !fields[0].Repeated() {
+		return nil
+	}
+	_, element := unwrapNestedNode(node)
+	return element
+}
+
+func appendRowMapValue(b []byte, node Node, value any) []byte {
+	if elems, ok := value.([]any); ok && node.Repeated() {
+		return appendRowMapList(b, elems, func(b []byte, elem any) []byte {
+			return appendRowMapRequiredValue(b, node, elem)
+		})
+	}
+	return appendRowMapRequiredValue(b, node, value)
+}
+
+func appendRowMapRequiredValue(b []byte, node Node, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(b, "null"...)
+	case map[string]any:
+		if node.Leaf() {
+			break
+		}
+		if keyValue := mapKeyValueGroupOf(node); keyValue != nil {
+			return appendRowMapEntries(b, fieldByName(keyValue, "value"), v)
+		}
+		b = append(b, '{')
+		for i, field := range node.Fields() {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b = append(b, field.Name()...)
+			b = append(b, ": "...)
+			b = appendRowMapValue(b, field, v[field.Name()])
+		}
+		return append(b, '}')
+	case []any:
+		if element := listElementGroupOf(node); element != nil {
+			return appendRowMapList(b, v, func(b []byte, elem any) []byte {
+				if element.Repeated() {
+					return appendRowMapRequiredValue(b, element, elem)
+				}
+				return appendRowMapValue(b, element, elem)
+			})
+		}
+	case string:
+		return strconv.AppendQuote(b, v)
+	case []byte:
+		return fmt.Appendf(b, "%q", v)
+	case time.Time:
+		return v.AppendFormat(b, time.RFC3339Nano)
+	}
+	return fmt.Append(b, value)
+}
+
+func appendRowMapList(b []byte, elems []any, appendElem func([]byte, any) []byte) []byte {
+	b = append(b, '[')
+	for i, elem := range elems {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = appendElem(b, elem)
+	}
+	return append(b, ']')
+}
+
+func appendRowMapEntries(b []byte, value Node, entries map[string]any) []byte {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	b = append(b, '{')
+	for i, key := range keys {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = strconv.AppendQuote(b, key)
+		b = append(b, ": "...)
+		b = appendRowMapValue(b, value, entries[key])
+	}
+	return append(b, '}')
+}
@@ -0,0 +1,119 @@
+package parquet
+
+import "io"
+
+// RowRange represents the range of rows starting at index Start (included) and
+// ending at index End (excluded).
+type RowRange struct {
+	Start int64
+	End   int64
+}
+
+// NumRows returns the number of rows in the range.
+func (r RowRange) NumRows() int64 { return r.End - r.Start }
+
+// RowSelection is a list of row ranges sorted by increasing row index, which do
+// not overlap each other.
+//
+// Row selections are usually produced by evaluating predicates over the page
+// index of column chunks (see FilterPages), and used to skip the rows that the
+// program has no interest in when reading a row group (see SelectRows).
+type RowSelection []RowRange
+
+// NumRows returns the total number of rows in the selection.
+func (s RowSelection) NumRows() (numRows int64) {
+	for _, r := range s {
+		numRows += r.NumRows()
+	}
+	return numRows
+}
+
+// Intersect returns the selection of rows which are present in both s and
+// other.
+//
+// The method is useful to combine selections produced for multiple columns of
+// a row group.
+func (s RowSelection) Intersect(other RowSelection) RowSelection {
+	var selection RowSelection
+	for i, j := 0, 0; i < len(s) && j < len(other); {
+		r := RowRange{
+			Start: max(s[i].Start, other[j].Start),
+			End:   min(s[i].End, other[j].End),
+		}
+		if r.Start < r.End {
+			selection = append(selection, r)
+		}
+		if s[i].End < other[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return selection
+}
+
+// union returns the selection of rows which are present in either s or other.
+func (s RowSelection) union(other RowSelection) RowSelection {
+	var selection RowSelection
+	for i, j := 0, 0; i < len(s) || j < len(other); {
+		var r RowRange
+		if j == len(other) || (i < len(s) && s[i].Start <= other[j].Start) {
+			r, i = s[i], i+1
+		} else {
+			r, j = other[j], j+1
+		}
+		if n := len(selection); n > 0 && selection[n-1].End >= r.Start {
+			selection[n-1].End = max(selection[n-1].End, r.End)
+		} else {
+			selection = append(selection, r)
+		}
+	}
+	return selection
+}
+
+// SelectRows constructs a RowReader which exposes the rows of the given
+// selection, skipping all other rows.
+//
+// The rows are expected to be positioned at the beginning of the row group,
+// rows are skipped by calling SeekToRow, so reading a selection only decodes
+// the pages which contain selected rows.
+func SelectRows(rows Rows, selection RowSelection) RowReader {
+	return &selectRowReader{rows: rows, selection: selection}
+}
+
+type selectRowReader struct {
+	rows      Rows
+	selection RowSelection
+	rowIndex  int64
+}
+
+func (r *selectRowReader) ReadRows(rows []Row) (int, error) {
+	for len(r.selection) > 0 && r.selection[0].End <= r.rowIndex {
+		r.selection = r.selection[1:]
+	}
+	if len(r.selection) == 0 {
+		return 0, io.EOF
+	}
+
+	next := r.selection[0]
+	if r.rowIndex < next.Start {
+		if err := r.rows.SeekToRow(next.Start); err != nil {
+			return 0, err
+		}
+		r.rowIndex = next.Start
+	}
+
+	if remain := next.End - r.rowIndex; remain < int64(len(rows)) {
+		rows = rows[:remain]
+	}
+
+	n, err := r.rows.ReadRows(rows)
+	r.rowIndex += int64(n)
+
+	if err == io.EOF && r.rowIndex < next.End {
+		r.selection = nil
+	} else if err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
@@ -1,9 +1,13 @@
 package parquet_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
+	"math"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parquet-go/parquet-go"
@@ -662,3 +666,155 @@ func BenchmarkReconstruct(b *testing.B) {
 		}
 	}
 }
+
+type rowMapAddress struct {
+	City string  `parquet:"city"`
+	Zip  *string `parquet:"zip"`
+}
+
+type rowMapRecord struct {
+	ID       int64              `parquet:"id"`
+	Name     string             `parquet:"name"`
+	Nickname *string            `parquet:"nickname"`
+	Tags     []string           `parquet:"tags,list"`
+	Scores   map[string]float64 `parquet:"scores"`
+	Address  rowMapAddress      `parquet:"address"`
+	Phones   []rowMapAddress    `parquet:"phones"`
+}
+
+func TestRowToMap(t *testing.T) {
+	zip := "94110"
+	record := rowMapRecord{
+		ID:     1,
+		Name:   "Luke",
+		Tags:   []string{"jedi", "pilot"},
+		Scores: map[string]float64{"math": 0.5, "art": 1},
+		Address: rowMapAddress{
+			City: "Tatooine",
+			Zip:  &zip,
+		},
+		Phones: []rowMapAddress{{City: "A"}, {City: "B", Zip: &zip}},
+	}
+
+	want := map[string]any{
+		"id":       int64(1),
+		"name":     "Luke",
+		"nickname": nil,
+		"tags":     []any{"jedi", "pilot"},
+		"scores":   map[string]any{"math": 0.5, "art": 1.0},
+		"address":  map[string]any{"city": "Tatooine", "zip": "94110"},
+		"phones": []any{
+			map[string]any{"city": "A", "zip": nil},
+			map[string]any{"city": "B", "zip": "94110"},
+		},
+	}
+
+	schema := parquet.SchemaOf(record)
+	row := schema.Deconstruct(nil, &record)
+
+	if got := parquet.RowToMap(schema, row); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong map:\nwant = %#v\ngot  = %#v", want, got)
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, []rowMapRecord{record}); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := make([]parquet.Row, 1)
+	reader := parquet.NewRowGroupRowReader(file.RowGroups()[0])
+	if n, _ := reader.ReadRows(rows); n != 1 {
+		t.Fatalf("wrong number of rows read: %d", n)
+	}
+	if got := parquet.RowToMap(file.Schema(), rows[0]); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong map from file:\nwant = %#v\ngot  = %#v", want, got)
+	}
+}
+
+func TestFormatRow(t *testing.T) {
+	zip := "94110"
+	record := rowMapRecord{
+		ID:      2,
+		Name:    "Leia",
+		Tags:    []string{},
+		Scores:  map[string]float64{"math": 0.5, "art": 1},
+		Address: rowMapAddress{City: "Alderaan"},
+		Phones:  []rowMapAddress{{City: "B", Zip: &zip}},
+	}
+
+	schema := parquet.SchemaOf(record)
+	row := schema.Deconstruct(nil, &record)
+
+	const want = `{id: 2, name: "Leia", nickname: null, tags: [], scores: {"art": 1, "math": 0.5}, ` +
+		`address: {city: "Alderaan", zip: null}, phones: [{city: "B", zip: "94110"}]}`
+
+	if got := parquet.FormatRow(schema, row); got != want {
+		t.Errorf("wrong formatted row:\nwant = %s\ngot  = %s", want, got)
+	}
+}
+
+func TestRowsToJSON(t *testing.T) {
+	type Event struct {
+		ID      int64              `parquet:"id"`
+		Time    time.Time          `parquet:"time,timestamp(microsecond)"`
+		Day     int32              `parquet:"day,date"`
+		Price   int64              `parquet:"price,decimal(2:10)"`
+		Payload []byte             `parquet:"payload"`
+		Meta    string             `parquet:"meta,json"`
+		Ratio   float64            `parquet:"ratio"`
+		Labels  map[string]string  `parquet:"labels"`
+		Tags    []string           `parquet:"tags,list"`
+		Parent  *int64             `parquet:"parent,optional"`
+		Scores  map[string]float32 `parquet:"scores"`
+	}
+
+	events := []Event{
+		{
+			ID:      1,
+			Time:    time.Date(2024, 3, 1, 12, 30, 0, 500000000, time.UTC),
+			Day:     19783,
+			Price:   -1234,
+			Payload: []byte{0, 1, 2, 0xff},
+			Meta:    "{\n  \"source\": \"web\"\n}",
+			Ratio:   math.NaN(),
+			Labels:  map[string]string{"b": "2", "a": "1"},
+			Tags:    []string{"x", "y"},
+			Scores:  map[string]float32{"s": 0.1},
+		},
+		{ID: 2, Time: time.Unix(0, 0).UTC(), Ratio: 1.5},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, events); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := file.RowGroups()[0].Rows()
+	defer rows.Close()
+
+	output := new(bytes.Buffer)
+	if err := parquet.RowsToJSON(output, nil, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `{"id":1,"time":"2024-03-01T12:30:00.5Z","day":"2024-03-01","price":"-12.34","payload":"AAEC/w==",` +
+		`"meta":{"source":"web"},"ratio":"NaN","labels":{"a":"1","b":"2"},"tags":["x","y"],"parent":null,"scores":{"s":0.1}}
+{"id":2,"time":"1970-01-01T00:00:00Z","day":"1970-01-01","price":"0.00","payload":"","meta":"",` +
+		`"ratio":1.5,"labels":{},"tags":[],"parent":null,"scores":{}}
+`
+	if got := output.String(); got != want {
+		t.Errorf("wrong JSON output:\nwant = %s\ngot  = %s", want, got)
+	}
+
+	for _, line := range bytes.Split(bytes.TrimSpace(output.Bytes()), []byte("\n")) {
+		if !json.Valid(line) {
+			t.Errorf("invalid JSON line: %s", line)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package parquet
+
+import (
+	"io"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// SampleWriter is a RowWriter which forwards rows to another writer, and
+// retains a uniform random sample of the rows that were written. When the
+// SampleWriter is closed, the sample is written as a parquet file with the same
+// schema as the rows, which provides a small sidecar file that can be used to
+// quickly preview or profile the content of large data files.
+//
+// The sample is maintained with reservoir sampling, so the memory footprint of
+// the SampleWriter is bounded by the size of the sample regardless of the
+// number of rows written. Rows of the sample are written in the order they were
+// written to the SampleWriter.
+//
+// SampleWriter does not close the writer that it forwards rows to, programs
+// must close both writers when they are done writing rows:
+//
+//	writer := parquet.NewGenericWriter[Row](output)
+//	sampler := parquet.NewSampleWriter(writer, sample, 1000)
+//	...
+//	if err := sampler.Close(); err != nil {
+//		...
+//	}
+//	if err := writer.Close(); err != nil {
+//		...
+//	}
+type SampleWriter struct {
+	writer  RowWriter
+	sample  *Writer
+	size    int
+	rand    *rand.Rand
+	rows    []Row
+	indexes []int64
+	seen    int64
+}
+
+// NewSampleWriter constructs a SampleWriter forwarding rows to writer, and
+// writing a sample of at most sampleSize rows to output when it is closed.
+//
+// The options configure the writer of the sample file. If no schema is passed
+// in the options, the schema of writer is used, which requires writer to
+// implement RowWriterWithSchema.
+func NewSampleWriter(writer RowWriter, output io.Writer, sampleSize int, options ...WriterOption) *SampleWriter {
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+	if config.Schema == nil {
+		config.Schema = targetSchemaOf(writer)
+	}
+	return &SampleWriter{
+		writer: writer,
+		sample: NewWriter(output, config),
+		size:   sampleSize,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// WriteRows writes rows to the underlying writer, and updates the sample with
+// the rows that were written.
+func (w *SampleWriter) WriteRows(rows []Row) (int, error) {
+	n, err := w.writer.WriteRows(rows)
+	for _, row := range rows[:n] {
+		w.observe(row)
+	}
+	return n, err
+}
+
+func (w *SampleWriter) observe(row Row) {
+	index := w.seen
+	w.seen++
+
+	if len(w.rows) < w.size {
+		w.rows = append(w.rows, row.Clone())
+		w.indexes = append(w.indexes, index)
+		return
+	}
+	if j := w.rand.Int63n(w.seen); j < int64(w.size) {
+		w.rows[j] = row.Clone()
+		w.indexes[j] = index
+	}
+}
+
+// Schema returns the schema of rows written to w.
+func (w *SampleWriter) Schema() *Schema { return w.sample.Schema() }
+
+// NumRows returns the number of rows written to w.
+func (w *SampleWriter) NumRows() int64 { return w.seen }
+
+// Sample returns the rows of the current sample, in the order they were
+// written. The returned rows must be treated as read-only.
+func (w *SampleWriter) Sample() []Row {
+	sort.Sort(sampleRows{w})
+	return w.rows
+}
+
+// Close writes the sample to the output of w, and flushes it.
+func (w *SampleWriter) Close() error {
+	if _, err := w.sample.WriteRows(w.Sample()); err != nil {
+		return err
+	}
+	return w.sample.Close()
+}
+
+type sampleRows struct{ *SampleWriter }
+
+func (s sampleRows) Len() int { return len(s.rows) }
+
+func (s sampleRows) Less(i, j int) bool { return s.indexes[i] < s.indexes[j] }
+
+func (s sampleRows) Swap(i, j int) {
+	s.rows[i], s.rows[j] = s.rows[j], s.rows[i]
+	s.indexes[i], s.indexes[j] = s.indexes[j], s.indexes[i]
+}
+
+var _ RowWriterWithSchema = (*SampleWriter)(nil)
@@ -0,0 +1,77 @@
+package parquet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestSampleWriter(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	tests := []struct {
+		scenario   string
+		numRows    int
+		sampleSize int
+		wantSize   int
+	}{
+		{scenario: "fewer rows than sample size", numRows: 5, sampleSize: 10, wantSize: 5},
+		{scenario: "more rows than sample size", numRows: 1000, sampleSize: 10, wantSize: 10},
+		{scenario: "empty sample", numRows: 10, sampleSize: 0, wantSize: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			output := new(bytes.Buffer)
+			sample := new(bytes.Buffer)
+
+			writer := parquet.NewGenericWriter[Row](output)
+			sampler := parquet.NewSampleWriter(writer, sample, test.sampleSize)
+
+			for i := 0; i < test.numRows; i++ {
+				row := writer.Schema().Deconstruct(nil, &Row{ID: int64(i), Name: "row"})
+				if _, err := sampler.WriteRows([]parquet.Row{row}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := sampler.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if n := sampler.NumRows(); n != int64(test.numRows) {
+				t.Errorf("wrong number of rows: %d", n)
+			}
+
+			rows, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != test.numRows {
+				t.Errorf("wrong number of rows in output: %d", len(rows))
+			}
+
+			sampled, err := parquet.Read[Row](bytes.NewReader(sample.Bytes()), int64(sample.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sampled) != test.wantSize {
+				t.Fatalf("wrong sample size: want=%d got=%d", test.wantSize, len(sampled))
+			}
+			for i, row := range sampled {
+				if row.ID < 0 || row.ID >= int64(test.numRows) || row.Name != "row" {
+					t.Errorf("invalid sampled row: %+v", row)
+				}
+				if i > 0 && row.ID <= sampled[i-1].ID {
+					t.Errorf("sampled rows are not in write order: %d after %d", row.ID, sampled[i-1].ID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package parquet
+
+import (
+	"strings"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// ScanEstimate is an estimate of the cost of scanning columns of a row group,
+// computed from the file metadata by FileRowGroup.EstimateScanCost.
+type ScanEstimate struct {
+	// Number of column chunks to read.
+	ColumnChunks int
+	// Number of bytes to read from the file, which is the size of the column
+	// chunks including the headers of their pages.
+	Bytes int64
+	// Number of pages to read, including dictionary pages.
+	Pages int64
+	// Size of the pages after decompression, including their headers.
+	DecompressedBytes int64
+}
+
+// EstimateScanCost returns an estimate of the cost of reading the given
+// columns of the row group. The columns are dot-separated paths of leaf
+// columns or groups, in which case all the leaf columns of the group are
+// selected; paths which do not exist in the schema are ignored. When columns
+// is empty, the estimate covers all the columns of the row group.
+//
+// The estimate is computed from the metadata of the file, without reading any
+// data, so schedulers can balance the work of reading files across workers
+// before starting. The number of pages comes from the encoding statistics of
+// column chunks, or from their offset index if it was loaded when opening the
+// file; column chunks which have neither are counted as a single data page,
+// plus their dictionary page.
+func (g *FileRowGroup) EstimateScanCost(columns []string) ScanEstimate {
+	estimate := ScanEstimate{}
+
+	for _, chunk := range g.columns {
+		c := chunk.(*FileColumnChunk)
+		if !columnPathIsSelected(c.column.Path(), columns) {
+			continue
+		}
+		metadata := &c.chunk.MetaData
+		estimate.ColumnChunks++
+		estimate.Bytes += metadata.TotalCompressedSize
+		estimate.DecompressedBytes += metadata.TotalUncompressedSize
+		estimate.Pages += c.numPages()
+	}
+
+	return estimate
+}
+
+// numPages returns the number of pages of the column chunk, including its
+// dictionary page, as recorded in the metadata.
+func (c *FileColumnChunk) numPages() int64 {
+	metadata := &c.chunk.MetaData
+	if len(metadata.EncodingStats) > 0 {
+		numPages := int64(0)
+		for _, stats := range metadata.EncodingStats {
+			numPages += int64(stats.Count)
+		}
+		return numPages
+	}
+
+	numPages := int64(1)
+	if c.offsetIndex != nil && len(c.offsetIndex.PageLocations) > 0 {
+		numPages = int64(len(c.offsetIndex.PageLocations))
+	}
+	if metadata.DictionaryPageOffset != 0 || hasDictionaryEncoding(metadata.Encoding) {
+		numPages++
+	}
+	return numPages
+}
+
+func hasDictionaryEncoding(encodings []format.Encoding) bool {
+	for _, encoding := range encodings {
+		if isDictionaryFormat(encoding) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnPathIsSelected returns true if the path is one of the dot-separated
+// columns, or a leaf of one of the groups that they designate. All the paths
+// are selected when columns is empty.
+func columnPathIsSelected(path []string, columns []string) bool {
+	if len(columns) == 0 {
+		return true
+	}
+	name := columnPath(path).String()
+	for _, column := range columns {
+		if name == column || strings.HasPrefix(name, column+".") {
+			return true
+		}
+	}
+	return false
+}
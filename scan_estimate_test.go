@@ -0,0 +1,86 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestFileRowGroupEstimateScanCost(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city,dict"`
+		Zip  string `parquet:"zip"`
+	}
+	type Row struct {
+		ID      int64   `parquet:"id"`
+		Name    string  `parquet:"name"`
+		Address Address `parquet:"address"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:      int64(i),
+			Name:    "name-" + strconv.Itoa(i),
+			Address: Address{City: "city-" + strconv.Itoa(i%7), Zip: strconv.Itoa(10000 + i)},
+		}
+	}
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(1024)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, skipPageIndex := range []bool{false, true} {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.SkipPageIndex(skipPageIndex))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rowGroup := f.RowGroups()[0].(*parquet.FileRowGroup)
+
+		scan := func(columns ...int) (want parquet.ScanEstimate) {
+			for _, i := range columns {
+				chunk := rowGroup.ColumnChunks()[i].(*parquet.FileColumnChunk)
+				metadata := &f.Metadata().RowGroups[0].Columns[i].MetaData
+				want.ColumnChunks++
+				want.Bytes += metadata.TotalCompressedSize
+				want.DecompressedBytes += metadata.TotalUncompressedSize
+
+				headers := chunk.PageHeaders()
+				for {
+					_, err := headers.ReadPageHeader()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+					want.Pages++
+				}
+				headers.Close()
+			}
+			return want
+		}
+
+		for _, test := range []struct {
+			columns []string
+			indices []int
+		}{
+			{columns: nil, indices: []int{0, 1, 2, 3}},
+			{columns: []string{"name"}, indices: []int{1}},
+			{columns: []string{"address"}, indices: []int{2, 3}},
+			{columns: []string{"id", "address.city", "missing"}, indices: []int{0, 2}},
+		} {
+			want := scan(test.indices...)
+			got := rowGroup.EstimateScanCost(test.columns)
+			if got != want {
+				t.Errorf("wrong estimate for columns %q:\nwant = %+v\ngot  = %+v", test.columns, want, got)
+			}
+			if test.columns == nil && (got.Pages <= 4 || got.Bytes > rowGroup.TotalCompressedSize()) {
+				t.Errorf("unexpected estimate of the full row group: %+v", got)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package parquet
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
@@ -26,6 +27,7 @@ type Schema struct {
 	reconstruct reconstructFunc
 	mapping     columnMapping
 	columns     [][]string
+	defaults    ColumnDefaults
 }
 
 // SchemaOf constructs a parquet schema from a Go value.
@@ -54,12 +56,40 @@ type Schema struct {
 //	list      | for slice types, use the parquet LIST logical type
 //	enum      | for string types, use the parquet ENUM logical type
 //	uuid      | for string and [16]byte types, use the parquet UUID logical type
+//	int96     | for [12]byte types, use the INT96 physical type
+//	ip        | for netip.Addr and netip.Prefix types, store values in binary form instead of text
 //	decimal   | for int32, int64 and [n]byte types, use the parquet DECIMAL logical type
 //	date      | for int32 types use the DATE logical type
 //	timestamp | for int64 types use the TIMESTAMP logical type with, by default, millisecond precision
+//	time      | for int32, int64 and time.Duration types use the TIME logical type with, by default, millisecond precision
 //	split     | for float32/float64, use the BYTE_STREAM_SPLIT encoding
+//	json      | use the parquet JSON logical type, values are serialized with encoding/json
 //	id(n)     | where n is int denoting a column field id. Example id(2) for a column with field id of 2
 //
+// The json tag may be used on fields of any type, including types which have
+// no native parquet representation (e.g. interfaces), the values are converted
+// with json.Marshal when writing and json.Unmarshal when reading.
+//
+// The int96 tag maps [12]byte fields to INT96 columns, where the bytes of the
+// fields are the raw representation of the values in parquet files. INT96
+// values can be read into [12]byte fields with or without the tag, which allows
+// programs to round-trip INT96 values exactly without converting them from and
+// to deprecated.Int96.
+//
+// Fields of types which have no native parquet representation but implement
+// both encoding.TextMarshaler and encoding.TextUnmarshaler (e.g. netip.Addr)
+// are stored in STRING columns using their text representation. This does not
+// apply to struct types with exported fields, which are mapped to groups.
+//
+// The ip tag stores netip.Addr fields in FIXED_LEN_BYTE_ARRAY(16) columns and
+// netip.Prefix fields in BYTE_ARRAY columns, which is more compact than text
+// and orders values by address when compared as bytes, so sorting columns,
+// statistics and page indexes are meaningful for ranges of addresses.
+// Addresses are stored as returned by As16, IPv4 addresses are unmapped when
+// read back and zones are dropped; the zero netip.Addr is written as "::".
+// Prefixes are stored as the 16 bytes of their address followed by a byte
+// holding the number of bits, and the zero netip.Prefix as an empty value.
+//
 // # The date logical type is an int32 value of the number of days since the unix epoch
 //
 // The timestamp precision can be changed by defining which precision to use as an argument.
@@ -69,6 +99,18 @@ type Schema struct {
 //	  TimestrampMicros int64 `parquet:"timestamp_micros,timestamp(microsecond)"
 //	}
 //
+// The same precisions are supported by the time tag. TIME values with millisecond
+// precision are stored in int32 columns, so the tag must be used on int32 fields
+// with millisecond precision and on int64 fields with microsecond or nanosecond
+// precision. Fields of type time.Duration or parquet.TimeOfDay support all
+// precisions, their values are truncated to the precision of the column when
+// written.
+//
+// Fields of type parquet.CivilDate and parquet.TimeOfDay are mapped to DATE and
+// TIME columns without requiring struct tags, which avoids the time zone
+// conversions involved when using time.Time values to represent dates or times
+// of the day.
+//
 // The decimal tag must be followed by two integer parameters, the first integer
 // representing the scale and the second the precision; for example:
 //
@@ -96,8 +138,33 @@ type Schema struct {
 //	}
 //
 // The schema name is the Go type name of the value.
-func SchemaOf(model interface{}) *Schema {
-	return schemaOf(dereference(reflect.TypeOf(model)))
+//
+// Options may be passed to further configure the schema, for example to assign
+// field IDs with SequentialFieldIDs, to change the order of columns with
+// SchemaColumnOrder, or to set the default values of columns with
+// ColumnDefaults. The function panics if the configuration
+// is invalid.
+func SchemaOf(model interface{}, options ...SchemaOption) *Schema {
+	schema := schemaOf(dereference(reflect.TypeOf(model)))
+	if len(options) == 0 {
+		return schema
+	}
+	config, err := NewSchemaConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+	if config.ColumnOrder != nil {
+		schema = NewSchema(schema.Name(), orderColumnsOf(schema.root, config.ColumnOrder))
+	}
+	if config.SequentialFieldIDs {
+		schema = NewSchema(schema.Name(), withSequentialFieldIDs(schema.root))
+	}
+	if config.Defaults != nil {
+		withDefaults := *schema
+		withDefaults.defaults = config.Defaults
+		schema = &withDefaults
+	}
+	return schema
 }
 
 var cachedSchemas sync.Map // map[reflect.Type]*Schema
@@ -121,7 +188,10 @@ func schemaOf(model reflect.Type) *Schema {
 // NewSchema constructs a new Schema object with the given name and root node.
 //
 // The function panics if Node contains more leaf columns than supported by the
-// package (see parquet.MaxColumnIndex).
+// package (see parquet.MaxColumnIndex), or with an error wrapping
+// ErrTooManyLevels if its columns are nested too deeply (see
+// parquet.MaxColumnDepth, parquet.MaxRepetitionLevel, and
+// parquet.MaxDefinitionLevel).
 func NewSchema(name string, root Node) *Schema {
 	mapping, columns := columnMappingOf(root)
 	return &Schema{
@@ -182,6 +252,57 @@ func (s *Schema) ID() int { return s.root.ID() }
 // String returns a parquet schema representation of s.
 func (s *Schema) String() string { return sprint(s.name, s.root) }
 
+// MarshalJSON satisfies the json.Marshaler interface, producing a stable
+// representation of the schema which includes the logical types, field IDs,
+// encodings and compression codecs of the columns. Fields are listed in the
+// order of the schema.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(schemaNodeJSONOf(s.name, s.root, true))
+}
+
+type schemaNodeJSON struct {
+	Name        string           `json:"name"`
+	Repetition  string           `json:"repetition,omitempty"`
+	Type        string           `json:"type,omitempty"`
+	Length      int              `json:"length,omitempty"`
+	LogicalType string           `json:"logicalType,omitempty"`
+	ID          int              `json:"id,omitempty"`
+	Encoding    string           `json:"encoding,omitempty"`
+	Compression string           `json:"compression,omitempty"`
+	Fields      []schemaNodeJSON `json:"fields,omitempty"`
+}
+
+func schemaNodeJSONOf(name string, node Node, root bool) schemaNodeJSON {
+	n := schemaNodeJSON{
+		Name:        name,
+		LogicalType: annotationOf(node),
+		ID:          node.ID(),
+	}
+	if !root {
+		n.Repetition = strings.ToLower(fieldRepetitionTypeOf(node).String())
+	}
+	if node.Leaf() {
+		t := node.Type()
+		n.Type = t.Kind().String()
+		if t.Kind() == FixedLenByteArray {
+			n.Length = t.Length()
+		}
+		if enc := node.Encoding(); enc != nil {
+			n.Encoding = enc.Encoding().String()
+		}
+		if codec := node.Compression(); codec != nil {
+			n.Compression = codec.CompressionCodec().String()
+		}
+	} else {
+		fields := node.Fields()
+		n.Fields = make([]schemaNodeJSON, len(fields))
+		for i, field := range fields {
+			n.Fields[i] = schemaNodeJSONOf(field.Name(), field, false)
+		}
+	}
+	return n
+}
+
 // Name returns the name of s.
 func (s *Schema) Name() string { return s.name }
 
@@ -330,6 +451,91 @@ func (s *Schema) Lookup(path ...string) (LeafColumn, bool) {
 	}, leaf.node != nil
 }
 
+// LookupPath is like Lookup but resolves paths through the wrapper groups of
+// LIST and MAP columns, so the path does not need to contain their names. For
+// example, with the following schema:
+//
+//	message Row {
+//		required group prices (MAP) {
+//			repeated group key_value {
+//				required binary key (STRING);
+//				required group value {
+//					required double price;
+//				}
+//			}
+//		}
+//		required group tags (LIST) {
+//			repeated group list {
+//				required binary element (STRING);
+//			}
+//		}
+//	}
+//
+// The paths "prices.value.price" and "tags" resolve to the columns at
+// "prices.key_value.value.price" and "tags.list.element". Paths containing
+// the names of the wrapper groups are also accepted, as well as two-level
+// lists of legacy parquet writers.
+//
+// The Path field of the returned LeafColumn is the full path of the column in
+// the schema. If the path did not resolve to a leaf column, the boolean will
+// be false.
+func (s *Schema) LookupPath(path ...string) (LeafColumn, bool) {
+	var node Node = s.root
+	var resolved columnPath
+
+	for _, name := range path {
+		for {
+			if field := fieldByName(node, name); field != nil {
+				node, resolved = field, resolved.append(name)
+				break
+			}
+			wrapper, inner := unwrapNestedNode(node)
+			if inner == nil {
+				return LeafColumn{}, false
+			}
+			node, resolved = inner, resolved.append(wrapper...)
+			if wrapper[len(wrapper)-1] == name {
+				break
+			}
+		}
+	}
+
+	for !node.Leaf() {
+		wrapper, inner := unwrapNestedNode(node)
+		if inner == nil || !isListGroup(node) {
+			return LeafColumn{}, false
+		}
+		node, resolved = inner, resolved.append(wrapper...)
+	}
+
+	return s.Lookup(resolved...)
+}
+
+// unwrapNestedNode returns the node holding the values of a LIST or MAP node,
+// and the names of the wrapper groups leading to it. The returned node is nil
+// if node is neither a LIST nor a MAP.
+func unwrapNestedNode(node Node) ([]string, Node) {
+	if node.Leaf() {
+		return nil, nil
+	}
+	fields := node.Fields()
+	if len(fields) != 1 || !fields[0].Repeated() {
+		return nil, nil
+	}
+	repeated := fields[0]
+	switch {
+	case isListGroup(node):
+		if isLegacyListElement(node, repeated) {
+			return []string{repeated.Name()}, repeated
+		}
+		element := repeated.Fields()[0]
+		return []string{repeated.Name(), element.Name()}, element
+	case isMap(node):
+		return []string{repeated.Name()}, repeated
+	}
+	return nil, nil
+}
+
 // Columns returns the list of column paths available in the schema.
 //
 // The method always returns the same slice value across calls to ColumnPaths,
@@ -536,6 +742,14 @@ func nodeOf(t reflect.Type, tag []string) Node {
 		return UUID()
 	case reflect.TypeOf(time.Time{}):
 		return Timestamp(Nanosecond)
+	case reflect.TypeOf(CivilDate(0)):
+		return Date()
+	case reflect.TypeOf(TimeOfDay(0)):
+		return Time(Nanosecond)
+	}
+
+	if isTextFallbackType(t) {
+		return &goNode{Node: String(), gotype: t}
 	}
 
 	var n Node
@@ -862,6 +1076,24 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 				throwInvalidTag(t, name, option)
 			}
 
+		case "ip":
+			switch dereference(t) {
+			case netipAddrType:
+				setNode(Leaf(FixedLenByteArrayType(16)))
+			case netipPrefixType:
+				setNode(Leaf(ByteArrayType))
+			default:
+				throwInvalidTag(t, name, option)
+			}
+
+		case "int96":
+			switch {
+			case t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8 && t.Len() == 12:
+				setNode(Leaf(Int96Type))
+			default:
+				throwInvalidTag(t, name, option)
+			}
+
 		case "decimal":
 			scale, precision, err := parseDecimalArgs(args)
 			if err != nil {
@@ -907,6 +1139,20 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 					throwInvalidTag(t, name, option)
 				}
 			}
+		case "time":
+			timeUnit, err := parseTimestampArgs(args)
+			if err != nil {
+				throwInvalidTag(t, name, option+args)
+			}
+			millis := timeUnit.TimeUnit().Millis != nil
+			switch {
+			case t == reflect.TypeOf(time.Duration(0)), t == reflect.TypeOf(TimeOfDay(0)):
+			case t.Kind() == reflect.Int32 && millis:
+			case t.Kind() == reflect.Int64 && !millis:
+			default:
+				throwInvalidTag(t, name, option+args)
+			}
+			setNode(Time(timeUnit))
 		case "id":
 			id, err := parseIDArgs(args)
 			if err != nil {
@@ -958,7 +1204,9 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 		}
 	}
 
-	if optional {
+	// Pointer types already map to optional nodes, wrapping them again would
+	// make the Go type of the node a pointer to pointer.
+	if optional && !node.Optional() {
 		node = Optional(node)
 	}
 	if fieldID != 0 {
@@ -0,0 +1,197 @@
+package parquet
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SchemaHistoryKey is the key of the footer key/value metadata holding the
+// JSON representation of the schema history of parquet files.
+const SchemaHistoryKey = "parquet-go.schema.history"
+
+// SchemaHistory is an append-only list of the schema versions of a parquet
+// file, which is carried over from one file generation to the next when files
+// are rewritten or merged, so consumers can trace when columns were added,
+// dropped, renamed, or changed type.
+//
+// Histories are stored in the footer key/value metadata of parquet files under
+// SchemaHistoryKey. Rewriting a file while retaining its history is done by
+// reading the history of the source file, appending the schema of the new
+// file, and passing the result to the writer:
+//
+//	history, err := parquet.SchemaHistoryOf(source)
+//	if err != nil {
+//		...
+//	}
+//	history = history.Append(schema)
+//	writer := parquet.NewWriter(output, schema, parquet.SchemaHistoryMetadata(history))
+type SchemaHistory []SchemaVersion
+
+// SchemaVersion represents a version of the schema in a SchemaHistory.
+type SchemaVersion struct {
+	// Version number, starting at 1 for the first schema of the history.
+	Version int `json:"version"`
+	// Time at which the version was appended to the history.
+	Timestamp time.Time `json:"timestamp"`
+	// Leaf columns of the schema, in the order they appear in the schema.
+	Columns []SchemaColumn `json:"columns"`
+	// Changes since the previous version, empty for the first version.
+	Changes []SchemaChange `json:"changes,omitempty"`
+}
+
+// SchemaColumn describes a leaf column of a schema version.
+type SchemaColumn struct {
+	// Dot-separated path to the column.
+	Path string `json:"path"`
+	// Repetition and type of the column, for example "OPTIONAL INT64".
+	Type string `json:"type"`
+	// The field ID of the column, zero if the schema had no field IDs.
+	FieldID int `json:"field-id,omitempty"`
+}
+
+// SchemaChangeKind enumerates the kinds of changes recorded in schema
+// histories.
+type SchemaChangeKind string
+
+const (
+	// ColumnAdded is the kind of changes adding a column to the schema.
+	ColumnAdded SchemaChangeKind = "add"
+	// ColumnDropped is the kind of changes removing a column from the schema.
+	ColumnDropped SchemaChangeKind = "drop"
+	// ColumnRenamed is the kind of changes moving a column to a new path.
+	// Renames can only be detected when the schemas have field IDs, otherwise
+	// they are recorded as dropping the column and adding a new one.
+	ColumnRenamed SchemaChangeKind = "rename"
+	// ColumnUpdated is the kind of changes modifying the type or repetition
+	// of a column.
+	ColumnUpdated SchemaChangeKind = "update"
+)
+
+// SchemaChange describes the change of a column between two schema versions.
+type SchemaChange struct {
+	Kind SchemaChangeKind `json:"kind"`
+	// Path to the column in the new version, or in the previous version for
+	// dropped columns.
+	Path string `json:"path"`
+	// Path to the column in the previous version of renamed columns.
+	PreviousPath string `json:"previous-path,omitempty"`
+	// Type of the column in the previous version of updated columns.
+	PreviousType string `json:"previous-type,omitempty"`
+}
+
+// SchemaHistoryOf returns the schema history recorded in the metadata of f.
+//
+// The function returns a nil history and no error if f has no history.
+func SchemaHistoryOf(f *File) (SchemaHistory, error) {
+	value, ok := f.Lookup(SchemaHistoryKey)
+	if !ok {
+		return nil, nil
+	}
+	var history SchemaHistory
+	if err := json.Unmarshal([]byte(value), &history); err != nil {
+		return nil, fmt.Errorf("decoding parquet schema history: %w", err)
+	}
+	return history, nil
+}
+
+// Latest returns the last version of the history, or nil if it is empty.
+func (h SchemaHistory) Latest() *SchemaVersion {
+	if len(h) == 0 {
+		return nil
+	}
+	return &h[len(h)-1]
+}
+
+// Append returns a history with a new version for the given schema, recording
+// the changes since the latest version of h.
+//
+// If the schema has the same columns as the latest version, h is returned
+// unchanged, so rewriting files without changing their schema does not grow
+// their history. The method never modifies h.
+func (h SchemaHistory) Append(schema *Schema) SchemaHistory {
+	columns := schemaColumnsOf(schema)
+	version := SchemaVersion{
+		Version:   1,
+		Timestamp: time.Now().UTC(),
+		Columns:   columns,
+	}
+
+	if latest := h.Latest(); latest != nil {
+		version.Version = latest.Version + 1
+		version.Changes = schemaChanges(latest.Columns, columns)
+		if len(version.Changes) == 0 {
+			return h
+		}
+	}
+
+	history := make(SchemaHistory, len(h), len(h)+1)
+	copy(history, h)
+	return append(history, version)
+}
+
+// SchemaHistoryMetadata is a writer option which records the history in the
+// key/value metadata of the parquet file.
+func SchemaHistoryMetadata(history SchemaHistory) WriterOption {
+	b, err := json.Marshal(history)
+	if err != nil {
+		panic(err) // the types of schema histories can always be serialized
+	}
+	return KeyValueMetadata(SchemaHistoryKey, string(b))
+}
+
+func schemaColumnsOf(schema *Schema) []SchemaColumn {
+	var columns []SchemaColumn
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		columns = append(columns, SchemaColumn{
+			Path:    leaf.path.String(),
+			Type:    fieldRepetitionTypeOf(leaf.node).String() + " " + leaf.node.Type().String(),
+			FieldID: leaf.node.ID(),
+		})
+	})
+	return columns
+}
+
+func schemaChanges(previous, columns []SchemaColumn) []SchemaChange {
+	var changes []SchemaChange
+	matched := make([]bool, len(previous))
+
+	lookup := func(column *SchemaColumn) int {
+		for i := range previous {
+			if matched[i] {
+				continue
+			}
+			if column.FieldID != 0 && previous[i].FieldID != 0 {
+				if column.FieldID == previous[i].FieldID {
+					return i
+				}
+			} else if column.Path == previous[i].Path {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for i := range columns {
+		column := &columns[i]
+		j := lookup(column)
+		if j < 0 {
+			changes = append(changes, SchemaChange{Kind: ColumnAdded, Path: column.Path})
+			continue
+		}
+		matched[j] = true
+		if prev := &previous[j]; prev.Path != column.Path {
+			changes = append(changes, SchemaChange{Kind: ColumnRenamed, Path: column.Path, PreviousPath: prev.Path})
+		}
+		if prev := &previous[j]; prev.Type != column.Type {
+			changes = append(changes, SchemaChange{Kind: ColumnUpdated, Path: column.Path, PreviousType: prev.Type})
+		}
+	}
+
+	for i := range previous {
+		if !matched[i] {
+			changes = append(changes, SchemaChange{Kind: ColumnDropped, Path: previous[i].Path})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,85 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestSchemaHistory(t *testing.T) {
+	type V1 struct {
+		ID    int64  `parquet:"id,id(1)"`
+		Name  string `parquet:"name,id(2)"`
+		Score int32  `parquet:"score,id(3)"`
+	}
+	type V2 struct {
+		ID       int64  `parquet:"id,id(1)"`
+		FullName string `parquet:"full_name,id(2)"`
+		Score    int64  `parquet:"score,id(3)"`
+		Email    string `parquet:"email,optional,id(4)"`
+	}
+
+	open := func(buf *bytes.Buffer, err error) *parquet.File {
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	var history parquet.SchemaHistory
+	history = history.Append(parquet.SchemaOf(V1{}))
+	buf := new(bytes.Buffer)
+	f := open(buf, parquet.Write(buf, []V1{{ID: 1, Name: "Luke"}}, parquet.SchemaHistoryMetadata(history)))
+
+	history, err := parquet.SchemaHistoryOf(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].Version != 1 || len(history[0].Changes) != 0 {
+		t.Fatalf("wrong initial history: %+v", history)
+	}
+	if unchanged := history.Append(parquet.SchemaOf(V1{})); len(unchanged) != 1 {
+		t.Fatalf("appending the same schema grew the history to %d versions", len(unchanged))
+	}
+
+	history = history.Append(parquet.SchemaOf(V2{}))
+	buf = new(bytes.Buffer)
+	f = open(buf, parquet.Write(buf, []V2{{ID: 1, FullName: "Luke"}}, parquet.SchemaHistoryMetadata(history)))
+
+	history, err = parquet.SchemaHistoryOf(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("wrong number of versions: %d", len(history))
+	}
+
+	latest := history.Latest()
+	if latest.Version != 2 {
+		t.Errorf("wrong version number: %d", latest.Version)
+	}
+	if want := (parquet.SchemaColumn{Path: "score", Type: "REQUIRED INT(64,true)", FieldID: 3}); latest.Columns[2] != want {
+		t.Errorf("wrong column: want=%+v got=%+v", want, latest.Columns[2])
+	}
+
+	want := []parquet.SchemaChange{
+		{Kind: parquet.ColumnRenamed, Path: "full_name", PreviousPath: "name"},
+		{Kind: parquet.ColumnUpdated, Path: "score", PreviousType: "REQUIRED INT(32,true)"},
+		{Kind: parquet.ColumnAdded, Path: "email"},
+	}
+	if !reflect.DeepEqual(latest.Changes, want) {
+		t.Errorf("wrong changes:\nwant: %+v\ngot:  %+v", want, latest.Changes)
+	}
+
+	buf = new(bytes.Buffer)
+	f = open(buf, parquet.Write(buf, []V1{{ID: 1}}))
+	if history, err := parquet.SchemaHistoryOf(f); err != nil || history != nil {
+		t.Errorf("file without history: %v %v", history, err)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package parquet
+
+import "iter"
+
+// Leaves returns a sequence of the leaf columns of the schema, in the order of
+// their column indexes.
+//
+// The sequence is a convenient alternative to looking up each path returned by
+// Columns:
+//
+//	for leaf := range schema.Leaves() {
+//		fmt.Println(leaf.ColumnIndex, strings.Join(leaf.Path, "."), leaf.Kind)
+//	}
+//
+// The Path field of yielded values is shared with the schema, applications
+// should treat it as immutable.
+func (s *Schema) Leaves() iter.Seq[LeafInfo] {
+	return func(yield func(LeafInfo) bool) {
+		for _, path := range s.columns {
+			if !yield(leafInfoOf(s.mapping.lookup(path))) {
+				return
+			}
+		}
+	}
+}
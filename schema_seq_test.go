@@ -0,0 +1,83 @@
+//go:build go1.23
+
+package parquet_test
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func ExampleSchema_Leaves() {
+	schema := parquet.SchemaOf(struct {
+		ID         int64   `parquet:"id"`
+		Name       *string `parquet:"name"`
+		Attributes []struct {
+			Key   string `parquet:"key"`
+			Value string `parquet:"value"`
+		} `parquet:"attributes"`
+	}{})
+
+	for leaf := range schema.Leaves() {
+		fmt.Printf("%d => %q %s (r=%d, d=%d, optional=%t)\n",
+			leaf.ColumnIndex,
+			strings.Join(leaf.Path, "."),
+			leaf.Kind,
+			leaf.MaxRepetitionLevel,
+			leaf.MaxDefinitionLevel,
+			leaf.Optional,
+		)
+	}
+
+	// Output:
+	// 0 => "id" INT64 (r=0, d=0, optional=false)
+	// 1 => "name" BYTE_ARRAY (r=0, d=1, optional=true)
+	// 2 => "attributes.key" BYTE_ARRAY (r=1, d=1, optional=false)
+	// 3 => "attributes.value" BYTE_ARRAY (r=1, d=1, optional=false)
+}
+
+func TestSchemaLeaves(t *testing.T) {
+	schema := parquet.SchemaOf(struct {
+		A int32             `parquet:"a"`
+		B string            `parquet:"b"`
+		C []float64         `parquet:"c,list"`
+		D map[string]string `parquet:"d"`
+	}{})
+
+	var paths []string
+	for leaf := range schema.Leaves() {
+		lookup, ok := schema.Lookup(leaf.Path...)
+		if !ok {
+			t.Fatalf("leaf %q not found by Lookup", leaf.Path)
+		}
+		if !reflect.DeepEqual(lookup, leaf.LeafColumn) {
+			t.Errorf("leaf %q mismatch:\nwant = %+v\ngot  = %+v", leaf.Path, lookup, leaf.LeafColumn)
+		}
+		if leaf.Kind != leaf.Node.Type().Kind() {
+			t.Errorf("leaf %q has kind %s, want %s", leaf.Path, leaf.Kind, leaf.Node.Type().Kind())
+		}
+		paths = append(paths, strings.Join(leaf.Path, "."))
+	}
+
+	want := []string{"a", "b", "c.list.element", "d.key_value.key", "d.key_value.value"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("wrong leaf paths:\nwant = %q\ngot  = %q", want, paths)
+	}
+
+	n := 0
+	for leaf := range schema.Leaves() {
+		if n++; leaf.ColumnIndex == 1 {
+			if leaf.LogicalType == nil || leaf.LogicalType.UTF8 == nil {
+				t.Errorf("leaf %q should have the STRING logical type", leaf.Path)
+			}
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("iteration did not stop after break: %d leaves yielded", n)
+	}
+}
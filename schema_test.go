@@ -1,6 +1,10 @@
 package parquet_test
 
 import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -111,6 +115,19 @@ func TestSchemaOf(t *testing.T) {
 }`,
 		},
 
+		{
+			value: new(struct {
+				TimeMillis int32         `parquet:"time_millis,time"`
+				TimeMicros int64         `parquet:"time_micros,time(microsecond)"`
+				TimeNanos  time.Duration `parquet:"time_nanos,time(nanosecond)"`
+			}),
+			print: `message {
+	required int32 time_millis (TIME(isAdjustedToUTC=true,unit=MILLIS));
+	required int64 time_micros (TIME(isAdjustedToUTC=true,unit=MICROS));
+	required int64 time_nanos (TIME(isAdjustedToUTC=true,unit=NANOS));
+}`,
+		},
+
 		{
 			value: new(struct {
 				Name string `parquet:",json"`
@@ -225,3 +242,138 @@ func TestSchemaOf(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaMarshalJSON(t *testing.T) {
+	schema := parquet.NewSchema("Test", parquet.Group{
+		"id":   parquet.FieldID(parquet.Compressed(parquet.Encoded(parquet.Int(64), &parquet.DeltaBinaryPacked), &parquet.Zstd), 1),
+		"hash": parquet.Leaf(parquet.FixedLenByteArrayType(16)),
+		"tags": parquet.List(parquet.String()),
+	})
+
+	b, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `{"name":"Test","fields":[` +
+		`{"name":"hash","repetition":"required","type":"FIXED_LEN_BYTE_ARRAY","length":16},` +
+		`{"name":"id","repetition":"required","type":"INT64","logicalType":"INT(64,true)","id":1,"encoding":"DELTA_BINARY_PACKED","compression":"ZSTD"},` +
+		`{"name":"tags","repetition":"required","logicalType":"LIST","fields":[` +
+		`{"name":"list","repetition":"repeated","fields":[` +
+		`{"name":"element","repetition":"required","type":"BYTE_ARRAY","logicalType":"STRING"}]}]}]}`
+
+	if string(b) != want {
+		t.Errorf("\nwant = %s\ngot  = %s", want, b)
+	}
+}
+
+func TestSchemaLookupPath(t *testing.T) {
+	type Price struct {
+		Price float64 `parquet:"price"`
+	}
+	type Item struct {
+		Name  string `parquet:"name"`
+		Count int32  `parquet:"count"`
+	}
+	type Row struct {
+		ID     int64            `parquet:"id"`
+		Prices map[string]Price `parquet:"prices"`
+		Tags   []string         `parquet:"tags,list"`
+		Items  []Item           `parquet:"items,list"`
+		Names  []string         `parquet:"names"`
+	}
+	schema := parquet.SchemaOf(Row{})
+
+	tests := []struct {
+		path   []string
+		column []string
+	}{
+		{path: []string{"id"}, column: []string{"id"}},
+		{path: []string{"prices", "key"}, column: []string{"prices", "key_value", "key"}},
+		{path: []string{"prices", "value", "price"}, column: []string{"prices", "key_value", "value", "price"}},
+		{path: []string{"prices", "key_value", "value", "price"}, column: []string{"prices", "key_value", "value", "price"}},
+		{path: []string{"tags"}, column: []string{"tags", "list", "element"}},
+		{path: []string{"tags", "element"}, column: []string{"tags", "list", "element"}},
+		{path: []string{"items", "count"}, column: []string{"items", "list", "element", "count"}},
+		{path: []string{"names"}, column: []string{"names"}},
+		{path: []string{"prices"}},
+		{path: []string{"prices", "value"}},
+		{path: []string{"items"}},
+		{path: []string{"missing"}},
+		{path: []string{"id", "missing"}},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.path, "."), func(t *testing.T) {
+			leaf, ok := schema.LookupPath(test.path...)
+			if test.column == nil {
+				if ok {
+					t.Fatalf("expected path to not resolve but got %q", leaf.Path)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("path did not resolve")
+			}
+			want, _ := schema.Lookup(test.column...)
+			if leaf.ColumnIndex != want.ColumnIndex {
+				t.Errorf("column index mismatch: want=%d got=%d", want.ColumnIndex, leaf.ColumnIndex)
+			}
+			if leaf.MaxRepetitionLevel != want.MaxRepetitionLevel || leaf.MaxDefinitionLevel != want.MaxDefinitionLevel {
+				t.Errorf("levels mismatch: want=(%d,%d) got=(%d,%d)",
+					want.MaxRepetitionLevel, want.MaxDefinitionLevel,
+					leaf.MaxRepetitionLevel, leaf.MaxDefinitionLevel)
+			}
+			if !slices.Equal(leaf.Path, test.column) {
+				t.Errorf("path mismatch: want=%q got=%q", test.column, leaf.Path)
+			}
+		})
+	}
+}
+
+func TestNewSchemaTooManyLevels(t *testing.T) {
+	nested := func(depth int, wrap func(parquet.Node) parquet.Node) parquet.Node {
+		node := parquet.Leaf(parquet.Int32Type)
+		for i := 0; i < depth; i++ {
+			node = parquet.Group{"v": wrap(node)}
+		}
+		return node
+	}
+	repeated := func(node parquet.Node) parquet.Node { return parquet.Repeated(node) }
+	optional := func(node parquet.Node) parquet.Node { return parquet.Optional(node) }
+	required := func(node parquet.Node) parquet.Node { return parquet.Required(node) }
+
+	tests := []struct {
+		scenario string
+		root     parquet.Node
+		valid    bool
+	}{
+		{scenario: "max depth", root: nested(parquet.MaxColumnDepth, required), valid: true},
+		{scenario: "max depth repeated", root: nested(parquet.MaxColumnDepth, repeated), valid: true},
+		{scenario: "too deep", root: nested(parquet.MaxColumnDepth+1, required)},
+		{scenario: "too deep repeated", root: nested(parquet.MaxColumnDepth+1, repeated)},
+		{scenario: "too deep optional", root: parquet.Group{
+			"a": nested(1, optional),
+			"b": nested(parquet.MaxColumnDepth, optional),
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if test.valid {
+					if r != nil {
+						t.Fatalf("unexpected panic: %v", r)
+					}
+					return
+				}
+				err, _ := r.(error)
+				if !errors.Is(err, parquet.ErrTooManyLevels) {
+					t.Fatalf("expected panic wrapping ErrTooManyLevels, got %v", r)
+				}
+			}()
+			parquet.NewSchema("test", test.root)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync/atomic"
+)
+
+// ShardedWriter is a writer which distributes the batches of rows it receives
+// across multiple underlying writers, each running on its own goroutine.
+//
+// ShardedWriter is intended for ingest services where a single writer cannot
+// keep up with the rate of incoming rows because encoding and compressing pages
+// is bound by the throughput of one CPU core. Each shard produces its own
+// parquet file, batches are distributed in a round-robin fashion, so the order
+// of rows across shards is not preserved.
+//
+// Errors that occur while writing to a shard are reported by the next call to
+// Write, Flush, or Close. When closed, a shard which failed discards the rows
+// it had not yet written in a row group, and writes the footer of its file so
+// the output remains a valid parquet file holding the rows written before the
+// error.
+//
+// The Write, Flush, and Close methods must not be called concurrently.
+type ShardedWriter[T any] struct {
+	shards []*writerShard[T]
+	next   int
+	closed bool
+}
+
+type writerShard[T any] struct {
+	writer   *GenericWriter[T]
+	requests chan shardRequest[T]
+	done     chan struct{}
+	err      atomic.Pointer[error]
+	rows     atomic.Int64
+	batches  atomic.Int64
+}
+
+type shardRequest[T any] struct {
+	rows  []T
+	flush chan error
+}
+
+// ShardStats carries statistics about the rows written to a shard of a
+// ShardedWriter.
+type ShardStats struct {
+	// Number of rows and batches written to the shard.
+	NumRows    int64
+	NumBatches int64
+}
+
+// ShardedWriterStats carries statistics aggregated across the shards of a
+// ShardedWriter.
+type ShardedWriterStats struct {
+	// Total number of rows and batches written to all shards.
+	NumRows    int64
+	NumBatches int64
+	// Statistics of each shard, in the order of the outputs of the writer.
+	Shards []ShardStats
+}
+
+// NewShardedWriter constructs a writer which distributes rows across the given
+// outputs, starting one goroutine per output. All the outputs are written with
+// the same schema and configuration.
+//
+// The queueSize argument is the maximum number of batches that may be pending
+// in each shard before calls to Write block. The function panics if the writer
+// configuration is invalid or if no outputs were given.
+func NewShardedWriter[T any](outputs []io.Writer, queueSize int, options ...WriterOption) *ShardedWriter[T] {
+	if len(outputs) == 0 {
+		panic("sharded writer must be instantiated with at least one output")
+	}
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+	w := &ShardedWriter[T]{
+		shards: make([]*writerShard[T], len(outputs)),
+	}
+	for i, output := range outputs {
+		s := &writerShard[T]{
+			writer:   NewGenericWriter[T](output, config),
+			requests: make(chan shardRequest[T], queueSize),
+			done:     make(chan struct{}),
+		}
+		w.shards[i] = s
+		go s.run()
+	}
+	return w
+}
+
+func (s *writerShard[T]) run() {
+	defer close(s.done)
+
+	for req := range s.requests {
+		if req.flush != nil {
+			err := s.error()
+			if err == nil {
+				err = s.writer.Flush()
+			}
+			req.flush <- err
+			continue
+		}
+		if s.error() != nil {
+			continue // drain the queue after an error
+		}
+		n, err := s.writer.Write(req.rows)
+		s.rows.Add(int64(n))
+		s.batches.Add(1)
+		if err != nil {
+			s.err.Store(&err)
+		}
+	}
+}
+
+func (s *writerShard[T]) error() error {
+	if err := s.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// Write queues the rows to be written by the next shard. The rows are copied,
+// the application may reuse the slice after the method returns.
+//
+// The method returns an error if one of the shards failed to write rows
+// previously; in that case the rows are not queued.
+func (w *ShardedWriter[T]) Write(rows []T) (int, error) {
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if err := w.error(); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	s := w.shards[w.next]
+	w.next = (w.next + 1) % len(w.shards)
+	s.requests <- shardRequest[T]{rows: slices.Clone(rows)}
+	return len(rows), nil
+}
+
+// SetKeyValueMetadata sets a key/value pair in the metadata of all the output
+// files. The method must be called before the writer is closed.
+func (w *ShardedWriter[T]) SetKeyValueMetadata(key, value string) {
+	// The metadata is only read when the shards are closed, which happens on
+	// the goroutine calling Close after the workers exited, so it is safe to
+	// mutate it while workers are writing rows.
+	for _, s := range w.shards {
+		s.writer.SetKeyValueMetadata(key, value)
+	}
+}
+
+// Flush waits for all queued batches to be written, then flushes the row groups
+// buffered by each shard.
+func (w *ShardedWriter[T]) Flush() error {
+	if w.closed {
+		return io.ErrClosedPipe
+	}
+	results := make([]chan error, len(w.shards))
+	for i, s := range w.shards {
+		results[i] = make(chan error, 1)
+		s.requests <- shardRequest[T]{flush: results[i]}
+	}
+	var err error
+	for i, result := range results {
+		if flushErr := <-result; flushErr != nil && err == nil {
+			err = fmt.Errorf("flushing shard %d: %w", i, flushErr)
+		}
+	}
+	return err
+}
+
+// Close waits for all queued batches to be written, then closes the shards in
+// the order of their outputs. All shards are closed even if errors occur, the
+// returned error joins the errors of all the shards which failed.
+func (w *ShardedWriter[T]) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	for _, s := range w.shards {
+		close(s.requests)
+	}
+	for _, s := range w.shards {
+		<-s.done
+	}
+
+	var errs []error
+	for i, s := range w.shards {
+		writeErr := s.error()
+		if writeErr != nil {
+			// The rows of the current row group may have been partially
+			// written to the column buffers, they cannot be flushed.
+			s.writer.base.writer.discard()
+			errs = append(errs, fmt.Errorf("writing to shard %d: %w", i, writeErr))
+		}
+		if err := s.writer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing shard %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Stats returns statistics about the rows written so far. Batches which are
+// still queued are not accounted for.
+func (w *ShardedWriter[T]) Stats() ShardedWriterStats {
+	stats := ShardedWriterStats{Shards: make([]ShardStats, len(w.shards))}
+	for i, s := range w.shards {
+		shard := ShardStats{
+			NumRows:    s.rows.Load(),
+			NumBatches: s.batches.Load(),
+		}
+		stats.Shards[i] = shard
+		stats.NumRows += shard.NumRows
+		stats.NumBatches += shard.NumBatches
+	}
+	return stats
+}
+
+// Schema returns the schema of the files written by w.
+func (w *ShardedWriter[T]) Schema() *Schema {
+	return w.shards[0].writer.Schema()
+}
+
+func (w *ShardedWriter[T]) error() error {
+	for i, s := range w.shards {
+		if err := s.error(); err != nil {
+			return fmt.Errorf("writing to shard %d: %w", i, err)
+		}
+	}
+	return nil
+}
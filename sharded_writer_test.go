@@ -0,0 +1,121 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestShardedWriter(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	outputs := []*bytes.Buffer{new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)}
+	w := parquet.NewShardedWriter[Row](
+		[]io.Writer{outputs[0], outputs[1], outputs[2]}, 4,
+	)
+	w.SetKeyValueMetadata("source", "test")
+
+	const numBatches, batchSize = 10, 100
+	batch := make([]Row, batchSize)
+	for i := 0; i < numBatches; i++ {
+		for j := range batch {
+			batch[j].ID = int64(i*batchSize + j)
+		}
+		if _, err := w.Write(batch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := w.Stats()
+	if stats.NumRows != numBatches*batchSize || stats.NumBatches != numBatches {
+		t.Errorf("wrong stats: %+v", stats)
+	}
+	if stats.Shards[0].NumBatches != 4 || stats.Shards[1].NumBatches != 3 || stats.Shards[2].NumBatches != 3 {
+		t.Errorf("batches were not distributed across shards: %+v", stats.Shards)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(batch); err == nil {
+		t.Error("expected an error writing to a closed writer")
+	}
+
+	var ids []int64
+	for i, output := range outputs {
+		f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, _ := f.Lookup("source"); v != "test" {
+			t.Errorf("shard %d: missing key/value metadata", i)
+		}
+		rows, err := parquet.Read[Row](f, f.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) != numBatches*batchSize {
+		t.Fatalf("wrong number of rows: %d", len(ids))
+	}
+	for i, id := range ids {
+		if id != int64(i) {
+			t.Fatalf("wrong row at index %d: %d", i, id)
+		}
+	}
+}
+
+func TestShardedWriterCloseFailedShard(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name"`
+	}
+
+	output := new(bytes.Buffer)
+	w := parquet.NewShardedWriter[Row]([]io.Writer{output}, 4,
+		parquet.MaxRowsPerRowGroup(100),
+		parquet.UTF8Validate,
+	)
+
+	batch := make([]Row, 250)
+	for i := range batch {
+		batch[i].Name = "name"
+	}
+	if _, err := w.Write(batch); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]Row{{Name: "\xff"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := w.Close()
+	if err == nil {
+		t.Fatal("expected an error closing a writer with a failed shard")
+	}
+	if !strings.Contains(err.Error(), "writing to shard 0") {
+		t.Errorf("the error of the failed shard was not reported: %v", err)
+	}
+
+	// The rows buffered when the shard failed are discarded, the row groups
+	// written before are readable.
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := f.NumRows(); n != 200 {
+		t.Errorf("wrong number of rows in the file of the failed shard: %d", n)
+	}
+}
@@ -0,0 +1,81 @@
+package parquet
+
+import (
+	"github.com/parquet-go/parquet-go/deprecated"
+)
+
+// SortOrder represents the order used to compare the values of a parquet type,
+// as defined by the parquet format specification.
+//
+// The sort order determines how the min and max statistics of columns, and the
+// bounds recorded in their page index, are computed. It does not always match
+// the physical type of the values: for example the values of UINT32 and UINT64
+// columns are stored as INT32 and INT64 but ordered by unsigned comparisons,
+// and strings are ordered by unsigned byte-wise comparisons.
+type SortOrder int8
+
+const (
+	// SignedSortOrder is the order of signed integers, floating point
+	// numbers, booleans, and decimals.
+	SignedSortOrder SortOrder = iota
+
+	// UnsignedSortOrder is the order of unsigned integers, and byte arrays
+	// compared lexicographically as unsigned bytes.
+	UnsignedSortOrder
+
+	// UndefinedSortOrder is the order of types which have no defined order,
+	// for example INT96 and INTERVAL. The min and max statistics of these
+	// types should not be used.
+	UndefinedSortOrder
+)
+
+// String returns a human-readable representation of o.
+func (o SortOrder) String() string {
+	switch o {
+	case SignedSortOrder:
+		return "SIGNED"
+	case UnsignedSortOrder:
+		return "UNSIGNED"
+	default:
+		return "UNDEFINED"
+	}
+}
+
+// SortOrderOf returns the sort order of the given type.
+//
+// The Compare method of types implements their sort order, which is the
+// function that programs should use to compare values with the min and max
+// statistics of columns rather than comparing the physical values.
+func SortOrderOf(t Type) SortOrder {
+	if lt := t.LogicalType(); lt != nil {
+		switch {
+		case lt.Integer != nil:
+			if lt.Integer.IsSigned {
+				return SignedSortOrder
+			}
+			return UnsignedSortOrder
+		case lt.Decimal != nil:
+			return SignedSortOrder
+		case lt.UTF8 != nil, lt.Enum != nil, lt.Json != nil, lt.Bson != nil, lt.UUID != nil:
+			return UnsignedSortOrder
+		}
+	}
+	if ct := t.ConvertedType(); ct != nil {
+		switch *ct {
+		case deprecated.Uint8, deprecated.Uint16, deprecated.Uint32, deprecated.Uint64:
+			return UnsignedSortOrder
+		case deprecated.Decimal:
+			return SignedSortOrder
+		case deprecated.Interval:
+			return UndefinedSortOrder
+		}
+	}
+	switch t.Kind() {
+	case Int96:
+		return UndefinedSortOrder
+	case ByteArray, FixedLenByteArray:
+		return UnsignedSortOrder
+	default:
+		return SignedSortOrder
+	}
+}
@@ -0,0 +1,150 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestSortOrderOf(t *testing.T) {
+	tests := []struct {
+		node  parquet.Node
+		order parquet.SortOrder
+	}{
+		{parquet.Leaf(parquet.BooleanType), parquet.SignedSortOrder},
+		{parquet.Int(32), parquet.SignedSortOrder},
+		{parquet.Uint(32), parquet.UnsignedSortOrder},
+		{parquet.Uint(64), parquet.UnsignedSortOrder},
+		{parquet.Leaf(parquet.DoubleType), parquet.SignedSortOrder},
+		{parquet.String(), parquet.UnsignedSortOrder},
+		{parquet.Leaf(parquet.ByteArrayType), parquet.UnsignedSortOrder},
+		{parquet.Decimal(2, 10, parquet.FixedLenByteArrayType(8)), parquet.SignedSortOrder},
+		{parquet.Timestamp(parquet.Millisecond), parquet.SignedSortOrder},
+		{parquet.Leaf(parquet.Int96Type), parquet.UndefinedSortOrder},
+	}
+
+	for _, test := range tests {
+		if order := parquet.SortOrderOf(test.node.Type()); order != test.order {
+			t.Errorf("%s: sort order = %s, want %s", test.node.Type(), order, test.order)
+		}
+	}
+}
+
+func TestUnsignedColumnStatistics(t *testing.T) {
+	type Row struct {
+		U32 uint32 `parquet:"u32"`
+		U64 uint64 `parquet:"u64"`
+		I64 int64  `parquet:"i64"`
+	}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			U32: math.MaxInt32 - 500 + uint32(i),
+			U64: math.MaxInt64 - 500 + uint64(i),
+			I64: int64(i) - 500,
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := f.RowGroups()[0].ColumnChunks()
+	u32 := chunks[0].(*parquet.FileColumnChunk)
+	u64 := chunks[1].(*parquet.FileColumnChunk)
+
+	if order := f.Root().Column("u64").ColumnOrder(); order == nil || order.TypeOrder == nil {
+		t.Fatalf("missing type defined order of the u64 column: %+v", order)
+	}
+	if min, max, ok := u32.Bounds(); !ok || min.Uint32() != rows[0].U32 || max.Uint32() != rows[len(rows)-1].U32 {
+		t.Errorf("wrong bounds of u32 column: %d, %d, %t", min.Uint32(), max.Uint32(), ok)
+	}
+	if min, max, ok := u64.Bounds(); !ok || min.Uint64() != rows[0].U64 || max.Uint64() != rows[len(rows)-1].U64 {
+		t.Errorf("wrong bounds of u64 column: %d, %d, %t", min.Uint64(), max.Uint64(), ok)
+	}
+
+	// Values past the range of signed integers are found in the pages which
+	// contain them since the column index is compared as unsigned.
+	columnIndex, err := u64.ColumnIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if columnIndex.NumPages() < 2 || !columnIndex.IsAscending() {
+		t.Fatalf("expected multiple pages in ascending order: pages=%d ascending=%t", columnIndex.NumPages(), columnIndex.IsAscending())
+	}
+	value := parquet.ValueOf(rows[len(rows)-1].U64)
+	if i := parquet.Search(columnIndex, value, u64.Type()); i != columnIndex.NumPages()-1 {
+		t.Errorf("value found in page %d/%d", i, columnIndex.NumPages())
+	}
+
+	// Without column orders, the min and max statistics of unsigned columns
+	// cannot be interpreted, but those of signed columns can.
+	metadata := *f.Metadata()
+	metadata.ColumnOrders = nil
+	data := rewriteFooter(t, buf.Bytes(), &metadata)
+	f, err = parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks = f.RowGroups()[0].ColumnChunks()
+	if _, _, ok := chunks[1].(*parquet.FileColumnChunk).Bounds(); ok {
+		t.Error("bounds of u64 column without column order were trusted")
+	}
+	if min, max, ok := chunks[2].(*parquet.FileColumnChunk).Bounds(); !ok || min.Int64() != -500 || max.Int64() != 499 {
+		t.Errorf("wrong bounds of i64 column: %d, %d, %t", min.Int64(), max.Int64(), ok)
+	}
+}
+
+func TestFilterPagesWithoutColumnOrder(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%03d", i)}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(64)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Files written before column orders were added to the format do not
+	// declare how the min and max values of unsigned types were computed.
+	data := rewriteFileMetadata(t, f, buf.Bytes(), func(metadata *format.FileMetaData) {
+		metadata.ColumnOrders = nil
+	})
+	f, err = parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := f.RowGroups()[0].ColumnChunks()
+
+	selection := parquet.FilterPages(chunks[0], func(min, max []byte, nullPage bool) bool {
+		return int64(binary.LittleEndian.Uint64(min)) <= 10 && int64(binary.LittleEndian.Uint64(max)) >= 10
+	})
+	if selection.NumRows() == int64(len(rows)) {
+		t.Errorf("pages of signed column were not pruned: %v", selection)
+	}
+
+	selection = parquet.FilterPages(chunks[1], func(min, max []byte, nullPage bool) bool {
+		return string(min) <= "name-010" && string(max) >= "name-010"
+	})
+	if selection.NumRows() != int64(len(rows)) {
+		t.Errorf("pages of unsigned column were pruned without column order: %v", selection)
+	}
+}
@@ -118,8 +118,13 @@ func (w *SortingWriter[T]) Flush() error {
 	rows := m.Rows()
 	defer rows.Close()
 
+	// The merge is stable, duplicated rows are produced in the order of the
+	// temporary row groups, which is the order they were written in.
 	reader := RowReader(rows)
-	if w.sorting.DropDuplicatedRows {
+	switch {
+	case w.sorting.DropDuplicatedRows && w.sorting.KeepLastDuplicatedRow:
+		reader = &dedupeLastRowReader{reader: rows, compare: w.rowbuf.compare}
+	case w.sorting.DropDuplicatedRows:
 		reader = DedupeRowReader(rows, w.rowbuf.compare)
 	}
 
@@ -195,9 +200,17 @@ func (w *SortingWriter[T]) sortAndWriteBufferedRows() error {
 	}
 
 	defer w.rowbuf.Reset()
-	sort.Sort(w.rowbuf)
 
-	if w.sorting.DropDuplicatedRows {
+	switch {
+	case !w.sorting.DropDuplicatedRows:
+		sort.Sort(w.rowbuf)
+	case w.sorting.KeepLastDuplicatedRow:
+		// The sort must be stable to retain the duplicated row which was
+		// written first, or last.
+		sort.Stable(w.rowbuf)
+		w.rowbuf.rows = w.rowbuf.rows[:deduplicateLast(w.rowbuf.rows, w.rowbuf.compare)]
+	default:
+		sort.Stable(w.rowbuf)
 		w.rowbuf.rows = w.rowbuf.rows[:w.dedupe.deduplicate(w.rowbuf.rows, w.rowbuf.compare)]
 		defer w.dedupe.reset()
 	}
@@ -3,6 +3,7 @@ package parquet_test
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"io"
 	"math/rand"
 	"os"
@@ -114,6 +115,66 @@ func TestSortingWriterDropDuplicatedRows(t *testing.T) {
 	assertRowsEqual(t, rows[:n], read)
 }
 
+func TestSortingWriterKeepLastDuplicatedRow(t *testing.T) {
+	type Row struct {
+		Key     int32  `parquet:"key"`
+		Version int32  `parquet:"version"`
+		Name    string `parquet:"name"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	rows := make([]Row, 1000)
+	for i := range rows {
+		key := prng.Int31n(100)
+		rows[i] = Row{Key: key, Version: int32(i), Name: fmt.Sprintf("key-%d-version-%d", key, i)}
+	}
+
+	for _, keepLast := range []bool{false, true} {
+		t.Run(fmt.Sprintf("keepLast=%t", keepLast), func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			writer := parquet.NewSortingWriter[Row](buffer, 99,
+				parquet.SortingWriterConfig(
+					parquet.SortingColumns(
+						parquet.Ascending("key"),
+					),
+					parquet.DropDuplicatedRows(true),
+					parquet.KeepLastDuplicatedRow(keepLast),
+				),
+			)
+
+			// Rows are written in multiple calls so they are spread across
+			// multiple temporary row groups.
+			for i := 0; i < len(rows); i += 10 {
+				if _, err := writer.Write(rows[i : i+10]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			read, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			retained := make(map[int32]Row)
+			for _, row := range rows {
+				if _, ok := retained[row.Key]; !ok || keepLast {
+					retained[row.Key] = row
+				}
+			}
+			want := make([]Row, 0, len(retained))
+			for _, row := range retained {
+				want = append(want, row)
+			}
+			sort.Slice(want, func(i, j int) bool { return want[i].Key < want[j].Key })
+
+			assertRowsEqual(t, want, read)
+		})
+	}
+}
+
 func TestSortingWriterCorruptedString(t *testing.T) {
 	type Row struct {
 		Tag string `parquet:"tag"`
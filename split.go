@@ -0,0 +1,179 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// SplitFile writes each row group of f as an independent parquet file to the
+// writer returned by calling newWriter with the ordinal of the row group.
+//
+// The pages of column chunks are copied without being decoded, and each output
+// file receives a footer containing the metadata of its row group, so the
+// operation is much cheaper than reading and writing the rows. Bloom filters
+// and page indexes of the source file are carried over to the output files,
+// unless they were skipped when opening f.
+//
+// SplitFile does not close the writers; programs which write to files must
+// close them after the function returned.
+func SplitFile(f *File, newWriter func(i int) io.Writer) error {
+	for i := range f.metadata.RowGroups {
+		if err := f.writeRowGroupFile(i, newWriter(i)); err != nil {
+			return fmt.Errorf("splitting row group %d of parquet file: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (f *File) writeRowGroupFile(ordinal int, output io.Writer) error {
+	source := &f.metadata.RowGroups[ordinal]
+	rowGroup := *source
+	rowGroup.Columns = make([]format.ColumnChunk, len(source.Columns))
+	copy(rowGroup.Columns, source.Columns)
+	rowGroup.Ordinal = 0
+
+	w := offsetTrackingWriter{writer: output}
+	if _, err := w.WriteString("PAR1"); err != nil {
+		return err
+	}
+
+	rowGroup.FileOffset = w.offset
+	rowGroup.TotalCompressedSize = 0
+
+	for i := range rowGroup.Columns {
+		c := &rowGroup.Columns[i]
+		if c.FilePath != "" {
+			return fmt.Errorf("column chunk %d is stored in an external file: %q", i, c.FilePath)
+		}
+
+		start := c.MetaData.DataPageOffset
+		if offset := c.MetaData.DictionaryPageOffset; offset != 0 && offset < start {
+			start = offset
+		}
+		delta := w.offset - start
+
+		section := io.NewSectionReader(f.reader, start, c.MetaData.TotalCompressedSize)
+		if _, err := io.Copy(&w, section); err != nil {
+			return fmt.Errorf("copying pages of column chunk %d: %w", i, err)
+		}
+
+		c.MetaData.DataPageOffset += delta
+		if c.MetaData.DictionaryPageOffset != 0 {
+			c.MetaData.DictionaryPageOffset += delta
+		}
+		if c.MetaData.IndexPageOffset != 0 {
+			c.MetaData.IndexPageOffset += delta
+		}
+		if c.FileOffset != 0 {
+			c.FileOffset += delta
+		}
+		rowGroup.TotalCompressedSize += c.MetaData.TotalCompressedSize
+	}
+
+	for i := range rowGroup.Columns {
+		c := &rowGroup.Columns[i]
+		if f.config.SkipBloomFilters {
+			c.MetaData.BloomFilterOffset = 0
+			continue
+		}
+		if c.MetaData.BloomFilterOffset <= 0 {
+			continue
+		}
+		length, err := f.bloomFilterLength(c.MetaData.BloomFilterOffset)
+		if err != nil {
+			return fmt.Errorf("reading bloom filter of column chunk %d: %w", i, err)
+		}
+		offset := w.offset
+		if _, err := io.Copy(&w, io.NewSectionReader(f.reader, c.MetaData.BloomFilterOffset, length)); err != nil {
+			return fmt.Errorf("copying bloom filter of column chunk %d: %w", i, err)
+		}
+		c.MetaData.BloomFilterOffset = offset
+	}
+
+	protocol := new(thrift.CompactProtocol)
+	encoder := thrift.NewEncoder(protocol.NewWriter(&w))
+	numColumns := len(rowGroup.Columns)
+
+	for i := range rowGroup.Columns {
+		c := &rowGroup.Columns[i]
+		c.ColumnIndexOffset, c.ColumnIndexLength = 0, 0
+		c.OffsetIndexOffset, c.OffsetIndexLength = 0, 0
+	}
+
+	// Only the chunks which had page indexes in the source file get indexes in
+	// the output, the indexes loaded for the other chunks are empty.
+	if f.hasIndexes() {
+		for i := range rowGroup.Columns {
+			c := &rowGroup.Columns[i]
+			if source.Columns[i].ColumnIndexOffset == 0 {
+				continue
+			}
+			c.ColumnIndexOffset = w.offset
+			if err := encoder.Encode(&f.columnIndexes[ordinal*numColumns+i]); err != nil {
+				return err
+			}
+			c.ColumnIndexLength = int32(w.offset - c.ColumnIndexOffset)
+		}
+
+		for i := range rowGroup.Columns {
+			c := &rowGroup.Columns[i]
+			if source.Columns[i].OffsetIndexOffset == 0 {
+				continue
+			}
+			src := &source.Columns[i].MetaData
+			delta := c.MetaData.DataPageOffset - src.DataPageOffset
+
+			offsetIndex := f.offsetIndexes[ordinal*numColumns+i]
+			offsetIndex.PageLocations = make([]format.PageLocation, len(offsetIndex.PageLocations))
+			copy(offsetIndex.PageLocations, f.offsetIndexes[ordinal*numColumns+i].PageLocations)
+			for j := range offsetIndex.PageLocations {
+				offsetIndex.PageLocations[j].Offset += delta
+			}
+
+			c.OffsetIndexOffset = w.offset
+			if err := encoder.Encode(&offsetIndex); err != nil {
+				return err
+			}
+			c.OffsetIndexLength = int32(w.offset - c.OffsetIndexOffset)
+		}
+	}
+
+	metadata := f.metadata
+	metadata.NumRows = rowGroup.NumRows
+	metadata.RowGroups = []format.RowGroup{rowGroup}
+
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+	if err != nil {
+		return err
+	}
+
+	length := len(footer)
+	footer = append(footer, 0, 0, 0, 0)
+	footer = append(footer, "PAR1"...)
+	binary.LittleEndian.PutUint32(footer[length:], uint32(length))
+
+	_, err = w.Write(footer)
+	return err
+}
+
+// bloomFilterLength returns the length of the bloom filter at the given offset
+// of the file, including its header.
+func (f *File) bloomFilterLength(offset int64) (int64, error) {
+	section := io.NewSectionReader(f.reader, offset, f.size-offset)
+	rbuf, rbufpool := getBufioReader(section, f.config.ReadBufferSize)
+	defer putBufioReader(rbuf, rbufpool)
+
+	header := format.BloomFilterHeader{}
+	compact := thrift.CompactProtocol{}
+	if err := thrift.NewDecoder(compact.NewReader(rbuf)).Decode(&header); err != nil {
+		return 0, fmt.Errorf("decoding bloom filter header: %w", err)
+	}
+
+	headerLength, _ := section.Seek(0, io.SeekCurrent)
+	headerLength -= int64(rbuf.Buffered())
+	return headerLength + int64(header.NumBytes), nil
+}
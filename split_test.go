@@ -0,0 +1,185 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestSplitFile(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	rows := make([]Row, 25)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: "name-" + strconv.Itoa(i%4)}
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer,
+		parquet.MaxRowsPerRowGroup(10),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "id")),
+	)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var outputs []*bytes.Buffer
+	err = parquet.SplitFile(file, func(i int) io.Writer {
+		if i != len(outputs) {
+			t.Errorf("wrong row group ordinal: want=%d got=%d", len(outputs), i)
+		}
+		outputs = append(outputs, new(bytes.Buffer))
+		return outputs[i]
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 3 {
+		t.Fatalf("wrong number of files: %d", len(outputs))
+	}
+
+	offset := 0
+	for i, output := range outputs {
+		f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+		if err != nil {
+			t.Fatalf("file %d: %v", i, err)
+		}
+		if n := len(f.RowGroups()); n != 1 {
+			t.Fatalf("file %d: wrong number of row groups: %d", i, n)
+		}
+
+		got, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+		if err != nil {
+			t.Fatalf("file %d: %v", i, err)
+		}
+		want := rows[offset:min(offset+10, len(rows))]
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("file %d: wrong rows:\nwant = %+v\ngot  = %+v", i, want, got)
+		}
+
+		chunk := f.RowGroups()[0].ColumnChunks()[0].(*parquet.FileColumnChunk)
+		if chunk.BloomFilter() == nil {
+			t.Errorf("file %d: missing bloom filter", i)
+		} else if ok, err := chunk.MayContain(parquet.ValueOf(want[0].ID)); err != nil || !ok {
+			t.Errorf("file %d: bloom filter does not contain %d: %v", i, want[0].ID, err)
+		}
+
+		columnIndex, err := chunk.ColumnIndex()
+		if err != nil {
+			t.Fatalf("file %d: %v", i, err)
+		}
+		if min := columnIndex.MinValue(0).Int64(); min != want[0].ID {
+			t.Errorf("file %d: wrong min value in column index: %d", i, min)
+		}
+		offsetIndex, err := chunk.OffsetIndex()
+		if err != nil {
+			t.Fatalf("file %d: %v", i, err)
+		}
+		if offsetIndex.Offset(0) != f.RowGroups()[0].(*parquet.FileRowGroup).FileOffset() {
+			t.Errorf("file %d: wrong offset of first page: %d", i, offsetIndex.Offset(0))
+		}
+
+		offset += len(want)
+	}
+}
+
+func TestSplitFileSkipBloomFilters(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer,
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "id")),
+	)
+	if _, err := writer.Write([]Row{{ID: 1}, {ID: 2}, {ID: 3}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.SkipBloomFilters(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := new(bytes.Buffer)
+	if err := parquet.SplitFile(file, func(int) io.Writer { return output }); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset := f.Metadata().RowGroups[0].Columns[0].MetaData.BloomFilterOffset; offset != 0 {
+		t.Errorf("bloom filter copied to the split file at offset %d", offset)
+	}
+	if f.RowGroups()[0].ColumnChunks()[0].(*parquet.FileColumnChunk).BloomFilter() != nil {
+		t.Error("split file has a bloom filter")
+	}
+}
+
+func TestSplitFileColumnsWithoutPageIndexes(t *testing.T) {
+	// The column chunks of this file have no column index when the min and
+	// max values are too large, the split files must not claim empty indexes
+	// for them.
+	source, err := os.ReadFile("testdata/alltypes_tiny_pages.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(source), int64(len(source)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var outputs []*bytes.Buffer
+	err = parquet.SplitFile(file, func(int) io.Writer {
+		outputs = append(outputs, new(bytes.Buffer))
+		return outputs[len(outputs)-1]
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missing := 0
+	for i, output := range outputs {
+		f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+		if err != nil {
+			t.Fatalf("file %d: %v", i, err)
+		}
+		want := file.Metadata().RowGroups[i].Columns
+		got := f.Metadata().RowGroups[0].Columns
+		for j := range got {
+			if (want[j].ColumnIndexOffset == 0) != (got[j].ColumnIndexOffset == 0) {
+				t.Errorf("file %d, column %d: wrong column index offset: source=%d split=%d", i, j, want[j].ColumnIndexOffset, got[j].ColumnIndexOffset)
+			}
+			if (want[j].OffsetIndexOffset == 0) != (got[j].OffsetIndexOffset == 0) {
+				t.Errorf("file %d, column %d: wrong offset index offset: source=%d split=%d", i, j, want[j].OffsetIndexOffset, got[j].OffsetIndexOffset)
+			}
+			if want[j].ColumnIndexOffset == 0 {
+				missing++
+			}
+		}
+	}
+	if missing == 0 {
+		t.Error("expected the source file to have column chunks without column index")
+	}
+}
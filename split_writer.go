@@ -0,0 +1,120 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+)
+
+// SplitWriter is a writer which routes the rows it receives to one of several
+// parquet files, based on a routing function provided by the application.
+//
+// The use case for SplitWriter is to split streams of mixed rows (e.g. events
+// of multiple tenants) into separate files in a single pass. All the files are
+// written with the same schema and configuration, and key/value metadata set
+// on the SplitWriter is applied to all of them.
+//
+// SplitWriter instances are not safe to use concurrently from multiple
+// goroutines.
+type SplitWriter[T any] struct {
+	writers []*GenericWriter[T]
+	route   func(*T) int
+	batches [][]T
+}
+
+// NewSplitWriter constructs a writer which routes rows to the given outputs.
+//
+// The route function receives each row written to the SplitWriter, and returns
+// the index of the output that it must be written to. Rows are written to the
+// outputs in the order they were received.
+//
+// The function panics if the writer configuration is invalid.
+func NewSplitWriter[T any](outputs []io.Writer, route func(*T) int, options ...WriterOption) *SplitWriter[T] {
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+	w := &SplitWriter[T]{
+		writers: make([]*GenericWriter[T], len(outputs)),
+		route:   route,
+		batches: make([][]T, len(outputs)),
+	}
+	for i, output := range outputs {
+		w.writers[i] = NewGenericWriter[T](output, config)
+	}
+	return w
+}
+
+// Write routes the rows to the underlying writers. It returns the number of
+// rows written, and a non-nil error if a row was routed to an index out of
+// range or if writing to one of the outputs failed.
+func (w *SplitWriter[T]) Write(rows []T) (int, error) {
+	defer func() {
+		for i, batch := range w.batches {
+			clear(batch)
+			w.batches[i] = batch[:0]
+		}
+	}()
+
+	for i := range rows {
+		index := w.route(&rows[i])
+		if index < 0 || index >= len(w.writers) {
+			return 0, fmt.Errorf("row at index %d routed to output %d out of range [0:%d]", i, index, len(w.writers))
+		}
+		w.batches[index] = append(w.batches[index], rows[i])
+	}
+
+	written := 0
+	for i, batch := range w.batches {
+		if len(batch) == 0 {
+			continue
+		}
+		n, err := w.writers[i].Write(batch)
+		written += n
+		if err != nil {
+			return written, fmt.Errorf("writing to output %d: %w", i, err)
+		}
+	}
+	return written, nil
+}
+
+// SetKeyValueMetadata sets a key/value pair in the metadata of all the output
+// files.
+func (w *SplitWriter[T]) SetKeyValueMetadata(key, value string) {
+	for _, writer := range w.writers {
+		writer.SetKeyValueMetadata(key, value)
+	}
+}
+
+// Flush flushes the row groups buffered by all the underlying writers.
+func (w *SplitWriter[T]) Flush() error {
+	for i, writer := range w.writers {
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("flushing output %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Close closes all the underlying writers. All writers are closed even if an
+// error occurs, the first error is returned.
+func (w *SplitWriter[T]) Close() (err error) {
+	for i, writer := range w.writers {
+		if closeErr := writer.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("closing output %d: %w", i, closeErr)
+		}
+	}
+	return err
+}
+
+// Schema returns the schema of the files written by w.
+func (w *SplitWriter[T]) Schema() *Schema {
+	if len(w.writers) == 0 {
+		return nil
+	}
+	return w.writers[0].Schema()
+}
+
+// Writer returns the writer of the output at the given index.
+func (w *SplitWriter[T]) Writer(index int) *GenericWriter[T] {
+	return w.writers[index]
+}
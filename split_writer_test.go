@@ -0,0 +1,67 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestSplitWriter(t *testing.T) {
+	type Event struct {
+		Tenant int64  `parquet:"tenant"`
+		Name   string `parquet:"name"`
+	}
+
+	outputs := []*bytes.Buffer{new(bytes.Buffer), new(bytes.Buffer)}
+	w := parquet.NewSplitWriter[Event](
+		[]io.Writer{outputs[0], outputs[1]},
+		func(e *Event) int { return int(e.Tenant % 2) },
+	)
+	w.SetKeyValueMetadata("source", "test")
+
+	events := []Event{
+		{Tenant: 1, Name: "a"},
+		{Tenant: 2, Name: "b"},
+		{Tenant: 3, Name: "c"},
+		{Tenant: 4, Name: "d"},
+		{Tenant: 5, Name: "e"},
+	}
+	n, err := w.Write(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(events) {
+		t.Errorf("wrong number of rows written: %d", n)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]Event{
+		{events[1], events[3]},
+		{events[0], events[2], events[4]},
+	}
+	for i, output := range outputs {
+		f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, ok := f.Lookup("source"); !ok || v != "test" {
+			t.Errorf("output %d: missing key/value metadata", i)
+		}
+		rows, err := parquet.Read[Event](f, f.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(rows, want[i]) {
+			t.Errorf("output %d: rows mismatch:\nwant: %+v\ngot:  %+v", i, want[i], rows)
+		}
+	}
+
+	if _, err := parquet.NewSplitWriter[Event]([]io.Writer{io.Discard}, func(*Event) int { return 1 }).Write(events); err == nil {
+		t.Error("expected an error when routing rows out of range")
+	}
+}
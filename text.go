@@ -0,0 +1,76 @@
+package parquet
+
+import (
+	"reflect"
+
+	"github.com/parquet-go/parquet-go/sparse"
+)
+
+// The textMarshaler and textUnmarshaler interfaces mirror the interfaces of the
+// standard encoding package, which is shadowed by the parquet encoding package
+// in this code base.
+type textMarshaler interface {
+	MarshalText() ([]byte, error)
+}
+
+type textUnmarshaler interface {
+	UnmarshalText([]byte) error
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*textMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*textUnmarshaler)(nil)).Elem()
+)
+
+// isTextFallbackType returns true if t has no native parquet representation
+// but implements encoding.TextMarshaler and encoding.TextUnmarshaler, in which
+// case values are stored in STRING columns using their text representation.
+//
+// Struct types are only considered when they have no exported fields (e.g.
+// netip.Addr), otherwise they map to parquet groups.
+func isTextFallbackType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		if len(structFieldsOf(t)) != 0 {
+			return false
+		}
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return false
+		}
+	case reflect.Complex64, reflect.Complex128:
+	default:
+		return false
+	}
+	return t.Implements(textMarshalerType) && reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+func marshalText(v reflect.Value) []byte {
+	b, err := v.Interface().(textMarshaler).MarshalText()
+	if err != nil {
+		panic("cannot marshal go value of type " + v.Type().String() + " to text: " + err.Error())
+	}
+	return b
+}
+
+func writeRowsFuncOfText(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	writer := writeRowsFuncOfRequired(reflect.TypeOf(""), schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writer(columns, rows, levels)
+		}
+
+		texts := make([]string, rows.Len())
+		for i := range texts {
+			val := reflect.NewAt(t, rows.Index(i)).Elem()
+
+			b, err := val.Interface().(textMarshaler).MarshalText()
+			if err != nil {
+				return err
+			}
+			texts[i] = string(b)
+		}
+		return writer(columns, sparse.MakeStringArray(texts).UnsafeArray(), levels)
+	}
+}
@@ -0,0 +1,75 @@
+package parquet_test
+
+import (
+	"bytes"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type fallbackPayload struct {
+	A int
+	B []string
+}
+
+type fallbackRow struct {
+	Addr    netip.Addr       `parquet:"addr"`
+	Prefix  netip.Prefix     `parquet:"prefix,optional"`
+	Payload fallbackPayload  `parquet:"payload,json"`
+	Any     interface{}      `parquet:"any,json"`
+	Ptr     *fallbackPayload `parquet:"ptr,json"`
+}
+
+func TestTextAndJSONFallbacks(t *testing.T) {
+	const schema = `message fallbackRow {
+	required binary addr (STRING);
+	optional binary prefix (STRING);
+	required binary payload (JSON);
+	required binary any (JSON);
+	required binary ptr (JSON);
+}`
+
+	if s := parquet.SchemaOf(fallbackRow{}).String(); s != schema {
+		t.Fatalf("wrong schema:\n%s\nwant:\n%s", s, schema)
+	}
+
+	rows := []fallbackRow{
+		{
+			Addr:    netip.MustParseAddr("192.168.0.1"),
+			Prefix:  netip.MustParsePrefix("10.0.0.0/8"),
+			Payload: fallbackPayload{A: 1, B: []string{"x"}},
+			Any:     map[string]interface{}{"k": "v"},
+			Ptr:     &fallbackPayload{A: 2},
+		},
+		{
+			Addr: netip.MustParseAddr("::1"),
+			Any:  []interface{}{1.0, "2"},
+		},
+	}
+
+	for _, test := range []struct {
+		scenario string
+		options  []parquet.WriterOption
+	}{
+		{scenario: "generic"},
+		// Strict mode uses the row deconstruction code path.
+		{scenario: "deconstruct", options: []parquet.WriterOption{parquet.StrictWrite(true)}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := parquet.Write(buf, rows, test.options...); err != nil {
+				t.Fatal(err)
+			}
+
+			values, err := parquet.Read[fallbackRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(values, rows) {
+				t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, values)
+			}
+		})
+	}
+}
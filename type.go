@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/bits"
+	"net/netip"
 	"reflect"
 	"time"
 	"unsafe"
@@ -81,6 +82,11 @@ type Type interface {
 	// Compares two values and returns a negative integer if a < b, positive if
 	// a > b, or zero if a == b.
 	//
+	// The comparison implements the sort order of the type (see SortOrderOf),
+	// which is the order of the min and max statistics of columns; for example
+	// values of unsigned integer types are compared as unsigned integers even
+	// though their kind is Int32 or Int64.
+	//
 	// The values' Kind must match the type, otherwise the result is undefined.
 	//
 	// The method panics if it is called on a group type.
@@ -560,6 +566,11 @@ func (t int96Type) EstimateDecodeSize(numValues int, src []byte, enc encoding.En
 }
 
 func (t int96Type) AssignValue(dst reflect.Value, src Value) error {
+	if dst.Kind() == reflect.Array && dst.Type().Elem().Kind() == reflect.Uint8 && dst.Len() == 12 {
+		// The raw representation of INT96 values, as stored in parquet files.
+		reflect.Copy(dst, reflect.ValueOf(src.byteArray()))
+		return nil
+	}
 	v := src.Int96()
 	dst.Set(reflect.ValueOf(v))
 	return nil
@@ -805,6 +816,12 @@ func (t byteArrayType) AssignValue(dst reflect.Value, src Value) error {
 	case reflect.Slice:
 		dst.SetBytes(copyBytes(v))
 	default:
+		if dst.Type() == netipPrefixType {
+			return assignNetipValue(dst, v)
+		}
+		if dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+			return dst.Addr().Interface().(textUnmarshaler).UnmarshalText(v)
+		}
 		val := reflect.ValueOf(string(v))
 		dst.Set(val)
 	}
@@ -908,6 +925,10 @@ func (t fixedLenByteArrayType) AssignValue(dst reflect.Value, src Value) error {
 	case reflect.Slice:
 		dst.SetBytes(copyBytes(v))
 		return nil
+	case reflect.Struct:
+		if dst.Type() == netipAddrType {
+			return assignNetipValue(dst, v)
+		}
 	}
 
 	val := reflect.ValueOf(copyBytes(v))
@@ -1236,9 +1257,9 @@ func (t *intType) ConvertValue(val Value, typ Type) (Value, error) {
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#decimal
 func Decimal(scale, precision int, typ Type) Node {
 	switch typ.Kind() {
-	case Int32, Int64, FixedLenByteArray:
+	case Int32, Int64, ByteArray, FixedLenByteArray:
 	default:
-		panic("DECIMAL node must annotate Int32, Int64 or FixedLenByteArray but got " + typ.String())
+		panic("DECIMAL node must annotate Int32, Int64, ByteArray or FixedLenByteArray but got " + typ.String())
 	}
 	return Leaf(&decimalType{
 		decimal: format.DecimalType{
@@ -1334,6 +1355,16 @@ func (t *stringType) EstimateDecodeSize(numValues int, src []byte, enc encoding.
 }
 
 func (t *stringType) AssignValue(dst reflect.Value, src Value) error {
+	if dst.Type() == netipPrefixType {
+		// Prefixes are only stored in binary form in untyped BYTE_ARRAY
+		// columns, STRING columns hold their text representation.
+		prefix := new(netip.Prefix)
+		if err := prefix.UnmarshalText(src.byteArray()); err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(*prefix))
+		return nil
+	}
 	return byteArrayType{}.AssignValue(dst, src)
 }
 
@@ -1343,9 +1374,12 @@ func (t *stringType) ConvertValue(val Value, typ Type) (Value, error) {
 		return convertDateToString(val)
 	case *timeType:
 		tz := t2.tz()
-		if t2.Unit.Micros != nil {
+		switch {
+		case t2.Unit.Nanos != nil:
+			return convertTimeNanosToString(val, tz)
+		case t2.Unit.Micros != nil:
 			return convertTimeMicrosToString(val, tz)
-		} else {
+		default:
 			return convertTimeMillisToString(val, tz)
 		}
 	}
@@ -1892,25 +1926,45 @@ func (t *timeType) EstimateDecodeSize(numValues int, src []byte, enc encoding.En
 }
 
 func (t *timeType) AssignValue(dst reflect.Value, src Value) error {
-	return t.baseType().AssignValue(dst, src)
+	switch dst.Type() {
+	case reflect.TypeOf(time.Duration(0)), reflect.TypeOf(TimeOfDay(0)):
+		var val time.Duration
+		if t.useInt32() {
+			val = time.Duration(src.int32()) * time.Millisecond
+		} else {
+			val = time.Duration(src.int64()) * timeUnitDuration(t.Unit)
+		}
+		dst.SetInt(int64(val))
+		return nil
+	default:
+		return t.baseType().AssignValue(dst, src)
+	}
 }
 
 func (t *timeType) ConvertValue(val Value, typ Type) (Value, error) {
 	switch src := typ.(type) {
 	case *stringType:
 		tz := t.tz()
-		if t.Unit.Micros != nil {
+		switch {
+		case t.Unit.Nanos != nil:
+			return convertStringToTimeNanos(val, tz)
+		case t.Unit.Micros != nil:
 			return convertStringToTimeMicros(val, tz)
-		} else {
+		default:
 			return convertStringToTimeMillis(val, tz)
 		}
 	case *timestampType:
 		tz := t.tz()
-		if t.Unit.Micros != nil {
+		switch {
+		case t.Unit.Nanos != nil:
+			return convertTimestampToTimeNanos(val, src.Unit, src.tz(), tz)
+		case t.Unit.Micros != nil:
 			return convertTimestampToTimeMicros(val, src.Unit, src.tz(), tz)
-		} else {
+		default:
 			return convertTimestampToTimeMillis(val, src.Unit, src.tz(), tz)
 		}
+	case *timeType:
+		return convertTimeToTime(val, src.Unit, t.Unit)
 	}
 	return t.baseType().ConvertValue(val, typ)
 }
@@ -0,0 +1,185 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+)
+
+// ApplyUpserts constructs a row group where the rows of delta replace the rows
+// of base which have equal values in the key columns.
+//
+// Both row groups must be sorted by the key columns, which is verified by
+// testing that their sorting columns have keyColumns as prefix. Rows of delta
+// which have no equivalent in base are inserted at their position in the sort
+// order, so the returned row group is also sorted by the key columns. When
+// delta contains multiple rows with the same key, the last one is retained.
+//
+// This function is the core primitive used to compact streams of changes into
+// a base data set; the returned row group is materialized in memory, programs
+// will usually write it to a parquet file right after calling ApplyUpserts.
+//
+// If the schema of delta differs from the one of base, the rows of delta are
+// converted to the base schema, or an error is returned if the conversion is
+// not possible.
+func ApplyUpserts(base, delta RowGroup, keyColumns ...SortingColumn) (RowGroup, error) {
+	if len(keyColumns) == 0 {
+		return nil, fmt.Errorf("cannot apply upserts: no key columns")
+	}
+
+	schema := base.Schema()
+	if deltaSchema := delta.Schema(); !nodesAreEqual(schema, deltaSchema) {
+		conv, err := Convert(schema, deltaSchema)
+		if err != nil {
+			return nil, fmt.Errorf("cannot apply upserts: %w", err)
+		}
+		delta = ConvertRowGroup(delta, conv)
+	}
+
+	for _, rowGroup := range [...]RowGroup{base, delta} {
+		if !sortingColumnsHavePrefix(rowGroup.SortingColumns(), keyColumns) {
+			return nil, ErrRowGroupSortingColumnsMismatch
+		}
+	}
+
+	baseRows := base.Rows()
+	defer baseRows.Close()
+
+	deltaRows := delta.Rows()
+	defer deltaRows.Close()
+
+	output := NewBuffer(schema, SortingRowGroupConfig(SortingColumns(keyColumns...)))
+	upserts := newUpsertRowReader(baseRows, deltaRows, compareRowsFuncOf(schema, keyColumns))
+
+	if _, err := CopyRows(output, upserts); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+type upsertRowReader struct {
+	compare func(Row, Row) int
+	base    peekRowReader
+	delta   peekRowReader
+	// The upsert row is the last row of delta seen for the current key. It is
+	// held until a row with a different key is seen in delta, at which point
+	// it becomes the version of the row that gets produced.
+	upsert  Row
+	owned   Row
+	alloc   rowAllocator
+	pending bool
+}
+
+func newUpsertRowReader(base, delta RowReader, compare func(Row, Row) int) *upsertRowReader {
+	u := &upsertRowReader{compare: compare}
+	u.base.init(base)
+	u.delta.init(delta)
+	return u
+}
+
+func (u *upsertRowReader) ReadRows(rows []Row) (n int, err error) {
+	for n < len(rows) {
+		if u.base.needsFill() || u.delta.needsFill() {
+			// Reading more rows from either of the readers may invalidate the
+			// rows that we already produced during this call, so we must give
+			// them back to the caller first.
+			if n > 0 {
+				return n, nil
+			}
+			if u.pending {
+				u.captureUpsert()
+			}
+			if err := u.base.fill(); err != nil {
+				return n, err
+			}
+			if err := u.delta.fill(); err != nil {
+				return n, err
+			}
+			continue
+		}
+
+		baseRow := u.base.head()
+		deltaRow := u.delta.head()
+
+		if u.pending {
+			switch {
+			case deltaRow != nil && u.compare(deltaRow, u.upsert) == 0:
+				u.upsert = deltaRow
+				u.delta.next()
+			case baseRow != nil && u.compare(baseRow, u.upsert) == 0:
+				u.base.next()
+			default:
+				rows[n] = append(rows[n][:0], u.upsert...)
+				u.upsert = nil
+				u.pending = false
+				n++
+			}
+			continue
+		}
+
+		switch {
+		case baseRow == nil && deltaRow == nil:
+			return n, io.EOF
+		case deltaRow == nil || (baseRow != nil && u.compare(baseRow, deltaRow) < 0):
+			rows[n] = append(rows[n][:0], baseRow...)
+			u.base.next()
+			n++
+		default:
+			u.upsert = deltaRow
+			u.pending = true
+			u.delta.next()
+		}
+	}
+	return n, nil
+}
+
+func (u *upsertRowReader) captureUpsert() {
+	u.alloc.reset()
+	u.owned = append(u.owned[:0], u.upsert...)
+	u.alloc.capture(u.owned)
+	u.upsert = u.owned
+}
+
+// peekRowReader is a row reader which exposes the rows it buffered one at a
+// time, allowing the program to look at the next row without consuming it.
+type peekRowReader struct {
+	rows RowReader
+	buf  []Row
+	off  int
+	end  int
+	err  error
+}
+
+func (r *peekRowReader) init(rows RowReader) {
+	r.rows = rows
+	r.buf = makeRows(defaultRowBufferSize)
+}
+
+func (r *peekRowReader) head() Row {
+	if r.off < r.end {
+		return r.buf[r.off]
+	}
+	return nil
+}
+
+func (r *peekRowReader) next() { r.off++ }
+
+func (r *peekRowReader) needsFill() bool { return r.off == r.end && r.err == nil }
+
+func (r *peekRowReader) fill() error {
+	if !r.needsFill() {
+		return nil
+	}
+	n, err := r.rows.ReadRows(r.buf)
+	r.off, r.end = 0, n
+	switch {
+	case err == io.EOF:
+		r.err = err
+	case err != nil:
+		return err
+	case n == 0:
+		return io.ErrNoProgress
+	}
+	return nil
+}
+
+var _ RowReader = (*upsertRowReader)(nil)
@@ -0,0 +1,86 @@
+package parquet_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestApplyUpserts(t *testing.T) {
+	type Row struct {
+		Key   int64  `parquet:"key"`
+		Value string `parquet:"value"`
+	}
+
+	sorting := parquet.SortingRowGroupConfig(
+		parquet.SortingColumns(parquet.Ascending("key")),
+	)
+
+	base := parquet.NewGenericBuffer[Row](sorting)
+	for i := 0; i < 1000; i++ {
+		if _, err := base.Write([]Row{{Key: int64(2 * i), Value: "base"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	delta := parquet.NewGenericBuffer[Row](sorting)
+	if _, err := delta.Write([]Row{
+		{Key: -1, Value: "insert"},
+		{Key: 0, Value: "first"},
+		{Key: 0, Value: "update"},
+		{Key: 3, Value: "insert"},
+		{Key: 500, Value: "update"},
+		{Key: 1998, Value: "update"},
+		{Key: 5000, Value: "insert"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rowGroup, err := parquet.ApplyUpserts(base, delta, parquet.Ascending("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Row{{Key: -1, Value: "insert"}}
+	for i := 0; i < 1000; i++ {
+		row := Row{Key: int64(2 * i), Value: "base"}
+		switch row.Key {
+		case 0, 500, 1998:
+			row.Value = "update"
+		case 4:
+			want = append(want, Row{Key: 3, Value: "insert"})
+		}
+		want = append(want, row)
+	}
+	want = append(want, Row{Key: 5000, Value: "insert"})
+
+	if n := rowGroup.NumRows(); n != int64(len(want)) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(want), n)
+	}
+
+	reader := parquet.NewGenericRowGroupReader[Row](rowGroup)
+	defer reader.Close()
+
+	got := make([]Row, len(want))
+	n, err := reader.Read(got)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, want, got[:n])
+}
+
+func TestApplyUpsertsSortingColumnsMismatch(t *testing.T) {
+	type Row struct {
+		Key int64 `parquet:"key"`
+	}
+
+	base := parquet.NewGenericBuffer[Row]()
+	delta := parquet.NewGenericBuffer[Row]()
+
+	_, err := parquet.ApplyUpserts(base, delta, parquet.Ascending("key"))
+	if !errors.Is(err, parquet.ErrRowGroupSortingColumnsMismatch) {
+		t.Fatalf("wrong error: %v", err)
+	}
+}
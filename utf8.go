@@ -0,0 +1,87 @@
+package parquet
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// UTF8Policy defines how readers and writers handle invalid UTF-8 sequences in
+// columns of the STRING logical type.
+//
+// The parquet specification requires that STRING values be valid UTF-8, other
+// implementations may refuse to read files which contain invalid sequences.
+//
+// UTF8Policy values implement both the WriterOption and ReaderOption
+// interfaces, they can be passed directly to the writer and reader
+// constructors, for example:
+//
+//	writer := parquet.NewGenericWriter[Row](output, parquet.UTF8Replace)
+type UTF8Policy int
+
+const (
+	// UTF8PassThrough leaves the values unchecked. This is the default policy.
+	UTF8PassThrough UTF8Policy = iota
+
+	// UTF8Validate causes readers and writers to return a *RowError wrapping
+	// ErrInvalidUTF8 when they encounter an invalid STRING value.
+	UTF8Validate
+
+	// UTF8Replace causes readers and writers to replace invalid sequences in
+	// STRING values with the U+FFFD replacement character.
+	UTF8Replace
+)
+
+// String returns a human-readable representation of p.
+func (p UTF8Policy) String() string {
+	switch p {
+	case UTF8PassThrough:
+		return "pass-through"
+	case UTF8Validate:
+		return "validate"
+	case UTF8Replace:
+		return "replace"
+	default:
+		return "UTF8Policy(?)"
+	}
+}
+
+// ConfigureWriter satisfies the WriterOption interface.
+func (p UTF8Policy) ConfigureWriter(config *WriterConfig) { config.UTF8 = p }
+
+// ConfigureReader satisfies the ReaderOption interface.
+func (p UTF8Policy) ConfigureReader(config *ReaderConfig) { config.UTF8 = p }
+
+func isStringNode(node Node) bool {
+	lt := node.Type().LogicalType()
+	return lt != nil && lt.UTF8 != nil
+}
+
+// stringColumnsOf returns the paths of STRING columns of the schema, indexed by
+// column index, with nil entries for columns of other types.
+func stringColumnsOf(schema *Schema) []columnPath {
+	var columns []columnPath
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		var path columnPath
+		if isStringNode(leaf.node) {
+			path = leaf.path
+		}
+		columns = append(columns, path)
+	})
+	return columns
+}
+
+func isValidUTF8(v Value) bool {
+	return v.IsNull() || utf8.Valid(v.byteArray())
+}
+
+// replaceInvalidUTF8 replaces values holding invalid UTF-8 sequences with new
+// values where the sequences are replaced by U+FFFD. The original values, which
+// may be owned by the application or by page buffers, are not modified.
+func replaceInvalidUTF8(values []Value) {
+	for i, v := range values {
+		if !isValidUTF8(v) {
+			b := bytes.ToValidUTF8(v.byteArray(), []byte(string(utf8.RuneError)))
+			values[i] = ByteArrayValue(b).Level(v.RepetitionLevel(), v.DefinitionLevel(), v.Column())
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type utf8Row struct {
+	ID   int64  `parquet:"id"`
+	Name string `parquet:"name"`
+}
+
+var utf8Rows = []utf8Row{
+	{ID: 0, Name: "valid"},
+	{ID: 1, Name: "in\xffvalid"},
+	{ID: 2, Name: "also valid"},
+}
+
+func writeUTF8Rows(t *testing.T, options ...parquet.WriterOption) (*bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[utf8Row](buf, options...)
+	if _, err := w.Write(utf8Rows); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf, nil
+}
+
+func TestUTF8PolicyWriter(t *testing.T) {
+	t.Run("validate", func(t *testing.T) {
+		_, err := writeUTF8Rows(t, parquet.UTF8Validate)
+		if !errors.Is(err, parquet.ErrInvalidUTF8) {
+			t.Fatalf("expected ErrInvalidUTF8, got %v", err)
+		}
+		var rowErr *parquet.RowError
+		if !errors.As(err, &rowErr) || rowErr.Row != 1 {
+			t.Fatalf("expected error on row 1, got %v", err)
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		buf, err := writeUTF8Rows(t, parquet.UTF8Replace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows, err := parquet.Read[utf8Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows[1].Name != "in�valid" {
+			t.Errorf("invalid sequence was not replaced: %q", rows[1].Name)
+		}
+		if utf8Rows[1].Name != "in\xffvalid" {
+			t.Errorf("the input rows were modified: %q", utf8Rows[1].Name)
+		}
+	})
+}
+
+func TestUTF8PolicyReader(t *testing.T) {
+	buf, err := writeUTF8Rows(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := bytes.NewReader(buf.Bytes())
+
+	t.Run("pass-through", func(t *testing.T) {
+		rows, err := parquet.Read[utf8Row](input, input.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows[1].Name != utf8Rows[1].Name {
+			t.Errorf("value was modified: %q", rows[1].Name)
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		r := parquet.NewGenericReader[utf8Row](input, parquet.UTF8Validate)
+		defer r.Close()
+
+		rows := make([]utf8Row, 3)
+		n, err := r.Read(rows)
+		if n != 1 {
+			t.Errorf("wrong number of rows read: %d", n)
+		}
+		var rowErr *parquet.RowError
+		if !errors.As(err, &rowErr) || !errors.Is(err, parquet.ErrInvalidUTF8) {
+			t.Fatalf("expected *parquet.RowError wrapping ErrInvalidUTF8, got %v", err)
+		}
+		if rowErr.Row != 1 {
+			t.Errorf("wrong row index: %d", rowErr.Row)
+		}
+
+		// The error is reported again on the next read.
+		if _, err := r.Read(rows); !errors.Is(err, parquet.ErrInvalidUTF8) {
+			t.Errorf("expected ErrInvalidUTF8 on the next read, got %v", err)
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		r := parquet.NewGenericReader[utf8Row](input, parquet.UTF8Replace)
+		defer r.Close()
+
+		rows := make([]utf8Row, 3)
+		n, err := r.Read(rows)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		if n != 3 {
+			t.Fatalf("wrong number of rows read: %d", n)
+		}
+		if rows[1].Name != "in�valid" {
+			t.Errorf("invalid sequence was not replaced: %q", rows[1].Name)
+		}
+	})
+}
@@ -0,0 +1,309 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go/encoding"
+)
+
+// ValidationLevel represents the depth of verifications performed by
+// ValidateFile.
+type ValidationLevel int
+
+const (
+	// ValidateMetadata verifies the consistency of the file metadata, such
+	// as the number of rows and values of column chunks and their location
+	// in the file, without reading the pages.
+	ValidateMetadata ValidationLevel = iota
+
+	// ValidatePages also reads and decodes all the pages of the file, and
+	// verifies that the number of pages, rows and values match the metadata,
+	// that the repetition and definition levels are consistent with the
+	// schema, and that dictionary indexes are within the bounds of the
+	// dictionaries.
+	ValidatePages
+
+	// ValidateStatistics also compares the statistics recorded in the column
+	// chunk metadata and the page index with the values of the pages.
+	ValidateStatistics
+)
+
+// ValidateFile performs a structural verification of f, up to the given level.
+//
+// The function is a programmatic equivalent of the check commands of parquet
+// tools, which can be used to gate the ingestion of files produced by
+// untrusted writers. The returned error is nil if no issues were found;
+// otherwise, it is the result of calling errors.Join on a list of
+// *ValidationError values describing each issue.
+func ValidateFile(f *File, level ValidationLevel) error {
+	v := &fileValidator{file: f, level: level}
+	f.root.forEachLeaf(func(c *Column) { v.columns = append(v.columns, c) })
+	v.validate()
+	return errors.Join(v.errors...)
+}
+
+type fileValidator struct {
+	file    *File
+	level   ValidationLevel
+	columns []*Column
+	errors  []error
+}
+
+func (v *fileValidator) report(rowGroup int, column *Column, page int, format string, args ...any) {
+	v.reportError(rowGroup, column, page, fmt.Errorf(format, args...))
+}
+
+func (v *fileValidator) reportError(rowGroup int, column *Column, page int, err error) {
+	e := &ValidationError{RowGroup: rowGroup, Page: page, Err: err}
+	if column != nil {
+		e.Path = column.Path()
+	}
+	v.errors = append(v.errors, e)
+}
+
+func (v *fileValidator) validate() {
+	metadata := &v.file.metadata
+	numRows := int64(0)
+
+	for i := range metadata.RowGroups {
+		numRows += metadata.RowGroups[i].NumRows
+		v.validateRowGroup(i)
+	}
+
+	if numRows != metadata.NumRows {
+		v.report(-1, nil, -1, "file has %d rows but its row groups have %d rows", metadata.NumRows, numRows)
+	}
+}
+
+func (v *fileValidator) validateRowGroup(i int) {
+	rowGroup := &v.file.metadata.RowGroups[i]
+
+	if len(rowGroup.Columns) != len(v.columns) {
+		v.report(i, nil, -1, "row group has %d column chunks but the schema has %d leaf columns", len(rowGroup.Columns), len(v.columns))
+		return
+	}
+
+	for _, column := range v.columns {
+		if v.validateColumnChunkMetadata(i, column) && v.level >= ValidatePages {
+			v.validateColumnChunkPages(i, column)
+		}
+	}
+}
+
+func (v *fileValidator) validateColumnChunkMetadata(i int, column *Column) bool {
+	rowGroup := &v.file.metadata.RowGroups[i]
+	metadata := &rowGroup.Columns[column.Index()].MetaData
+	valid := true
+
+	if column.schema.Type != nil && metadata.Type != *column.schema.Type {
+		v.report(i, column, -1, "column chunk has type %s but the schema declares %s", Kind(metadata.Type), Kind(*column.schema.Type))
+		return false
+	}
+
+	start := metadata.DataPageOffset
+	if offset := metadata.DictionaryPageOffset; offset != 0 && offset < start {
+		start = offset
+	}
+	if end := start + metadata.TotalCompressedSize; start < 4 || end > v.file.size || end < start {
+		v.report(i, column, -1, "column chunk spans the byte range [%d:%d] outside of the file of size %d", start, end, v.file.size)
+		valid = false
+	}
+
+	if column.MaxRepetitionLevel() == 0 {
+		if metadata.NumValues != rowGroup.NumRows {
+			v.report(i, column, -1, "column chunk has %d values but the row group has %d rows", metadata.NumValues, rowGroup.NumRows)
+		}
+	} else if metadata.NumValues < rowGroup.NumRows {
+		v.report(i, column, -1, "repeated column chunk has %d values but the row group has %d rows", metadata.NumValues, rowGroup.NumRows)
+	}
+
+	stats := &metadata.Statistics
+	if stats.NullCount < 0 || stats.NullCount > metadata.NumValues {
+		v.report(i, column, -1, "column chunk statistics have %d nulls out of %d values", stats.NullCount, metadata.NumValues)
+	}
+	if stats.MinValue != nil && stats.MaxValue != nil {
+		kind := column.Type().Kind()
+		minValue, maxValue := kind.Value(stats.MinValue), kind.Value(stats.MaxValue)
+		if column.Type().Compare(minValue, maxValue) > 0 {
+			v.report(i, column, -1, "column chunk statistics have a min value greater than the max value: %v > %v", minValue, maxValue)
+		}
+	}
+
+	return valid
+}
+
+func (v *fileValidator) validateColumnChunkPages(i int, column *Column) {
+	rowGroup := v.file.rowGroups[i].(*FileRowGroup)
+	chunk := rowGroup.ColumnChunks()[column.Index()].(*FileColumnChunk)
+	metadata := &chunk.chunk.MetaData
+	typ := column.Type()
+	maxRepetitionLevel := byte(column.MaxRepetitionLevel())
+	maxDefinitionLevel := byte(column.MaxDefinitionLevel())
+
+	var columnIndex ColumnIndex
+	if v.level >= ValidateStatistics {
+		columnIndex, _ = chunk.ColumnIndex()
+	}
+
+	var minValue, maxValue Value
+	var numPages int
+	var numValues, numNulls, numRows int64
+
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	for {
+		page, err := pages.ReadPage()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				v.reportError(i, column, numPages, err)
+				return
+			}
+			break
+		}
+
+		pageIndex := numPages
+		numPages++
+		numValues += page.NumValues()
+		numNulls += page.NumNulls()
+		numRows += page.NumRows()
+
+		ok := v.validatePageLevels(i, column, pageIndex, page, maxRepetitionLevel, maxDefinitionLevel)
+		ok = ok && v.validatePageDictionary(i, column, pageIndex, page)
+
+		if ok && v.level >= ValidateStatistics {
+			pageMin, pageMax, hasBounds := page.Bounds()
+			if hasBounds {
+				if minValue.IsNull() || typ.Compare(pageMin, minValue) < 0 {
+					minValue = pageMin.Clone()
+				}
+				if maxValue.IsNull() || typ.Compare(pageMax, maxValue) > 0 {
+					maxValue = pageMax.Clone()
+				}
+			}
+			if columnIndex != nil && pageIndex < columnIndex.NumPages() {
+				v.validatePageIndex(i, column, pageIndex, page, columnIndex, pageMin, pageMax, hasBounds)
+			}
+		}
+
+		Release(page)
+	}
+
+	if numValues != metadata.NumValues {
+		v.report(i, column, -1, "column chunk metadata has %d values but its pages contain %d values", metadata.NumValues, numValues)
+	}
+	if numRows != rowGroup.NumRows() {
+		v.report(i, column, -1, "row group has %d rows but the pages of the column chunk contain %d rows", rowGroup.NumRows(), numRows)
+	}
+	if offsetIndex, err := chunk.OffsetIndex(); err == nil && offsetIndex.NumPages() != numPages {
+		v.report(i, column, -1, "offset index has %d pages but the column chunk contains %d data pages", offsetIndex.NumPages(), numPages)
+	}
+	if columnIndex != nil && columnIndex.NumPages() != numPages {
+		v.report(i, column, -1, "column index has %d pages but the column chunk contains %d data pages", columnIndex.NumPages(), numPages)
+	}
+
+	if v.level >= ValidateStatistics {
+		stats := &metadata.Statistics
+		// Writers disagree on whether empty or null lists are counted as null
+		// values, null counts are only verified for flat columns.
+		if (stats.MinValue != nil || stats.NullCount != 0) && isFlatColumn(column) {
+			if stats.NullCount != numNulls {
+				v.report(i, column, -1, "column chunk statistics have %d nulls but the pages contain %d nulls", stats.NullCount, numNulls)
+			}
+		}
+		kind := typ.Kind()
+		if stats.MinValue != nil && !minValue.IsNull() && typ.Compare(kind.Value(stats.MinValue), minValue) > 0 {
+			v.report(i, column, -1, "column chunk statistics have a min value greater than the smallest value: %v > %v", kind.Value(stats.MinValue), minValue)
+		}
+		if stats.MaxValue != nil && !maxValue.IsNull() && typ.Compare(kind.Value(stats.MaxValue), maxValue) < 0 && !isTruncatedMaxValue(kind.Value(stats.MaxValue), maxValue) {
+			v.report(i, column, -1, "column chunk statistics have a max value less than the largest value: %v < %v", kind.Value(stats.MaxValue), maxValue)
+		}
+	}
+}
+
+func (v *fileValidator) validatePageLevels(i int, column *Column, pageIndex int, page Page, maxRepetitionLevel, maxDefinitionLevel byte) bool {
+	repetitionLevels := page.RepetitionLevels()
+	definitionLevels := page.DefinitionLevels()
+
+	for _, level := range repetitionLevels {
+		if level > maxRepetitionLevel {
+			v.report(i, column, pageIndex, "page has a repetition level of %d but the column has a max repetition level of %d", level, maxRepetitionLevel)
+			return false
+		}
+	}
+
+	numDefined := int64(0)
+	for _, level := range definitionLevels {
+		if level > maxDefinitionLevel {
+			v.report(i, column, pageIndex, "page has a definition level of %d but the column has a max definition level of %d", level, maxDefinitionLevel)
+			return false
+		}
+		if level == maxDefinitionLevel {
+			numDefined++
+		}
+	}
+	if maxDefinitionLevel > 0 {
+		if numNonNulls := page.NumValues() - page.NumNulls(); numDefined != numNonNulls {
+			v.report(i, column, pageIndex, "page has %d non-null values but %d definition levels equal to the max definition level", numNonNulls, numDefined)
+			return false
+		}
+	}
+	return true
+}
+
+func (v *fileValidator) validatePageDictionary(i int, column *Column, pageIndex int, page Page) bool {
+	dict := page.Dictionary()
+	if dict == nil {
+		return true
+	}
+	data := page.Data()
+	if data.Kind() != encoding.Int32 {
+		return true
+	}
+	dictLen := int32(dict.Len())
+	for _, index := range data.Int32() {
+		if index < 0 || index >= dictLen {
+			v.report(i, column, pageIndex, "page references the dictionary index %d but the dictionary has %d values", index, dictLen)
+			return false
+		}
+	}
+	return true
+}
+
+func (v *fileValidator) validatePageIndex(i int, column *Column, pageIndex int, page Page, columnIndex ColumnIndex, pageMin, pageMax Value, hasBounds bool) {
+	typ := column.Type()
+
+	if nullCount := columnIndex.NullCount(pageIndex); nullCount != page.NumNulls() && isFlatColumn(column) {
+		v.report(i, column, pageIndex, "column index has %d nulls but the page contains %d nulls", nullCount, page.NumNulls())
+	}
+	// Some writers are known to produce incorrect null page flags, pages are
+	// only verified to be within the bounds of non-null pages of the index.
+	if columnIndex.NullPage(pageIndex) || !hasBounds {
+		return
+	}
+	if minValue := columnIndex.MinValue(pageIndex); typ.Compare(minValue, pageMin) > 0 {
+		v.report(i, column, pageIndex, "column index has a min value greater than the smallest value of the page: %v > %v", minValue, pageMin)
+	}
+	if maxValue := columnIndex.MaxValue(pageIndex); typ.Compare(maxValue, pageMax) < 0 && !isTruncatedMaxValue(maxValue, pageMax) {
+		v.report(i, column, pageIndex, "column index has a max value less than the largest value of the page: %v < %v", maxValue, pageMax)
+	}
+}
+
+func isFlatColumn(column *Column) bool {
+	return column.MaxRepetitionLevel() == 0 && column.MaxDefinitionLevel() <= 1
+}
+
+// isTruncatedMaxValue returns true if maxValue is a prefix of value, which
+// happens when writers truncate large binary values recorded in statistics
+// without incrementing the last byte of the upper bound.
+func isTruncatedMaxValue(maxValue, value Value) bool {
+	switch maxValue.Kind() {
+	case ByteArray, FixedLenByteArray:
+		return bytes.HasPrefix(value.byteArray(), maxValue.byteArray())
+	default:
+		return false
+	}
+}
@@ -0,0 +1,137 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+func TestValidateFile(t *testing.T) {
+	type Row struct {
+		ID   int64   `parquet:"id"`
+		Name *string `parquet:"name,optional"`
+		Tags []int32 `parquet:"tags,list"`
+	}
+
+	name := "Luke"
+	rows := []Row{
+		{ID: 1, Name: &name, Tags: []int32{1, 2}},
+		{ID: 2},
+		{ID: 3, Name: &name, Tags: []int32{3}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(16)); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parquet.ValidateFile(f, parquet.ValidateStatistics); err != nil {
+		t.Fatalf("valid file reported issues: %v", err)
+	}
+
+	tests := []struct {
+		scenario string
+		level    parquet.ValidationLevel
+		rewrite  func(*format.FileMetaData)
+		issue    string
+	}{
+		{
+			scenario: "valid",
+			level:    parquet.ValidateStatistics,
+			rewrite:  func(*format.FileMetaData) {},
+		},
+
+		{
+			scenario: "file row count",
+			level:    parquet.ValidateMetadata,
+			rewrite:  func(m *format.FileMetaData) { m.NumRows = 4 },
+			issue:    "file has 4 rows but its row groups have 3 rows",
+		},
+
+		{
+			scenario: "column chunk value count",
+			level:    parquet.ValidateMetadata,
+			rewrite:  func(m *format.FileMetaData) { m.RowGroups[0].Columns[0].MetaData.NumValues = 2 },
+			issue:    "row group 0: column id: column chunk has 2 values but the row group has 3 rows",
+		},
+
+		{
+			scenario: "min value not detected without statistics",
+			level:    parquet.ValidatePages,
+			rewrite: func(m *format.FileMetaData) {
+				m.RowGroups[0].Columns[0].MetaData.Statistics.MinValue = binary.LittleEndian.AppendUint64(nil, 2)
+			},
+		},
+
+		{
+			scenario: "min value greater than the smallest value",
+			level:    parquet.ValidateStatistics,
+			rewrite: func(m *format.FileMetaData) {
+				m.RowGroups[0].Columns[0].MetaData.Statistics.MinValue = binary.LittleEndian.AppendUint64(nil, 2)
+			},
+			issue: "row group 0: column id: column chunk statistics have a min value greater than the smallest value: 2 > 1",
+		},
+
+		{
+			scenario: "null count",
+			level:    parquet.ValidateStatistics,
+			rewrite:  func(m *format.FileMetaData) { m.RowGroups[0].Columns[1].MetaData.Statistics.NullCount = 0 },
+			issue:    "row group 0: column name: column chunk statistics have 0 nulls but the pages contain 1 nulls",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			metadata := *f.Metadata()
+			metadata.RowGroups = []format.RowGroup{metadata.RowGroups[0]}
+			metadata.RowGroups[0].Columns = append([]format.ColumnChunk{}, metadata.RowGroups[0].Columns...)
+			test.rewrite(&metadata)
+
+			corrupted := rewriteFooter(t, data, &metadata)
+			f, err := parquet.OpenFile(bytes.NewReader(corrupted), int64(len(corrupted)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = parquet.ValidateFile(f, test.level)
+			if test.issue == "" {
+				if err != nil {
+					t.Fatalf("unexpected issues: %v", err)
+				}
+				return
+			}
+
+			var validationError *parquet.ValidationError
+			if !errors.As(err, &validationError) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), test.issue) {
+				t.Errorf("issue not reported:\nwant: %s\ngot:  %v", test.issue, err)
+			}
+		})
+	}
+}
+
+func rewriteFooter(t *testing.T, data []byte, metadata *format.FileMetaData) []byte {
+	t.Helper()
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	footerSize := binary.LittleEndian.Uint32(data[len(data)-8:])
+	rewritten := append([]byte{}, data[:len(data)-8-int(footerSize)]...)
+	rewritten = append(rewritten, footer...)
+	rewritten = binary.LittleEndian.AppendUint32(rewritten, uint32(len(footer)))
+	return append(rewritten, "PAR1"...)
+}
@@ -3,11 +3,15 @@ package parquet
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
+	"math/big"
+	"net/netip"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -280,6 +284,15 @@ func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 			val = t.UnixNano()
 		}
 		return makeValueInt64(val)
+
+	case reflect.TypeOf(time.Duration(0)), reflect.TypeOf(TimeOfDay(0)):
+		if lt != nil && lt.Time != nil {
+			d := time.Duration(v.Int())
+			if lt.Time.Unit.Millis != nil {
+				return makeValueInt32(int32(d.Milliseconds()))
+			}
+			return makeValueInt64(int64(d / timeUnitDuration(lt.Time.Unit)))
+		}
 	}
 
 	switch k {
@@ -307,6 +320,11 @@ func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 		case reflect.TypeOf(deprecated.Int96{}):
 			return makeValueInt96(v.Interface().(deprecated.Int96))
 		}
+		if v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 && v.Len() == 12 {
+			var b [12]byte
+			reflect.Copy(reflect.ValueOf(&b).Elem(), v)
+			return makeValueInt96(makeInt96(b[:]))
+		}
 
 	case Float:
 		switch v.Kind() {
@@ -334,6 +352,11 @@ func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 		switch v.Kind() {
 		case reflect.String: // uuid
 			return makeValueString(k, v.String())
+		case reflect.Struct:
+			if v.Type() == netipAddrType {
+				addr := v.Interface().(netip.Addr).As16()
+				return makeValueBytes(k, addr[:])
+			}
 		case reflect.Array:
 			if v.Type().Elem().Kind() == reflect.Uint8 {
 				return makeValueFixedLenByteArray(v)
@@ -345,9 +368,127 @@ func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 		}
 	}
 
+	if k == ByteArray {
+		switch {
+		case lt != nil && lt.Json != nil:
+			b, err := json.Marshal(v.Interface())
+			if err != nil {
+				panic("cannot marshal go value of type " + v.Type().String() + " to JSON: " + err.Error())
+			}
+			return makeValueBytes(k, b)
+		case lt == nil && v.Type() == netipPrefixType:
+			return makeValueBytes(k, appendNetipPrefix(nil, v.Interface().(netip.Prefix)))
+		case isTextFallbackType(v.Type()):
+			return makeValueBytes(k, marshalText(v))
+		}
+	}
+
 	panic("cannot create parquet value of type " + k.String() + " from go value of type " + v.Type().String())
 }
 
+// writesGoValuesDirectly returns true if the in-memory representation of Go
+// values of type t is the representation of values of the parquet type typ,
+// in which case column buffers can copy the values without converting them.
+func writesGoValuesDirectly(t reflect.Type, typ Type) bool {
+	switch typ.Kind() {
+	case Boolean:
+		return t.Kind() == reflect.Bool
+	case Int32:
+		switch t.Kind() {
+		case reflect.Int32, reflect.Uint32:
+			return true
+		case reflect.Int, reflect.Uint:
+			return t.Size() == 4
+		}
+	case Int64:
+		switch t.Kind() {
+		case reflect.Int64, reflect.Uint64:
+			return true
+		case reflect.Int, reflect.Uint:
+			return t.Size() == 8
+		}
+	case Int96:
+		return t == reflect.TypeOf(deprecated.Int96{}) || (t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8 && t.Len() == 12)
+	case Float:
+		return t.Kind() == reflect.Float32
+	case Double:
+		return t.Kind() == reflect.Float64
+	case ByteArray:
+		return t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+	case FixedLenByteArray:
+		return t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8 && t.Len() == typ.Length()
+	}
+	return false
+}
+
+// canConvertGoValue returns true if Go values of type t can be converted to
+// parquet values of kind k by convertGoValue without losing information.
+// Integers are widened to larger integer types and to the floating point types
+// which represent all of their values exactly, and float32 values are widened
+// to DOUBLE. Conversions which could truncate, round, or reinterpret values are
+// not supported.
+func canConvertGoValue(t reflect.Type, k Kind) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch k {
+		case Int32:
+			return t.Bits() <= 32
+		case Int64, Int96:
+			return true
+		case Float:
+			return t.Bits() <= 16
+		case Double:
+			return t.Bits() <= 32
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch k {
+		case Int32:
+			return t.Bits() < 32
+		case Int64, Int96:
+			return t.Bits() < 64
+		case Float:
+			return t.Bits() <= 16
+		case Double:
+			return t.Bits() <= 32
+		}
+	case reflect.Float32:
+		return k == Double
+	}
+	return false
+}
+
+// conversionTypeOf returns the Go type that values are converted to when they
+// are written to columns of kind k, see convertGoValue.
+func conversionTypeOf(k Kind) reflect.Type {
+	switch k {
+	case Int32:
+		return reflect.TypeOf(int32(0))
+	case Int64:
+		return reflect.TypeOf(int64(0))
+	case Int96:
+		return reflect.TypeOf(deprecated.Int96{})
+	case Float:
+		return reflect.TypeOf(float32(0))
+	case Double:
+		return reflect.TypeOf(float64(0))
+	default:
+		return nil
+	}
+}
+
+// convertGoValue converts src to dst, which must be of the Go type returned by
+// conversionTypeOf for a kind that canConvertGoValue accepts for values of src.
+func convertGoValue(dst, src reflect.Value) {
+	switch {
+	case dst.Kind() != reflect.Array:
+		dst.Set(src.Convert(dst.Type()))
+	case src.CanInt():
+		dst.Set(reflect.ValueOf(deprecated.Int64ToInt96(src.Int())))
+	default:
+		dst.Set(reflect.ValueOf(deprecated.Int64ToInt96(int64(src.Uint()))))
+	}
+}
+
 func makeValueKind(kind Kind) Value {
 	return Value{kind: ^int8(kind)}
 }
@@ -792,6 +933,114 @@ func (v Value) Clone() Value {
 	return v
 }
 
+// GoValue returns the natural Go representation of v, using the logical type
+// of node to interpret the physical value:
+//
+//   - STRING, ENUM and JSON values are returned as string
+//   - UUID values are returned as uuid.UUID
+//   - DECIMAL values are returned as a string in base 10, such as "-12.30"
+//   - DATE and TIMESTAMP values are returned as time.Time, in UTC
+//   - TIME values are returned as the time.Duration elapsed since midnight
+//   - INT(64, false) values are returned as uint64
+//
+// Values without logical types are returned as bool, int64, float64 or []byte
+// depending on their physical type, with INT96 values returned as
+// deprecated.Int96. Byte slices are copied and do not share memory with v.
+//
+// If v is the null value, nil is returned. The node may be nil, in which case
+// only the physical type of v is used.
+func (v Value) GoValue(node Node) any {
+	if v.IsNull() {
+		return nil
+	}
+
+	var lt *format.LogicalType
+	if node != nil {
+		lt = node.Type().LogicalType()
+	}
+
+	switch {
+	case lt == nil:
+	case lt.UTF8 != nil, lt.Enum != nil, lt.Json != nil:
+		return string(v.byteArray())
+	case lt.UUID != nil:
+		if b := v.byteArray(); len(b) == 16 {
+			return uuid.UUID(b)
+		}
+	case lt.Decimal != nil:
+		return formatDecimal(v, int(lt.Decimal.Scale))
+	case lt.Date != nil:
+		return unixEpoch.AddDate(0, 0, int(v.int32()))
+	case lt.Time != nil:
+		if v.Kind() == Int32 {
+			return time.Duration(v.int32()) * timeUnitDuration(lt.Time.Unit)
+		}
+		return time.Duration(v.int64()) * timeUnitDuration(lt.Time.Unit)
+	case lt.Timestamp != nil:
+		return timestamp(v, lt.Timestamp.Unit, time.UTC)
+	case lt.Integer != nil:
+		if !lt.Integer.IsSigned {
+			if v.Kind() == Int32 {
+				return uint64(v.uint32())
+			}
+			return v.uint64()
+		}
+	}
+
+	switch v.Kind() {
+	case Boolean:
+		return v.boolean()
+	case Int32:
+		return int64(v.int32())
+	case Int64:
+		return v.int64()
+	case Int96:
+		return v.Int96()
+	case Float:
+		return float64(v.float())
+	case Double:
+		return v.double()
+	default:
+		return copyBytes(v.byteArray())
+	}
+}
+
+// formatDecimal formats the unscaled value held in v as a decimal number with
+// the given scale. Values of BYTE_ARRAY and FIXED_LEN_BYTE_ARRAY types hold the
+// unscaled value in big-endian two's complement representation.
+func formatDecimal(v Value, scale int) string {
+	unscaled := decimalUnscaledValueOf(v)
+	digits := new(big.Int).Abs(unscaled).String()
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if unscaled.Sign() < 0 {
+		digits = "-" + digits
+	}
+	return digits
+}
+
+// decimalUnscaledValueOf returns the unscaled value of the decimal held in v.
+func decimalUnscaledValueOf(v Value) *big.Int {
+	unscaled := new(big.Int)
+	switch v.Kind() {
+	case Int32:
+		unscaled.SetInt64(int64(v.int32()))
+	case Int64:
+		unscaled.SetInt64(v.int64())
+	default:
+		b := v.byteArray()
+		unscaled.SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+		}
+	}
+	return unscaled
+}
+
 func makeInt96(bits []byte) (i96 deprecated.Int96) {
 	return deprecated.Int96{
 		2: binary.LittleEndian.Uint32(bits[8:12]),
@@ -3,10 +3,12 @@ package parquet_test
 import (
 	"bytes"
 	"math"
+	"reflect"
 	"testing"
 	"time"
 	"unsafe"
 
+	"github.com/google/uuid"
 	"github.com/parquet-go/parquet-go"
 	"github.com/parquet-go/parquet-go/deprecated"
 )
@@ -150,3 +152,65 @@ func TestZeroValue(t *testing.T) {
 		t.Errorf("byte array not zero value: got=%#v", v.ByteArray())
 	}
 }
+
+func TestValueGoValue(t *testing.T) {
+	id := uuid.MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	ts := time.Date(2024, 3, 15, 10, 30, 0, 123456000, time.UTC)
+
+	tests := []struct {
+		scenario string
+		node     parquet.Node
+		value    parquet.Value
+		want     interface{}
+	}{
+		{"null", parquet.String(), parquet.NullValue(), nil},
+		{"boolean", parquet.Leaf(parquet.BooleanType), parquet.ValueOf(true), true},
+		{"int32", parquet.Leaf(parquet.Int32Type), parquet.ValueOf(int32(-1)), int64(-1)},
+		{"int64", parquet.Leaf(parquet.Int64Type), parquet.ValueOf(int64(42)), int64(42)},
+		{"float", parquet.Leaf(parquet.FloatType), parquet.ValueOf(float32(0.5)), float64(0.5)},
+		{"double", parquet.Leaf(parquet.DoubleType), parquet.ValueOf(1.5), 1.5},
+		{"bytes", parquet.Leaf(parquet.ByteArrayType), parquet.ValueOf([]byte("abc")), []byte("abc")},
+		{"no node", nil, parquet.ValueOf("abc"), []byte("abc")},
+		{"string", parquet.String(), parquet.ValueOf("hello"), "hello"},
+		{"enum", parquet.Enum(), parquet.ValueOf("A"), "A"},
+		{"json", parquet.JSON(), parquet.ValueOf(`{"a":1}`), `{"a":1}`},
+		{"uuid", parquet.UUID(), parquet.ValueOf(id), id},
+		{"uint32", parquet.Uint(32), parquet.ValueOf(int32(-1)), uint64(math.MaxUint32)},
+		{"uint64", parquet.Uint(64), parquet.ValueOf(int64(-1)), uint64(math.MaxUint64)},
+		{"int8", parquet.Int(8), parquet.ValueOf(int32(-8)), int64(-8)},
+		{"decimal int32", parquet.Decimal(2, 9, parquet.Int32Type), parquet.ValueOf(int32(-1230)), "-12.30"},
+		{"decimal int64", parquet.Decimal(3, 18, parquet.Int64Type), parquet.ValueOf(int64(5)), "0.005"},
+		{"decimal scale 0", parquet.Decimal(0, 9, parquet.Int32Type), parquet.ValueOf(int32(77)), "77"},
+		{"decimal bytes", parquet.Decimal(2, 20, parquet.FixedLenByteArrayType(9)),
+			parquet.ValueOf([9]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x85}), "-1.23"},
+		{"date", parquet.Date(), parquet.ValueOf(int32(19797)), time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"time millis", parquet.Time(parquet.Millisecond), parquet.ValueOf(int32(1500)), 1500 * time.Millisecond},
+		{"time micros", parquet.Time(parquet.Microsecond), parquet.ValueOf(int64(2)), 2 * time.Microsecond},
+		{"timestamp", parquet.Timestamp(parquet.Microsecond), parquet.ValueOf(ts.UnixMicro()), ts},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			got := test.value.GoValue(test.node)
+			if tm, ok := got.(time.Time); ok {
+				if want, ok := test.want.(time.Time); !ok || !tm.Equal(want) {
+					t.Errorf("wrong value: want=%v got=%v", test.want, got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("wrong value: want=%#v got=%#v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestValueGoValueCopiesBytes(t *testing.T) {
+	b := []byte("abc")
+	v := parquet.ValueOf(b)
+	got := v.GoValue(nil).([]byte)
+	got[0] = 'x'
+	if string(v.ByteArray()) != "abc" {
+		t.Errorf("byte slice returned by GoValue shares memory with the value")
+	}
+}
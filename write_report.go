@@ -0,0 +1,133 @@
+package parquet
+
+import (
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// WriteReport describes the layout of the row groups produced by a writer,
+// allowing applications to audit the effect of the compression, encoding,
+// dictionary, and bloom filter settings without re-opening the files.
+//
+// Reports are obtained by calling the Report method of writers.
+type WriteReport struct {
+	// Number of rows and row groups written.
+	NumRows      int64
+	NumRowGroups int
+	// Reports of each leaf column, in the order of the columns in the schema.
+	Columns []ColumnReport
+}
+
+// ColumnReport describes the layout of a column in the row groups produced by
+// a writer. The sizes and counts are summed over all the row groups.
+type ColumnReport struct {
+	// Path to the column in the schema.
+	Path []string
+	// Compression codec used for the pages of the column.
+	Compression compress.Codec
+	// Encodings of the dictionary and data pages written for the column.
+	Encodings []encoding.Encoding
+	// Number of values and of data pages written.
+	NumValues int64
+	NumPages  int64
+	// Sizes of the column chunks in bytes, including the page headers and
+	// the dictionary pages.
+	CompressedSize   int64
+	UncompressedSize int64
+	// Number of values held in the dictionaries of the column chunks, and
+	// size of the dictionary pages in bytes, including their headers. Both are
+	// zero if the column does not use dictionary encoding.
+	DictionaryValues int64
+	DictionarySize   int64
+	// Number of column chunks where the dictionary exceeded the limit set by
+	// DictionaryMaxBytes, and the following pages were written with the PLAIN
+	// encoding. DictionaryDisabled is true if column chunks were written
+	// without dictionary after the dictionary encoding of the column was
+	// disabled by the DictionaryFallbackLimit option.
+	DictionaryFallbacks int64
+	DictionaryDisabled  bool
+	// Size of the bloom filters written for the column in bytes, including
+	// their headers. The size is zero if the column has no bloom filter.
+	BloomFilterSize int64
+}
+
+// DictionaryFallback describes the fallback of a column chunk from dictionary
+// to PLAIN encoding, reported to the function set by OnDictionaryFallback.
+type DictionaryFallback struct {
+	// Path to the column in the schema.
+	Path []string
+	// Number of values written to the column chunk before the fallback.
+	NumValues int64
+	// Number of values held in the dictionary, and size of its values in
+	// bytes when the fallback occurred.
+	DictionaryValues int64
+	DictionarySize   int64
+	// Disabled is true if the fallback disables the dictionary encoding of
+	// the column in the next row groups, per the DictionaryFallbackLimit
+	// option.
+	Disabled bool
+}
+
+// CompressionRatio returns the ratio of the uncompressed size of the column to
+// its compressed size, or zero if nothing was written to the column.
+func (c *ColumnReport) CompressionRatio() float64 {
+	if c.CompressedSize == 0 {
+		return 0
+	}
+	return float64(c.UncompressedSize) / float64(c.CompressedSize)
+}
+
+func (c *ColumnReport) addColumnChunk(metadata *format.ColumnMetaData) {
+	c.NumValues += metadata.NumValues
+	c.CompressedSize += metadata.TotalCompressedSize
+	c.UncompressedSize += metadata.TotalUncompressedSize
+
+	for _, stats := range metadata.EncodingStats {
+		if stats.PageType != format.DictionaryPage {
+			c.NumPages += int64(stats.Count)
+		}
+		if !c.hasEncoding(stats.Encoding) {
+			c.Encodings = append(c.Encodings, LookupEncoding(stats.Encoding))
+		}
+	}
+}
+
+func (c *ColumnReport) hasEncoding(enc format.Encoding) bool {
+	for _, e := range c.Encodings {
+		if e.Encoding() == enc {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ColumnReport) reset() {
+	*c = ColumnReport{
+		Path:        c.Path,
+		Compression: c.Compression,
+		Encodings:   c.Encodings[:0],
+	}
+}
+
+func (r *WriteReport) clone() WriteReport {
+	columns := make([]ColumnReport, len(r.Columns))
+	for i, c := range r.Columns {
+		c.Path = append([]string(nil), c.Path...)
+		c.Encodings = append([]encoding.Encoding(nil), c.Encodings...)
+		columns[i] = c
+	}
+	return WriteReport{
+		NumRows:      r.NumRows,
+		NumRowGroups: r.NumRowGroups,
+		Columns:      columns,
+	}
+}
+
+func (r *WriteReport) reset() {
+	r.NumRows = 0
+	r.NumRowGroups = 0
+	for i := range r.Columns {
+		r.Columns[i].reset()
+	}
+}
@@ -13,8 +13,12 @@ import (
 	"reflect"
 	"slices"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/parquet-go/parquet-go/bloom"
 	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/encoding"
 	"github.com/parquet-go/parquet-go/encoding/plain"
 	"github.com/parquet-go/parquet-go/format"
@@ -83,7 +87,10 @@ type GenericWriter[T any] struct {
 // similar to using a Writer.
 //
 // If the option list may explicitly declare a schema, it must be compatible
-// with the schema generated from T.
+// with the schema generated from T. Values of T are converted when the types
+// differ but are compatible, for example float32 fields written to DOUBLE
+// columns; if some of the types are incompatible, calls to Write return an
+// error listing the paths of the mismatching columns.
 //
 // Sorting columns may be set on the writer to configure the generated row
 // groups metadata. However, rows are always written in the order they were
@@ -109,6 +116,20 @@ func NewGenericWriter[T any](output io.Writer, options ...WriterOption) *Generic
 		panic("generic writer must be instantiated with schema or concrete type.")
 	}
 
+	write := writeFuncOf[T](t, config.Schema)
+	if writerChecksRows(config) && t != nil && dereference(t).Kind() == reflect.Struct {
+		// When the writer must check or retain the rows, they are
+		// deconstructed so they can be validated or buffered before being
+		// written to the column buffers.
+		write = (*GenericWriter[T]).writeRows
+	}
+
+	if t != nil {
+		if err := checkWriteSchema(dereference(t), schema); err != nil {
+			write = func(*GenericWriter[T], []T) (int, error) { return 0, err }
+		}
+	}
+
 	return &GenericWriter[T]{
 		base: Writer{
 			output: output,
@@ -116,12 +137,109 @@ func NewGenericWriter[T any](output io.Writer, options ...WriterOption) *Generic
 			schema: schema,
 			writer: newWriter(output, config),
 		},
-		write: writeFuncOf[T](t, config.Schema),
+		write: write,
 	}
 }
 
+// writerChecksRows returns true if the configuration requires writers to
+// inspect the values of rows before buffering them.
+func writerChecksRows(config *WriterConfig) bool {
+	return config.StrictWrite || config.UTF8 != UTF8PassThrough || len(config.Enums) > 0 || config.Clustering != nil ||
+		config.ChunkPageSize > 0 || config.ChunkRowGroupSize > 0 || len(config.WriteHooks) > 0
+}
+
 type writeFunc[T any] func(*GenericWriter[T], []T) (int, error)
 
+// checkWriteSchema verifies that values of the Go type t can be written to
+// columns of the given schema, returning an error which lists the paths of all
+// the columns where the types mismatch.
+//
+// The check walks the Go type the same way that writeRowsFuncOf does, so it
+// only reports the types that the column buffers cannot write or convert.
+func checkWriteSchema(t reflect.Type, schema *Schema) error {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var mismatches []string
+	checkWriteType(t, schema, nil, &mismatches)
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return fmt.Errorf("cannot write values of type %s with parquet schema %s: the types of %d column(s) mismatch:\n\t%s",
+		t, schema.Name(), len(mismatches), strings.Join(mismatches, "\n\t"))
+}
+
+func checkWriteType(t reflect.Type, schema *Schema, path columnPath, mismatches *[]string) {
+	leaf, isLeaf := schema.Lookup(path...)
+	if isLeaf {
+		if lt := leaf.Node.Type().LogicalType(); lt != nil && lt.Json != nil {
+			return
+		}
+		if isNetipColumn(t, leaf.Node.Type()) {
+			return
+		}
+	}
+
+	switch t {
+	case reflect.TypeOf(deprecated.Int96{}):
+		checkWriteLeaf(t, schema, path, mismatches)
+		return
+	case reflect.TypeOf(time.Time{}):
+		checkWriteLeaf(reflect.TypeOf(int64(0)), schema, path, mismatches)
+		return
+	case reflect.TypeOf(time.Duration(0)), reflect.TypeOf(TimeOfDay(0)):
+		if isLeaf && leaf.Node.Type().LogicalType() != nil && leaf.Node.Type().LogicalType().Time != nil {
+			return
+		}
+	}
+
+	if isTextFallbackType(t) {
+		checkWriteLeaf(reflect.TypeOf(""), schema, path, mismatches)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			checkWriteLeaf(t, schema, path, mismatches)
+		} else {
+			checkWriteType(t.Elem(), schema, path, mismatches)
+		}
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			checkWriteLeaf(t, schema, path, mismatches)
+		}
+	case reflect.Pointer:
+		checkWriteType(t.Elem(), schema, path, mismatches)
+	case reflect.Struct:
+		for _, f := range structFieldsOf(t) {
+			fieldPath := path.append(f.Name)
+			forEachStructTagOption(f, func(_ reflect.Type, option, _ string) {
+				if option == "list" {
+					fieldPath = fieldPath.append("list", "element")
+				}
+			})
+			checkWriteType(f.Type, schema, fieldPath, mismatches)
+		}
+	case reflect.Map:
+		keyValuePath := mapKeyValuePath(schema, path)
+		checkWriteType(t.Key(), schema, keyValuePath.append("key"), mismatches)
+		checkWriteType(t.Elem(), schema, keyValuePath.append("value"), mismatches)
+	default:
+		checkWriteLeaf(t, schema, path, mismatches)
+	}
+}
+
+func checkWriteLeaf(t reflect.Type, schema *Schema, path columnPath, mismatches *[]string) {
+	leaf, ok := schema.Lookup(path...)
+	switch {
+	case !ok:
+		*mismatches = append(*mismatches, fmt.Sprintf("%s: Go type %s has no matching leaf column in the schema", path, t))
+	case !writesGoValuesDirectly(t, leaf.Node.Type()) && !canConvertGoValue(t, leaf.Node.Type().Kind()):
+		*mismatches = append(*mismatches, fmt.Sprintf("%s: Go type %s cannot be written to column of type %s", path, t, leaf.Node.Type()))
+	}
+}
+
 func writeFuncOf[T any](t reflect.Type, schema *Schema) writeFunc[T] {
 	if t == nil {
 		return (*GenericWriter[T]).writeAny
@@ -146,12 +264,15 @@ func makeWriteFunc[T any](t reflect.Type, schema *Schema) writeFunc[T] {
 	return func(w *GenericWriter[T], rows []T) (n int, err error) {
 		if w.columns == nil {
 			w.columns = make([]ColumnBuffer, len(w.base.writer.columns))
-			for i, c := range w.base.writer.columns {
-				// These fields are usually lazily initialized when writing rows,
-				// we need them to exist now tho.
+		}
+		for i, c := range w.base.writer.columns {
+			// These fields are usually lazily initialized when writing rows,
+			// we need them to exist now tho. The buffers are replaced when
+			// the encoding of columns changes after dictionary fallbacks.
+			if c.columnBuffer == nil {
 				c.columnBuffer = c.newColumnBuffer()
-				w.columns[i] = c.columnBuffer
 			}
+			w.columns[i] = c.columnBuffer
 		}
 		err = writeRows(w.columns, makeArrayOf(rows), columnLevels{})
 		if err == nil {
@@ -177,11 +298,14 @@ func (w *GenericWriter[T]) Write(rows []T) (int, error) {
 	return w.base.writer.writeRows(len(rows), func(i, j int) (int, error) {
 		n, err := w.write(w, rows[i:j:j])
 		if err != nil {
+			if rowErr, ok := err.(*RowError); ok {
+				rowErr.Row += i
+			}
 			return n, err
 		}
 
 		for _, c := range w.base.writer.columns {
-			if c.columnBuffer.Size() >= int64(c.bufferSize) {
+			if c.chunker == nil && c.columnBuffer != nil && c.columnBuffer.Size() >= int64(c.bufferSize) {
 				if err := c.flush(); err != nil {
 					return n, err
 				}
@@ -200,6 +324,19 @@ func (w *GenericWriter[T]) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	return w.base.WriteRowGroup(rowGroup)
 }
 
+// Report returns a report of the layout of the row groups written by w, with
+// the sizes, encodings, dictionaries, and bloom filters of each column.
+//
+// The report covers the row groups flushed so far; after the writer is closed
+// it describes the whole file. The returned value does not share memory with w
+// and remains valid after further writes.
+func (w *Writer) Report() WriteReport {
+	if w.writer == nil {
+		return WriteReport{}
+	}
+	return w.writer.report.clone()
+}
+
 // SetKeyValueMetadata sets a key/value pair in the Parquet file metadata.
 //
 // Keys are assumed to be unique, if the same key is repeated multiple times the
@@ -221,6 +358,38 @@ func (w *GenericWriter[T]) Schema() *Schema {
 	return w.base.Schema()
 }
 
+// FlushIfDue flushes the current row group if rows have been buffered for
+// longer than the interval configured with the FlushInterval option. The method
+// returns true if the row group was flushed.
+func (w *GenericWriter[T]) FlushIfDue() (bool, error) {
+	return w.base.FlushIfDue()
+}
+
+// BufferedRows returns the number of rows written to w since the last row
+// group was flushed.
+func (w *GenericWriter[T]) BufferedRows() int64 {
+	return w.base.BufferedRows()
+}
+
+// BufferedBytes returns an estimate of the memory held by rows written to w
+// since the last row group was flushed.
+func (w *GenericWriter[T]) BufferedBytes() int64 {
+	return w.base.BufferedBytes()
+}
+
+// CommittedBytes returns the number of bytes of the output which hold complete
+// row groups, as of the last time a row group was flushed. See the documentation
+// of Writer.CommittedBytes for details.
+func (w *GenericWriter[T]) CommittedBytes() int64 {
+	return w.base.CommittedBytes()
+}
+
+// Report returns a report of the layout of the row groups written by w. See
+// the documentation of Writer.Report for details.
+func (w *GenericWriter[T]) Report() WriteReport {
+	return w.base.Report()
+}
+
 func (w *GenericWriter[T]) writeRows(rows []T) (int, error) {
 	if cap(w.base.rowbuf) < len(rows) {
 		w.base.rowbuf = make([]Row, len(rows))
@@ -234,7 +403,23 @@ func (w *GenericWriter[T]) writeRows(rows []T) (int, error) {
 		w.base.rowbuf[i] = schema.Deconstruct(w.base.rowbuf[i], &rows[i])
 	}
 
-	return w.base.WriteRows(w.base.rowbuf)
+	rowbuf := w.base.rowbuf
+	if w.base.writer.hooks != nil {
+		hooked, err := w.base.writer.applyHooks(rowbuf)
+		if err != nil {
+			return 0, err
+		}
+		defer clearRows(hooked)
+		rowbuf = hooked
+	}
+
+	// The method is called by Write for each chunk of rows of the current
+	// row group, the rows are buffered without going through WriteRows, which
+	// would count them again.
+	if err := w.base.writer.validateRows(rowbuf); err != nil {
+		return 0, err
+	}
+	return w.base.writer.bufferRows(rowbuf)
 }
 
 func (w *GenericWriter[T]) writeAny(rows []T) (n int, err error) {
@@ -455,6 +640,65 @@ func (w *Writer) ReadRowsFrom(rows RowReader) (written int64, err error) {
 // The returned value will be nil if no schema has yet been configured on w.
 func (w *Writer) Schema() *Schema { return w.schema }
 
+// FlushIfDue flushes the current row group if rows have been buffered for
+// longer than the interval configured with the FlushInterval option. The method
+// returns true if the row group was flushed.
+//
+// The method does nothing if no flush interval was configured.
+func (w *Writer) FlushIfDue() (bool, error) {
+	if w.writer == nil {
+		return false, nil
+	}
+	return w.writer.flushIfDue()
+}
+
+// BufferedRows returns the number of rows written to w since the last row
+// group was flushed.
+//
+// Applications may use this method together with BufferedBytes to implement
+// their own flush policies, for example flushing based on memory pressure
+// rather than on a fixed number of rows.
+func (w *Writer) BufferedRows() int64 {
+	if w.writer == nil {
+		return 0
+	}
+	return w.writer.numRows
+}
+
+// BufferedBytes returns an estimate of the memory held by rows written to w
+// since the last row group was flushed. The estimate accounts for the values
+// held in column buffers, the encoded and compressed pages waiting to be
+// written to the output, and the column dictionaries.
+func (w *Writer) BufferedBytes() int64 {
+	if w.writer == nil {
+		return 0
+	}
+	return w.writer.bufferedBytes()
+}
+
+// CommittedBytes returns the number of bytes of the output which hold complete
+// row groups, as of the last time a row group was flushed.
+//
+// The bytes written to the output before that offset form a valid prefix of a
+// parquet file: appending a footer describing the row groups written so far
+// produces a valid file. The bytes are never modified by later writes, and
+// have all been written to the output when the method returns, even if the
+// writer is configured with a write buffer. This is intended for sinks which
+// implement exactly-once delivery protocols, for example by recording the
+// committed length after each Flush as part of a two-phase commit against an
+// object store, and discarding the bytes written after it when recovering
+// from a failure.
+//
+// After the writer is closed, the method returns the size of the whole file,
+// footer included. The method returns zero if no row groups were written, and
+// after the writer was reset.
+func (w *Writer) CommittedBytes() int64 {
+	if w.writer == nil {
+		return 0
+	}
+	return w.writer.committed
+}
+
 // SetKeyValueMetadata sets a key/value pair in the Parquet file metadata.
 //
 // Keys are assumed to be unique, if the same key is repeated multiple times the
@@ -484,11 +728,53 @@ type writer struct {
 	values  [][]Value
 	numRows int64
 	maxRows int64
+	strict  bool
+	utf8    UTF8Policy
+	enums   bool
+
+	// Hooks applied to the values of columns, indexed by column index, and
+	// buffer of the rows holding the transformed values.
+	hooks    []func(Value) (Value, error)
+	hookRows []Row
+
+	// State used to flush row groups when rows have been buffered for longer
+	// than the configured flush interval.
+	flushInterval time.Duration
+	clock         func() time.Time
+	firstRowTime  time.Time
+
+	// When a clustering expression is configured, rows are buffered by the
+	// clusterer and written to the columns when the row group is flushed.
+	clusterer *rowClusterer
+
+	// Length of the output which holds complete row groups, or the whole
+	// file after it was closed.
+	committed int64
+
+	// Layout of the row groups written so far, see Writer.Report.
+	report WriteReport
+
+	// When content-defined chunking of row groups is enabled, the chunker
+	// finds the cut points of row groups, and cutRowGroup is set when the
+	// last rows written ended at a cut point.
+	chunker     *contentDefinedChunker
+	cutRowGroup bool
+
+	// Alignment of the offsets of row groups and column chunks in the
+	// output, zero when they are not aligned.
+	rowGroupAlignment    int64
+	columnChunkAlignment int64
 
 	createdBy string
 	metadata  []format.KeyValue
 
+	// Encrypts the modules of the file when encryption is configured, nil
+	// otherwise.
+	encryptor *fileEncryptor
+
 	columns     []*writerColumn
+	layout      []int // order in which column chunks are written
+	layoutErr   error // reported when flushing row groups if the layout is invalid
 	columnChunk []format.ColumnChunk
 	columnIndex []format.ColumnIndex
 	offsetIndex []format.OffsetIndex
@@ -510,19 +796,58 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		w.writer.Reset(w.buffer)
 	}
 	w.maxRows = config.MaxRowsPerRowGroup
+	w.strict = config.StrictWrite
+	w.utf8 = config.UTF8
+	w.enums = len(config.Enums) > 0
+	if len(config.WriteHooks) > 0 {
+		w.hooks = columnHooksOf(config.Schema, config.WriteHooks)
+	}
+	w.flushInterval = config.FlushInterval
+	w.clock = config.Clock
+	if w.clock == nil {
+		w.clock = time.Now
+	}
+	if config.Clustering != nil {
+		w.clusterer = newRowClusterer(config.Clustering, config.Schema)
+	}
+	if config.ChunkRowGroupSize > 0 {
+		w.chunker = newContentDefinedChunker(config.ChunkRowGroupSize)
+	}
+	w.rowGroupAlignment = config.RowGroupAlignment
+	w.columnChunkAlignment = config.ColumnChunkAlignment
 	w.createdBy = config.CreatedBy
 	w.metadata = make([]format.KeyValue, 0, len(config.KeyValueMetadata))
 	for k, v := range config.KeyValueMetadata {
 		w.metadata = append(w.metadata, format.KeyValue{Key: k, Value: v})
 	}
+	if _, ok := config.KeyValueMetadata[ColumnDefaultsKey]; !ok && config.Schema.defaults != nil {
+		w.metadata = append(w.metadata, format.KeyValue{Key: ColumnDefaultsKey, Value: config.Schema.defaults.encode()})
+	}
 	sortKeyValueMetadata(w.metadata)
 	w.sortingColumns = make([]format.SortingColumn, len(config.Sorting.SortingColumns))
 
-	config.Schema.forEachNode(func(name string, node Node) {
+	// The schema written to the file may differ from the one used to produce
+	// the columns when writing legacy lists, but the levels of the columns are
+	// the same in both structures, only their paths differ.
+	var fileSchema Node = config.Schema
+	if config.LegacyLists {
+		fileSchema, _ = legacyListsOf(config.Schema)
+	}
+
+	// Nodes are visited in depth-first order, the repeated key/value group of
+	// a map is always the node following the map group.
+	mapKeyValue := false
+
+	forEachNodeOf(config.Schema.Name(), fileSchema, func(name string, node Node) {
 		nodeType := node.Type()
+		convertedType := nodeType.ConvertedType()
+		if mapKeyValue && config.LegacyConvertedTypes {
+			convertedType = &convertedTypes[deprecated.MapKeyValue]
+		}
+		mapKeyValue = !node.Leaf() && isMap(node)
 
 		repetitionType := (*format.FieldRepetitionType)(nil)
-		if node != config.Schema { // the root has no repetition type
+		if node != fileSchema { // the root has no repetition type
 			repetitionType = fieldRepetitionTypePtrOf(node)
 		}
 		// For backward compatibility with older readers, the parquet specification
@@ -546,7 +871,7 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			RepetitionType: repetitionType,
 			Name:           name,
 			NumChildren:    int32(len(node.Fields())),
-			ConvertedType:  nodeType.ConvertedType(),
+			ConvertedType:  convertedType,
 			Scale:          scale,
 			Precision:      precision,
 			FieldID:        int32(node.ID()),
@@ -572,8 +897,13 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 
 	forEachLeafColumnOf(config.Schema, func(leaf leafColumn) {
 		encoding := encodingOf(leaf.node)
+		enumValues := searchEnumColumn(config.Enums, leaf.path)
+		if enumValues != nil {
+			encoding = &RLEDictionary
+		}
 		dictionary := Dictionary(nil)
-		columnType := leaf.node.Type()
+		valueType := leaf.node.Type()
+		columnType := valueType
 		columnIndex := int(leaf.columnIndex)
 		compression := leaf.node.Compression()
 
@@ -590,25 +920,43 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			columnType = dictionary.Type()
 		}
 
+		var chunker *contentDefinedChunker
+		if config.ChunkPageSize > 0 {
+			chunker = newContentDefinedChunker(int64(config.ChunkPageSize))
+		}
+
 		c := &writerColumn{
 			buffers:            buffers,
 			pool:               config.ColumnPageBuffers,
 			columnPath:         leaf.path,
 			columnType:         columnType,
+			valueType:          valueType,
 			columnIndex:        columnType.NewColumnIndexer(config.ColumnIndexSizeLimit),
 			columnFilter:       searchBloomFilterColumn(config.BloomFilters, leaf.path),
+			filterHashBudget:   config.BloomFilterMemoryBudget / 8,
 			compression:        compression,
 			dictionary:         dictionary,
+			columnDictionary:   dictionary,
 			dataPageType:       dataPageType,
 			maxRepetitionLevel: leaf.maxRepetitionLevel,
 			maxDefinitionLevel: leaf.maxDefinitionLevel,
+			stringColumn:       isStringNode(leaf.node),
+			enumValues:         enumValues,
 			bufferIndex:        int32(leaf.columnIndex),
 			bufferSize:         int32(float64(config.PageBufferSize) * 0.98),
 			writePageStats:     config.DataPageStatistics,
 			writePageBounds: !slices.ContainsFunc(config.SkipPageBounds, func(skip []string) bool {
 				return columnPath(skip).equal(leaf.path)
 			}),
-			encodings: make([]format.Encoding, 0, 3),
+			pageStatsSizeLimit:      config.PageStatisticsSizeLimit,
+			pageIndexSizeLimit:      config.ColumnIndexSizeLimit,
+			skipIndexedPageStats:    config.SkipIndexedPageStats,
+			encodings:               make([]format.Encoding, 0, 3),
+			chunker:                 chunker,
+			dictionaryMaxBytes:      config.DictionaryMaxBytes,
+			dictionaryFallbackLimit: config.DictionaryFallbackLimit,
+			dictionaryFallbackFunc:  config.DictionaryFallbackFunc,
+			sortDictionary:          dictionary != nil && slices.Contains(config.SortedDictionaries, leaf.path.String()),
 			// Data pages in version 2 can omit compression when dictionary
 			// encoding is employed; only the dictionary page needs to be
 			// compressed, the data pages are encoded with the hybrid
@@ -625,6 +973,7 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 
 		if isDictionaryEncoding(encoding) {
 			c.encodings = addEncoding(c.encodings, format.Plain)
+			c.dictionaryEncoding = encoding
 		}
 
 		c.encoding = encoding
@@ -657,21 +1006,42 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 	w.offsetIndex = make([]format.OffsetIndex, len(w.columns))
 	w.columnOrders = make([]format.ColumnOrder, len(w.columns))
 
+	filePaths := make([]columnPath, 0, len(w.columns))
+	forEachLeafColumnOf(fileSchema, func(leaf leafColumn) {
+		filePaths = append(filePaths, leaf.path)
+	})
+
 	for i, c := range w.columns {
 		w.columnChunk[i] = format.ColumnChunk{
 			MetaData: format.ColumnMetaData{
 				Type:             format.Type(c.columnType.Kind()),
 				Encoding:         c.encodings,
-				PathInSchema:     c.columnPath,
+				PathInSchema:     filePaths[i],
 				Codec:            c.compression.CompressionCodec(),
 				KeyValueMetadata: nil, // TODO
 			},
 		}
 	}
 
+	if config.Encryption != nil {
+		if err := validateEncryptedColumns(config.Encryption, config.Schema); err != nil {
+			panic(err)
+		}
+		w.encryptor = newFileEncryptor(config.Encryption)
+		for i, c := range w.columns {
+			c.encryptor = w.encryptor.column(c.columnPath, filePaths[i], i)
+		}
+	}
+
+	w.layout, w.layoutErr = columnLayoutOf(config.ColumnLayout, config.Schema)
+	w.report.Columns = make([]ColumnReport, len(w.columns))
 	for i, c := range w.columns {
 		c.columnChunk = &w.columnChunk[i]
 		c.offsetIndex = &w.offsetIndex[i]
+		w.report.Columns[i] = ColumnReport{
+			Path:        filePaths[i],
+			Compression: c.compression,
+		}
 	}
 
 	for i, c := range w.columns {
@@ -690,6 +1060,10 @@ func (w *writer) reset(writer io.Writer) {
 	}
 	for _, c := range w.columns {
 		c.reset()
+		c.resetDictionaryFallbacks()
+	}
+	if w.clusterer != nil {
+		w.clusterer.reset()
 	}
 	for i := range w.rowGroups {
 		w.rowGroups[i] = format.RowGroup{}
@@ -703,6 +1077,32 @@ func (w *writer) reset(writer io.Writer) {
 	w.rowGroups = w.rowGroups[:0]
 	w.columnIndexes = w.columnIndexes[:0]
 	w.offsetIndexes = w.offsetIndexes[:0]
+	w.committed = 0
+	if w.encryptor != nil {
+		w.encryptor.reset()
+	}
+	w.report.reset()
+	w.cutRowGroup = false
+	if w.chunker != nil {
+		w.chunker.reset()
+	}
+}
+
+// discard drops the rows buffered in the current row group. The row groups
+// already written to the output are retained, so the file can still be closed
+// after an error occurred while writing rows.
+func (w *writer) discard() {
+	w.numRows = 0
+	w.cutRowGroup = false
+	if w.chunker != nil {
+		w.chunker.reset()
+	}
+	if w.clusterer != nil {
+		w.clusterer.reset()
+	}
+	for _, c := range w.columns {
+		c.reset()
+	}
 }
 
 func (w *writer) close() error {
@@ -716,8 +1116,11 @@ func (w *writer) close() error {
 		return err
 	}
 	if w.buffer != nil {
-		return w.buffer.Flush()
+		if err := w.buffer.Flush(); err != nil {
+			return err
+		}
 	}
+	w.committed = w.writer.offset
 	return nil
 }
 
@@ -726,17 +1129,85 @@ func (w *writer) flush() error {
 	return err
 }
 
+func (w *writer) bufferedBytes() (size int64) {
+	if w.clusterer != nil {
+		size += w.clusterer.size
+	}
+	for _, c := range w.columns {
+		size += c.columnChunk.MetaData.TotalCompressedSize
+		if c.columnBuffer != nil {
+			size += c.columnBuffer.Size()
+		}
+		if c.dictionary != nil {
+			size += c.dictionary.Page().Size()
+		}
+	}
+	return size
+}
+
 func (w *writer) writeFileHeader() error {
 	if w.writer.writer == nil {
 		return io.ErrClosedPipe
 	}
 	if w.writer.offset == 0 {
-		_, err := w.writer.WriteString("PAR1")
+		_, err := w.writer.WriteString(w.magic())
 		return err
 	}
 	return nil
 }
 
+func (w *writer) magic() string {
+	if w.encryptor != nil {
+		return w.encryptor.magic()
+	}
+	return "PAR1"
+}
+
+// columnLayoutOf returns the indexes of the leaf columns of schema in the order
+// that their column chunks are written, with the columns at the given paths
+// first.
+func columnLayoutOf(paths []string, schema *Schema) ([]int, error) {
+	numColumns := int(numLeafColumnsOf(schema))
+	layout := make([]int, 0, numColumns)
+	placed := make([]bool, numColumns)
+
+	for _, path := range paths {
+		leaf, ok := schema.Lookup(strings.Split(path, ".")...)
+		if !ok || !leaf.Node.Leaf() {
+			return nil, fmt.Errorf("column %q of the column layout does not exist in parquet schema %s", path, schema.Name())
+		}
+		if !placed[leaf.ColumnIndex] {
+			placed[leaf.ColumnIndex] = true
+			layout = append(layout, leaf.ColumnIndex)
+		}
+	}
+
+	for i := 0; i < numColumns; i++ {
+		if !placed[i] {
+			layout = append(layout, i)
+		}
+	}
+	return layout, nil
+}
+
+// writePadding writes zero bytes to the output until its offset is a multiple
+// of the alignment.
+func (w *writer) writePadding(alignment int64) error {
+	if alignment <= 1 {
+		return nil
+	}
+	for n := (alignment - w.writer.offset%alignment) % alignment; n > 0; {
+		b := zeroPadding[:min(n, int64(len(zeroPadding)))]
+		if _, err := w.writer.Write(b); err != nil {
+			return err
+		}
+		n -= int64(len(b))
+	}
+	return nil
+}
+
+var zeroPadding [4096]byte
+
 func (w *writer) configureBloomFilters(columnChunks []ColumnChunk) {
 	for i, c := range w.columns {
 		if c.columnFilter != nil {
@@ -766,7 +1237,7 @@ func (w *writer) writeFileFooter() error {
 		for j := range columnIndexes {
 			column := &rowGroup.Columns[j]
 			column.ColumnIndexOffset = w.writer.offset
-			if err := encoder.Encode(&columnIndexes[j]); err != nil {
+			if err := w.writeIndex(encoder, w.columns[j], columnIndexModule, i, &columnIndexes[j]); err != nil {
 				return err
 			}
 			column.ColumnIndexLength = int32(w.writer.offset - column.ColumnIndexOffset)
@@ -778,7 +1249,7 @@ func (w *writer) writeFileFooter() error {
 		for j := range offsetIndexes {
 			column := &rowGroup.Columns[j]
 			column.OffsetIndexOffset = w.writer.offset
-			if err := encoder.Encode(&offsetIndexes[j]); err != nil {
+			if err := w.writeIndex(encoder, w.columns[j], offsetIndexModule, i, &offsetIndexes[j]); err != nil {
 				return err
 			}
 			column.OffsetIndexLength = int32(w.writer.offset - column.OffsetIndexOffset)
@@ -797,7 +1268,7 @@ func (w *writer) writeFileFooter() error {
 	// https://github.com/apache/arrow/blob/70b9ef5/go/parquet/metadata/file.go#L122-L127
 	const parquetFileFormatVersion = 2
 
-	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &format.FileMetaData{
+	metadata := &format.FileMetaData{
 		Version:          parquetFileFormatVersion,
 		Schema:           w.schemaElements,
 		NumRows:          numRows,
@@ -805,21 +1276,84 @@ func (w *writer) writeFileFooter() error {
 		KeyValueMetadata: w.metadata,
 		CreatedBy:        w.createdBy,
 		ColumnOrders:     w.columnOrders,
-	})
+	}
+	if w.encryptor != nil {
+		if err := w.encryptMetadata(metadata); err != nil {
+			return err
+		}
+	}
+
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), metadata)
 	if err != nil {
 		return err
 	}
+	if w.encryptor != nil {
+		if footer, err = w.encryptor.appendFooter(nil, footer); err != nil {
+			return err
+		}
+	}
 
 	length := len(footer)
 	footer = append(footer, 0, 0, 0, 0)
-	footer = append(footer, "PAR1"...)
+	footer = append(footer, w.magic()...)
 	binary.LittleEndian.PutUint32(footer[length:], uint32(length))
 
 	_, err = w.writer.Write(footer)
 	return err
 }
 
+// writeIndex writes the column or offset index of a column chunk of the row
+// group at the given ordinal, encrypting it when the column is encrypted.
+func (w *writer) writeIndex(encoder *thrift.Encoder, c *writerColumn, module byte, rowGroup int, index any) error {
+	if c.encryptor == nil {
+		return encoder.Encode(index)
+	}
+	b, err := thrift.Marshal(new(thrift.CompactProtocol), index)
+	if err != nil {
+		return err
+	}
+	if b, err = c.encryptor.encryptAt(b[:0:0], module, rowGroup, -1, b); err != nil {
+		return err
+	}
+	_, err = w.writer.Write(b)
+	return err
+}
+
+// encryptMetadata sets the crypto metadata of the column chunks of the file
+// and encrypts the metadata of encrypted columns. The row groups of metadata
+// are replaced by copies so the writer retains the plaintext metadata.
+func (w *writer) encryptMetadata(metadata *format.FileMetaData) error {
+	metadata.RowGroups = make([]format.RowGroup, len(w.rowGroups))
+	for i := range w.rowGroups {
+		rowGroup := w.rowGroups[i]
+		rowGroup.Columns = slices.Clone(rowGroup.Columns)
+		for j, c := range w.columns {
+			if c.encryptor != nil {
+				if err := c.encryptor.encryptColumnChunk(&rowGroup.Columns[j], i); err != nil {
+					return err
+				}
+			}
+		}
+		metadata.RowGroups[i] = rowGroup
+	}
+	if w.encryptor.config.PlaintextFooter {
+		metadata.EncryptionAlgorithm = w.encryptor.algorithm()
+		metadata.FooterSigningKeyMetadata = w.encryptor.config.FooterKeyMetadata
+	}
+	return nil
+}
+
 func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []SortingColumn) (int64, error) {
+	if w.layoutErr != nil {
+		return 0, w.layoutErr
+	}
+	if w.clusterer != nil {
+		defer w.clusterer.reset()
+		if err := w.writeClusteredRows(); err != nil {
+			return 0, err
+		}
+	}
+
 	numRows := w.columns[0].totalRowCount()
 	if numRows == 0 {
 		return 0, nil
@@ -831,6 +1365,10 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 
 	defer func() {
 		w.numRows = 0
+		w.cutRowGroup = false
+		if w.chunker != nil {
+			w.chunker.reset()
+		}
 		for _, c := range w.columns {
 			c.reset()
 		}
@@ -843,6 +1381,9 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 		if err := c.flush(); err != nil {
 			return 0, err
 		}
+		if err := c.flushIndexPages(); err != nil {
+			return 0, err
+		}
 		if err := c.flushFilterPages(); err != nil {
 			return 0, err
 		}
@@ -851,25 +1392,48 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	if err := w.writeFileHeader(); err != nil {
 		return 0, err
 	}
+	if err := w.writePadding(w.rowGroupAlignment); err != nil {
+		return 0, err
+	}
 	fileOffset := w.writer.offset
 
-	for _, c := range w.columns {
+	for _, i := range w.layout {
+		c := w.columns[i]
 		if len(c.filter) > 0 {
-			c.columnChunk.MetaData.BloomFilterOffset = w.writer.offset
+			offset := w.writer.offset
+			c.columnChunk.MetaData.BloomFilterOffset = offset
 			if err := c.writeBloomFilter(&w.writer); err != nil {
 				return 0, err
 			}
+			w.report.Columns[i].BloomFilterSize += w.writer.offset - offset
 		}
 	}
 
-	for i, c := range w.columns {
+	for _, i := range w.layout {
+		c := w.columns[i]
 		w.columnIndex[i] = format.ColumnIndex(c.columnIndex.ColumnIndex())
 
+		if err := w.writePadding(w.columnChunkAlignment); err != nil {
+			return 0, err
+		}
+
 		if c.dictionary != nil {
-			c.columnChunk.MetaData.DictionaryPageOffset = w.writer.offset
-			if err := c.writeDictionaryPage(&w.writer, c.dictionary); err != nil {
+			offset := w.writer.offset
+			c.columnChunk.MetaData.DictionaryPageOffset = offset
+			dict, sorted := c.dictionary, c.sortedDictionary != nil
+			if sorted {
+				dict = c.sortedDictionary
+			}
+			if err := c.writeDictionaryPage(&w.writer, dict, sorted); err != nil {
 				return 0, fmt.Errorf("writing dictionary page of row group colum %d: %w", i, err)
 			}
+			w.report.Columns[i].DictionaryValues += int64(c.dictionary.Len())
+			w.report.Columns[i].DictionarySize += w.writer.offset - offset
+			if c.dictionaryFallback {
+				w.report.Columns[i].DictionaryFallbacks++
+			}
+		} else if c.columnDictionary != nil {
+			w.report.Columns[i].DictionaryDisabled = true
 		}
 
 		dataPageOffset := w.writer.offset
@@ -896,10 +1460,15 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 		sortPageEncodingStats(c.EncodingStats)
 		totalByteSize += int64(c.TotalUncompressedSize)
 		totalCompressedSize += int64(c.TotalCompressedSize)
+		w.report.Columns[i].addColumnChunk(c)
 	}
+	w.report.NumRows += numRows
+	w.report.NumRowGroups++
 
 	sortingColumns := w.sortingColumns
-	if len(sortingColumns) == 0 && len(rowGroupSortingColumns) > 0 {
+	if w.clusterer != nil {
+		sortingColumns = nil
+	} else if len(sortingColumns) == 0 && len(rowGroupSortingColumns) > 0 {
 		sortingColumns = make([]format.SortingColumn, 0, len(rowGroupSortingColumns))
 		forEachLeafColumnOf(rowGroupSchema, func(leaf leafColumn) {
 			if sortingIndex := searchSortingColumn(rowGroupSortingColumns, leaf.path); sortingIndex < len(sortingColumns) {
@@ -945,44 +1514,238 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 
 	w.columnIndexes = append(w.columnIndexes, columnIndex)
 	w.offsetIndexes = append(w.offsetIndexes, offsetIndex)
+	if w.encryptor != nil {
+		w.encryptor.rowGroup = len(w.rowGroups)
+	}
+
+	// The row group is pushed out of the write buffer so all committed bytes
+	// have been written to the output when the method returns.
+	if w.buffer != nil {
+		if err := w.buffer.Flush(); err != nil {
+			return numRows, err
+		}
+	}
+	w.committed = w.writer.offset
 	return numRows, nil
 }
 
 func (w *writer) WriteRows(rows []Row) (int, error) {
+	if w.hooks != nil {
+		hooked, err := w.applyHooks(rows)
+		if err != nil {
+			return 0, err
+		}
+		defer clearRows(hooked)
+		rows = hooked
+	}
+	if err := w.validateRows(rows); err != nil {
+		return 0, err
+	}
 	return w.writeRows(len(rows), func(start, end int) (int, error) {
-		defer func() {
-			for i, values := range w.values {
-				clearValues(values)
-				w.values[i] = values[:0]
+		return w.bufferRows(rows[start:end])
+	})
+}
+
+// applyHooks returns copies of rows where the write hooks were applied to the
+// values of their columns, the values owned by the application are not
+// modified. The copies are held in a buffer of the writer which is reused by
+// the next call.
+func (w *writer) applyHooks(rows []Row) ([]Row, error) {
+	if cap(w.hookRows) < len(rows) {
+		w.hookRows = make([]Row, len(rows))
+	}
+	hooked := w.hookRows[:len(rows)]
+	for i, row := range rows {
+		hooked[i] = append(hooked[i][:0], row...)
+		for j, v := range hooked[i] {
+			c := v.Column()
+			if c >= len(w.hooks) || w.hooks[c] == nil || v.IsNull() {
+				continue
 			}
-		}()
+			h, err := w.hooks[c](v)
+			if err != nil {
+				return nil, &RowError{Row: i, Path: w.columns[c].columnPath, Err: err}
+			}
+			hooked[i][j] = h.Level(v.RepetitionLevel(), v.DefinitionLevel(), c)
+		}
+	}
+	return hooked, nil
+}
 
-		// TODO: if an error occurs in this method the writer may be left in an
-		// partially functional state. Applications are not expected to continue
-		// using the writer after getting an error, but maybe we could ensure that
-		// we are preventing further use as well?
-		for _, row := range rows[start:end] {
-			row.Range(func(columnIndex int, columnValues []Value) bool {
-				w.values[columnIndex] = append(w.values[columnIndex], columnValues...)
-				return true
-			})
+func (w *writer) validateRows(rows []Row) error {
+	if w.strict || w.utf8 == UTF8Validate || w.enums {
+		for i, row := range rows {
+			if err := w.validateRow(row); err != nil {
+				err.Row = i
+				return err
+			}
 		}
+	}
+	return nil
+}
+
+// copiesValues returns true if the values of rows can be written directly to
+// the columns of the writer, which is not possible when rows must go through
+// write hooks, validation, the clusterer or the row group chunker.
+func (w *writer) copiesValues() bool {
+	return w.hooks == nil && !w.strict && !w.enums && w.utf8 == UTF8PassThrough &&
+		w.clusterer == nil && w.chunker == nil
+}
+
+// bufferRows writes rows to the column buffers, or to the clusterer when a
+// clustering expression is configured. Unlike WriteRows, the method does not
+// track row group boundaries, it is intended to be called from the callbacks
+// of writeRows.
+func (w *writer) bufferRows(rows []Row) (int, error) {
+	if w.chunker != nil {
+		for i, row := range rows {
+			for _, v := range row {
+				w.chunker.writeValue(v)
+			}
+			if w.chunker.cut() {
+				rows = rows[:i+1]
+				w.cutRowGroup = true
+				break
+			}
+		}
+	}
+	if w.clusterer != nil {
+		w.clusterer.writeRows(rows)
+		return len(rows), nil
+	}
+	if err := w.writeRowValues(rows); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
 
+// writeRowValues writes the values of rows to the column buffers.
+func (w *writer) writeRowValues(rows []Row) error {
+	defer func() {
 		for i, values := range w.values {
-			if len(values) > 0 {
-				if err := w.columns[i].writeRows(values); err != nil {
-					return 0, err
-				}
+			clearValues(values)
+			w.values[i] = values[:0]
+		}
+	}()
+
+	// TODO: if an error occurs in this method the writer may be left in an
+	// partially functional state. Applications are not expected to continue
+	// using the writer after getting an error, but maybe we could ensure that
+	// we are preventing further use as well?
+	for _, row := range rows {
+		row.Range(func(columnIndex int, columnValues []Value) bool {
+			w.values[columnIndex] = append(w.values[columnIndex], columnValues...)
+			return true
+		})
+	}
+
+	if w.utf8 == UTF8Replace {
+		for i, c := range w.columns {
+			if c.stringColumn {
+				replaceInvalidUTF8(w.values[i])
 			}
 		}
+	}
 
-		return end - start, nil
-	})
+	for i, values := range w.values {
+		if len(values) > 0 {
+			if err := w.columns[i].writeRows(values); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeClusteredRows writes the rows buffered by the clusterer to the column
+// buffers, in the order of their clustering keys.
+func (w *writer) writeClusteredRows() error {
+	rows := w.clusterer.sortedRows()
+	// Rows are written in small batches for the same reason that writeRows
+	// chunks its input, see the comment there.
+	const maxRowsPerWrite = 64
+	for i := 0; i < len(rows); i += maxRowsPerWrite {
+		if err := w.writeRowValues(rows[i:min(i+maxRowsPerWrite, len(rows))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRow checks that the values of row are consistent with the columns of
+// the writer. The method is used when strict mode, UTF-8 validation, or enum
+// values are enabled, the returned error has its Row field left to zero for the
+// caller to set.
+func (w *writer) validateRow(row Row) *RowError {
+	if !w.strict {
+		for _, v := range row {
+			c := v.Column()
+			if c >= len(w.columns) {
+				continue
+			}
+			col := w.columns[c]
+			if w.utf8 == UTF8Validate && col.stringColumn && !isValidUTF8(v) {
+				return &RowError{Path: col.columnPath, Err: ErrInvalidUTF8}
+			}
+			if err := col.validateEnumValue(v); err != nil {
+				return &RowError{Path: col.columnPath, Err: err}
+			}
+		}
+		return nil
+	}
+
+	columnIndex := 0
+
+	for i := 0; i < len(row); {
+		if columnIndex == len(w.columns) {
+			return &RowError{Err: fmt.Errorf("too many columns: the schema has %d columns", len(w.columns))}
+		}
+
+		j := i
+		for j < len(row) && row[j].Column() == columnIndex {
+			j++
+		}
+		c := w.columns[columnIndex]
+		if i == j {
+			return &RowError{Path: c.columnPath, Err: fmt.Errorf("missing values (found value of column %d)", row[i].Column())}
+		}
+		if err := c.validateValues(row[i:j], w.utf8 != UTF8Replace); err != nil {
+			return &RowError{Path: c.columnPath, Err: err}
+		}
+
+		columnIndex++
+		i = j
+	}
+
+	if columnIndex < len(w.columns) {
+		return &RowError{Path: w.columns[columnIndex].columnPath, Err: fmt.Errorf("missing values")}
+	}
+	return nil
+}
+
+// flushIfDue flushes the current row group if its first row was buffered more
+// than the flush interval ago.
+func (w *writer) flushIfDue() (bool, error) {
+	if w.flushInterval <= 0 || w.numRows == 0 {
+		return false, nil
+	}
+	if w.clock().Sub(w.firstRowTime) < w.flushInterval {
+		return false, nil
+	}
+	return true, w.flush()
 }
 
 func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int, error) {
 	written := 0
 
+	if _, err := w.flushIfDue(); err != nil {
+		return 0, err
+	}
+	if w.flushInterval > 0 && w.numRows == 0 && numRows > 0 {
+		w.firstRowTime = w.clock()
+	}
+
 	for written < numRows {
 		remain := w.maxRows - w.numRows
 		length := numRows - written
@@ -993,6 +1756,9 @@ func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int,
 			if err := w.flush(); err != nil {
 				return written, err
 			}
+			if w.flushInterval > 0 {
+				w.firstRowTime = w.clock()
+			}
 		}
 
 		if remain < int64(length) {
@@ -1018,6 +1784,15 @@ func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int,
 		if err != nil {
 			return written, err
 		}
+
+		if w.cutRowGroup {
+			if err := w.flush(); err != nil {
+				return written, err
+			}
+			if w.flushInterval > 0 {
+				w.firstRowTime = w.clock()
+			}
+		}
 	}
 
 	return written, nil
@@ -1044,6 +1819,7 @@ type writerBuffers struct {
 	definitions []byte       // buffer used to encode definition levels
 	page        []byte       // page buffer holding the page data
 	scratch     []byte       // scratch space used for compression
+	encrypted   []byte       // encrypted modules of the page header and data
 }
 
 func (wb *writerBuffers) crc32() (checksum uint32) {
@@ -1057,6 +1833,20 @@ func (wb *writerBuffers) size() int {
 	return len(wb.repetitions) + len(wb.definitions) + len(wb.page)
 }
 
+// encrypt encrypts the page header and data to the encrypted buffer, returning
+// the size of the encrypted page header.
+func (wb *writerBuffers) encrypt(e *columnEncryptor, headerModule, pageModule byte, page int) (headerSize int, err error) {
+	if wb.encrypted, err = e.encrypt(wb.encrypted[:0], headerModule, page, wb.header.Bytes()); err != nil {
+		return 0, err
+	}
+	headerSize = len(wb.encrypted)
+	wb.scratch = append(wb.scratch[:0], wb.repetitions...)
+	wb.scratch = append(wb.scratch, wb.definitions...)
+	wb.scratch = append(wb.scratch, wb.page...)
+	wb.encrypted, err = e.encrypt(wb.encrypted, pageModule, page, wb.scratch)
+	return headerSize, err
+}
+
 func (wb *writerBuffers) reset() {
 	wb.repetitions = wb.repetitions[:0]
 	wb.definitions = wb.definitions[:0]
@@ -1141,6 +1931,8 @@ type writerColumn struct {
 	dataPageType       format.PageType
 	maxRepetitionLevel byte
 	maxDefinitionLevel byte
+	stringColumn       bool
+	enumValues         map[string]struct{}
 
 	buffers *writerBuffers
 
@@ -1149,17 +1941,66 @@ type writerColumn struct {
 		encoder  thrift.Encoder
 	}
 
-	filter          []byte
-	numRows         int64
-	bufferIndex     int32
-	bufferSize      int32
-	writePageStats  bool
-	writePageBounds bool
-	isCompressed    bool
-	encodings       []format.Encoding
+	filter  []byte
+	numRows int64
+	// Hashes of the values of data pages written before the size of the bloom
+	// filter was known, inserted in bulk in the filter when it is flushed.
+	// Hashes are discarded when they exceed the budget, in which case the
+	// pages are read back to build the filter.
+	filterHashes           []uint64
+	filterHashBudget       int64
+	filterHashesOverBudget bool
+	bufferIndex            int32
+	bufferSize             int32
+	writePageStats         bool
+	writePageBounds        bool
+	skipIndexedPageStats   bool
+	pageStatsSizeLimit     int
+	pageIndexSizeLimit     int
+	isCompressed           bool
+	encodings              []format.Encoding
 
 	columnChunk *format.ColumnChunk
 	offsetIndex *format.OffsetIndex
+
+	// Encrypts the modules of the column, nil if the column is written in
+	// plaintext.
+	encryptor *columnEncryptor
+
+	// Finds the content-defined cut points of pages when content-defined
+	// chunking of pages is enabled, nil otherwise.
+	chunker *contentDefinedChunker
+
+	// When the dictionary of a column chunk grows beyond dictionaryMaxBytes,
+	// the rest of the column chunk is written with the PLAIN encoding of the
+	// values of type valueType. The dictionary field is set to nil when the
+	// dictionary encoding is disabled after dictionaryFallbackLimit consecutive
+	// column chunks fell back, columnDictionary retains it to be restored when
+	// the writer is reset.
+	valueType               Type
+	columnDictionary        Dictionary
+	dictionaryEncoding      encoding.Encoding
+	dictionaryMaxBytes      int64
+	dictionaryFallbackLimit int
+	dictionaryFallbacks     int
+	dictionaryFallback      bool
+	dictionaryFallbackFunc  func(DictionaryFallback)
+
+	// When the dictionary of the column is sorted, the dictionary encoded
+	// pages are retained in indexPages until the dictionary is complete, then
+	// written with the indexes of sortedDictionary.
+	sortDictionary   bool
+	sortedDictionary Dictionary
+	indexPages       []writerIndexPage
+}
+
+// writerIndexPage holds the dictionary indexes and levels of a data page of a
+// column with a sorted dictionary.
+type writerIndexPage struct {
+	indexes          []int32
+	repetitionLevels []byte
+	definitionLevels []byte
+	numRows          int64
 }
 
 func (c *writerColumn) reset() {
@@ -1172,14 +2013,32 @@ func (c *writerColumn) reset() {
 	if c.dictionary != nil {
 		c.dictionary.Reset()
 	}
+	if c.dictionaryFallback {
+		c.dictionaryFallback = false
+		c.dictionaryFallbacks++
+		if c.dictionaryFallbackLimit > 0 && c.dictionaryFallbacks >= c.dictionaryFallbackLimit {
+			c.disableDictionary()
+		} else {
+			c.setEncoding(c.dictionary.Type(), c.dictionaryEncoding)
+		}
+	} else if c.dictionary != nil {
+		c.dictionaryFallbacks = 0
+	}
+	c.sortedDictionary = nil
+	c.indexPages = c.indexPages[:0]
 	if c.pageBuffer != nil {
 		c.pool.PutBuffer(c.pageBuffer)
 		c.pageBuffer = nil
 	}
 	c.numPages = 0
+	if c.chunker != nil {
+		c.chunker.reset()
+	}
 	// Bloom filters may change in size between row groups, but we retain the
 	// buffer to avoid reallocating large memory blocks.
 	c.filter = c.filter[:0]
+	c.filterHashes = c.filterHashes[:0]
+	c.filterHashesOverBudget = false
 	c.numRows = 0
 	// Reset the fields of column chunks that change between row groups,
 	// but keep the ones that remain unchanged.
@@ -1196,6 +2055,9 @@ func (c *writerColumn) reset() {
 
 func (c *writerColumn) totalRowCount() int64 {
 	n := c.numRows
+	for i := range c.indexPages {
+		n += c.indexPages[i].numRows
+	}
 	if c.columnBuffer != nil {
 		n += int64(c.columnBuffer.Len())
 	}
@@ -1205,11 +2067,159 @@ func (c *writerColumn) totalRowCount() int64 {
 func (c *writerColumn) flush() (err error) {
 	if c.columnBuffer.Len() > 0 {
 		defer c.columnBuffer.Reset()
-		_, err = c.writeDataPage(c.columnBuffer.Page())
+		if page := c.columnBuffer.Page(); c.sortDictionary && page.Dictionary() != nil {
+			c.bufferIndexPage(page)
+		} else {
+			_, err = c.writeDataPage(page)
+		}
+		if err == nil && c.exceedsDictionaryMaxBytes() {
+			// The pages already written reference the dictionary, it is still
+			// written with the column chunk but does not grow anymore.
+			if err = c.flushIndexPages(); err != nil {
+				return err
+			}
+			c.dictionaryFallback = true
+			c.notifyDictionaryFallback()
+			c.setEncoding(c.valueType, &Plain)
+		}
 	}
 	return err
 }
 
+// bufferIndexPage retains a copy of the indexes and levels of a dictionary
+// encoded page, to be written when the dictionary is complete.
+func (c *writerColumn) bufferIndexPage(page Page) {
+	data := page.Data()
+	c.indexPages = append(c.indexPages, writerIndexPage{
+		indexes:          slices.Clone(data.Int32()),
+		repetitionLevels: slices.Clone(page.RepetitionLevels()),
+		definitionLevels: slices.Clone(page.DefinitionLevels()),
+		numRows:          page.NumRows(),
+	})
+}
+
+// flushIndexPages sorts the dictionary of the column and writes the pages that
+// were retained by bufferIndexPage. The dictionary must not grow after the
+// method was called, it is written with the column chunk as sortedDictionary.
+func (c *writerColumn) flushIndexPages() error {
+	if !c.sortDictionary || c.dictionary == nil || c.sortedDictionary != nil {
+		return nil
+	}
+
+	dict, order, err := sortDictionary(c.valueType, c.dictionary, int(c.bufferIndex))
+	if err != nil {
+		return fmt.Errorf("sorting dictionary of column %q: %w", c.columnPath, err)
+	}
+	typ := dict.Type()
+	c.sortedDictionary = dict
+
+	for i := range c.indexPages {
+		p := &c.indexPages[i]
+		for j, index := range p.indexes {
+			p.indexes[j] = order[index]
+		}
+
+		page := typ.NewPage(int(c.bufferIndex), len(p.indexes), encoding.Int32Values(p.indexes))
+		switch {
+		case c.maxRepetitionLevel > 0:
+			page = newRepeatedPage(page, c.maxRepetitionLevel, c.maxDefinitionLevel, p.repetitionLevels, p.definitionLevels)
+		case c.maxDefinitionLevel > 0:
+			page = newOptionalPage(page, c.maxDefinitionLevel, p.definitionLevels)
+		}
+		if _, err := c.writeDataPage(page); err != nil {
+			return err
+		}
+	}
+
+	clear(c.indexPages)
+	c.indexPages = c.indexPages[:0]
+	return nil
+}
+
+// sortDictionary returns a copy of dict with its values sorted in the order of
+// typ, and the mapping from the indexes of dict to those of the sorted copy.
+func sortDictionary(typ Type, dict Dictionary, columnIndex int) (Dictionary, []int32, error) {
+	numValues := dict.Len()
+	indexes := make([]int32, numValues)
+	for i := range indexes {
+		indexes[i] = int32(i)
+	}
+	values := make([]Value, numValues)
+	dict.Lookup(indexes, values)
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return typ.Compare(values[indexes[i]], values[indexes[j]]) < 0
+	})
+
+	order := make([]int32, numValues)
+	sorted := make([]Value, numValues)
+	for i, index := range indexes {
+		order[index] = int32(i)
+		sorted[i] = values[index]
+	}
+
+	buffer := typ.NewColumnBuffer(columnIndex, numValues)
+	if _, err := buffer.WriteValues(sorted); err != nil {
+		return nil, nil, err
+	}
+	return typ.NewDictionary(columnIndex, numValues, buffer.Page().Data()), order, nil
+}
+
+func (c *writerColumn) exceedsDictionaryMaxBytes() bool {
+	return c.dictionaryMaxBytes > 0 && c.dictionary != nil && !c.dictionaryFallback &&
+		c.dictionary.Page().Size() > c.dictionaryMaxBytes
+}
+
+func (c *writerColumn) notifyDictionaryFallback() {
+	if c.dictionaryFallbackFunc == nil {
+		return
+	}
+	c.dictionaryFallbackFunc(DictionaryFallback{
+		Path:             c.columnPath,
+		NumValues:        c.columnChunk.MetaData.NumValues,
+		DictionaryValues: int64(c.dictionary.Len()),
+		DictionarySize:   c.dictionary.Page().Size(),
+		Disabled:         c.dictionaryFallbackLimit > 0 && c.dictionaryFallbacks+1 >= c.dictionaryFallbackLimit,
+	})
+}
+
+// setEncoding changes the type and encoding of the values of the next pages
+// written to the column.
+func (c *writerColumn) setEncoding(typ Type, enc encoding.Encoding) {
+	c.columnType = typ
+	c.encoding = enc
+	c.isCompressed = isCompressed(c.compression) && (c.dataPageType != format.DataPageV2 || !isDictionaryEncoding(enc))
+	if c.columnBuffer != nil {
+		c.columnBuffer = c.newColumnBuffer()
+	}
+}
+
+// disableDictionary disables the dictionary encoding of the column for the
+// next column chunks, no dictionary pages are written and the dictionary
+// encodings are removed from the metadata of the column chunks.
+func (c *writerColumn) disableDictionary() {
+	c.dictionary = nil
+	c.setEncoding(c.valueType, &Plain)
+	encodings := make([]format.Encoding, 0, len(c.encodings))
+	for _, enc := range c.encodings {
+		if !isDictionaryFormat(enc) {
+			encodings = append(encodings, enc)
+		}
+	}
+	c.columnChunk.MetaData.Encoding = encodings
+}
+
+// resetDictionaryFallbacks restores the dictionary encoding of the column when
+// it was disabled after repeated fallbacks.
+func (c *writerColumn) resetDictionaryFallbacks() {
+	c.dictionaryFallbacks = 0
+	if c.columnDictionary != nil && c.dictionary == nil {
+		c.dictionary = c.columnDictionary
+		c.setEncoding(c.dictionary.Type(), c.dictionaryEncoding)
+		c.columnChunk.MetaData.Encoding = c.encodings
+	}
+}
+
 func (c *writerColumn) flushFilterPages() (err error) {
 	if c.columnFilter == nil {
 		return nil
@@ -1217,7 +2227,7 @@ func (c *writerColumn) flushFilterPages() (err error) {
 
 	// If there is a dictionary, it contains all the values that we need to
 	// write to the filter.
-	if dict := c.dictionary; dict != nil {
+	if dict := c.dictionary; dict != nil && !c.dictionaryFallback {
 		// Need to always attempt to resize the filter, as the writer might
 		// be reused after resetting which would have reset the length of
 		// the filter to 0.
@@ -1225,16 +2235,43 @@ func (c *writerColumn) flushFilterPages() (err error) {
 		return c.writePageToFilter(dict.Page())
 	}
 
+	// When the dictionary fell back to the PLAIN encoding, the dictionary holds
+	// the values of the pages written before the fallback, and the values of
+	// the other pages are collected like those of columns without dictionary.
+	var dictPage Page
+	if c.dictionaryFallback {
+		dictPage = c.dictionary.Page()
+	}
+
 	// When the filter was already allocated, pages have been written to it as
 	// they were seen by the column writer.
 	if len(c.filter) > 0 {
+		if dictPage != nil {
+			return c.writePageToFilter(dictPage)
+		}
 		return nil
 	}
 
 	// When the filter was not allocated, the writer did not know how many
 	// values were going to be seen and therefore could not properly size the
-	// filter ahead of time. In this case, we read back all the pages that we
-	// have encoded and copy their values back to the filter.
+	// filter ahead of time. Split block filters are built from the hashes of
+	// the values of pages, which were computed in bulk when the pages were
+	// written, and can now be inserted in the filter.
+	if c.hashesFilterValues() && !c.filterHashesOverBudget {
+		if dictPage != nil {
+			c.filterHashes = appendSplitBlockHashes(c.filterHashes, dictPage.Data())
+		}
+		c.resizeBloomFilter(c.columnChunk.MetaData.NumValues)
+		if len(c.filter) > 0 {
+			bloom.MakeSplitBlockFilter(c.filter).InsertBulk(c.filterHashes)
+		}
+		c.filterHashes = c.filterHashes[:0]
+		return nil
+	}
+
+	// Other filters, or filters of column chunks which had too many values to
+	// retain their hashes in memory, are built by reading back all the pages
+	// that we have encoded and copying their values back to the filter.
 	//
 	// A prior implementation of the column writer used to create in-memory
 	// copies of the pages to avoid this decoding step; however, this unbounded
@@ -1243,6 +2280,11 @@ func (c *writerColumn) flushFilterPages() (err error) {
 	// decoding step than having to trigger incident response when production
 	// systems are getting OOM-Killed.
 	c.resizeBloomFilter(c.columnChunk.MetaData.NumValues)
+	if dictPage != nil {
+		if err := c.writePageToFilter(dictPage); err != nil {
+			return err
+		}
+	}
 
 	column := &Column{
 		// Set all the fields required by the decodeDataPage* methods.
@@ -1280,7 +2322,12 @@ func (c *writerColumn) flushFilterPages() (err error) {
 
 	for i := 0; i < c.numPages; i++ {
 		header := new(format.PageHeader)
-		if err := decoder.Decode(header); err != nil {
+		if c.encryptor != nil {
+			err = c.readEncryptedPageHeader(pageReader, i, header)
+		} else {
+			err = decoder.Decode(header)
+		}
+		if err != nil {
 			return err
 		}
 
@@ -1291,14 +2338,27 @@ func (c *writerColumn) flushFilterPages() (err error) {
 		if _, err := io.ReadFull(pageReader, pbuf.data); err != nil {
 			return err
 		}
+		if c.encryptor != nil {
+			data, err := c.encryptor.decrypt(dataPageModule, i, pbuf.data)
+			if err != nil {
+				return err
+			}
+			pbuf.data = pbuf.data[:copy(pbuf.data, data)]
+		}
 
 		var page Page
 
 		switch header.Type {
 		case format.DataPage:
-			page, err = column.decodeDataPageV1(DataPageHeaderV1{header.DataPageHeader}, pbuf, nil, header.UncompressedPageSize)
+			if isDictionaryFormat(header.DataPageHeader.Encoding) {
+				continue // values already written from the dictionary
+			}
+			page, _, err = column.decodeDataPageV1(DataPageHeaderV1{header.DataPageHeader}, pbuf, nil, header.UncompressedPageSize, 0)
 		case format.DataPageV2:
-			page, err = column.decodeDataPageV2(DataPageHeaderV2{header.DataPageHeaderV2}, pbuf, nil, header.UncompressedPageSize)
+			if isDictionaryFormat(header.DataPageHeaderV2.Encoding) {
+				continue
+			}
+			page, _, err = column.decodeDataPageV2(DataPageHeaderV2{header.DataPageHeaderV2}, pbuf, nil, header.UncompressedPageSize, 0)
 		}
 		if page != nil {
 			err = c.writePageToFilter(page)
@@ -1312,6 +2372,35 @@ func (c *writerColumn) flushFilterPages() (err error) {
 	return nil
 }
 
+// readEncryptedPageHeader reads and decrypts the header of the data page at the
+// given ordinal in the page buffer.
+func (c *writerColumn) readEncryptedPageHeader(r io.Reader, page int, header *format.PageHeader) error {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return err
+	}
+	module := make([]byte, 4+binary.LittleEndian.Uint32(length[:]))
+	copy(module, length[:])
+	if _, err := io.ReadFull(r, module[4:]); err != nil {
+		return err
+	}
+	b, err := c.encryptor.decrypt(dataPageHeaderModule, page, module)
+	if err != nil {
+		return err
+	}
+	return thrift.Unmarshal(new(thrift.CompactProtocol), b, header)
+}
+
+// hashesFilterValues returns true if the column has a split block bloom filter,
+// which can be built from the hashes of values.
+func (c *writerColumn) hashesFilterValues() bool {
+	if c.columnFilter == nil {
+		return false
+	}
+	_, ok := c.columnFilter.Encoding().(splitBlockEncoding)
+	return ok
+}
+
 func (c *writerColumn) resizeBloomFilter(numValues int64) {
 	filterSize := c.columnFilter.Size(numValues)
 	if cap(c.filter) < filterSize {
@@ -1335,12 +2424,71 @@ func (c *writerColumn) newColumnBuffer() ColumnBuffer {
 	return column
 }
 
+func (c *writerColumn) validateValues(values []Value, checkUTF8 bool) error {
+	kind := c.columnType.Kind()
+	checkUTF8 = checkUTF8 && c.stringColumn
+
+	for i, v := range values {
+		repetitionLevel := v.RepetitionLevel()
+		definitionLevel := v.DefinitionLevel()
+
+		switch {
+		case repetitionLevel > int(c.maxRepetitionLevel):
+			return fmt.Errorf("repetition level %d of value %d exceeds the maximum of %d", repetitionLevel, i, c.maxRepetitionLevel)
+		case definitionLevel > int(c.maxDefinitionLevel):
+			return fmt.Errorf("definition level %d of value %d exceeds the maximum of %d", definitionLevel, i, c.maxDefinitionLevel)
+		case i == 0 && repetitionLevel != 0:
+			return fmt.Errorf("the first value has repetition level %d instead of 0", repetitionLevel)
+		case i > 0 && repetitionLevel == 0:
+			return fmt.Errorf("value %d has repetition level 0 but is not the first value of the row", i)
+		}
+
+		if v.IsNull() {
+			if definitionLevel == int(c.maxDefinitionLevel) {
+				return fmt.Errorf("null value %d has definition level %d but the column is not optional at this level", i, definitionLevel)
+			}
+			continue
+		}
+
+		if definitionLevel != int(c.maxDefinitionLevel) {
+			return fmt.Errorf("non-null value %d has definition level %d instead of %d", i, definitionLevel, c.maxDefinitionLevel)
+		}
+		if v.Kind() != kind {
+			return fmt.Errorf("value %d is of type %s instead of %s", i, v.Kind(), kind)
+		}
+		if kind == FixedLenByteArray && len(v.byteArray()) != c.columnType.Length() {
+			return fmt.Errorf("value %d has length %d instead of %d", i, len(v.byteArray()), c.columnType.Length())
+		}
+		if checkUTF8 && !isValidUTF8(v) {
+			return fmt.Errorf("value %d: %w", i, ErrInvalidUTF8)
+		}
+		if err := c.validateEnumValue(v); err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *writerColumn) validateEnumValue(v Value) error {
+	if c.enumValues == nil || v.IsNull() {
+		return nil
+	}
+	if _, ok := c.enumValues[string(v.byteArray())]; !ok {
+		return fmt.Errorf("%q: %w", v.byteArray(), ErrUnknownEnumValue)
+	}
+	return nil
+}
+
 func (c *writerColumn) writeRows(rows []Value) error {
 	if c.columnBuffer == nil {
 		// Lazily create the row group column so we don't need to allocate it if
 		// rows are not written individually to the column.
 		c.columnBuffer = c.newColumnBuffer()
 	}
+	if c.chunker != nil {
+		return c.writeChunkedRows(rows)
+	}
 	if _, err := c.columnBuffer.WriteValues(rows); err != nil {
 		return err
 	}
@@ -1350,6 +2498,31 @@ func (c *writerColumn) writeRows(rows []Value) error {
 	return nil
 }
 
+// writeChunkedRows writes rows to the column buffer, flushing pages at the
+// content-defined cut points found by the column chunker. Pages are only cut
+// at row boundaries, when the first value of a row is written after a cut
+// point was found.
+func (c *writerColumn) writeChunkedRows(rows []Value) error {
+	start := 0
+	for i, v := range rows {
+		if v.repetitionLevel == 0 && c.chunker.cut() {
+			if i > start {
+				if _, err := c.columnBuffer.WriteValues(rows[start:i]); err != nil {
+					return err
+				}
+				start = i
+			}
+			if err := c.flush(); err != nil {
+				return err
+			}
+			c.chunker.reset()
+		}
+		c.chunker.writeValue(v)
+	}
+	_, err := c.columnBuffer.WriteValues(rows[start:])
+	return err
+}
+
 func (c *writerColumn) WriteValues(values []Value) (numValues int, err error) {
 	if c.columnBuffer == nil {
 		c.columnBuffer = c.newColumnBuffer()
@@ -1358,9 +2531,12 @@ func (c *writerColumn) WriteValues(values []Value) (numValues int, err error) {
 }
 
 func (c *writerColumn) writeBloomFilter(w io.Writer) error {
-	e := thrift.NewEncoder(c.header.protocol.NewWriter(w))
 	h := bloomFilterHeader(c.columnFilter)
 	h.NumBytes = int32(len(c.filter))
+	if c.encryptor != nil {
+		return c.writeEncryptedBloomFilter(w, &h)
+	}
+	e := thrift.NewEncoder(c.header.protocol.NewWriter(w))
 	if err := e.Encode(&h); err != nil {
 		return err
 	}
@@ -1368,6 +2544,22 @@ func (c *writerColumn) writeBloomFilter(w io.Writer) error {
 	return err
 }
 
+func (c *writerColumn) writeEncryptedBloomFilter(w io.Writer, h *format.BloomFilterHeader) error {
+	header, err := thrift.Marshal(new(thrift.CompactProtocol), h)
+	if err != nil {
+		return err
+	}
+	b, err := c.encryptor.encrypt(nil, bloomFilterHeaderModule, -1, header)
+	if err != nil {
+		return err
+	}
+	if b, err = c.encryptor.encrypt(b, bloomFilterBitsetModule, -1, c.filter); err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
 func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 	numValues := page.NumValues()
 	if numValues == 0 {
@@ -1388,7 +2580,7 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 		return 0, fmt.Errorf("encoding parquet data page: %w", err)
 	}
 	if c.dataPageType == format.DataPage {
-		buf.prependLevelsToDataPageV1(c.maxDefinitionLevel, c.maxDefinitionLevel)
+		buf.prependLevelsToDataPageV1(c.maxRepetitionLevel, c.maxDefinitionLevel)
 	}
 
 	uncompressedPageSize := buf.size()
@@ -1410,6 +2602,15 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 		if err := c.writePageToFilter(page); err != nil {
 			return 0, err
 		}
+	} else if page.Dictionary() == nil && c.hashesFilterValues() && !c.filterHashesOverBudget {
+		// Otherwise, the values of the page are hashed in bulk from the typed
+		// slices of the page, and the hashes are inserted in the filter when
+		// it is flushed.
+		if int64(len(c.filterHashes))+numValues > c.filterHashBudget {
+			c.filterHashes, c.filterHashesOverBudget = nil, true
+		} else {
+			c.filterHashes = appendSplitBlockHashes(c.filterHashes, page.Data())
+		}
 	}
 
 	statistics := format.Statistics{}
@@ -1420,7 +2621,7 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 	pageHeader := &format.PageHeader{
 		Type:                 c.dataPageType,
 		UncompressedPageSize: int32(uncompressedPageSize),
-		CompressedPageSize:   int32(buf.size()),
+		CompressedPageSize:   int32(c.compressedPageSize(buf.size())),
 		CRC:                  int32(buf.crc32()),
 	}
 
@@ -1453,6 +2654,22 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 		return 0, err
 	}
 
+	if c.encryptor != nil {
+		headerSize, err := buf.encrypt(c.encryptor, dataPageHeaderModule, dataPageModule, c.numPages)
+		if err != nil {
+			return 0, err
+		}
+		err = c.writePageTo(int64(len(buf.encrypted)), func(output io.Writer) (int64, error) {
+			n, err := output.Write(buf.encrypted)
+			return int64(n), err
+		})
+		if err != nil {
+			return 0, err
+		}
+		c.recordPageStats(int32(headerSize), pageHeader, page)
+		return numValues, nil
+	}
+
 	size := int64(buf.header.Len()) +
 		int64(len(buf.repetitions)) +
 		int64(len(buf.definitions)) +
@@ -1481,7 +2698,7 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 	return numValues, nil
 }
 
-func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) (err error) {
+func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary, sorted bool) (err error) {
 	buf := c.buffers
 	buf.reset()
 
@@ -1502,12 +2719,12 @@ func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) (e
 	pageHeader := &format.PageHeader{
 		Type:                 format.DictionaryPage,
 		UncompressedPageSize: int32(uncompressedPageSize),
-		CompressedPageSize:   int32(buf.size()),
+		CompressedPageSize:   int32(c.compressedPageSize(buf.size())),
 		CRC:                  int32(buf.crc32()),
 		DictionaryPageHeader: &format.DictionaryPageHeader{
 			NumValues: int32(dict.Len()),
 			Encoding:  format.Plain,
-			IsSorted:  false,
+			IsSorted:  sorted,
 		},
 	}
 
@@ -1516,6 +2733,17 @@ func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) (e
 	if err := c.header.encoder.Encode(pageHeader); err != nil {
 		return err
 	}
+	if c.encryptor != nil {
+		headerSize, err := buf.encrypt(c.encryptor, dictionaryPageHeaderModule, dictionaryPageModule, -1)
+		if err != nil {
+			return err
+		}
+		if _, err := output.Write(buf.encrypted); err != nil {
+			return err
+		}
+		c.recordPageStats(int32(headerSize), pageHeader, nil)
+		return nil
+	}
 	if _, err := output.Write(header.Bytes()); err != nil {
 		return err
 	}
@@ -1557,11 +2785,23 @@ func (c *writerColumn) writePageTo(size int64, writeTo func(io.Writer) (int64, e
 	return nil
 }
 
+// compressedPageSize returns the size of the page data written to the file,
+// which includes the overhead of the encryption when the column is encrypted.
+func (c *writerColumn) compressedPageSize(size int) int {
+	if c.encryptor != nil {
+		size += gcmModuleOverhead
+	}
+	return size
+}
+
 func (c *writerColumn) makePageStatistics(page Page) format.Statistics {
 	numNulls := page.NumNulls()
 	minValue, maxValue, _ := page.Bounds()
 	minValueBytes := minValue.Bytes()
 	maxValueBytes := maxValue.Bytes()
+	if c.omitPageBounds(minValueBytes, maxValueBytes) {
+		return format.Statistics{NullCount: numNulls}
+	}
 	return format.Statistics{
 		Min:       minValueBytes, // deprecated
 		Max:       maxValueBytes, // deprecated
@@ -1571,6 +2811,23 @@ func (c *writerColumn) makePageStatistics(page Page) format.Statistics {
 	}
 }
 
+// omitPageBounds returns true if the min and max values of a page should not be
+// written to the statistics of its header.
+func (c *writerColumn) omitPageBounds(minValue, maxValue []byte) bool {
+	if limit := c.pageStatsSizeLimit; limit > 0 && (len(minValue) > limit || len(maxValue) > limit) {
+		return true
+	}
+	if !c.skipIndexedPageStats || !c.writePageBounds {
+		return false
+	}
+	// The column index truncates byte arrays longer than its size limit, in
+	// which case only the page statistics record the exact bounds.
+	if isByteArrayKind(c.columnType.Kind()) {
+		return len(minValue) <= c.pageIndexSizeLimit && len(maxValue) <= c.pageIndexSizeLimit
+	}
+	return true
+}
+
 func (c *writerColumn) recordPageStats(headerSize int32, header *format.PageHeader, page Page) {
 	uncompressedSize := headerSize + header.UncompressedPageSize
 	compressedSize := headerSize + header.CompressedPageSize
@@ -0,0 +1,77 @@
+//go:build go1.23
+
+package parquet
+
+import "iter"
+
+// seqWriteBatchSize is the number of rows buffered by WriteSeq and WriteSeq2
+// before they are written to the underlying writer.
+const seqWriteBatchSize = 64
+
+// WriteSeq writes the rows produced by seq to w, returning the number of rows
+// written.
+//
+// Rows are buffered in small batches before being passed to the Write method
+// of w, which amortizes the cost of writes without requiring the program to
+// materialize all the rows in a slice.
+func WriteSeq[T any](w *GenericWriter[T], seq iter.Seq[T]) (int64, error) {
+	batch := make([]T, 0, seqWriteBatchSize)
+	written := int64(0)
+
+	for row := range seq {
+		batch = append(batch, row)
+		if len(batch) == cap(batch) {
+			n, err := writeSeqBatch(w, batch)
+			written += n
+			if err != nil {
+				return written, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	n, err := writeSeqBatch(w, batch)
+	return written + n, err
+}
+
+// WriteSeq2 is like WriteSeq but the sequence also produces errors. Writing
+// stops at the first non-nil error produced by seq, which is then returned
+// after the rows produced before it were written to w.
+func WriteSeq2[T any](w *GenericWriter[T], seq iter.Seq2[T, error]) (int64, error) {
+	batch := make([]T, 0, seqWriteBatchSize)
+	written := int64(0)
+
+	for row, err := range seq {
+		if err != nil {
+			n, writeErr := writeSeqBatch(w, batch)
+			written += n
+			if writeErr != nil {
+				return written, writeErr
+			}
+			return written, err
+		}
+		batch = append(batch, row)
+		if len(batch) == cap(batch) {
+			n, err := writeSeqBatch(w, batch)
+			written += n
+			if err != nil {
+				return written, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	n, err := writeSeqBatch(w, batch)
+	return written + n, err
+}
+
+func writeSeqBatch[T any](w *GenericWriter[T], batch []T) (int64, error) {
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	n, err := w.Write(batch)
+	// Release references held by the batch so the rows can be garbage
+	// collected while the next batch is produced.
+	clear(batch)
+	return int64(n), err
+}
@@ -0,0 +1,85 @@
+//go:build go1.23
+
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type seqRow struct {
+	ID int64 `parquet:"id"`
+}
+
+func seqRows(n int) iter.Seq[seqRow] {
+	return func(yield func(seqRow) bool) {
+		for i := range n {
+			if !yield(seqRow{ID: int64(i)}) {
+				return
+			}
+		}
+	}
+}
+
+func TestWriteSeq(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[seqRow](buf)
+
+	n, err := parquet.WriteSeq(w, seqRows(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1000 {
+		t.Errorf("wrong number of rows written: %d", n)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := parquet.Read[seqRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1000 {
+		t.Fatalf("wrong number of rows read: %d", len(rows))
+	}
+	for i, row := range rows {
+		if row.ID != int64(i) {
+			t.Fatalf("wrong row at index %d: %+v", i, row)
+		}
+	}
+}
+
+func TestWriteSeq2(t *testing.T) {
+	errStop := errors.New("stop")
+
+	seq := func(yield func(seqRow, error) bool) {
+		for row := range seqRows(100) {
+			if !yield(row, nil) {
+				return
+			}
+		}
+		yield(seqRow{}, errStop)
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[seqRow](buf)
+
+	n, err := parquet.WriteSeq2(w, seq)
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected the error of the sequence, got %v", err)
+	}
+	if n != 100 {
+		t.Errorf("wrong number of rows written: %d", n)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if numRows := parquet.NewGenericReader[seqRow](bytes.NewReader(buf.Bytes())).NumRows(); numRows != 100 {
+		t.Errorf("wrong number of rows in the file: %d", numRows)
+	}
+}
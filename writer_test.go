@@ -2,15 +2,19 @@ package parquet_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
 	"os"
 	"os/exec"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hexops/gotextdiff"
@@ -19,6 +23,8 @@ import (
 
 	"github.com/parquet-go/parquet-go"
 	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
 )
 
 const (
@@ -986,6 +992,106 @@ func TestWriterGenerateBloomFilters(t *testing.T) {
 	}
 }
 
+func TestWriterBloomFilterHashes(t *testing.T) {
+	type Row struct {
+		Bool   bool     `parquet:"bool"`
+		Int32  int32    `parquet:"int32"`
+		Int64  int64    `parquet:"int64"`
+		Float  float32  `parquet:"float"`
+		Double float64  `parquet:"double"`
+		String string   `parquet:"string"`
+		Array  [3]byte  `parquet:"array"`
+		UUID   [16]byte `parquet:"uuid"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			Bool:   i%2 == 0,
+			Int32:  int32(i),
+			Int64:  int64(i) << 32,
+			Float:  float32(i) / 3,
+			Double: float64(i) / 7,
+			String: fmt.Sprintf("value-%d", i),
+			Array:  [3]byte{byte(i), byte(i >> 8), 1},
+			UUID:   [16]byte{byte(i), byte(i >> 8), 2},
+		}
+	}
+
+	options := []parquet.WriterOption{
+		parquet.PageBufferSize(256),
+		parquet.BloomFilters(
+			parquet.SplitBlockFilter(10, "bool"),
+			parquet.SplitBlockFilter(10, "int32"),
+			parquet.SplitBlockFilter(10, "int64"),
+			parquet.SplitBlockFilter(10, "float"),
+			parquet.SplitBlockFilter(10, "double"),
+			parquet.SplitBlockFilter(10, "string"),
+			parquet.SplitBlockFilter(10, "array"),
+			parquet.SplitBlockFilter(10, "uuid"),
+		),
+	}
+
+	buffer := parquet.NewGenericBuffer[Row]()
+	if _, err := buffer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	sized := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](sized, options...)
+	if _, err := writer.WriteRowGroup(buffer); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	readFilters := func(b *bytes.Buffer) [][]byte {
+		t.Helper()
+		f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var filters [][]byte
+		for _, chunk := range f.RowGroups()[0].ColumnChunks() {
+			filter := chunk.BloomFilter()
+			if filter == nil {
+				t.Fatalf("column %d has no bloom filter", chunk.Column())
+			}
+			data := make([]byte, filter.Size())
+			if _, err := filter.ReadAt(data, 0); err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			filters = append(filters, data)
+		}
+		return filters
+	}
+
+	// The filters built from the hashes of the pages written by Write, or by
+	// reading back the pages when the hashes exceed the memory budget, must be
+	// the same as the filters sized in advance by WriteRowGroup.
+	want := readFilters(sized)
+
+	for _, budget := range []int64{parquet.DefaultBloomFilterMemoryBudget, 1024} {
+		t.Run(fmt.Sprint(budget), func(t *testing.T) {
+			hashed := new(bytes.Buffer)
+			writer := parquet.NewGenericWriter[Row](hashed, append(options, parquet.BloomFilterMemoryBudget(budget))...)
+			if _, err := writer.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			got := readFilters(hashed)
+			for i := range want {
+				if !bytes.Equal(want[i], got[i]) {
+					t.Errorf("bloom filters of column %d differ", i)
+				}
+			}
+		})
+	}
+}
+
 func TestBloomFilterForDict(t *testing.T) {
 	type testStruct struct {
 		A string `parquet:"a,dict"`
@@ -1273,3 +1379,1132 @@ func TestColumnSkipPageBounds(t *testing.T) {
 		t.Fatalf("wrong max value of row groups in parquet file: want='' got=%s", string(statistics.MaxValue))
 	}
 }
+
+func TestWriterEncodingStats(t *testing.T) {
+	type testStruct struct {
+		A string `parquet:"a,dict"`
+		B int64  `parquet:"b,plain"`
+	}
+
+	rows := make([]testStruct, 100)
+	for i := range rows {
+		rows[i] = testStruct{A: strconv.Itoa(i % 10), B: int64(i)}
+	}
+
+	b := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[testStruct](b, parquet.PageBufferSize(64))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []struct {
+		dataEncoding   format.Encoding
+		dictionaryPage bool
+	}{
+		{dataEncoding: format.RLEDictionary, dictionaryPage: true},
+		{dataEncoding: format.Plain},
+	} {
+		chunk := f.RowGroups()[0].ColumnChunks()[i].(*parquet.FileColumnChunk)
+		offsetIndex, err := chunk.OffsetIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantStats := []format.PageEncodingStats{}
+		if want.dictionaryPage {
+			wantStats = append(wantStats, format.PageEncodingStats{
+				PageType: format.DictionaryPage,
+				Encoding: format.Plain,
+				Count:    1,
+			})
+		}
+		wantStats = append(wantStats, format.PageEncodingStats{
+			PageType: format.DataPageV2,
+			Encoding: want.dataEncoding,
+			Count:    int32(offsetIndex.NumPages()),
+		})
+		sort.Slice(wantStats, func(i, j int) bool { return wantStats[i].PageType < wantStats[j].PageType })
+
+		gotStats := f.Metadata().RowGroups[0].Columns[i].MetaData.EncodingStats
+		if !reflect.DeepEqual(wantStats, gotStats) {
+			t.Errorf("column %d: encoding stats mismatch:\nwant: %+v\ngot:  %+v", i, wantStats, gotStats)
+		}
+	}
+}
+
+func TestGenericWriterSchemaMismatch(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	schema := parquet.NewSchema("row", parquet.Group{
+		"id":   parquet.String(),
+		"name": parquet.String(),
+	})
+
+	w := parquet.NewGenericWriter[Row](new(bytes.Buffer), schema)
+	n, err := w.Write([]Row{{ID: 1, Name: "a"}})
+	if err == nil {
+		t.Fatal("expected writing rows with mismatching types to fail")
+	}
+	if n != 0 {
+		t.Errorf("wrong number of rows written: %d", n)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "id: ") || strings.Contains(msg, "name: ") {
+		t.Errorf("error does not list the mismatched column paths: %s", msg)
+	}
+}
+
+func TestGenericWriterSchemaConversions(t *testing.T) {
+	type Row struct {
+		Time  time.Time `parquet:"time"`
+		Ratio float32   `parquet:"ratio"`
+		Small int16     `parquet:"small"`
+		Count uint32    `parquet:"count"`
+		ID    int32     `parquet:"id"`
+		Name  []byte    `parquet:"name"`
+	}
+
+	schema := parquet.NewSchema("row", parquet.Group{
+		"time":  parquet.Leaf(parquet.Int96Type),
+		"ratio": parquet.Leaf(parquet.DoubleType),
+		"small": parquet.Int(32),
+		"count": parquet.Int(64),
+		"id":    parquet.Int(64),
+		"name":  parquet.String(),
+	})
+
+	now := time.Unix(1700000000, 123).UTC()
+	rows := []Row{
+		{Time: now, Ratio: 1.5, Small: -3, Count: 1 << 31, ID: -1, Name: []byte("a")},
+		{Time: now.Add(time.Second), Ratio: -0.25, Small: 7, Count: 42, ID: 2, Name: []byte("b")},
+	}
+	want := [][]parquet.Value{
+		{
+			parquet.ValueOf(deprecated.Int64ToInt96(now.UnixNano())),
+			parquet.ValueOf(1.5),
+			parquet.ValueOf(int32(-3)),
+			parquet.ValueOf(int64(1 << 31)),
+			parquet.ValueOf(int64(-1)),
+			parquet.ValueOf("a"),
+		},
+		{
+			parquet.ValueOf(deprecated.Int64ToInt96(now.Add(time.Second).UnixNano())),
+			parquet.ValueOf(-0.25),
+			parquet.ValueOf(int32(7)),
+			parquet.ValueOf(int64(42)),
+			parquet.ValueOf(int64(2)),
+			parquet.ValueOf("b"),
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, schema)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := f.RowGroups()[0].Rows()
+	defer r.Close()
+	got := make([]parquet.Row, len(rows))
+	if n, err := r.ReadRows(got); n != len(rows) {
+		t.Fatalf("reading rows: %d/%d: %v", n, len(rows), err)
+	}
+
+	// Columns are ordered by name in the schema.
+	order := []int{5, 3, 4, 0, 1, 2}
+	for i, row := range got {
+		for j, column := range order {
+			if !parquet.Equal(row[column], want[i][j]) {
+				t.Errorf("row %d, column %d: want=%v got=%v", i, column, want[i][j], row[column])
+			}
+		}
+	}
+}
+
+func TestGenericWriterSchemaLossyConversions(t *testing.T) {
+	tests := []struct {
+		scenario string
+		write    func(parquet.Node) error
+		node     parquet.Node
+	}{
+		{"int64 to INT32", writeSingleValue(int64(1 << 40)), parquet.Int(32)},
+		{"int to INT32", writeSingleValue(int(1 << 40)), parquet.Int(32)},
+		{"uint64 to INT32", writeSingleValue(uint64(1)), parquet.Int(32)},
+		{"float64 to INT64", writeSingleValue(3.9), parquet.Int(64)},
+		{"float32 to INT32", writeSingleValue(float32(3.9)), parquet.Int(32)},
+		{"float64 to FLOAT", writeSingleValue(0.1), parquet.Leaf(parquet.FloatType)},
+		{"int64 to DOUBLE", writeSingleValue(int64(1<<53 + 1)), parquet.Leaf(parquet.DoubleType)},
+		{"int32 to FLOAT", writeSingleValue(int32(1<<24 + 1)), parquet.Leaf(parquet.FloatType)},
+		{"string to INT64", writeSingleValue("42"), parquet.Int(64)},
+		{"bool to INT32", writeSingleValue(true), parquet.Int(32)},
+		{"int32 to BOOLEAN", writeSingleValue(int32(1)), parquet.Leaf(parquet.BooleanType)},
+		{"int64 to STRING", writeSingleValue(int64(1)), parquet.String()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			if err := test.write(test.node); err == nil {
+				t.Fatal("expected writing values with a lossy conversion to fail")
+			}
+		})
+	}
+}
+
+func writeSingleValue[T any](value T) func(parquet.Node) error {
+	type Row struct {
+		Value T `parquet:"value"`
+	}
+	return func(node parquet.Node) error {
+		schema := parquet.NewSchema("row", parquet.Group{"value": node})
+		w := parquet.NewGenericWriter[Row](new(bytes.Buffer), schema)
+		_, err := w.Write([]Row{{Value: value}})
+		return err
+	}
+}
+
+func TestStrictWrite(t *testing.T) {
+	schema := parquet.NewSchema("row", parquet.Group{
+		"id":   parquet.Int(64),
+		"name": parquet.Optional(parquet.String()),
+		"tags": parquet.Repeated(parquet.String()),
+	})
+
+	// Columns are ordered by name: id, name, tags.
+	valid := parquet.Row{
+		parquet.Int64Value(1).Level(0, 0, 0),
+		parquet.ByteArrayValue([]byte("A")).Level(0, 1, 1),
+		parquet.ByteArrayValue([]byte("x")).Level(0, 1, 2),
+		parquet.ByteArrayValue([]byte("y")).Level(1, 1, 2),
+	}
+
+	tests := []struct {
+		scenario string
+		row      parquet.Row
+		path     []string
+	}{
+		{
+			scenario: "wrong kind",
+			row: parquet.Row{
+				parquet.ByteArrayValue([]byte("1")).Level(0, 0, 0),
+				parquet.NullValue().Level(0, 0, 1),
+				parquet.NullValue().Level(0, 0, 2),
+			},
+			path: []string{"id"},
+		},
+		{
+			scenario: "null in required column",
+			row: parquet.Row{
+				parquet.NullValue().Level(0, 0, 0),
+				parquet.NullValue().Level(0, 0, 1),
+				parquet.NullValue().Level(0, 0, 2),
+			},
+			path: []string{"id"},
+		},
+		{
+			scenario: "invalid definition level",
+			row: parquet.Row{
+				parquet.Int64Value(1).Level(0, 0, 0),
+				parquet.ByteArrayValue([]byte("A")).Level(0, 0, 1),
+				parquet.NullValue().Level(0, 0, 2),
+			},
+			path: []string{"name"},
+		},
+		{
+			scenario: "invalid repetition level",
+			row: parquet.Row{
+				parquet.Int64Value(1).Level(0, 0, 0),
+				parquet.NullValue().Level(0, 0, 1),
+				parquet.ByteArrayValue([]byte("x")).Level(1, 1, 2),
+			},
+			path: []string{"tags"},
+		},
+		{
+			scenario: "invalid utf-8",
+			row: parquet.Row{
+				parquet.Int64Value(1).Level(0, 0, 0),
+				parquet.ByteArrayValue([]byte("\xff")).Level(0, 1, 1),
+				parquet.NullValue().Level(0, 0, 2),
+			},
+			path: []string{"name"},
+		},
+		{
+			scenario: "missing column",
+			row: parquet.Row{
+				parquet.Int64Value(1).Level(0, 0, 0),
+				parquet.NullValue().Level(0, 0, 1),
+			},
+			path: []string{"tags"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			w := parquet.NewWriter(new(bytes.Buffer), schema, parquet.StrictWrite(true))
+
+			n, err := w.WriteRows([]parquet.Row{valid, test.row})
+			if n != 0 {
+				t.Errorf("wrong number of rows written: %d", n)
+			}
+			rowErr, ok := err.(*parquet.RowError)
+			if !ok {
+				t.Fatalf("expected *parquet.RowError, got %T: %v", err, err)
+			}
+			if rowErr.Row != 1 {
+				t.Errorf("wrong row index: %d", rowErr.Row)
+			}
+			if !reflect.DeepEqual(rowErr.Path, test.path) {
+				t.Errorf("wrong column path: %q", rowErr.Path)
+			}
+		})
+	}
+
+	t.Run("valid rows", func(t *testing.T) {
+		w := parquet.NewWriter(new(bytes.Buffer), schema, parquet.StrictWrite(true))
+		if _, err := w.WriteRows([]parquet.Row{valid, valid}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestGenericWriterStrictWrite(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i].Name = strconv.Itoa(i)
+	}
+	rows[90].Name = "\xc3\x28"
+
+	w := parquet.NewGenericWriter[Row](new(bytes.Buffer), parquet.StrictWrite(true))
+	_, err := w.Write(rows)
+
+	var rowErr *parquet.RowError
+	if !errors.As(err, &rowErr) {
+		t.Fatalf("expected *parquet.RowError, got %T: %v", err, err)
+	}
+	if rowErr.Row != 90 {
+		t.Errorf("wrong row index: %d", rowErr.Row)
+	}
+}
+
+func TestWriterBufferedRowsAndBytes(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	w := parquet.NewGenericWriter[Row](io.Discard, parquet.PageBufferSize(256))
+	if n := w.BufferedRows(); n != 0 {
+		t.Errorf("wrong number of buffered rows: %d", n)
+	}
+	if n := w.BufferedBytes(); n != 0 {
+		t.Errorf("wrong number of buffered bytes: %d", n)
+	}
+
+	lastSize := int64(0)
+	for i := 0; i < 100; i++ {
+		if _, err := w.Write([]Row{{ID: int64(i), Name: strings.Repeat("x", 10)}}); err != nil {
+			t.Fatal(err)
+		}
+		if n := w.BufferedRows(); n != int64(i+1) {
+			t.Fatalf("wrong number of buffered rows: %d", n)
+		}
+		size := w.BufferedBytes()
+		if size <= lastSize {
+			t.Fatalf("buffered bytes did not grow after write: %d <= %d", size, lastSize)
+		}
+		lastSize = size
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if n := w.BufferedRows(); n != 0 {
+		t.Errorf("wrong number of buffered rows after flush: %d", n)
+	}
+	if n := w.BufferedBytes(); n != 0 {
+		t.Errorf("wrong number of buffered bytes after flush: %d", n)
+	}
+}
+
+func TestWriterFlushInterval(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.FlushInterval(time.Minute),
+		parquet.WriterClock(clock),
+	)
+
+	write := func(id int64) {
+		t.Helper()
+		if _, err := w.Write([]Row{{ID: id}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(0)
+	now = now.Add(30 * time.Second)
+	write(1)
+
+	if flushed, err := w.FlushIfDue(); err != nil {
+		t.Fatal(err)
+	} else if flushed {
+		t.Fatal("row group flushed before the interval elapsed")
+	}
+
+	now = now.Add(30 * time.Second)
+	if flushed, err := w.FlushIfDue(); err != nil {
+		t.Fatal(err)
+	} else if !flushed {
+		t.Fatal("row group not flushed after the interval elapsed")
+	}
+	if n := w.BufferedRows(); n != 0 {
+		t.Fatalf("wrong number of buffered rows after flush: %d", n)
+	}
+
+	// Writes also trigger the flush of expired row groups.
+	write(2)
+	now = now.Add(2 * time.Minute)
+	write(3)
+	write(4)
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var numRows []int64
+	for _, rowGroup := range f.RowGroups() {
+		numRows = append(numRows, rowGroup.NumRows())
+	}
+	if want := []int64{2, 1, 2}; !reflect.DeepEqual(numRows, want) {
+		t.Errorf("wrong row groups: %v != %v", numRows, want)
+	}
+}
+
+func TestWriterCommittedBytes(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.WriteBufferSize(1<<20))
+	if n := w.CommittedBytes(); n != 0 {
+		t.Errorf("bytes committed before writing rows: %d", n)
+	}
+
+	if _, err := w.Write([]Row{{ID: 1, Name: "Luke"}, {ID: 2, Name: "Leia"}}); err != nil {
+		t.Fatal(err)
+	}
+	if n := w.CommittedBytes(); n != 0 {
+		t.Errorf("bytes committed before flushing rows: %d", n)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	committed := w.CommittedBytes()
+	if committed == 0 || committed != int64(buf.Len()) {
+		t.Fatalf("committed bytes do not match the output: committed=%d output=%d", committed, buf.Len())
+	}
+
+	if _, err := w.Write([]Row{{ID: 3, Name: "Han"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := w.CommittedBytes(); n != int64(buf.Len()) {
+		t.Errorf("committed bytes do not match the file size after closing: committed=%d size=%d", n, buf.Len())
+	}
+
+	// Appending a footer describing the first row group to the committed
+	// prefix of the output must produce a valid file.
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := *f.Metadata()
+	metadata.RowGroups = metadata.RowGroups[:1]
+	metadata.NumRows = metadata.RowGroups[0].NumRows
+	columns := make([]format.ColumnChunk, len(metadata.RowGroups[0].Columns))
+	for i, c := range metadata.RowGroups[0].Columns {
+		c.ColumnIndexOffset, c.ColumnIndexLength = 0, 0
+		c.OffsetIndexOffset, c.OffsetIndexLength = 0, 0
+		columns[i] = c
+	}
+	metadata.RowGroups[0].Columns = columns
+
+	// An empty footer is appended to the prefix for rewriteFooter to replace.
+	prefix := append(buf.Bytes()[:committed:committed], "\x00\x00\x00\x00PAR1"...)
+	data := rewriteFooter(t, prefix, &metadata)
+	rows, err := parquet.Read[Row](bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Row{{ID: 1, Name: "Luke"}, {ID: 2, Name: "Leia"}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("wrong rows in committed prefix: %+v", rows)
+	}
+}
+
+func TestWriterReport(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.Compression(&parquet.Snappy),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "id")),
+	)
+	for i := 0; i < 3; i++ {
+		if _, err := w.Write([]Row{{ID: 1, Name: "Luke"}, {ID: 2, Name: "Leia"}, {ID: 3, Name: "Luke"}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := w.Report()
+	if report.NumRows != 9 || report.NumRowGroups != 3 {
+		t.Fatalf("wrong number of rows and row groups: rows=%d row_groups=%d", report.NumRows, report.NumRowGroups)
+	}
+	if len(report.Columns) != 2 {
+		t.Fatalf("wrong number of columns: %d", len(report.Columns))
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range report.Columns {
+		var compressed, uncompressed, numValues int64
+		for _, rowGroup := range f.Metadata().RowGroups {
+			metadata := rowGroup.Columns[i].MetaData
+			compressed += metadata.TotalCompressedSize
+			uncompressed += metadata.TotalUncompressedSize
+			numValues += metadata.NumValues
+		}
+		if c.CompressedSize != compressed || c.UncompressedSize != uncompressed || c.NumValues != numValues {
+			t.Errorf("column %q: report does not match the file metadata: compressed=%d/%d uncompressed=%d/%d values=%d/%d",
+				c.Path, c.CompressedSize, compressed, c.UncompressedSize, uncompressed, c.NumValues, numValues)
+		}
+		if c.Compression.CompressionCodec() != format.Snappy {
+			t.Errorf("column %q: wrong compression codec: %s", c.Path, c.Compression)
+		}
+		if c.NumPages != 3 {
+			t.Errorf("column %q: wrong number of pages: %d", c.Path, c.NumPages)
+		}
+		if c.CompressionRatio() <= 0 {
+			t.Errorf("column %q: invalid compression ratio: %g", c.Path, c.CompressionRatio())
+		}
+	}
+
+	id, name := report.Columns[0], report.Columns[1]
+	if id.BloomFilterSize == 0 || name.BloomFilterSize != 0 {
+		t.Errorf("wrong bloom filter sizes: id=%d name=%d", id.BloomFilterSize, name.BloomFilterSize)
+	}
+	if id.DictionaryValues != 0 || id.DictionarySize != 0 {
+		t.Errorf("dictionary reported for column without dictionary encoding: values=%d size=%d", id.DictionaryValues, id.DictionarySize)
+	}
+	if name.DictionaryValues != 6 || name.DictionarySize == 0 {
+		t.Errorf("wrong dictionary of column %q: values=%d size=%d", name.Path, name.DictionaryValues, name.DictionarySize)
+	}
+	encodings := make([]string, len(name.Encodings))
+	for i, enc := range name.Encodings {
+		encodings[i] = enc.String()
+	}
+	if got, want := strings.Join(encodings, ","), "PLAIN,RLE_DICTIONARY"; got != want {
+		t.Errorf("wrong encodings of column %q: want %s, got %s", name.Path, want, got)
+	}
+}
+
+func TestWriterDictionaryFallback(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			var fallbacks []parquet.DictionaryFallback
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf,
+				parquet.DataPageVersion(version),
+				parquet.Compression(&parquet.Snappy),
+				parquet.PageBufferSize(1024),
+				parquet.DictionaryMaxBytes(512),
+				parquet.DictionaryFallbackLimit(2),
+				parquet.OnDictionaryFallback(func(event parquet.DictionaryFallback) {
+					fallbacks = append(fallbacks, event)
+				}),
+				parquet.BloomFilterMemoryBudget(8),
+				parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+			)
+
+			const numRowGroups, numRows = 4, 500
+			var want []Row
+			for i := 0; i < numRowGroups; i++ {
+				rows := make([]Row, numRows)
+				for j := range rows {
+					rows[j] = Row{ID: int64(len(want) + j), Name: fmt.Sprintf("name-%d", len(want)+j)}
+				}
+				if _, err := w.Write(rows); err != nil {
+					t.Fatal(err)
+				}
+				if err := w.Flush(); err != nil {
+					t.Fatal(err)
+				}
+				want = append(want, rows...)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			report := w.Report().Columns[1]
+			if report.DictionaryFallbacks != 2 || !report.DictionaryDisabled {
+				t.Errorf("wrong dictionary fallbacks: fallbacks=%d disabled=%t", report.DictionaryFallbacks, report.DictionaryDisabled)
+			}
+			if len(fallbacks) != 2 {
+				t.Fatalf("wrong number of dictionary fallback events: %d", len(fallbacks))
+			}
+			for i, event := range fallbacks {
+				if !slices.Equal(event.Path, []string{"name"}) {
+					t.Errorf("event %d: wrong column path: %q", i, event.Path)
+				}
+				if event.DictionarySize <= 512 || event.DictionaryValues == 0 || event.NumValues < event.DictionaryValues {
+					t.Errorf("event %d: wrong dictionary sizes: %+v", i, event)
+				}
+				if event.Disabled != (i == 1) {
+					t.Errorf("event %d: wrong disabled flag: %t", i, event.Disabled)
+				}
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, rowGroup := range f.Metadata().RowGroups {
+				metadata := rowGroup.Columns[1].MetaData
+				hasDictionary := metadata.DictionaryPageOffset != 0
+				if wantDictionary := i < 2; hasDictionary != wantDictionary {
+					t.Errorf("row group %d: wrong dictionary page: want=%t got=%t", i, wantDictionary, hasDictionary)
+				}
+				if slices.Contains(metadata.Encoding, format.RLEDictionary) != hasDictionary {
+					t.Errorf("row group %d: wrong column chunk encodings: %v", i, metadata.Encoding)
+				}
+				plainPages := 0
+				for _, stats := range metadata.EncodingStats {
+					if stats.PageType != format.DictionaryPage && stats.Encoding == format.Plain {
+						plainPages += int(stats.Count)
+					}
+				}
+				if plainPages == 0 {
+					t.Errorf("row group %d: no data pages written with the PLAIN encoding", i)
+				}
+
+				filter := f.RowGroups()[i].ColumnChunks()[1].BloomFilter()
+				for _, row := range want[i*numRows : (i+1)*numRows] {
+					if ok, err := filter.Check(parquet.ValueOf(row.Name)); err != nil {
+						t.Fatal(err)
+					} else if !ok {
+						t.Fatalf("row group %d: value %q missing from the bloom filter", i, row.Name)
+					}
+				}
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Error("rows read do not match the rows written")
+			}
+		})
+	}
+}
+
+func TestWriterAlignment(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	const (
+		rowGroupAlignment    = 4096
+		columnChunkAlignment = 512
+	)
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.AlignRowGroups(rowGroupAlignment),
+		parquet.AlignColumnChunks(columnChunkAlignment),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "id")),
+	)
+
+	var want []Row
+	for i := 0; i < 3; i++ {
+		rows := make([]Row, 10*(i+1))
+		for j := range rows {
+			rows[j] = Row{ID: int64(len(want) + j), Name: fmt.Sprintf("name-%d", j%3)}
+		}
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, rows...)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parquet.ValidateFile(f, parquet.ValidateStatistics); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, rowGroup := range f.Metadata().RowGroups {
+		if rowGroup.FileOffset%rowGroupAlignment != 0 {
+			t.Errorf("row group %d starts at unaligned offset %d", i, rowGroup.FileOffset)
+		}
+		for j, c := range rowGroup.Columns {
+			offset := c.MetaData.DataPageOffset
+			if c.MetaData.DictionaryPageOffset != 0 {
+				offset = c.MetaData.DictionaryPageOffset
+			}
+			if offset%columnChunkAlignment != 0 {
+				t.Errorf("column chunk %d of row group %d starts at unaligned offset %d", j, i, offset)
+			}
+		}
+	}
+
+	rows, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, rows)
+	}
+}
+
+func TestWriterColumnLayout(t *testing.T) {
+	type Row struct {
+		A int64  `parquet:"a"`
+		B string `parquet:"b"`
+		C int32  `parquet:"c"`
+		D string `parquet:"d,dict"`
+	}
+
+	want := make([]Row, 100)
+	for i := range want {
+		want[i] = Row{A: int64(i), B: strconv.Itoa(i), C: int32(i % 7), D: strconv.Itoa(i % 3)}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, want, parquet.ColumnLayout("d", "b")); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parquet.ValidateFile(f, parquet.ValidatePages); err != nil {
+		t.Fatal(err)
+	}
+
+	columns := f.Metadata().RowGroups[0].Columns
+	offsetOf := func(name string) int64 {
+		for _, c := range columns {
+			if c.MetaData.PathInSchema[0] == name {
+				if c.MetaData.DictionaryPageOffset != 0 {
+					return c.MetaData.DictionaryPageOffset
+				}
+				return c.MetaData.DataPageOffset
+			}
+		}
+		t.Fatalf("column %q not found", name)
+		return 0
+	}
+	layout := []string{"d", "b", "a", "c"}
+	for i := 1; i < len(layout); i++ {
+		if prev, next := offsetOf(layout[i-1]), offsetOf(layout[i]); prev >= next {
+			t.Errorf("column chunk %q at offset %d is not written before %q at offset %d", layout[i-1], prev, layout[i], next)
+		}
+	}
+	if columns[0].MetaData.PathInSchema[0] != "a" {
+		t.Errorf("order of column chunks in the metadata changed: %v", columns[0].MetaData.PathInSchema)
+	}
+
+	rows, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, rows)
+	}
+
+	if err := parquet.Write(io.Discard, want, parquet.ColumnLayout("e")); err == nil {
+		t.Error("expected an error for a column layout with an unknown column")
+	}
+	w := parquet.NewGenericWriter[Row](io.Discard, parquet.ColumnLayout("e"))
+	if err := w.Close(); err == nil {
+		t.Error("expected an error closing a writer with an unknown column in its layout")
+	}
+}
+
+func TestWriterDataPageV1OptionalColumn(t *testing.T) {
+	type Row struct {
+		ID    int64   `parquet:"id"`
+		Value *string `parquet:"value,optional"`
+	}
+
+	want := make([]Row, 100)
+	for i := range want {
+		want[i].ID = int64(i)
+		if i%3 != 0 {
+			s := strconv.Itoa(i)
+			want[i].Value = &s
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, want, parquet.DataPageVersion(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got)
+	}
+}
+
+func TestWriterSortedDictionaries(t *testing.T) {
+	type Row struct {
+		Name  string   `parquet:"name,dict"`
+		Label string   `parquet:"label,dict,optional"`
+		Tags  []string `parquet:"tags,dict"`
+		Other string   `parquet:"other,dict"`
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			rows := make([]Row, 1000)
+			for i := range rows {
+				rows[i] = Row{
+					Name:  fmt.Sprintf("name-%03d", (i*37)%200),
+					Tags:  []string{fmt.Sprintf("tag-%d", (i*7)%13), fmt.Sprintf("tag-%d", i%5)}[:i%3],
+					Other: fmt.Sprintf("other-%d", (i*11)%17),
+				}
+				if i%4 != 0 {
+					rows[i].Label = fmt.Sprintf("label-%d", 100-(i%50))
+				}
+			}
+
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf,
+				parquet.DataPageVersion(version),
+				parquet.PageBufferSize(512),
+				parquet.MaxRowsPerRowGroup(400),
+				parquet.SortedDictionaries("name", "label"),
+				parquet.SortedDictionaries("tags"),
+			)
+			if _, err := w.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(rows) {
+				t.Fatalf("wrong number of rows: %d/%d", len(got), len(rows))
+			}
+			for i := range rows {
+				if !reflect.DeepEqual(got[i], rows[i]) {
+					t.Fatalf("wrong row at index %d:\nwant = %+v\ngot  = %+v", i, rows[i], got[i])
+				}
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(f.RowGroups()); n != 3 {
+				t.Fatalf("wrong number of row groups: %d", n)
+			}
+			if err := parquet.ValidateFile(f, parquet.ValidateStatistics); err != nil {
+				t.Error(err)
+			}
+			for i, rowGroup := range f.RowGroups() {
+				for j, columnChunk := range rowGroup.ColumnChunks() {
+					chunk := columnChunk.(*parquet.FileColumnChunk)
+					headers := chunk.PageHeaders()
+					h, err := headers.ReadPageHeader()
+					headers.Close()
+					if err != nil {
+						t.Fatal(err)
+					}
+					if h.Header.DictionaryPageHeader == nil {
+						t.Fatalf("row group %d: column %d: missing dictionary page", i, j)
+					}
+					if sorted, want := h.Header.DictionaryPageHeader.IsSorted, j != 3; sorted != want {
+						t.Errorf("row group %d: column %d: wrong sorted flag: %t", i, j, sorted)
+					}
+
+					pages := chunk.Pages()
+					page, err := pages.ReadPage()
+					if err != nil {
+						t.Fatal(err)
+					}
+					dict := page.Dictionary()
+					for k := 1; j != 3 && k < dict.Len(); k++ {
+						if a, b := dict.Index(int32(k-1)), dict.Index(int32(k)); bytes.Compare(a.ByteArray(), b.ByteArray()) >= 0 {
+							t.Fatalf("row group %d: column %d: dictionary is not sorted: %q >= %q", i, j, a, b)
+						}
+					}
+					parquet.Release(page)
+					pages.Close()
+				}
+
+				name := rowGroup.ColumnChunks()[0].(*parquet.FileColumnChunk)
+				for _, test := range []struct {
+					value string
+					want  bool
+				}{
+					{value: rows[i*400].Name, want: true},
+					{value: "name-000", want: true},
+					{value: "name-199", want: true},
+					{value: "name-200", want: false},
+					{value: "a", want: false},
+				} {
+					if ok, err := name.MayContain(parquet.ValueOf(test.value)); err != nil {
+						t.Fatal(err)
+					} else if ok != test.want {
+						t.Errorf("row group %d: MayContain(%q) = %t, want %t", i, test.value, ok, test.want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriteHook(t *testing.T) {
+	type Row struct {
+		ID    int64  `parquet:"id"`
+		Email string `parquet:"email,optional"`
+	}
+
+	reverse := func(v parquet.Value) (parquet.Value, error) {
+		b := []byte(v.String())
+		slices.Reverse(b)
+		return parquet.ValueOf(b), nil
+	}
+
+	rows := []Row{{ID: 1, Email: "a@example.com"}, {ID: 2}, {ID: 3, Email: "c@example.com"}}
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.WriteHook("email", reverse),
+		parquet.AnnotateWriteHook("email", "reverse"),
+	)
+	if _, err := w.Write(rows[:2]); err != nil {
+		t.Fatal(err)
+	}
+	row := parquet.Row{
+		parquet.ValueOf(int64(3)).Level(0, 0, 0),
+		parquet.ValueOf("c@example.com").Level(0, 1, 1),
+	}
+	if _, err := w.WriteRows([]parquet.Row{row}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if row[1].String() != "c@example.com" {
+		t.Errorf("the row passed to the writer was modified: %v", row)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations, err := parquet.WriteHookAnnotationsOf(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(annotations, map[string]string{"email": "reverse"}) {
+		t.Errorf("wrong write hook annotations: %v", annotations)
+	}
+
+	stored, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Row{{ID: 1, Email: "moc.elpmaxe@a"}, {ID: 2}, {ID: 3, Email: "moc.elpmaxe@c"}}
+	if !reflect.DeepEqual(stored, want) {
+		t.Errorf("wrong values written:\nwant: %+v\ngot:  %+v", want, stored)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.ReadHook("email", reverse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("wrong values read:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+
+	errInvalid := errors.New("invalid email")
+	w = parquet.NewGenericWriter[Row](new(bytes.Buffer),
+		parquet.WriteHook("email", func(v parquet.Value) (parquet.Value, error) {
+			if !strings.Contains(v.String(), "@") {
+				return v, errInvalid
+			}
+			return v, nil
+		}),
+	)
+	_, err = w.Write([]Row{{ID: 1, Email: "a@example.com"}, {ID: 2, Email: "b"}})
+	var rowErr *parquet.RowError
+	if !errors.As(err, &rowErr) || !errors.Is(err, errInvalid) || rowErr.Row != 1 {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestWriterPageStatisticsOmission(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	// Each row group holds a single page of names of a different length: the
+	// short names fit in the column index, the medium names are truncated in
+	// the column index (limited to 16 bytes by default), and the long names
+	// exceed the page statistics size limit of the test.
+	names := []string{
+		"short",
+		strings.Repeat("medium", 5),
+		strings.Repeat("long", 25),
+	}
+
+	for _, test := range []struct {
+		scenario string
+		options  []parquet.WriterOption
+		// Whether the page statistics have bounds, for each row group and
+		// column.
+		want [3][2]bool
+	}{
+		{
+			scenario: "default",
+			want:     [3][2]bool{{true, true}, {true, true}, {true, true}},
+		},
+		{
+			scenario: "size limit",
+			options:  []parquet.WriterOption{parquet.PageStatisticsSizeLimit(64)},
+			want:     [3][2]bool{{true, true}, {true, true}, {true, false}},
+		},
+		{
+			scenario: "skip indexed",
+			options:  []parquet.WriterOption{parquet.SkipIndexedPageStats(true)},
+			want:     [3][2]bool{{false, false}, {false, true}, {false, true}},
+		},
+		{
+			scenario: "skip indexed with size limit",
+			options:  []parquet.WriterOption{parquet.SkipIndexedPageStats(true), parquet.PageStatisticsSizeLimit(64)},
+			want:     [3][2]bool{{false, false}, {false, true}, {false, false}},
+		},
+		{
+			scenario: "skip indexed without page bounds",
+			options:  []parquet.WriterOption{parquet.SkipIndexedPageStats(true), parquet.SkipPageBounds("name")},
+			want:     [3][2]bool{{false, true}, {false, true}, {false, true}},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			options := append([]parquet.WriterOption{parquet.DataPageStatistics(true)}, test.options...)
+			w := parquet.NewGenericWriter[Row](buf, options...)
+			for i, name := range names {
+				rows := []Row{{ID: int64(2 * i), Name: name + "a"}, {ID: int64(2*i + 1), Name: name + "b"}}
+				if _, err := w.Write(rows); err != nil {
+					t.Fatal(err)
+				}
+				if err := w.Flush(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, rowGroup := range f.RowGroups() {
+				for j, columnChunk := range rowGroup.ColumnChunks() {
+					headers := columnChunk.(*parquet.FileColumnChunk).PageHeaders()
+					h, err := headers.ReadPageHeader()
+					headers.Close()
+					if err != nil {
+						t.Fatal(err)
+					}
+					stats := h.Header.DataPageHeaderV2.Statistics
+					if hasBounds := stats.MinValue != nil && stats.MaxValue != nil; hasBounds != test.want[i][j] {
+						t.Errorf("row group %d: column %d: page statistics have bounds: want=%t got=%t", i, j, test.want[i][j], hasBounds)
+					}
+					if hasBounds := stats.Min != nil || stats.Max != nil; hasBounds != test.want[i][j] {
+						t.Errorf("row group %d: column %d: page statistics have deprecated bounds: want=%t got=%t", i, j, test.want[i][j], hasBounds)
+					}
+				}
+			}
+			if err := parquet.ValidateFile(f, parquet.ValidateStatistics); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}